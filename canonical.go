@@ -1,53 +1,108 @@
 package capnp
 
 import (
+	"errors"
+
 	"capnproto.org/go/capnp/v3/exc"
 	"capnproto.org/go/capnp/v3/internal/str"
 )
 
+// CanonicalOptions controls how objects are canonicalized.
+// The zero value (or a nil *CanonicalOptions) produces the same
+// encoding as Canonicalize.
+type CanonicalOptions struct {
+	// Caps determines how capability pointers are encoded.
+	Caps CanonicalCapPolicy
+}
+
+// CanonicalCapPolicy determines how capability pointers are handled
+// during canonicalization.  Capabilities have no canonical encoding,
+// since a capability table index is only meaningful alongside the
+// message that holds the table.
+type CanonicalCapPolicy uint8
+
+const (
+	// CanonicalCapIndex encodes a capability pointer with its index in
+	// the source message's capability table.  This is the behavior of
+	// Canonicalize.
+	CanonicalCapIndex CanonicalCapPolicy = iota
+
+	// CanonicalCapError fails canonicalization if a capability pointer
+	// is encountered.
+	CanonicalCapError
+
+	// CanonicalCapNull encodes capability pointers as null pointers.
+	CanonicalCapNull
+)
+
+func (opts *CanonicalOptions) capPolicy() CanonicalCapPolicy {
+	if opts == nil {
+		return CanonicalCapIndex
+	}
+	return opts.Caps
+}
+
 // Canonicalize encodes a struct into its canonical form: a single-
 // segment blob without a segment table.  The result will be identical
 // for equivalent structs, even as the schema evolves.  The blob is
 // suitable for hashing or signing.
 func Canonicalize(s Struct) ([]byte, error) {
+	return CanonicalizePtr(s.ToPtr(), nil)
+}
+
+// CanonicalizePtr encodes the object tree rooted at p into its
+// canonical form, like Canonicalize.  p may be any kind of pointer,
+// including a list, and may come from a multi-segment message.
+func CanonicalizePtr(p Ptr, opts *CanonicalOptions) ([]byte, error) {
+	c := canonicalizer{caps: opts.capPolicy()}
 	msg, seg, _ := NewMessage(SingleSegment(nil))
-	if !s.IsValid() {
+	if !p.IsValid() {
 		return seg.Data(), nil
 	}
-	root, err := NewRootStruct(seg, canonicalStructSize(s))
+	root, err := c.canonicalPtr(seg, p)
 	if err != nil {
 		return nil, exc.WrapError("canonicalize", err)
 	}
-	if err := msg.SetRoot(root.ToPtr()); err != nil {
+	if err := msg.SetRoot(root); err != nil {
 		return nil, exc.WrapError("canonicalize", err)
 	}
-	if err := fillCanonicalStruct(root, s); err != nil {
-		return nil, exc.WrapError("canonicalize", err)
+	if msg.NumSegments() != 1 {
+		return nil, errors.New("canonicalize: message too large for a single segment")
 	}
 	return seg.Data(), nil
 }
 
-func canonicalPtr(dst *Segment, p Ptr) (Ptr, error) {
+type canonicalizer struct {
+	caps CanonicalCapPolicy
+}
+
+func (c canonicalizer) canonicalPtr(dst *Segment, p Ptr) (Ptr, error) {
 	if !p.IsValid() {
 		return Ptr{}, nil
 	}
 	switch p.flags.ptrType() {
 	case structPtrType:
-		ss, err := NewStruct(dst, canonicalStructSize(p.Struct()))
+		ss, err := NewStruct(dst, c.canonicalStructSize(p.Struct()))
 		if err != nil {
 			return Ptr{}, exc.WrapError("struct", err)
 		}
-		if err := fillCanonicalStruct(ss, p.Struct()); err != nil {
+		if err := c.fillCanonicalStruct(ss, p.Struct()); err != nil {
 			return Ptr{}, err
 		}
 		return ss.ToPtr(), nil
 	case listPtrType:
-		ll, err := canonicalList(dst, p.List())
+		ll, err := c.canonicalList(dst, p.List())
 		if err != nil {
 			return Ptr{}, err
 		}
 		return ll.ToPtr(), nil
 	case interfacePtrType:
+		switch c.caps {
+		case CanonicalCapError:
+			return Ptr{}, errors.New("capability pointers have no canonical form")
+		case CanonicalCapNull:
+			return Ptr{}, nil
+		}
 		iface := NewInterface(dst, p.Interface().Capability())
 		return iface.ToPtr(), nil
 	default:
@@ -55,14 +110,14 @@ func canonicalPtr(dst *Segment, p Ptr) (Ptr, error) {
 	}
 }
 
-func fillCanonicalStruct(dst, s Struct) error {
+func (c canonicalizer) fillCanonicalStruct(dst, s Struct) error {
 	copy(dst.seg.slice(dst.off, dst.size.DataSize), s.seg.slice(s.off, s.size.DataSize))
 	for i := uint16(0); i < dst.size.PointerCount; i++ {
 		p, err := s.Ptr(i)
 		if err != nil {
 			return exc.WrapError("struct pointer "+str.Utod(i), err)
 		}
-		cp, err := canonicalPtr(dst.seg, p)
+		cp, err := c.canonicalPtr(dst.seg, p)
 		if err != nil {
 			return exc.WrapError("struct pointer "+str.Utod(i), err)
 		}
@@ -73,7 +128,7 @@ func fillCanonicalStruct(dst, s Struct) error {
 	return nil
 }
 
-func canonicalStructSize(s Struct) ObjectSize {
+func (c canonicalizer) canonicalStructSize(s Struct) ObjectSize {
 	if !s.IsValid() {
 		return ObjectSize{}
 	}
@@ -86,18 +141,43 @@ func canonicalStructSize(s Struct) ObjectSize {
 		}
 	}
 	for i := int32(s.size.PointerCount) - 1; i >= 0; i-- {
-		if s.seg.readRawPointer(s.pointerAddress(uint16(i))) != 0 {
-			sz.PointerCount = uint16(i + 1)
-			break
+		raw := s.seg.readRawPointer(s.pointerAddress(uint16(i)))
+		if raw == 0 || c.caps == CanonicalCapNull && raw.pointerType() == otherPointer {
+			continue
 		}
+		sz.PointerCount = uint16(i + 1)
+		break
 	}
 	return sz
 }
 
-func canonicalList(dst *Segment, l List) (List, error) {
+func (c canonicalizer) canonicalList(dst *Segment, l List) (List, error) {
 	if !l.IsValid() {
 		return List{}, nil
 	}
+	if l.flags&isCompositeList != 0 {
+		// Struct/composite list
+		var elemSize ObjectSize
+		for i := 0; i < l.Len(); i++ {
+			sz := c.canonicalStructSize(l.Struct(i))
+			if sz.DataSize > elemSize.DataSize {
+				elemSize.DataSize = sz.DataSize
+			}
+			if sz.PointerCount > elemSize.PointerCount {
+				elemSize.PointerCount = sz.PointerCount
+			}
+		}
+		cl, err := NewCompositeList(dst, elemSize, l.length)
+		if err != nil {
+			return List{}, exc.WrapError("list", err)
+		}
+		for i := 0; i < cl.Len(); i++ {
+			if err := c.fillCanonicalStruct(cl.Struct(i), l.Struct(i)); err != nil {
+				return List{}, exc.WrapError("list element "+str.Itod(i), err)
+			}
+		}
+		return cl, nil
+	}
 	if l.size.PointerCount == 0 {
 		// Data only, just copy over.
 		sz := l.allocSize()
@@ -115,48 +195,179 @@ func canonicalList(dst *Segment, l List) (List, error) {
 		}
 		end, _ := l.off.addSize(sz) // list was already validated
 		copy(dst.data[newAddr:], l.seg.data[l.off:end])
+		if l.flags&isBitList != 0 && l.length%8 != 0 {
+			// Unused bits at the end of a bit list must be zero.
+			last := &dst.data[int(newAddr)+int(sz)-1]
+			*last &= 1<<uint(l.length%8) - 1
+		}
 		return cl, nil
 	}
-	if l.flags&isCompositeList == 0 {
-		cl, err := NewPointerList(dst, l.length)
+	cl, err := NewPointerList(dst, l.length)
+	if err != nil {
+		return List{}, exc.WrapError("list", err)
+	}
+	for i := 0; i < l.Len(); i++ {
+		p, err := PointerList(l).At(i)
 		if err != nil {
-			return List{}, exc.WrapError("list", err)
+			return List{}, exc.WrapError("list element "+str.Itod(i), err)
 		}
-		for i := 0; i < l.Len(); i++ {
-			p, err := PointerList(l).At(i)
-			if err != nil {
-				return List{}, exc.WrapError("list element "+str.Itod(i), err)
+		cp, err := c.canonicalPtr(dst, p)
+		if err != nil {
+			return List{}, exc.WrapError("list element "+str.Itod(i), err)
+		}
+		if err := cl.Set(i, cp); err != nil {
+			return List{}, exc.WrapError("list element "+str.Itod(i), err)
+		}
+	}
+	return List(cl), nil
+}
+
+// VerifyCanonical reports whether data, a single segment without a
+// segment table, is already in the canonical form that CanonicalizePtr
+// would produce with the given options.  It returns nil if data is
+// canonical, or an error that describes the first deviation found.
+// Data is checked in place, without being re-encoded.
+func VerifyCanonical(data []byte, opts *CanonicalOptions) error {
+	if len(data) < int(wordSize) || len(data)%int(wordSize) != 0 {
+		return errors.New("verify canonical: data is not a whole number of words")
+	}
+	if int64(len(data)) > int64(maxSegmentSize) {
+		return errors.New("verify canonical: data too large for a single segment")
+	}
+	msg := &Message{Arena: roSingleSegment(data)}
+	seg, err := msg.Segment(0)
+	if err != nil {
+		return exc.WrapError("verify canonical", err)
+	}
+	v := canonicalVerifier{
+		canonicalizer: canonicalizer{caps: opts.capPolicy()},
+		seg:           seg,
+		next:          address(wordSize),
+	}
+	if err := v.verifyPtr(0, msg.depthLimit()); err != nil {
+		return exc.WrapError("verify canonical", err)
+	}
+	if v.next != address(len(data)) {
+		return errors.New("verify canonical: " + str.Itod(len(data)-int(v.next)) + " bytes of unreachable data at end of segment")
+	}
+	return nil
+}
+
+// canonicalVerifier checks that objects appear in the order and
+// layout that canonicalizer would have written them.
+type canonicalVerifier struct {
+	canonicalizer
+	seg *Segment
+
+	// next is the address at which the next object must start.
+	next address
+}
+
+func (v *canonicalVerifier) verifyPtr(paddr address, depthLimit uint) error {
+	raw := v.seg.readRawPointer(paddr)
+	if raw == 0 {
+		return nil
+	}
+	if depthLimit == 0 {
+		return errors.New("depth limit reached")
+	}
+	base := paddr.addSizeUnchecked(wordSize)
+	switch raw.pointerType() {
+	case structPointer:
+		if raw.structSize().isZero() {
+			if raw != rawStructPointer(-1, ObjectSize{}) {
+				return errors.New("zero-sized struct pointer at " + paddr.String() + " has non-canonical offset")
 			}
-			cp, err := canonicalPtr(dst, p)
-			if err != nil {
-				return List{}, exc.WrapError("list element "+str.Itod(i), err)
+			return nil
+		}
+		s, err := v.seg.readStructPtr(base, raw)
+		if err != nil {
+			return err
+		}
+		if s.off != v.next {
+			return errors.New("struct at " + s.off.String() + " is out of order (expected " + v.next.String() + ")")
+		}
+		v.next = s.off.addSizeUnchecked(s.size.totalSize())
+		if v.canonicalStructSize(s) != s.size {
+			return errors.New("struct at " + s.off.String() + " is not truncated")
+		}
+		return v.verifyStructPtrs(s, depthLimit-1)
+	case listPointer:
+		l, err := v.seg.readListPtr(base, raw)
+		if err != nil {
+			return err
+		}
+		start := l.off
+		if l.flags&isCompositeList != 0 {
+			start -= address(wordSize)
+		}
+		if start != v.next {
+			return errors.New("list at " + start.String() + " is out of order (expected " + v.next.String() + ")")
+		}
+		sz := l.allocSize()
+		end := start.addSizeUnchecked(sz)
+		v.next = start.addSizeUnchecked(sz.padToWord())
+		if !v.seg.regionInBounds(start, sz.padToWord()) {
+			return errors.New("list at " + start.String() + " padding out of bounds")
+		}
+		if !isZeroFilled(v.seg.data[end:v.next]) {
+			return errors.New("list at " + start.String() + " has non-zero padding")
+		}
+		if l.flags&isBitList != 0 && l.length%8 != 0 {
+			if v.seg.data[end-1]>>uint(l.length%8) != 0 {
+				return errors.New("bit list at " + start.String() + " has non-zero padding")
 			}
-			if err := cl.Set(i, cp); err != nil {
-				return List{}, exc.WrapError("list element "+str.Itod(i), err)
+		}
+		switch {
+		case l.flags&isCompositeList != 0:
+			if raw.numListElements() != l.length*l.size.totalWordCount() {
+				return errors.New("composite list at " + start.String() + " has inconsistent word count")
+			}
+			var elemSize ObjectSize
+			for i := 0; i < l.Len(); i++ {
+				sz := v.canonicalStructSize(l.Struct(i))
+				if sz.DataSize > elemSize.DataSize {
+					elemSize.DataSize = sz.DataSize
+				}
+				if sz.PointerCount > elemSize.PointerCount {
+					elemSize.PointerCount = sz.PointerCount
+				}
+			}
+			if elemSize != l.size {
+				return errors.New("composite list at " + start.String() + " elements are not truncated")
+			}
+			for i := 0; i < l.Len(); i++ {
+				if err := v.verifyStructPtrs(l.Struct(i), depthLimit-1); err != nil {
+					return exc.WrapError("list element "+str.Itod(i), err)
+				}
+			}
+		case l.size.PointerCount != 0:
+			for i := 0; i < l.Len(); i++ {
+				addr, _ := l.off.element(int32(i), wordSize) // list was already validated
+				if err := v.verifyPtr(addr, depthLimit-1); err != nil {
+					return exc.WrapError("list element "+str.Itod(i), err)
+				}
 			}
 		}
-		return List(cl), nil
-	}
-
-	// Struct/composite list
-	var elemSize ObjectSize
-	for i := 0; i < l.Len(); i++ {
-		sz := canonicalStructSize(l.Struct(i))
-		if sz.DataSize > elemSize.DataSize {
-			elemSize.DataSize = sz.DataSize
+		return nil
+	case otherPointer:
+		if raw.otherPointerType() != 0 {
+			return errors.New("unknown pointer type at " + paddr.String())
 		}
-		if sz.PointerCount > elemSize.PointerCount {
-			elemSize.PointerCount = sz.PointerCount
+		if v.caps != CanonicalCapIndex {
+			return errors.New("capability pointer at " + paddr.String())
 		}
+		return nil
+	default:
+		return errors.New("far pointer at " + paddr.String())
 	}
-	cl, err := NewCompositeList(dst, elemSize, l.length)
-	if err != nil {
-		return List{}, exc.WrapError("list", err)
-	}
-	for i := 0; i < cl.Len(); i++ {
-		if err := fillCanonicalStruct(cl.Struct(i), l.Struct(i)); err != nil {
-			return List{}, exc.WrapError("list element "+str.Itod(i), err)
+}
+
+func (v *canonicalVerifier) verifyStructPtrs(s Struct, depthLimit uint) error {
+	for i := uint16(0); i < s.size.PointerCount; i++ {
+		if err := v.verifyPtr(s.pointerAddress(i), depthLimit); err != nil {
+			return exc.WrapError("struct pointer "+str.Utod(i), err)
 		}
 	}
-	return cl, nil
+	return nil
 }
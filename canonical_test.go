@@ -208,3 +208,175 @@ func TestCanonicalize(t *testing.T) {
 		}
 	}
 }
+
+func TestCanonicalizePtr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ListRoot", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg := NewSingleSegmentMessage(nil)
+		l, _ := NewTextList(seg, 2)
+		l.Set(0, "a")
+		l.Set(1, "bc")
+		b, err := CanonicalizePtr(l.ToPtr(), nil)
+		if err != nil {
+			t.Fatal("CanonicalizePtr(text list):", err)
+		}
+		want := []byte{
+			0x01, 0, 0, 0, 0x16, 0, 0, 0,
+			0x05, 0, 0, 0, 0x12, 0, 0, 0,
+			0x05, 0, 0, 0, 0x1a, 0, 0, 0,
+			'a', 0, 0, 0, 0, 0, 0, 0,
+			'b', 'c', 0, 0, 0, 0, 0, 0,
+		}
+		if !bytes.Equal(b, want) {
+			t.Errorf("CanonicalizePtr(text list) =\n%s\n; want\n%s", hex.Dump(b), hex.Dump(want))
+		}
+	})
+
+	t.Run("MultiSegmentSource", func(t *testing.T) {
+		t.Parallel()
+
+		// Leave only enough room in the first segment for the root
+		// pointer, so that the root struct is reached by a far pointer.
+		msg, seg := NewMultiSegmentMessage([][]byte{make([]byte, 0, 8)})
+		s, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.SetUint64(0, 0xdeadbeef)
+		txt, err := NewText(seg, "xyzzy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SetPtr(0, txt.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		if msg.NumSegments() < 2 {
+			t.Fatalf("source message has %d segments; want at least 2", msg.NumSegments())
+		}
+		b, err := Canonicalize(s)
+		if err != nil {
+			t.Fatal("Canonicalize(multi-segment struct):", err)
+		}
+		want := []byte{
+			0, 0, 0, 0, 1, 0, 1, 0,
+			0xef, 0xbe, 0xad, 0xde, 0, 0, 0, 0,
+			0x01, 0, 0, 0, 0x32, 0, 0, 0,
+			'x', 'y', 'z', 'z', 'y', 0, 0, 0,
+		}
+		if !bytes.Equal(b, want) {
+			t.Errorf("Canonicalize(multi-segment struct) =\n%s\n; want\n%s", hex.Dump(b), hex.Dump(want))
+		}
+	})
+
+	t.Run("CapPolicy", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg := NewSingleSegmentMessage(nil)
+		s, _ := NewStruct(seg, ObjectSize{PointerCount: 2})
+		s.SetPtr(0, NewInterface(seg, 0).ToPtr())
+		s.SetPtr(1, NewInterface(seg, 1).ToPtr())
+
+		if _, err := CanonicalizePtr(s.ToPtr(), &CanonicalOptions{Caps: CanonicalCapError}); err == nil {
+			t.Error("CanonicalizePtr(CanonicalCapError) did not return an error")
+		}
+		b, err := CanonicalizePtr(s.ToPtr(), &CanonicalOptions{Caps: CanonicalCapNull})
+		if err != nil {
+			t.Fatal("CanonicalizePtr(CanonicalCapNull):", err)
+		}
+		want := []byte{0xfc, 0xff, 0xff, 0xff, 0, 0, 0, 0}
+		if !bytes.Equal(b, want) {
+			t.Errorf("CanonicalizePtr(CanonicalCapNull) =\n%s\n; want\n%s", hex.Dump(b), hex.Dump(want))
+		}
+	})
+
+	t.Run("DataOnlyStructList", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg := NewSingleSegmentMessage(nil)
+		l, _ := NewCompositeList(seg, ObjectSize{DataSize: 16}, 2)
+		l.Struct(1).SetUint32(0, 0xbeef)
+		b, err := CanonicalizePtr(l.ToPtr(), nil)
+		if err != nil {
+			t.Fatal("CanonicalizePtr(data-only struct list):", err)
+		}
+		want := []byte{
+			0x01, 0, 0, 0, 0x17, 0, 0, 0,
+			0x08, 0, 0, 0, 1, 0, 0, 0,
+			0, 0, 0, 0, 0, 0, 0, 0,
+			0xef, 0xbe, 0, 0, 0, 0, 0, 0,
+		}
+		if !bytes.Equal(b, want) {
+			t.Errorf("CanonicalizePtr(data-only struct list) =\n%s\n; want\n%s", hex.Dump(b), hex.Dump(want))
+		}
+	})
+}
+
+func TestVerifyCanonical(t *testing.T) {
+	t.Parallel()
+
+	_, seg := NewSingleSegmentMessage(nil)
+	s, _ := NewStruct(seg, ObjectSize{DataSize: 16, PointerCount: 3})
+	s.SetUint64(0, 42)
+	s.SetText(0, "hello")
+	l, _ := NewCompositeList(seg, ObjectSize{DataSize: 8, PointerCount: 1}, 2)
+	l.Struct(0).SetUint64(0, 1)
+	l.Struct(1).SetText(0, "world")
+	s.SetPtr(1, l.ToPtr())
+	bits, _ := NewBitList(seg, 3)
+	bits.Set(2, true)
+	s.SetPtr(2, bits.ToPtr())
+
+	canonical, err := Canonicalize(s)
+	if err != nil {
+		t.Fatal("Canonicalize:", err)
+	}
+	if err := VerifyCanonical(canonical, nil); err != nil {
+		t.Errorf("VerifyCanonical(Canonicalize(s)) = %v; want <nil>", err)
+	}
+	if err := VerifyCanonical([]byte{0, 0, 0, 0, 0, 0, 0, 0}, nil); err != nil {
+		t.Errorf("VerifyCanonical(null root) = %v; want <nil>", err)
+	}
+
+	// The original, non-truncated struct is not canonical.
+	_, seg2 := NewSingleSegmentMessage(nil)
+	root, _ := NewRootStruct(seg2, ObjectSize{DataSize: 16, PointerCount: 1})
+	root.SetUint64(0, 42)
+	if err := VerifyCanonical(seg2.Data(), nil); err == nil {
+		t.Error("VerifyCanonical(untruncated struct) = <nil>; want error")
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", []byte{}},
+		{"TrailingGarbage", append(append([]byte(nil), canonical...), 0, 0, 0, 0, 0, 0, 0, 0)},
+		{"FarPointer", []byte{0x02, 0, 0, 0, 0, 0, 0, 0}},
+		{"Gap", []byte{
+			0x04, 0, 0, 0, 1, 0, 0, 0,
+			0, 0, 0, 0, 0, 0, 0, 0,
+			1, 0, 0, 0, 0, 0, 0, 0,
+		}},
+		{"NonZeroPadding", []byte{
+			0x01, 0, 0, 0, 0x1a, 0, 0, 0,
+			'a', 'b', 0, 0, 0, 0, 0, 1,
+		}},
+		{"ZeroStructOffset", []byte{0x04, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	for _, test := range tests {
+		if err := VerifyCanonical(test.data, nil); err == nil {
+			t.Errorf("VerifyCanonical(%s) = <nil>; want error", test.name)
+		}
+	}
+
+	capMsg := []byte{0x03, 0, 0, 0, 0, 0, 0, 0}
+	if err := VerifyCanonical(capMsg, nil); err != nil {
+		t.Errorf("VerifyCanonical(capability root) = %v; want <nil>", err)
+	}
+	if err := VerifyCanonical(capMsg, &CanonicalOptions{Caps: CanonicalCapNull}); err == nil {
+		t.Error("VerifyCanonical(capability root, CanonicalCapNull) = <nil>; want error")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+capnp-compat reports changes between two versions of a schema, and
+whether they break wire compatibility.
+
+Usage:
+
+	capnp-compat [flags] OLD NEW
+
+OLD and NEW are either schema files ending in .capnp, which are compiled
+directly, or CodeGeneratorRequests written by `capnp compile -o-`.
+Breaking changes are always printed; safe changes are printed with -v.
+capnp-compat exits with status 1 if there are breaking changes and 2 on
+error, so it can be used as a CI check.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/schemas/compat"
+	"capnproto.org/go/capnp/v3/schemas/compiler"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+func main() {
+	var c compiler.Compiler
+	flag.Var((*stringList)(&c.ImportPath), "I", "add `dir` to the import path for .capnp files")
+	flag.Var((*stringList)(&c.SourcePrefixes), "src-prefix", "remove `prefix` from the names of .capnp files")
+	verbose := flag.Bool("v", false, "also print safe changes")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: capnp-compat [flags] OLD NEW")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	old, err := load(&c, flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capnp-compat:", err)
+		os.Exit(2)
+	}
+	new, err := load(&c, flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capnp-compat:", err)
+		os.Exit(2)
+	}
+	changes, err := compat.Check(old, new)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capnp-compat:", err)
+		os.Exit(2)
+	}
+	for _, ch := range changes {
+		if ch.Breaking || *verbose {
+			fmt.Println(ch)
+		}
+	}
+	if compat.HasBreaking(changes) {
+		os.Exit(1)
+	}
+}
+
+// load reads a schema from a .capnp file or a serialized
+// CodeGeneratorRequest.
+func load(c *compiler.Compiler, name string) (*compat.Schema, error) {
+	if strings.HasSuffix(name, ".capnp") {
+		req, err := c.Compile(name)
+		if err != nil {
+			return nil, err
+		}
+		return compat.FromRequest(req)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	msg, err := capnp.NewDecoder(f).Decode()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	req, err := schema.ReadRootCodeGeneratorRequest(msg)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	return compat.FromRequest(req)
+}
+
+// stringList is a flag.Value that collects repeated flags.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
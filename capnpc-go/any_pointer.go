@@ -7,10 +7,12 @@ type anyPointerRenderStrategy interface {
 	ListParams() any
 	CapabilityParams() any
 	PtrParams() any
+	TypeParamParams() any
 }
 
 type anyPointer struct {
 	G    *generator
+	Node *node // the node that has the field
 	Type schema.Type
 }
 
@@ -20,7 +22,12 @@ func (ap anyPointer) Render(s anyPointerRenderStrategy) error {
 		return ap.RenderUnconstrained(s)
 
 	case schema.Type_anyPointer_Which_parameter:
-		// TODO(soon):  implement parameter
+		// A parameter of a node that isn't generic in Go is a plain
+		// pointer.
+		p := ap.Type.AnyPointer().Parameter()
+		if _, ok := ap.G.env(ap.Node).lookup(p.ScopeId(), p.ParameterIndex()); ok {
+			return ap.G.r.Render(s.TypeParamParams())
+		}
 	case schema.Type_anyPointer_Which_implicitMethodParameter:
 		// TODO(soon):  implement implicit method parameter
 	}
@@ -73,6 +80,10 @@ func (s structAnyPointerRenderStrategy) PtrParams() any {
 	}
 }
 
+func (s structAnyPointerRenderStrategy) TypeParamParams() any {
+	return structTypeParamFieldParams(s.Params)
+}
+
 type promiseAnyPointerRenderStrategy struct {
 	G     *generator
 	Node  *node
@@ -95,6 +106,12 @@ func (s promiseAnyPointerRenderStrategy) PtrParams() any {
 	return promiseFieldAnyPointerParams(s)
 }
 
+// TypeParamParams renders a generic parameter field as a plain future,
+// since its type is only known to the caller.
+func (s promiseAnyPointerRenderStrategy) TypeParamParams() any {
+	return promiseFieldAnyPointerParams(s)
+}
+
 func isAnyCap(ap schema.Type_anyPointer) bool {
 	if ap.Which() != schema.Type_anyPointer_Which_unconstrained {
 		return false
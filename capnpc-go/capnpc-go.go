@@ -5,6 +5,13 @@ foo.capnp.go.  This is usually invoked from `capnp compile -ogo`.
 
 See https://capnproto.org/otherlang.html#how-to-write-compiler-plugins
 for more details.
+
+capnpc-go can also compile schema files itself, without the capnp tool:
+
+	capnpc-go -I /path/to/std -src-prefix src src/foo.capnp
+
+The -I and -src-prefix flags match those of `capnp compile` and may be
+repeated.
 */
 package main
 
@@ -43,6 +50,7 @@ type genoptions struct {
 	promises      bool
 	schemas       bool
 	structStrings bool
+	pogs          bool
 }
 
 type renderer interface {
@@ -78,6 +86,10 @@ type generator struct {
 	imports imports
 	data    staticData
 	opts    genoptions
+
+	// validating is the set of structs that have Validate methods,
+	// computed on first use by validates.
+	validating map[uint64]bool
 }
 
 func newGenerator(fileID uint64, nodes nodeMap, opts genoptions) *generator {
@@ -199,11 +211,11 @@ func (g *generator) RemoteNodeNew(n, rel *node) (string, error) {
 	if ref.newfunc == "" {
 		return "", fmt.Errorf("no new function for %s", ref.name)
 	}
-	if ref.imp.path == "" {
-		return ref.newfunc, nil
+	args, err := g.typeArgs(n, schema.Brand{}, rel, g.env(rel))
+	if err != nil {
+		return "", err
 	}
-	qname := g.imports.add(ref.imp)
-	return qname + "." + ref.newfunc, nil
+	return g.qualify(ref.imp, ref.newfunc) + args, nil
 }
 
 func (g *generator) RemoteNodeName(n, rel *node) (string, error) {
@@ -211,14 +223,147 @@ func (g *generator) RemoteNodeName(n, rel *node) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if ref.imp.path == "" {
-		return ref.name, nil
+	args, err := g.typeArgs(n, schema.Brand{}, rel, g.env(rel))
+	if err != nil {
+		return "", err
 	}
-	qname := g.imports.add(ref.imp)
-	return qname + "." + ref.name, nil
+	return g.qualify(ref.imp, ref.name) + args, nil
 }
 
 func (g *generator) RemoteTypeNew(t schema.Type, rel *node) (string, error) {
+	return g.typeNew(t, rel, g.env(rel))
+}
+
+func (g *generator) RemoteTypeName(t schema.Type, rel *node) (string, error) {
+	return g.typeName(t, rel, g.env(rel))
+}
+
+// RemoteTypeFuture returns the name of the future type for the struct
+// type t as referenced from rel.
+func (g *generator) RemoteTypeFuture(t schema.Type, rel *node) (string, error) {
+	if t.Which() != schema.Type_Which_structType {
+		return "", fmt.Errorf("no future type for %v", t.Which())
+	}
+	n, err := g.nodes.mustFind(t.StructType().TypeId())
+	if err != nil {
+		return "", err
+	}
+	b, _ := t.StructType().Brand()
+	return g.nodeRef(n, "_Future", b, rel, g.env(rel))
+}
+
+func (g *generator) qualify(imp importSpec, name string) string {
+	if imp.path == "" {
+		return name
+	}
+	return g.imports.add(imp) + "." + name
+}
+
+// nodeRef returns the name of n plus suffix, qualified for use from rel,
+// followed by the type arguments for brand b.
+func (g *generator) nodeRef(n *node, suffix string, b schema.Brand, rel *node, env typeEnv) (string, error) {
+	ref, err := makeNodeTypeRef(n, rel)
+	if err != nil {
+		return "", err
+	}
+	args, err := g.typeArgs(n, b, rel, env)
+	if err != nil {
+		return "", err
+	}
+	return g.qualify(ref.imp, ref.name+suffix) + args, nil
+}
+
+// typeName returns the Go type for t as referenced from rel, with
+// generic parameters bound according to env.
+func (g *generator) typeName(t schema.Type, rel *node, env typeEnv) (string, error) {
+	switch t.Which() {
+	case schema.Type_Which_anyPointer:
+		if ap := t.AnyPointer(); ap.Which() == schema.Type_anyPointer_Which_parameter {
+			return g.paramName(env, ap.Parameter().ScopeId(), ap.Parameter().ParameterIndex()), nil
+		}
+	case schema.Type_Which_structType:
+		n, err := g.nodes.mustFind(t.StructType().TypeId())
+		if err != nil {
+			return "", err
+		}
+		b, _ := t.StructType().Brand()
+		return g.nodeRef(n, "", b, rel, env)
+	case schema.Type_Which_interface:
+		n, err := g.nodes.mustFind(t.Interface().TypeId())
+		if err != nil {
+			return "", err
+		}
+		b, _ := t.Interface().Brand()
+		return g.nodeRef(n, "", b, rel, env)
+	case schema.Type_Which_list:
+		// Lists of generic types have no named list type, since Go
+		// does not allow generic type aliases.
+		elem, _ := t.List().ElementType()
+		if !g.isGenericType(elem) {
+			break
+		}
+		en, err := g.typeName(elem, rel, env)
+		if err != nil {
+			return "", err
+		}
+		if elem.Which() == schema.Type_Which_structType {
+			return g.imports.Capnp() + ".StructList[" + en + "]", nil
+		}
+		return g.imports.Capnp() + ".CapList[" + en + "]", nil
+	}
+	ref, err := makeTypeRef(t, rel, g.nodes)
+	if err != nil {
+		return "", err
+	}
+	return g.qualify(ref.imp, ref.name), nil
+}
+
+// typeNew returns the name of the function that allocates a value of
+// type t as referenced from rel, with generic parameters bound
+// according to env.
+func (g *generator) typeNew(t schema.Type, rel *node, env typeEnv) (string, error) {
+	var (
+		id     uint64
+		b      schema.Brand
+		suffix string
+	)
+	switch t.Which() {
+	case schema.Type_Which_structType:
+		id = t.StructType().TypeId()
+		b, _ = t.StructType().Brand()
+	case schema.Type_Which_list:
+		elem, _ := t.List().ElementType()
+		if !g.isGenericType(elem) {
+			break
+		}
+		switch elem.Which() {
+		case schema.Type_Which_structType:
+			id = elem.StructType().TypeId()
+			b, _ = elem.StructType().Brand()
+		case schema.Type_Which_interface:
+			id = elem.Interface().TypeId()
+			b, _ = elem.Interface().Brand()
+		}
+		suffix = "_List"
+	}
+	if id != 0 {
+		n, err := g.nodes.mustFind(id)
+		if err != nil {
+			return "", err
+		}
+		ref, err := makeNodeTypeRef(n, rel)
+		if err != nil {
+			return "", err
+		}
+		if ref.newfunc == "" {
+			return "", fmt.Errorf("no new function for %s", ref.name)
+		}
+		args, err := g.typeArgs(n, b, rel, env)
+		if err != nil {
+			return "", err
+		}
+		return g.qualify(ref.imp, ref.newfunc+suffix) + args, nil
+	}
 	ref, err := makeTypeRef(t, rel, g.nodes)
 	if err != nil {
 		return "", err
@@ -226,23 +371,161 @@ func (g *generator) RemoteTypeNew(t schema.Type, rel *node) (string, error) {
 	if ref.newfunc == "" {
 		return "", fmt.Errorf("no new function for %s", ref.name)
 	}
-	if ref.imp.path == "" {
-		return ref.newfunc, nil
+	return g.qualify(ref.imp, ref.newfunc), nil
+}
+
+// isGenericType reports whether t is a struct or interface type that
+// has Go type parameters.
+func (g *generator) isGenericType(t schema.Type) bool {
+	var id uint64
+	switch t.Which() {
+	case schema.Type_Which_structType:
+		id = t.StructType().TypeId()
+	case schema.Type_Which_interface:
+		id = t.Interface().TypeId()
+	default:
+		return false
 	}
-	qname := g.imports.add(ref.imp)
-	return qname + "." + ref.newfunc, nil
+	n := g.nodes[id]
+	return n != nil && len(n.params) > 0
 }
 
-func (g *generator) RemoteTypeName(t schema.Type, rel *node) (string, error) {
-	ref, err := makeTypeRef(t, rel, g.nodes)
+// A typeEnv maps the IDs of generic scopes to the Go type arguments
+// bound to their parameters.
+type typeEnv map[uint64][]string
+
+// env returns the environment inside n's declarations, where each
+// parameter is bound to the corresponding Go type parameter.
+func (g *generator) env(n *node) typeEnv {
+	if n == nil || len(n.params) == 0 {
+		return nil
+	}
+	env := make(typeEnv)
+	for _, p := range n.params {
+		env[p.scope] = append(env[p.scope], p.name)
+	}
+	return env
+}
+
+// lookup returns the Go type bound to a generic parameter in env.
+func (env typeEnv) lookup(scope uint64, index uint16) (string, bool) {
+	if args := env[scope]; int(index) < len(args) {
+		return args[index], true
+	}
+	return "", false
+}
+
+// paramName returns the Go type bound to a generic parameter in env.
+// Parameters that are not bound are represented as capnp.Ptr.
+func (g *generator) paramName(env typeEnv, scope uint64, index uint16) string {
+	if name, ok := env.lookup(scope, index); ok {
+		return name
+	}
+	return g.imports.Capnp() + ".Ptr"
+}
+
+// typeArgs returns the type argument list for referencing n from rel
+// with brand b, or the empty string if n is not generic.  Scopes that
+// b does not bind are looked up in env.
+func (g *generator) typeArgs(n *node, b schema.Brand, rel *node, env typeEnv) (string, error) {
+	if len(n.params) == 0 {
+		return "", nil
+	}
+	benv, err := g.brandEnv(n, b, rel, env)
 	if err != nil {
 		return "", err
 	}
-	if ref.imp.path == "" {
-		return ref.name, nil
+	args := make([]string, len(n.params))
+	for i, p := range n.params {
+		args[i] = g.paramName(benv, p.scope, p.index)
+	}
+	return "[" + strings.Join(args, ", ") + "]", nil
+}
+
+// brandEnv returns the environment for the scopes of n under brand b,
+// as referenced from rel in env.
+func (g *generator) brandEnv(n *node, b schema.Brand, rel *node, env typeEnv) (typeEnv, error) {
+	scopes := make(map[uint64]schema.Brand_Scope)
+	if b.IsValid() {
+		bs, err := b.Scopes()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < bs.Len(); i++ {
+			scopes[bs.At(i).ScopeId()] = bs.At(i)
+		}
+	}
+	benv := make(typeEnv)
+	for _, p := range n.params {
+		arg := g.paramName(env, p.scope, p.index)
+		if sc, ok := scopes[p.scope]; ok && sc.Which() == schema.Brand_Scope_Which_bind {
+			arg = g.imports.Capnp() + ".Ptr"
+			bind, err := sc.Bind()
+			if err != nil {
+				return nil, err
+			}
+			if int(p.index) < bind.Len() && bind.At(int(p.index)).Which() == schema.Brand_Binding_Which_type {
+				t, err := bind.At(int(p.index)).Type()
+				if err != nil {
+					return nil, err
+				}
+				if arg, err = g.typeArg(t, rel, env); err != nil {
+					return nil, err
+				}
+			}
+		}
+		benv[p.scope] = append(benv[p.scope], arg)
+	}
+	return benv, nil
+}
+
+// typeArg returns the Go type argument for binding a generic parameter
+// to t.  Text and Data have no Go type that satisfies capnp.TypeParam,
+// so they are bound as capnp.Ptr.
+func (g *generator) typeArg(t schema.Type, rel *node, env typeEnv) (string, error) {
+	switch t.Which() {
+	case schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_list, schema.Type_Which_anyPointer:
+		return g.typeName(t, rel, env)
+	default:
+		return g.imports.Capnp() + ".Ptr", nil
 	}
-	qname := g.imports.add(ref.imp)
-	return qname + "." + ref.name, nil
+}
+
+// resolveMethodTypes fills in the Go types of methods' structs and
+// server calls, as seen from the interface n, and whether their params
+// are validated.
+func (g *generator) resolveMethodTypes(n *node, methods []interfaceMethod) error {
+	for i := range methods {
+		m := &methods[i]
+		env := g.env(n)
+		for _, s := range m.path {
+			sn, err := g.nodes.mustFind(s.Id())
+			if err != nil {
+				return err
+			}
+			b, _ := s.Brand()
+			if env, err = g.brandEnv(sn, b, n, env); err != nil {
+				return err
+			}
+		}
+		pb, _ := m.ParamBrand()
+		rb, _ := m.ResultBrand()
+		var err error
+		if m.ParamsType, err = g.nodeRef(m.Params, "", pb, n, env); err != nil {
+			return err
+		}
+		if m.ResultsType, err = g.nodeRef(m.Results, "", rb, n, env); err != nil {
+			return err
+		}
+		if m.ResultsFutureType, err = g.nodeRef(m.Results, "_Future", rb, n, env); err != nil {
+			return err
+		}
+		if m.CallType, err = g.nodeRef(m.Interface, "_"+m.Name, schema.Brand{}, n, env); err != nil {
+			return err
+		}
+		m.ValidateParams = g.validates(m.Params)
+	}
+	return nil
 }
 
 func (g *generator) defineEnum(n *node) error {
@@ -603,6 +886,7 @@ func (g *generator) defineField(n *node, f field) (err error) {
 
 		return anyPointer{
 			G:    g,
+			Node: n,
 			Type: t,
 		}.Render(s)
 
@@ -843,6 +1127,14 @@ func (g *generator) defineStruct(n *node) error {
 			return err
 		}
 	}
+	if err := g.defineValidate(n); err != nil {
+		return err
+	}
+	if g.opts.pogs {
+		if err := g.definePogs(n, n); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1048,6 +1340,7 @@ func (g *generator) definePromiseField(n *node, f field) error {
 	case schema.Type_Which_anyPointer:
 		return anyPointer{
 			G:    g,
+			Node: n,
 			Type: t,
 		}.Render(promiseAnyPointerRenderStrategy{
 			G:     g,
@@ -1076,6 +1369,9 @@ func (g *generator) defineInterface(n *node) error {
 	if err != nil {
 		return fmt.Errorf("building method set of interface %s: %v", n, err)
 	}
+	if err := g.resolveMethodTypes(n, m); err != nil {
+		return fmt.Errorf("resolving method types of interface %s: %v", n, err)
+	}
 	nann, _ := n.Annotations()
 	err = g.r.Render(interfaceClientParams{
 		G:           g,
@@ -1215,17 +1511,31 @@ func main() {
 	flag.BoolVar(&opts.promises, "promises", true, "generate code for promises")
 	flag.BoolVar(&opts.schemas, "schemas", true, "embed schema information in generated code")
 	flag.BoolVar(&opts.structStrings, "structstrings", true, "generate String() methods for structs (-schemas must be true)")
+	flag.BoolVar(&opts.pogs, "pogs", false, "generate plain Go structs with ToCapnp and FromCapnp methods")
+	var importPath, srcPrefixes stringList
+	flag.Var(&importPath, "I", "add `dir` to the import path for schema files given as arguments")
+	flag.Var(&srcPrefixes, "src-prefix", "remove `prefix` from the names of schema files given as arguments")
 	flag.Parse()
 
-	msg, err := capnp.NewDecoder(os.Stdin).Decode()
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "capnpc-go: reading input:", err)
-		os.Exit(1)
-	}
-	req, err := schema.ReadRootCodeGeneratorRequest(msg)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "capnpc-go: reading input:", err)
-		os.Exit(1)
+	var req schema.CodeGeneratorRequest
+	if flag.NArg() > 0 {
+		var err error
+		req, err = compileRequest(flag.Args(), importPath, srcPrefixes)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "capnpc-go:", err)
+			os.Exit(1)
+		}
+	} else {
+		msg, err := capnp.NewDecoder(os.Stdin).Decode()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "capnpc-go: reading input:", err)
+			os.Exit(1)
+		}
+		req, err = schema.ReadRootCodeGeneratorRequest(msg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "capnpc-go: reading input:", err)
+			os.Exit(1)
+		}
 	}
 	nodes, err := buildNodeMap(req)
 	if err != nil {
@@ -238,6 +238,147 @@ func TestRemoteScope(t *testing.T) {
 	}
 }
 
+func TestGenericTypes(t *testing.T) {
+	const (
+		keyValueID          = 0x94a081e4abb13424
+		assignableID        = 0xeaf255b498229199
+		assignableGetterID  = 0x80f2f65360d64224
+		assignableResultsID = 0xb351b437cd426a4f // Assignable.get results
+	)
+	req := mustReadGeneratorRequest(t, "util.capnp.out")
+	nodes, err := buildNodeMap(req)
+	if err != nil {
+		t.Fatal("buildNodeMap:", err)
+	}
+	enableGenerics(t, nodes)
+	paramTests := []struct {
+		id         uint64
+		typeParams string
+		typeArgs   string
+	}{
+		{keyValueID, "", ""},
+		{assignableID, "[T capnp.TypeParam[T]]", "[T]"},
+		{assignableGetterID, "[T capnp.TypeParam[T]]", "[T]"},
+		{assignableResultsID, "[T capnp.TypeParam[T]]", "[T]"},
+	}
+	for _, test := range paramTests {
+		n := nodes[test.id]
+		if n == nil {
+			t.Errorf("Can't find node @%#x", test.id)
+			continue
+		}
+		if got := n.TypeParams(); got != test.typeParams {
+			t.Errorf("%s.TypeParams() = %q; want %q", n.Name, got, test.typeParams)
+		}
+		if got := n.TypeArgs(); got != test.typeArgs {
+			t.Errorf("%s.TypeArgs() = %q; want %q", n.Name, got, test.typeArgs)
+		}
+	}
+
+	// Bind Assignable(KeyValue) and reference it from KeyValue.
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	typ, _ := schema.NewRootType(seg)
+	typ.SetInterface()
+	typ.Interface().SetTypeId(assignableID)
+	brand, _ := typ.Interface().NewBrand()
+	scopes, _ := brand.NewScopes(1)
+	scopes.At(0).SetScopeId(assignableID)
+	bind, _ := scopes.At(0).NewBind(1)
+	arg, _ := bind.At(0).NewType()
+	arg.SetStructType()
+	arg.StructType().SetTypeId(keyValueID)
+
+	g := newGenerator(0xecd50d792c3d9992, nodes, genoptions{})
+	if rn, err := g.RemoteTypeName(typ, nodes[keyValueID]); err != nil {
+		t.Errorf("g.RemoteTypeName(Assignable(KeyValue), KeyValue) error: %v", err)
+	} else if want := "Assignable[KeyValue]"; rn != want {
+		t.Errorf("g.RemoteTypeName(Assignable(KeyValue), KeyValue) = %q; want %q", rn, want)
+	}
+	if rn, err := g.RemoteTypeName(typ, nodes[assignableGetterID]); err != nil {
+		t.Errorf("g.RemoteTypeName(Assignable(KeyValue), Assignable.Getter) error: %v", err)
+	} else if want := "Assignable[KeyValue]"; rn != want {
+		t.Errorf("g.RemoteTypeName(Assignable(KeyValue), Assignable.Getter) = %q; want %q", rn, want)
+	}
+
+	// An unbound reference from inside the scope uses the type parameter.
+	typ.Interface().SetBrand(schema.Brand{})
+	if rn, err := g.RemoteTypeName(typ, nodes[assignableGetterID]); err != nil {
+		t.Errorf("g.RemoteTypeName(Assignable, Assignable.Getter) error: %v", err)
+	} else if want := "Assignable[T]"; rn != want {
+		t.Errorf("g.RemoteTypeName(Assignable, Assignable.Getter) = %q; want %q", rn, want)
+	}
+}
+
+func TestGenericTypes_NoAnnotation(t *testing.T) {
+	// Generic types in a file without $Go.generics keep the
+	// non-generic API.
+	const persistentID uint64 = 0xc8cb212fcd9f5691
+	req, err := compileRequest([]string{"../std/capnp/persistent.capnp"}, []string{"../std"}, []string{"../std/capnp"})
+	if err != nil {
+		t.Fatal("compile:", err)
+	}
+	nodes, err := buildNodeMap(req)
+	if err != nil {
+		t.Fatal("buildNodeMap:", err)
+	}
+	n := nodes[persistentID]
+	if n == nil {
+		t.Fatalf("Can't find node @%#x", persistentID)
+	}
+	if !n.IsGeneric() {
+		t.Fatalf("%s is not generic", n.Name)
+	}
+	if got := n.TypeParams(); got != "" {
+		t.Errorf("%s.TypeParams() = %q; want \"\"", n.Name, got)
+	}
+	for _, nn := range nodes {
+		if nn.ScopeId() == persistentID && nn.TypeArgs() != "" {
+			t.Errorf("%s.TypeArgs() = %q; want \"\"", nn.Name, nn.TypeArgs())
+		}
+	}
+}
+
+// enableGenerics resolves the type parameters of nodes as if their
+// files had the $Go.generics annotation, for fixtures that predate it.
+func enableGenerics(t *testing.T, nodes nodeMap) {
+	t.Helper()
+	for _, n := range nodes {
+		n.generics = true
+		n.params = nil
+	}
+	for _, n := range nodes {
+		if err := n.resolveParams(); err != nil {
+			t.Fatal("resolveParams:", err)
+		}
+	}
+}
+
+func TestNumericBound(t *testing.T) {
+	tests := []struct {
+		typ    schema.Type_Which
+		b      float64
+		isMin  bool
+		lit    string
+		always bool
+		never  bool
+	}{
+		{schema.Type_Which_int32, 10, true, "10", false, false},
+		{schema.Type_Which_int32, 0.5, true, "1", false, false},
+		{schema.Type_Which_int32, 0.5, false, "0", false, false},
+		{schema.Type_Which_uint8, -5, true, "", true, false},
+		{schema.Type_Which_uint8, 300, false, "", true, false},
+		{schema.Type_Which_uint8, 300, true, "", false, true},
+		{schema.Type_Which_int8, -200, false, "", false, true},
+		{schema.Type_Which_float64, 0.25, false, "0.25", false, false},
+	}
+	for _, test := range tests {
+		lit, always, never := numericBound(test.typ, test.b, test.isMin)
+		if lit != test.lit || always != test.always || never != test.never {
+			t.Errorf("numericBound(%v, %v, %t) = %q, %t, %t; want %q, %t, %t", test.typ, test.b, test.isMin, lit, always, never, test.lit, test.always, test.never)
+		}
+	}
+}
+
 func hasExactImports(specs []importSpec, imp imports) bool {
 	used := imp.usedImports()
 	if len(used) != len(specs) {
@@ -335,7 +476,21 @@ func TestDefineFile(t *testing.T) {
 			schemas:       true,
 			structStrings: true,
 		}},
+		{0x832bcc6686a26d56, "aircraft.capnp.out", genoptions{
+			promises:      true,
+			schemas:       true,
+			structStrings: true,
+			pogs:          true,
+		}},
 		{0x83c2b5818e83ab19, "group.capnp.out", defaultOptions},
+		{0x83c2b5818e83ab19, "group.capnp.out", genoptions{
+			schemas: true,
+			pogs:    true,
+		}},
+		{0xb312981b2552a250, "rpc.capnp.out", genoptions{
+			schemas: true,
+			pogs:    true,
+		}},
 		{0xb312981b2552a250, "rpc.capnp.out", defaultOptions},
 		{0xd68755941d99d05e, "scopes.capnp.out", defaultOptions},
 		{0xecd50d792c3d9992, "util.capnp.out", defaultOptions},
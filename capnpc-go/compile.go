@@ -0,0 +1,34 @@
+package main
+
+import (
+	"strings"
+
+	"capnproto.org/go/capnp/v3/internal/schema"
+	"capnproto.org/go/capnp/v3/schemas/compiler"
+)
+
+// compileRequest compiles schema files directly, for use in place of a
+// request read from stdin.
+func compileRequest(files, importPath, srcPrefixes []string) (schema.CodeGeneratorRequest, error) {
+	c := &compiler.Compiler{
+		ImportPath:     importPath,
+		SourcePrefixes: srcPrefixes,
+	}
+	req, err := c.Compile(files...)
+	if err != nil {
+		return schema.CodeGeneratorRequest{}, err
+	}
+	return schema.CodeGeneratorRequest(req), nil
+}
+
+// stringList is a flag.Value that collects repeated flags.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
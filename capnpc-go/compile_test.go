@@ -0,0 +1,160 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/encoding/text"
+	"capnproto.org/go/capnp/v3/internal/schema"
+)
+
+// TestCompile checks that compiling the test schemas yields the same
+// requests as the reference compiler.  The fixtures only include the
+// imported nodes that the reference compiler needed, so extra nodes are
+// allowed.  Some schemas have changed since their fixtures were made;
+// the nodes that changed are listed in drift and only checked for
+// presence.
+func TestCompile(t *testing.T) {
+	const (
+		goFile    = 0xd12a1c51fedd6c88 // annotations added since
+		goImport  = 0xe130b601260e44b5 // imported as ../../go.capnp
+		goPackage = 0xbea97f1023792be0 // imported as ../../go.capnp
+	)
+	tests := []struct {
+		name       string
+		dir        string // directory of the schema file, if not testdata
+		importPath []string
+		drift      []uint64
+	}{
+		{
+			name:       "aircraft.capnp",
+			dir:        filepath.Join("..", "internal", "aircraftlib"),
+			importPath: []string{filepath.Join("..", "std")},
+			drift: []uint64{
+				0x832bcc6686a26d56, // aircraft.capnp: nodes added since
+				0x8748bc095e10cb5d, // Counter: fields added since
+				0xea26e9973bd6a0d9, // Z: members added since
+				goFile,
+			},
+		},
+		{name: "const.capnp"},
+		{name: "go.capnp"},
+		{name: "group.capnp"},
+		{
+			name:       "rpc.capnp",
+			dir:        filepath.Join("..", "std", "capnp"),
+			importPath: []string{filepath.Join("..", "std")},
+			drift: []uint64{
+				0xb312981b2552a250, // rpc.capnp: C++ namespace and Go package changed since
+				0x8523ddc40b86b8b0, // CapDescriptor: attachedFd added since
+				0xd625b7063acf691a, // Exception: trace added since
+				goFile,
+				goImport,
+				goPackage,
+			},
+		},
+		{name: "scopes.capnp"},
+		{name: "util.capnp"},
+	}
+	for _, test := range tests {
+		name, dir := test.name, test.dir
+		if dir == "" {
+			dir = "testdata"
+		}
+		drift := make(map[uint64]bool)
+		for _, id := range test.drift {
+			drift[id] = true
+		}
+		t.Run(name, func(t *testing.T) {
+			want := mustReadGeneratorRequest(t, name+".out")
+			got, err := compileRequest([]string{filepath.Join(dir, name)}, test.importPath, []string{dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotNodes, _ := got.Nodes()
+			wantNodes, _ := want.Nodes()
+			byID := make(map[uint64]schema.Node)
+			for i := 0; i < gotNodes.Len(); i++ {
+				byID[gotNodes.At(i).Id()] = gotNodes.At(i)
+			}
+			for i := 0; i < wantNodes.Len(); i++ {
+				w := wantNodes.At(i)
+				dn, _ := w.DisplayName()
+				g, ok := byID[w.Id()]
+				if !ok {
+					t.Errorf("missing node %s (@%#x)", dn, w.Id())
+					continue
+				}
+				if drift[w.Id()] {
+					continue
+				}
+				if gs, ws := encodeText(t, schema.Node_TypeID, capnp.Struct(g)), encodeText(t, schema.Node_TypeID, capnp.Struct(w)); gs != ws {
+					t.Errorf("node %s:\ngot  %s\nwant %s", dn, gs, ws)
+				}
+			}
+
+			// The reference compiler writes an empty entry with ID 0
+			// and omits method parameter structs.
+			gotInfo, _ := got.SourceInfo()
+			wantInfo, _ := want.SourceInfo()
+			infoByID := make(map[uint64]schema.Node_SourceInfo)
+			for i := 0; i < gotInfo.Len(); i++ {
+				infoByID[gotInfo.At(i).Id()] = gotInfo.At(i)
+			}
+			for i := 0; i < wantInfo.Len(); i++ {
+				w := wantInfo.At(i)
+				if w.Id() == 0 || drift[w.Id()] {
+					continue
+				}
+				g, ok := infoByID[w.Id()]
+				if !ok {
+					t.Errorf("missing source info for @%#x", w.Id())
+					continue
+				}
+				if gs, ws := encodeText(t, schema.Node_SourceInfo_TypeID, capnp.Struct(g)), encodeText(t, schema.Node_SourceInfo_TypeID, capnp.Struct(w)); gs != ws {
+					t.Errorf("source info @%#x:\ngot  %s\nwant %s", w.Id(), gs, ws)
+				}
+			}
+
+			gotFiles, _ := got.RequestedFiles()
+			wantFiles, _ := want.RequestedFiles()
+			if gotFiles.Len() != wantFiles.Len() {
+				t.Fatalf("len(requestedFiles) = %d; want %d", gotFiles.Len(), wantFiles.Len())
+			}
+			for i := 0; i < wantFiles.Len(); i++ {
+				g, w := gotFiles.At(i), wantFiles.At(i)
+				if drift[w.Id()] {
+					gn, _ := g.Filename()
+					wn, _ := w.Filename()
+					if g.Id() != w.Id() || gn != wn {
+						t.Errorf("requestedFiles[%d] = %s (@%#x); want %s (@%#x)", i, gn, g.Id(), wn, w.Id())
+					}
+					continue
+				}
+				typeID := uint64(schema.CodeGeneratorRequest_RequestedFile_TypeID)
+				if gs, ws := encodeText(t, typeID, capnp.Struct(g)), encodeText(t, typeID, capnp.Struct(w)); gs != ws {
+					t.Errorf("requestedFiles[%d]:\ngot  %s\nwant %s", i, gs, ws)
+				}
+			}
+		})
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	_, err := compileRequest([]string{filepath.Join("testdata", "nonexistent.capnp")}, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "nonexistent.capnp") {
+		t.Errorf("compileRequest(nonexistent.capnp) error = %v; want error naming the file", err)
+	}
+}
+
+func encodeText(t *testing.T, typeID uint64, s capnp.Struct) string {
+	t.Helper()
+	var sb strings.Builder
+	if err := text.NewEncoder(&sb).Encode(typeID, s); err != nil {
+		t.Fatal(err)
+	}
+	return sb.String()
+}
@@ -12,5 +12,6 @@ var (
 
 	templates = template.Must(template.New("").Funcs(template.FuncMap{
 		"title": strings.Title,
+		"pogs":  pogsName,
 	}).ParseFS(templateFS, "templates/*"))
 )
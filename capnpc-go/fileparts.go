@@ -21,10 +21,12 @@ var (
 		{path: flowcontrolImport, name: "fc"},
 
 		// stdlib imports
+		{path: "errors", name: "errors"},
 		{path: "fmt", name: "fmt"},
 		{path: "context", name: "context"},
 		{path: "math", name: "math"},
 		{path: "strconv", name: "strconv"},
+		{path: "regexp", name: "regexp"},
 	}
 )
 
@@ -106,6 +108,10 @@ func (i *imports) FlowControl() string {
 	return i.add(importSpec{path: flowcontrolImport, name: "fc"})
 }
 
+func (i *imports) Errors() string {
+	return i.add(importSpec{path: "errors", name: "errors"})
+}
+
 func (i *imports) Fmt() string {
 	return i.add(importSpec{path: "fmt", name: "fmt"})
 }
@@ -122,6 +128,10 @@ func (i *imports) Strconv() string {
 	return i.add(importSpec{path: "strconv", name: "strconv"})
 }
 
+func (i *imports) Regexp() string {
+	return i.add(importSpec{path: "regexp", name: "regexp"})
+}
+
 func (i *imports) usedImports() []importSpec {
 	specs := make([]importSpec, 0, len(i.specs))
 	for _, s := range i.specs {
@@ -0,0 +1,3 @@
+package generics
+
+//go:generate go run capnproto.org/go/capnp/v3/capnpc-go -I ../../../std generics.capnp
@@ -0,0 +1,1688 @@
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package generics
+
+import (
+	capnp "capnproto.org/go/capnp/v3"
+	text "capnproto.org/go/capnp/v3/encoding/text"
+	fc "capnproto.org/go/capnp/v3/flowcontrol"
+	schemas "capnproto.org/go/capnp/v3/schemas"
+	server "capnproto.org/go/capnp/v3/server"
+	context "context"
+	fmt "fmt"
+)
+
+type Box[T capnp.TypeParam[T]] capnp.Struct
+
+// Box_TypeID is the unique identifier for the type Box.
+const Box_TypeID = 0xc6cdd9287c95f9b5
+
+func NewBox[T capnp.TypeParam[T]](s *capnp.Segment) (Box[T], error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Box[T](st), err
+}
+
+func NewRootBox[T capnp.TypeParam[T]](s *capnp.Segment) (Box[T], error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Box[T](st), err
+}
+
+func ReadRootBox[T capnp.TypeParam[T]](msg *capnp.Message) (Box[T], error) {
+	root, err := msg.Root()
+	return Box[T](root.Struct()), err
+}
+
+func (s Box[T]) String() string {
+	str, _ := text.Marshal(0xc6cdd9287c95f9b5, capnp.Struct(s))
+	return str
+}
+
+func (s Box[T]) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Box[T]) DecodeFromPtr(p capnp.Ptr) Box[T] {
+	return Box[T](capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Box[T]) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Box[T]) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Box[T]) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Box[T]) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Box[T]) Value() (T, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	var v T
+	return v.DecodeFromPtr(p), err
+}
+
+func (s Box[T]) HasValue() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownValue detaches the value field from s without
+// copying it.  The field is left null.
+func (s Box[T]) DisownValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptValue sets the value field to an orphan from
+// s's message without copying it.
+func (s Box[T]) AdoptValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Box[T]) SetValue(v T) error {
+	return capnp.Struct(s).SetPtr(0, v.EncodeAsPtr(capnp.Struct(s).Segment()))
+}
+func (s Box[T]) Inner() (Box_Inner[T], error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return Box_Inner[T](p.Struct()), err
+}
+
+func (s Box[T]) HasInner() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+// DisownInner detaches the inner field from s without
+// copying it.  The field is left null.
+func (s Box[T]) DisownInner() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptInner sets the inner field to an orphan from
+// s's message without copying it.
+func (s Box[T]) AdoptInner(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
+func (s Box[T]) SetInner(v Box_Inner[T]) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
+}
+
+// NewInner sets the inner field to a newly
+// allocated Box_Inner[T] struct, preferring placement in s's segment.
+func (s Box[T]) NewInner() (Box_Inner[T], error) {
+	ss, err := NewBox_Inner[T](capnp.Struct(s).Segment())
+	if err != nil {
+		return Box_Inner[T]{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+// NewBox_List creates a new list of Box.
+func NewBox_List[T capnp.TypeParam[T]](s *capnp.Segment, sz int32) (capnp.StructList[Box[T]], error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return capnp.StructList[Box[T]](l), err
+}
+
+// Box_Future is a wrapper for a Box promised by a client call.
+type Box_Future[T capnp.TypeParam[T]] struct{ *capnp.Future }
+
+func (f Box_Future[T]) Struct() (Box[T], error) {
+	p, err := f.Future.Ptr()
+	return Box[T](p.Struct()), err
+}
+func (p Box_Future[T]) Value() *capnp.Future {
+	return p.Future.Field(0, nil)
+}
+func (p Box_Future[T]) Inner() Box_Inner_Future[T] {
+	return Box_Inner_Future[T]{Future: p.Future.Field(1, nil)}
+}
+
+type Box_Inner[T capnp.TypeParam[T]] capnp.Struct
+
+// Box_Inner_TypeID is the unique identifier for the type Box_Inner.
+const Box_Inner_TypeID = 0xf75a62a9bb718247
+
+func NewBox_Inner[T capnp.TypeParam[T]](s *capnp.Segment) (Box_Inner[T], error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Box_Inner[T](st), err
+}
+
+func NewRootBox_Inner[T capnp.TypeParam[T]](s *capnp.Segment) (Box_Inner[T], error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Box_Inner[T](st), err
+}
+
+func ReadRootBox_Inner[T capnp.TypeParam[T]](msg *capnp.Message) (Box_Inner[T], error) {
+	root, err := msg.Root()
+	return Box_Inner[T](root.Struct()), err
+}
+
+func (s Box_Inner[T]) String() string {
+	str, _ := text.Marshal(0xf75a62a9bb718247, capnp.Struct(s))
+	return str
+}
+
+func (s Box_Inner[T]) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Box_Inner[T]) DecodeFromPtr(p capnp.Ptr) Box_Inner[T] {
+	return Box_Inner[T](capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Box_Inner[T]) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Box_Inner[T]) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Box_Inner[T]) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Box_Inner[T]) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Box_Inner[T]) Value() (T, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	var v T
+	return v.DecodeFromPtr(p), err
+}
+
+func (s Box_Inner[T]) HasValue() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownValue detaches the value field from s without
+// copying it.  The field is left null.
+func (s Box_Inner[T]) DisownValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptValue sets the value field to an orphan from
+// s's message without copying it.
+func (s Box_Inner[T]) AdoptValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Box_Inner[T]) SetValue(v T) error {
+	return capnp.Struct(s).SetPtr(0, v.EncodeAsPtr(capnp.Struct(s).Segment()))
+}
+
+// NewBox_Inner_List creates a new list of Box_Inner.
+func NewBox_Inner_List[T capnp.TypeParam[T]](s *capnp.Segment, sz int32) (capnp.StructList[Box_Inner[T]], error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[Box_Inner[T]](l), err
+}
+
+// Box_Inner_Future is a wrapper for a Box_Inner promised by a client call.
+type Box_Inner_Future[T capnp.TypeParam[T]] struct{ *capnp.Future }
+
+func (f Box_Inner_Future[T]) Struct() (Box_Inner[T], error) {
+	p, err := f.Future.Ptr()
+	return Box_Inner[T](p.Struct()), err
+}
+func (p Box_Inner_Future[T]) Value() *capnp.Future {
+	return p.Future.Field(0, nil)
+}
+
+type Pair[K capnp.TypeParam[K], V capnp.TypeParam[V]] capnp.Struct
+
+// Pair_TypeID is the unique identifier for the type Pair.
+const Pair_TypeID = 0xe444fbe75eb3fba8
+
+func NewPair[K capnp.TypeParam[K], V capnp.TypeParam[V]](s *capnp.Segment) (Pair[K, V], error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Pair[K, V](st), err
+}
+
+func NewRootPair[K capnp.TypeParam[K], V capnp.TypeParam[V]](s *capnp.Segment) (Pair[K, V], error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return Pair[K, V](st), err
+}
+
+func ReadRootPair[K capnp.TypeParam[K], V capnp.TypeParam[V]](msg *capnp.Message) (Pair[K, V], error) {
+	root, err := msg.Root()
+	return Pair[K, V](root.Struct()), err
+}
+
+func (s Pair[K, V]) String() string {
+	str, _ := text.Marshal(0xe444fbe75eb3fba8, capnp.Struct(s))
+	return str
+}
+
+func (s Pair[K, V]) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Pair[K, V]) DecodeFromPtr(p capnp.Ptr) Pair[K, V] {
+	return Pair[K, V](capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Pair[K, V]) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Pair[K, V]) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Pair[K, V]) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Pair[K, V]) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Pair[K, V]) Key() (K, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	var v K
+	return v.DecodeFromPtr(p), err
+}
+
+func (s Pair[K, V]) HasKey() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownKey detaches the key field from s without
+// copying it.  The field is left null.
+func (s Pair[K, V]) DisownKey() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptKey sets the key field to an orphan from
+// s's message without copying it.
+func (s Pair[K, V]) AdoptKey(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Pair[K, V]) SetKey(v K) error {
+	return capnp.Struct(s).SetPtr(0, v.EncodeAsPtr(capnp.Struct(s).Segment()))
+}
+func (s Pair[K, V]) Value() (V, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	var v V
+	return v.DecodeFromPtr(p), err
+}
+
+func (s Pair[K, V]) HasValue() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+// DisownValue detaches the value field from s without
+// copying it.  The field is left null.
+func (s Pair[K, V]) DisownValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptValue sets the value field to an orphan from
+// s's message without copying it.
+func (s Pair[K, V]) AdoptValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
+func (s Pair[K, V]) SetValue(v V) error {
+	return capnp.Struct(s).SetPtr(1, v.EncodeAsPtr(capnp.Struct(s).Segment()))
+}
+
+// NewPair_List creates a new list of Pair.
+func NewPair_List[K capnp.TypeParam[K], V capnp.TypeParam[V]](s *capnp.Segment, sz int32) (capnp.StructList[Pair[K, V]], error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return capnp.StructList[Pair[K, V]](l), err
+}
+
+// Pair_Future is a wrapper for a Pair promised by a client call.
+type Pair_Future[K capnp.TypeParam[K], V capnp.TypeParam[V]] struct{ *capnp.Future }
+
+func (f Pair_Future[K, V]) Struct() (Pair[K, V], error) {
+	p, err := f.Future.Ptr()
+	return Pair[K, V](p.Struct()), err
+}
+func (p Pair_Future[K, V]) Key() *capnp.Future {
+	return p.Future.Field(0, nil)
+}
+func (p Pair_Future[K, V]) Value() *capnp.Future {
+	return p.Future.Field(1, nil)
+}
+
+type Thing capnp.Struct
+
+// Thing_TypeID is the unique identifier for the type Thing.
+const Thing_TypeID = 0xa2a99ceeed95cd17
+
+func NewThing(s *capnp.Segment) (Thing, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Thing(st), err
+}
+
+func NewRootThing(s *capnp.Segment) (Thing, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Thing(st), err
+}
+
+func ReadRootThing(msg *capnp.Message) (Thing, error) {
+	root, err := msg.Root()
+	return Thing(root.Struct()), err
+}
+
+func (s Thing) String() string {
+	str, _ := text.Marshal(0xa2a99ceeed95cd17, capnp.Struct(s))
+	return str
+}
+
+func (s Thing) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Thing) DecodeFromPtr(p capnp.Ptr) Thing {
+	return Thing(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Thing) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Thing) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Thing) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Thing) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Thing) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s Thing) HasName() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s Thing) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s Thing) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Thing) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s Thing) SetName(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+// Thing_List is a list of Thing.
+type Thing_List = capnp.StructList[Thing]
+
+// NewThing creates a new list of Thing.
+func NewThing_List(s *capnp.Segment, sz int32) (Thing_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[Thing](l), err
+}
+
+// Thing_Future is a wrapper for a Thing promised by a client call.
+type Thing_Future struct{ *capnp.Future }
+
+func (f Thing_Future) Struct() (Thing, error) {
+	p, err := f.Future.Ptr()
+	return Thing(p.Struct()), err
+}
+
+type User capnp.Struct
+
+// User_TypeID is the unique identifier for the type User.
+const User_TypeID = 0xcc481762ed686ff0
+
+func NewUser(s *capnp.Segment) (User, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 7})
+	return User(st), err
+}
+
+func NewRootUser(s *capnp.Segment) (User, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 7})
+	return User(st), err
+}
+
+func ReadRootUser(msg *capnp.Message) (User, error) {
+	root, err := msg.Root()
+	return User(root.Struct()), err
+}
+
+func (s User) String() string {
+	str, _ := text.Marshal(0xcc481762ed686ff0, capnp.Struct(s))
+	return str
+}
+
+func (s User) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (User) DecodeFromPtr(p capnp.Ptr) User {
+	return User(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s User) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s User) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s User) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s User) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s User) Box() (Box[Thing], error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Box[Thing](p.Struct()), err
+}
+
+func (s User) HasBox() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownBox detaches the box field from s without
+// copying it.  The field is left null.
+func (s User) DisownBox() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBox sets the box field to an orphan from
+// s's message without copying it.
+func (s User) AdoptBox(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s User) SetBox(v Box[Thing]) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewBox sets the box field to a newly
+// allocated Box[Thing] struct, preferring placement in s's segment.
+func (s User) NewBox() (Box[Thing], error) {
+	ss, err := NewBox[Thing](capnp.Struct(s).Segment())
+	if err != nil {
+		return Box[Thing]{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s User) Boxes() (capnp.StructList[Box[Thing]], error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return capnp.StructList[Box[Thing]](p.List()), err
+}
+
+func (s User) HasBoxes() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+// DisownBoxes detaches the boxes field from s without
+// copying it.  The field is left null.
+func (s User) DisownBoxes() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptBoxes sets the boxes field to an orphan from
+// s's message without copying it.
+func (s User) AdoptBoxes(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
+func (s User) SetBoxes(v capnp.StructList[Box[Thing]]) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewBoxes sets the boxes field to a newly
+// allocated capnp.StructList[Box[Thing]], preferring placement in s's segment.
+func (s User) NewBoxes(n int32) (capnp.StructList[Box[Thing]], error) {
+	l, err := NewBox_List[Thing](capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.StructList[Box[Thing]]{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+func (s User) Pair() (Pair[Thing, Box[Thing]], error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return Pair[Thing, Box[Thing]](p.Struct()), err
+}
+
+func (s User) HasPair() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+// DisownPair detaches the pair field from s without
+// copying it.  The field is left null.
+func (s User) DisownPair() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptPair sets the pair field to an orphan from
+// s's message without copying it.
+func (s User) AdoptPair(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
+func (s User) SetPair(v Pair[Thing, Box[Thing]]) error {
+	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
+}
+
+// NewPair sets the pair field to a newly
+// allocated Pair[Thing, Box[Thing]] struct, preferring placement in s's segment.
+func (s User) NewPair() (Pair[Thing, Box[Thing]], error) {
+	ss, err := NewPair[Thing, Box[Thing]](capnp.Struct(s).Segment())
+	if err != nil {
+		return Pair[Thing, Box[Thing]]{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s User) TextBox() (Box[capnp.Ptr], error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return Box[capnp.Ptr](p.Struct()), err
+}
+
+func (s User) HasTextBox() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+// DisownTextBox detaches the textBox field from s without
+// copying it.  The field is left null.
+func (s User) DisownTextBox() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptTextBox sets the textBox field to an orphan from
+// s's message without copying it.
+func (s User) AdoptTextBox(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
+func (s User) SetTextBox(v Box[capnp.Ptr]) error {
+	return capnp.Struct(s).SetPtr(3, capnp.Struct(v).ToPtr())
+}
+
+// NewTextBox sets the textBox field to a newly
+// allocated Box[capnp.Ptr] struct, preferring placement in s's segment.
+func (s User) NewTextBox() (Box[capnp.Ptr], error) {
+	ss, err := NewBox[capnp.Ptr](capnp.Struct(s).Segment())
+	if err != nil {
+		return Box[capnp.Ptr]{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s User) AnyBox() (Box[capnp.Ptr], error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return Box[capnp.Ptr](p.Struct()), err
+}
+
+func (s User) HasAnyBox() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+// DisownAnyBox detaches the anyBox field from s without
+// copying it.  The field is left null.
+func (s User) DisownAnyBox() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(4)
+}
+
+// AdoptAnyBox sets the anyBox field to an orphan from
+// s's message without copying it.
+func (s User) AdoptAnyBox(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(4, o)
+}
+
+func (s User) SetAnyBox(v Box[capnp.Ptr]) error {
+	return capnp.Struct(s).SetPtr(4, capnp.Struct(v).ToPtr())
+}
+
+// NewAnyBox sets the anyBox field to a newly
+// allocated Box[capnp.Ptr] struct, preferring placement in s's segment.
+func (s User) NewAnyBox() (Box[capnp.Ptr], error) {
+	ss, err := NewBox[capnp.Ptr](capnp.Struct(s).Segment())
+	if err != nil {
+		return Box[capnp.Ptr]{}, err
+	}
+	err = capnp.Struct(s).SetPtr(4, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s User) Inner() (Box_Inner[Thing], error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return Box_Inner[Thing](p.Struct()), err
+}
+
+func (s User) HasInner() bool {
+	return capnp.Struct(s).HasPtr(5)
+}
+
+// DisownInner detaches the inner field from s without
+// copying it.  The field is left null.
+func (s User) DisownInner() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(5)
+}
+
+// AdoptInner sets the inner field to an orphan from
+// s's message without copying it.
+func (s User) AdoptInner(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(5, o)
+}
+
+func (s User) SetInner(v Box_Inner[Thing]) error {
+	return capnp.Struct(s).SetPtr(5, capnp.Struct(v).ToPtr())
+}
+
+// NewInner sets the inner field to a newly
+// allocated Box_Inner[Thing] struct, preferring placement in s's segment.
+func (s User) NewInner() (Box_Inner[Thing], error) {
+	ss, err := NewBox_Inner[Thing](capnp.Struct(s).Segment())
+	if err != nil {
+		return Box_Inner[Thing]{}, err
+	}
+	err = capnp.Struct(s).SetPtr(5, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s User) Holder() Holder[Thing] {
+	p, _ := capnp.Struct(s).Ptr(6)
+	return Holder[Thing](p.Interface().Client())
+}
+
+func (s User) HasHolder() bool {
+	return capnp.Struct(s).HasPtr(6)
+}
+
+func (s User) SetHolder(v Holder[Thing]) error {
+	if !v.IsValid() {
+		return capnp.Struct(s).SetPtr(6, capnp.Ptr{})
+	}
+	seg := s.Segment()
+	in := capnp.NewInterface(seg, seg.Message().AddCap(capnp.Client(v)))
+	return capnp.Struct(s).SetPtr(6, in.ToPtr())
+}
+
+// User_List is a list of User.
+type User_List = capnp.StructList[User]
+
+// NewUser creates a new list of User.
+func NewUser_List(s *capnp.Segment, sz int32) (User_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 7}, sz)
+	return capnp.StructList[User](l), err
+}
+
+// User_Future is a wrapper for a User promised by a client call.
+type User_Future struct{ *capnp.Future }
+
+func (f User_Future) Struct() (User, error) {
+	p, err := f.Future.Ptr()
+	return User(p.Struct()), err
+}
+func (p User_Future) Box() Box_Future[Thing] {
+	return Box_Future[Thing]{Future: p.Future.Field(0, nil)}
+}
+func (p User_Future) Pair() Pair_Future[Thing, Box[Thing]] {
+	return Pair_Future[Thing, Box[Thing]]{Future: p.Future.Field(2, nil)}
+}
+func (p User_Future) TextBox() Box_Future[capnp.Ptr] {
+	return Box_Future[capnp.Ptr]{Future: p.Future.Field(3, nil)}
+}
+func (p User_Future) AnyBox() Box_Future[capnp.Ptr] {
+	return Box_Future[capnp.Ptr]{Future: p.Future.Field(4, nil)}
+}
+func (p User_Future) Inner() Box_Inner_Future[Thing] {
+	return Box_Inner_Future[Thing]{Future: p.Future.Field(5, nil)}
+}
+func (p User_Future) Holder() Holder[Thing] {
+	return Holder[Thing](p.Future.Field(6, nil).Client())
+}
+
+type Holder[T capnp.TypeParam[T]] capnp.Client
+
+// Holder_TypeID is the unique identifier for the type Holder.
+const Holder_TypeID = 0xa03cef631e51b1e3
+
+func (c Holder[T]) Get(ctx context.Context, params func(Holder_get_Params[T]) error) (Holder_get_Results_Future[T], capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xa03cef631e51b1e3,
+			MethodID:      0,
+			InterfaceName: "generics.capnp:Holder",
+			MethodName:    "get",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(Holder_get_Params[T](s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return Holder_get_Results_Future[T]{Future: ans.Future()}, release
+}
+func (c Holder[T]) Set(ctx context.Context, params func(Holder_set_Params[T]) error) (Holder_set_Results_Future[T], capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xa03cef631e51b1e3,
+			MethodID:      1,
+			InterfaceName: "generics.capnp:Holder",
+			MethodName:    "set",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(Holder_set_Params[T](s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return Holder_set_Results_Future[T]{Future: ans.Future()}, release
+}
+
+// String returns a string that identifies this capability for debugging
+// purposes.  Its format should not be depended on: in particular, it
+// should not be used to compare clients.  Use IsSame to compare clients
+// for equality.
+func (c Holder[T]) String() string {
+	return fmt.Sprintf("%T(%v)", c, capnp.Client(c))
+}
+
+// AddRef creates a new Client that refers to the same capability as c.
+// If c is nil or has resolved to null, then AddRef returns nil.
+func (c Holder[T]) AddRef() Holder[T] {
+	return Holder[T](capnp.Client(c).AddRef())
+}
+
+// Release releases a capability reference.  If this is the last
+// reference to the capability, then the underlying resources associated
+// with the capability will be released.
+//
+// Release will panic if c has already been released, but not if c is
+// nil or resolved to null.
+func (c Holder[T]) Release() {
+	capnp.Client(c).Release()
+}
+
+// Resolve blocks until the capability is fully resolved or the Context
+// expires.
+func (c Holder[T]) Resolve(ctx context.Context) error {
+	return capnp.Client(c).Resolve(ctx)
+}
+
+func (c Holder[T]) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Client(c).EncodeAsPtr(seg)
+}
+
+func (Holder[T]) DecodeFromPtr(p capnp.Ptr) Holder[T] {
+	return Holder[T](capnp.Client{}.DecodeFromPtr(p))
+}
+
+// IsValid reports whether c is a valid reference to a capability.
+// A reference is invalid if it is nil, has resolved to null, or has
+// been released.
+func (c Holder[T]) IsValid() bool {
+	return capnp.Client(c).IsValid()
+}
+
+// IsSame reports whether c and other refer to a capability created by the
+// same call to NewClient.  This can return false negatives if c or other
+// are not fully resolved: use Resolve if this is an issue.  If either
+// c or other are released, then IsSame panics.
+func (c Holder[T]) IsSame(other Holder[T]) bool {
+	return capnp.Client(c).IsSame(capnp.Client(other))
+}
+
+// Update the flowcontrol.FlowLimiter used to manage flow control for
+// this client. This affects all future calls, but not calls already
+// waiting to send. Passing nil sets the value to flowcontrol.NopLimiter,
+// which is also the default.
+func (c Holder[T]) SetFlowLimiter(lim fc.FlowLimiter) {
+	capnp.Client(c).SetFlowLimiter(lim)
+}
+
+// Get the current flowcontrol.FlowLimiter used to manage flow control
+// for this client.
+func (c Holder[T]) GetFlowLimiter() fc.FlowLimiter {
+	return capnp.Client(c).GetFlowLimiter()
+} // A Holder_Server is a Holder with a local implementation.
+type Holder_Server[T capnp.TypeParam[T]] interface {
+	Get(context.Context, Holder_get[T]) error
+
+	Set(context.Context, Holder_set[T]) error
+}
+
+// Holder_NewServer creates a new Server from an implementation of Holder_Server.
+func Holder_NewServer[T capnp.TypeParam[T]](s Holder_Server[T], opts ...server.Option) *server.Server {
+	c, _ := s.(server.Shutdowner)
+	return server.New(Holder_Methods[T](nil, s), s, c, opts...)
+}
+
+// Holder_ServerToClient creates a new Client from an implementation of Holder_Server.
+// The caller is responsible for calling Release on the returned Client.
+func Holder_ServerToClient[T capnp.TypeParam[T]](s Holder_Server[T]) Holder[T] {
+	return Holder[T](capnp.NewClient(Holder_NewServer(s)))
+}
+
+// Holder_Methods appends Methods to a slice that invoke the methods on s.
+// This can be used to create a more complicated Server.
+func Holder_Methods[T capnp.TypeParam[T]](methods []server.Method, s Holder_Server[T]) []server.Method {
+	if cap(methods) == 0 {
+		methods = make([]server.Method, 0, 2)
+	}
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xa03cef631e51b1e3,
+			MethodID:      0,
+			InterfaceName: "generics.capnp:Holder",
+			MethodName:    "get",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Get(ctx, Holder_get[T]{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xa03cef631e51b1e3,
+			MethodID:      1,
+			InterfaceName: "generics.capnp:Holder",
+			MethodName:    "set",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Set(ctx, Holder_set[T]{call})
+		},
+	})
+
+	return methods
+}
+
+// Holder_get holds the state for a server call to Holder.get.
+// See server.Call for documentation.
+type Holder_get[T capnp.TypeParam[T]] struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c Holder_get[T]) Args() Holder_get_Params[T] {
+	return Holder_get_Params[T](c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c Holder_get[T]) AllocResults() (Holder_get_Results[T], error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Holder_get_Results[T](r), err
+}
+
+// Holder_set holds the state for a server call to Holder.set.
+// See server.Call for documentation.
+type Holder_set[T capnp.TypeParam[T]] struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c Holder_set[T]) Args() Holder_set_Params[T] {
+	return Holder_set_Params[T](c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c Holder_set[T]) AllocResults() (Holder_set_Results[T], error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return Holder_set_Results[T](r), err
+}
+
+// NewHolder_List creates a new list of Holder.
+func NewHolder_List[T capnp.TypeParam[T]](s *capnp.Segment, sz int32) (capnp.CapList[Holder[T]], error) {
+	l, err := capnp.NewPointerList(s, sz)
+	return capnp.CapList[Holder[T]](l), err
+}
+
+type Holder_get_Params[T capnp.TypeParam[T]] capnp.Struct
+
+// Holder_get_Params_TypeID is the unique identifier for the type Holder_get_Params.
+const Holder_get_Params_TypeID = 0x9156f251931616b0
+
+func NewHolder_get_Params[T capnp.TypeParam[T]](s *capnp.Segment) (Holder_get_Params[T], error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return Holder_get_Params[T](st), err
+}
+
+func NewRootHolder_get_Params[T capnp.TypeParam[T]](s *capnp.Segment) (Holder_get_Params[T], error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return Holder_get_Params[T](st), err
+}
+
+func ReadRootHolder_get_Params[T capnp.TypeParam[T]](msg *capnp.Message) (Holder_get_Params[T], error) {
+	root, err := msg.Root()
+	return Holder_get_Params[T](root.Struct()), err
+}
+
+func (s Holder_get_Params[T]) String() string {
+	str, _ := text.Marshal(0x9156f251931616b0, capnp.Struct(s))
+	return str
+}
+
+func (s Holder_get_Params[T]) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Holder_get_Params[T]) DecodeFromPtr(p capnp.Ptr) Holder_get_Params[T] {
+	return Holder_get_Params[T](capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Holder_get_Params[T]) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Holder_get_Params[T]) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Holder_get_Params[T]) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Holder_get_Params[T]) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// NewHolder_get_Params_List creates a new list of Holder_get_Params.
+func NewHolder_get_Params_List[T capnp.TypeParam[T]](s *capnp.Segment, sz int32) (capnp.StructList[Holder_get_Params[T]], error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[Holder_get_Params[T]](l), err
+}
+
+// Holder_get_Params_Future is a wrapper for a Holder_get_Params promised by a client call.
+type Holder_get_Params_Future[T capnp.TypeParam[T]] struct{ *capnp.Future }
+
+func (f Holder_get_Params_Future[T]) Struct() (Holder_get_Params[T], error) {
+	p, err := f.Future.Ptr()
+	return Holder_get_Params[T](p.Struct()), err
+}
+
+type Holder_get_Results[T capnp.TypeParam[T]] capnp.Struct
+
+// Holder_get_Results_TypeID is the unique identifier for the type Holder_get_Results.
+const Holder_get_Results_TypeID = 0x8d203366f25ee361
+
+func NewHolder_get_Results[T capnp.TypeParam[T]](s *capnp.Segment) (Holder_get_Results[T], error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Holder_get_Results[T](st), err
+}
+
+func NewRootHolder_get_Results[T capnp.TypeParam[T]](s *capnp.Segment) (Holder_get_Results[T], error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Holder_get_Results[T](st), err
+}
+
+func ReadRootHolder_get_Results[T capnp.TypeParam[T]](msg *capnp.Message) (Holder_get_Results[T], error) {
+	root, err := msg.Root()
+	return Holder_get_Results[T](root.Struct()), err
+}
+
+func (s Holder_get_Results[T]) String() string {
+	str, _ := text.Marshal(0x8d203366f25ee361, capnp.Struct(s))
+	return str
+}
+
+func (s Holder_get_Results[T]) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Holder_get_Results[T]) DecodeFromPtr(p capnp.Ptr) Holder_get_Results[T] {
+	return Holder_get_Results[T](capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Holder_get_Results[T]) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Holder_get_Results[T]) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Holder_get_Results[T]) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Holder_get_Results[T]) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Holder_get_Results[T]) Value() (T, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	var v T
+	return v.DecodeFromPtr(p), err
+}
+
+func (s Holder_get_Results[T]) HasValue() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownValue detaches the value field from s without
+// copying it.  The field is left null.
+func (s Holder_get_Results[T]) DisownValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptValue sets the value field to an orphan from
+// s's message without copying it.
+func (s Holder_get_Results[T]) AdoptValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Holder_get_Results[T]) SetValue(v T) error {
+	return capnp.Struct(s).SetPtr(0, v.EncodeAsPtr(capnp.Struct(s).Segment()))
+}
+
+// NewHolder_get_Results_List creates a new list of Holder_get_Results.
+func NewHolder_get_Results_List[T capnp.TypeParam[T]](s *capnp.Segment, sz int32) (capnp.StructList[Holder_get_Results[T]], error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[Holder_get_Results[T]](l), err
+}
+
+// Holder_get_Results_Future is a wrapper for a Holder_get_Results promised by a client call.
+type Holder_get_Results_Future[T capnp.TypeParam[T]] struct{ *capnp.Future }
+
+func (f Holder_get_Results_Future[T]) Struct() (Holder_get_Results[T], error) {
+	p, err := f.Future.Ptr()
+	return Holder_get_Results[T](p.Struct()), err
+}
+func (p Holder_get_Results_Future[T]) Value() *capnp.Future {
+	return p.Future.Field(0, nil)
+}
+
+type Holder_set_Params[T capnp.TypeParam[T]] capnp.Struct
+
+// Holder_set_Params_TypeID is the unique identifier for the type Holder_set_Params.
+const Holder_set_Params_TypeID = 0xa513779fa150f3c0
+
+func NewHolder_set_Params[T capnp.TypeParam[T]](s *capnp.Segment) (Holder_set_Params[T], error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Holder_set_Params[T](st), err
+}
+
+func NewRootHolder_set_Params[T capnp.TypeParam[T]](s *capnp.Segment) (Holder_set_Params[T], error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return Holder_set_Params[T](st), err
+}
+
+func ReadRootHolder_set_Params[T capnp.TypeParam[T]](msg *capnp.Message) (Holder_set_Params[T], error) {
+	root, err := msg.Root()
+	return Holder_set_Params[T](root.Struct()), err
+}
+
+func (s Holder_set_Params[T]) String() string {
+	str, _ := text.Marshal(0xa513779fa150f3c0, capnp.Struct(s))
+	return str
+}
+
+func (s Holder_set_Params[T]) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Holder_set_Params[T]) DecodeFromPtr(p capnp.Ptr) Holder_set_Params[T] {
+	return Holder_set_Params[T](capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Holder_set_Params[T]) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Holder_set_Params[T]) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Holder_set_Params[T]) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Holder_set_Params[T]) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Holder_set_Params[T]) Value() (T, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	var v T
+	return v.DecodeFromPtr(p), err
+}
+
+func (s Holder_set_Params[T]) HasValue() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownValue detaches the value field from s without
+// copying it.  The field is left null.
+func (s Holder_set_Params[T]) DisownValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptValue sets the value field to an orphan from
+// s's message without copying it.
+func (s Holder_set_Params[T]) AdoptValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Holder_set_Params[T]) SetValue(v T) error {
+	return capnp.Struct(s).SetPtr(0, v.EncodeAsPtr(capnp.Struct(s).Segment()))
+}
+
+// NewHolder_set_Params_List creates a new list of Holder_set_Params.
+func NewHolder_set_Params_List[T capnp.TypeParam[T]](s *capnp.Segment, sz int32) (capnp.StructList[Holder_set_Params[T]], error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[Holder_set_Params[T]](l), err
+}
+
+// Holder_set_Params_Future is a wrapper for a Holder_set_Params promised by a client call.
+type Holder_set_Params_Future[T capnp.TypeParam[T]] struct{ *capnp.Future }
+
+func (f Holder_set_Params_Future[T]) Struct() (Holder_set_Params[T], error) {
+	p, err := f.Future.Ptr()
+	return Holder_set_Params[T](p.Struct()), err
+}
+func (p Holder_set_Params_Future[T]) Value() *capnp.Future {
+	return p.Future.Field(0, nil)
+}
+
+type Holder_set_Results[T capnp.TypeParam[T]] capnp.Struct
+
+// Holder_set_Results_TypeID is the unique identifier for the type Holder_set_Results.
+const Holder_set_Results_TypeID = 0x9df1d04a6cda547f
+
+func NewHolder_set_Results[T capnp.TypeParam[T]](s *capnp.Segment) (Holder_set_Results[T], error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return Holder_set_Results[T](st), err
+}
+
+func NewRootHolder_set_Results[T capnp.TypeParam[T]](s *capnp.Segment) (Holder_set_Results[T], error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return Holder_set_Results[T](st), err
+}
+
+func ReadRootHolder_set_Results[T capnp.TypeParam[T]](msg *capnp.Message) (Holder_set_Results[T], error) {
+	root, err := msg.Root()
+	return Holder_set_Results[T](root.Struct()), err
+}
+
+func (s Holder_set_Results[T]) String() string {
+	str, _ := text.Marshal(0x9df1d04a6cda547f, capnp.Struct(s))
+	return str
+}
+
+func (s Holder_set_Results[T]) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Holder_set_Results[T]) DecodeFromPtr(p capnp.Ptr) Holder_set_Results[T] {
+	return Holder_set_Results[T](capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Holder_set_Results[T]) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Holder_set_Results[T]) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Holder_set_Results[T]) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Holder_set_Results[T]) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// NewHolder_set_Results_List creates a new list of Holder_set_Results.
+func NewHolder_set_Results_List[T capnp.TypeParam[T]](s *capnp.Segment, sz int32) (capnp.StructList[Holder_set_Results[T]], error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[Holder_set_Results[T]](l), err
+}
+
+// Holder_set_Results_Future is a wrapper for a Holder_set_Results promised by a client call.
+type Holder_set_Results_Future[T capnp.TypeParam[T]] struct{ *capnp.Future }
+
+func (f Holder_set_Results_Future[T]) Struct() (Holder_set_Results[T], error) {
+	p, err := f.Future.Ptr()
+	return Holder_set_Results[T](p.Struct()), err
+}
+
+type ThingHolder capnp.Client
+
+// ThingHolder_TypeID is the unique identifier for the type ThingHolder.
+const ThingHolder_TypeID = 0x8e9659a00a0ca1f2
+
+func (c ThingHolder) Reset(ctx context.Context, params func(ThingHolder_reset_Params) error) (ThingHolder_reset_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0x8e9659a00a0ca1f2,
+			MethodID:      0,
+			InterfaceName: "generics.capnp:ThingHolder",
+			MethodName:    "reset",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(ThingHolder_reset_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return ThingHolder_reset_Results_Future{Future: ans.Future()}, release
+}
+func (c ThingHolder) Get(ctx context.Context, params func(Holder_get_Params[Thing]) error) (Holder_get_Results_Future[Thing], capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xa03cef631e51b1e3,
+			MethodID:      0,
+			InterfaceName: "generics.capnp:Holder",
+			MethodName:    "get",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(Holder_get_Params[Thing](s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return Holder_get_Results_Future[Thing]{Future: ans.Future()}, release
+}
+func (c ThingHolder) Set(ctx context.Context, params func(Holder_set_Params[Thing]) error) (Holder_set_Results_Future[Thing], capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xa03cef631e51b1e3,
+			MethodID:      1,
+			InterfaceName: "generics.capnp:Holder",
+			MethodName:    "set",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(Holder_set_Params[Thing](s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return Holder_set_Results_Future[Thing]{Future: ans.Future()}, release
+}
+
+// String returns a string that identifies this capability for debugging
+// purposes.  Its format should not be depended on: in particular, it
+// should not be used to compare clients.  Use IsSame to compare clients
+// for equality.
+func (c ThingHolder) String() string {
+	return fmt.Sprintf("%T(%v)", c, capnp.Client(c))
+}
+
+// AddRef creates a new Client that refers to the same capability as c.
+// If c is nil or has resolved to null, then AddRef returns nil.
+func (c ThingHolder) AddRef() ThingHolder {
+	return ThingHolder(capnp.Client(c).AddRef())
+}
+
+// Release releases a capability reference.  If this is the last
+// reference to the capability, then the underlying resources associated
+// with the capability will be released.
+//
+// Release will panic if c has already been released, but not if c is
+// nil or resolved to null.
+func (c ThingHolder) Release() {
+	capnp.Client(c).Release()
+}
+
+// Resolve blocks until the capability is fully resolved or the Context
+// expires.
+func (c ThingHolder) Resolve(ctx context.Context) error {
+	return capnp.Client(c).Resolve(ctx)
+}
+
+func (c ThingHolder) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Client(c).EncodeAsPtr(seg)
+}
+
+func (ThingHolder) DecodeFromPtr(p capnp.Ptr) ThingHolder {
+	return ThingHolder(capnp.Client{}.DecodeFromPtr(p))
+}
+
+// IsValid reports whether c is a valid reference to a capability.
+// A reference is invalid if it is nil, has resolved to null, or has
+// been released.
+func (c ThingHolder) IsValid() bool {
+	return capnp.Client(c).IsValid()
+}
+
+// IsSame reports whether c and other refer to a capability created by the
+// same call to NewClient.  This can return false negatives if c or other
+// are not fully resolved: use Resolve if this is an issue.  If either
+// c or other are released, then IsSame panics.
+func (c ThingHolder) IsSame(other ThingHolder) bool {
+	return capnp.Client(c).IsSame(capnp.Client(other))
+}
+
+// Update the flowcontrol.FlowLimiter used to manage flow control for
+// this client. This affects all future calls, but not calls already
+// waiting to send. Passing nil sets the value to flowcontrol.NopLimiter,
+// which is also the default.
+func (c ThingHolder) SetFlowLimiter(lim fc.FlowLimiter) {
+	capnp.Client(c).SetFlowLimiter(lim)
+}
+
+// Get the current flowcontrol.FlowLimiter used to manage flow control
+// for this client.
+func (c ThingHolder) GetFlowLimiter() fc.FlowLimiter {
+	return capnp.Client(c).GetFlowLimiter()
+} // A ThingHolder_Server is a ThingHolder with a local implementation.
+type ThingHolder_Server interface {
+	Reset(context.Context, ThingHolder_reset) error
+
+	Get(context.Context, Holder_get[Thing]) error
+
+	Set(context.Context, Holder_set[Thing]) error
+}
+
+// ThingHolder_NewServer creates a new Server from an implementation of ThingHolder_Server.
+func ThingHolder_NewServer(s ThingHolder_Server, opts ...server.Option) *server.Server {
+	c, _ := s.(server.Shutdowner)
+	return server.New(ThingHolder_Methods(nil, s), s, c, opts...)
+}
+
+// ThingHolder_ServerToClient creates a new Client from an implementation of ThingHolder_Server.
+// The caller is responsible for calling Release on the returned Client.
+func ThingHolder_ServerToClient(s ThingHolder_Server) ThingHolder {
+	return ThingHolder(capnp.NewClient(ThingHolder_NewServer(s)))
+}
+
+// ThingHolder_Methods appends Methods to a slice that invoke the methods on s.
+// This can be used to create a more complicated Server.
+func ThingHolder_Methods(methods []server.Method, s ThingHolder_Server) []server.Method {
+	if cap(methods) == 0 {
+		methods = make([]server.Method, 0, 3)
+	}
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0x8e9659a00a0ca1f2,
+			MethodID:      0,
+			InterfaceName: "generics.capnp:ThingHolder",
+			MethodName:    "reset",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Reset(ctx, ThingHolder_reset{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xa03cef631e51b1e3,
+			MethodID:      0,
+			InterfaceName: "generics.capnp:Holder",
+			MethodName:    "get",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Get(ctx, Holder_get[Thing]{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xa03cef631e51b1e3,
+			MethodID:      1,
+			InterfaceName: "generics.capnp:Holder",
+			MethodName:    "set",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Set(ctx, Holder_set[Thing]{call})
+		},
+	})
+
+	return methods
+}
+
+// ThingHolder_reset holds the state for a server call to ThingHolder.reset.
+// See server.Call for documentation.
+type ThingHolder_reset struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c ThingHolder_reset) Args() ThingHolder_reset_Params {
+	return ThingHolder_reset_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c ThingHolder_reset) AllocResults() (ThingHolder_reset_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return ThingHolder_reset_Results(r), err
+}
+
+// ThingHolder_List is a list of ThingHolder.
+type ThingHolder_List = capnp.CapList[ThingHolder]
+
+// NewThingHolder creates a new list of ThingHolder.
+func NewThingHolder_List(s *capnp.Segment, sz int32) (ThingHolder_List, error) {
+	l, err := capnp.NewPointerList(s, sz)
+	return capnp.CapList[ThingHolder](l), err
+}
+
+type ThingHolder_reset_Params capnp.Struct
+
+// ThingHolder_reset_Params_TypeID is the unique identifier for the type ThingHolder_reset_Params.
+const ThingHolder_reset_Params_TypeID = 0xfda6b31727513be0
+
+func NewThingHolder_reset_Params(s *capnp.Segment) (ThingHolder_reset_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return ThingHolder_reset_Params(st), err
+}
+
+func NewRootThingHolder_reset_Params(s *capnp.Segment) (ThingHolder_reset_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return ThingHolder_reset_Params(st), err
+}
+
+func ReadRootThingHolder_reset_Params(msg *capnp.Message) (ThingHolder_reset_Params, error) {
+	root, err := msg.Root()
+	return ThingHolder_reset_Params(root.Struct()), err
+}
+
+func (s ThingHolder_reset_Params) String() string {
+	str, _ := text.Marshal(0xfda6b31727513be0, capnp.Struct(s))
+	return str
+}
+
+func (s ThingHolder_reset_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (ThingHolder_reset_Params) DecodeFromPtr(p capnp.Ptr) ThingHolder_reset_Params {
+	return ThingHolder_reset_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s ThingHolder_reset_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s ThingHolder_reset_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s ThingHolder_reset_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s ThingHolder_reset_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// ThingHolder_reset_Params_List is a list of ThingHolder_reset_Params.
+type ThingHolder_reset_Params_List = capnp.StructList[ThingHolder_reset_Params]
+
+// NewThingHolder_reset_Params creates a new list of ThingHolder_reset_Params.
+func NewThingHolder_reset_Params_List(s *capnp.Segment, sz int32) (ThingHolder_reset_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[ThingHolder_reset_Params](l), err
+}
+
+// ThingHolder_reset_Params_Future is a wrapper for a ThingHolder_reset_Params promised by a client call.
+type ThingHolder_reset_Params_Future struct{ *capnp.Future }
+
+func (f ThingHolder_reset_Params_Future) Struct() (ThingHolder_reset_Params, error) {
+	p, err := f.Future.Ptr()
+	return ThingHolder_reset_Params(p.Struct()), err
+}
+
+type ThingHolder_reset_Results capnp.Struct
+
+// ThingHolder_reset_Results_TypeID is the unique identifier for the type ThingHolder_reset_Results.
+const ThingHolder_reset_Results_TypeID = 0xeec1aeef74293c21
+
+func NewThingHolder_reset_Results(s *capnp.Segment) (ThingHolder_reset_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return ThingHolder_reset_Results(st), err
+}
+
+func NewRootThingHolder_reset_Results(s *capnp.Segment) (ThingHolder_reset_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return ThingHolder_reset_Results(st), err
+}
+
+func ReadRootThingHolder_reset_Results(msg *capnp.Message) (ThingHolder_reset_Results, error) {
+	root, err := msg.Root()
+	return ThingHolder_reset_Results(root.Struct()), err
+}
+
+func (s ThingHolder_reset_Results) String() string {
+	str, _ := text.Marshal(0xeec1aeef74293c21, capnp.Struct(s))
+	return str
+}
+
+func (s ThingHolder_reset_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (ThingHolder_reset_Results) DecodeFromPtr(p capnp.Ptr) ThingHolder_reset_Results {
+	return ThingHolder_reset_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s ThingHolder_reset_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s ThingHolder_reset_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s ThingHolder_reset_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s ThingHolder_reset_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// ThingHolder_reset_Results_List is a list of ThingHolder_reset_Results.
+type ThingHolder_reset_Results_List = capnp.StructList[ThingHolder_reset_Results]
+
+// NewThingHolder_reset_Results creates a new list of ThingHolder_reset_Results.
+func NewThingHolder_reset_Results_List(s *capnp.Segment, sz int32) (ThingHolder_reset_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[ThingHolder_reset_Results](l), err
+}
+
+// ThingHolder_reset_Results_Future is a wrapper for a ThingHolder_reset_Results promised by a client call.
+type ThingHolder_reset_Results_Future struct{ *capnp.Future }
+
+func (f ThingHolder_reset_Results_Future) Struct() (ThingHolder_reset_Results, error) {
+	p, err := f.Future.Ptr()
+	return ThingHolder_reset_Results(p.Struct()), err
+}
+
+const schema_d0a87f36fa0182f6 = "x\xda\xacUM\x8cSU\x14>\xdf}\xef\xbe3L" +
+	"ghoo\xa5\x10\xc1\xe2D\x03C\xc2\x84v\xd4\x05" +
+	"\x92\xd0N\x98\xf0\xe3\xa6\xcf\x0e$\x1a!v\xf01L" +
+	"\x1c:\xd8\x16\x19\x12\x13\"\x891,\xd0\x103&\x18" +
+	"\x89\x0c\x09\x06\\\xf8\x87\xac\xdc\xe8\xc6\x85\x91\x84\x85$" +
+	"\xca\xca\x04\x8d+@Q\xfc#\xe6\x99\xdby\xaf}t" +
+	"~2 \x8b\xaf\x8b\x9e\xf3\xce\xf9\xeew\xcfw\xee\xba" +
+	"=V\xde\xcev\xfb\x8bH\xb8u\xe9\xf8\xe5\xab\xbbn" +
+	"\xee\xe9_y\x8c\xd4\x12\x10Ip\x02\xfd?\xd9I\xe8" +
+	"[6\x07\xd8H\xa4\xb3\x92\xfd\x9bS]\x9d\xa7\x9ey" +
+	"\xfb\x0dRq\xcb\xff\xe3\x08\xfey\xe2\xf0\xd9KD\xd0" +
+	"+\xe4\x15\xdd+9\xc0f\xed\xc9\xb4>#\xd9\xffx" +
+	"\xc9\x92\xb7\xdc\x9b;\x8eO\xd7\xb6M\xe9\xe3\xb2\x13z" +
+	"Jr\x08\"}R\xb2\x7fx\xe8\xca\xd8\xb6K\xbf\x9e" +
+	"\x8cd\x1e\x95I\xe8\x13\x92C\x10\xe9I\xc9\xfe\xd5O" +
+	"\xdc\x87v\xdf\xd8p\xaa\x8d\x04%\xa0_\x95\x9f\xea\xa3" +
+	"\x92\x03\xbc\xae\xb3\x0e\xeb\xac\x13\xf7\xd3\x17'\xaf]\x7f" +
+	"\xf7\xdciRq\xb4>\x910%\x1fu>\xd0k\x1d" +
+	"\x0e`N:\xea\xb0\xff\xc5o\xc5\xa9\xf7\x0e\xea3Q" +
+	"Q\xb6;\x9d\xd0\x9e\xc3\x01L\xea\x94\xc3\xfe\x85\xbf'" +
+	"_Y\xfd\xfd\xc5\xaf\xda\x8a\x0bN@\x1fs\xde\xd1\x93" +
+	"N\xda\xe4\xe9)\xe7\xa0\x91\x91\xe3\xfe/\xe3{\xaf\x0d" +
+	"\xa7\xb7|\xd3\xf6\x057\xe8\xf0i\xbd\x96\xd9\xa0\x7f-" +
+	"\xbf\x09\"}\xa2\x93\xfd\xb3\xb7\xcf\xef\xfa\xf9\xf6\xa6\x1f" +
+	"gk\xf2Z\xe7i}\xac\x93\x03\x98&\x8bbi\xff" +
+	"\xe1\x0d\xbd\xf5\x1b\x1f~y\x9d\xd4\x83\x0dA\x89\xfae" +
+	"l\x1b\xf4\xb2\x18\x87 \xd2\x0f\xc4\xd8\xdf|\xe4\xa5\xcf" +
+	"\xcf\x0d?\xfb')\x8d\xd6i\x1ag\xd6\x88}\xad\xbb" +
+	"c\x1c\xc0\x1cyk\x8c\xfd\x1f\x9etW\xa5\xcf\xbf\xff" +
+	"o\xa4\xf6\xe3\xb1\x01\xe8\xc1\x18\x87 \xd2\x85\x18\xd3G" +
+	"\xfe\x88W\xf1\xaa\xa3\xbbkV\xdf\xee\xf2\xfe\xca\xfe\xf5" +
+	"[\xc6\xc7^\xf0\xaa}#^\xfd\x91\xa7\xbd\xda\x81\xb1" +
+	":jE\xa0\x08\xe1\xda\x96Md\x83Hu\xe7T7" +
+	"\xbb]\x16\xdc\xa5\x02\x99\x97\xcbc\x07\xbc\"\x04\x92@" +
+	"\xeb\xf6\x89\x90$\xe4\xd1l \x82\x06C{G+#" +
+	"\xa6\x8b\xe5U\x9b\x95%Q\x935Bi\x94\xca)\xc5" +
+	"\x85\x04\x0a)\xa8e\x9c\xa9z5\xaf^\x84P\xe0\xa2" +
+	"\xc0\xf4o\x1e\xae\x1dm\x9b\x87B\xc6\xb5\xc5\x1dL\x14" +
+	"\xd2&\x0b(Z@\xa25nD\xf9f\x99\xf9\x84(" +
+	"\x96\xab\xe5}5\xa2i\xbaE\xcb\x9e;\xbd6C\xb7" +
+	";\xd3\x11\xa6g\x1a\xf9\x81\x00\x1d\x0d\x01B7\"\xb4" +
+	"\xbc\xca\xf6\xa8,\x17\xd6\xa1\x90\x87r\x19h\xce=B" +
+	"?\xaa\xc1\x1e5\xc8\x85M(\x0cAy\xcc#\x81B" +
+	"m\x1a\x00D\xb3\xfe\x99\x07\xd7\xee\xfa\x93\"P\xb0\xa1" +
+	"\x90\xc4\xd0\x8c\x83\x0d\xc5\xcd\xfd\xce\x1c\x995\x91\x91\x89" +
+	"W\xca\xfb\x1a\x13\xd3E\x06\x98_\xce;\xd5\xff\xbfc" +
+	"\x18\xf2\x1c\xb0\xc6'\x1a\xa3\xd3\xb2\x17r\x99\xad\x95\x8a" +
+	"W\x9d\xbe\x91\xb0KoN\xf5\xb2\xbb\xda\x82\xfb\x98\x19" +
+	":\xa4\x1a\xc2ds*\xcb\xee:\x0bn\xb1\xbdw\xd3" +
+	"\xa2\xd3\xbd3\xa3AM$Z\xbd(2\xa8\xcd|\x80" +
+	"\x08\x09\x9a[\xdb\xed\\k\x8e\xcc\xf2&\xc1\x0b=\xea" +
+	"\x02\xbb\x9fYp\xff\x8a\x10\xbc\x95S\xb7\xd8\xfd\xddB" +
+	"i\x0b\x04\x94\x10)\x08\"=\x885z\x10\\\xda\x04" +
+	"\x0b\xa5\xefL\xc4\xb2R\xb0\x88\xf4e\x0c\xe8\xcb\xe0\xd2" +
+	"\xb7&\x92\x10\x02\xca\xb6S\xb0\x89t\xb7X\xaf\xbb\x05" +
+	"\x97\xba\x84\x85\xd2J\x13\x912\x05I\xa4W\x88\x9c^" +
+	"!\xb8\xb4\xdcD\x9e7\x11\xc7I\xc1!\xd2;\xc5z" +
+	"\xbdSp\xe99\x139%\x04xx|bZ\x84\x88" +
+	">\xb3\x88\xb0\x10\xb7\x1a\x912\xc3\xe3\x13^\xcdT\\" +
+	"LA\xe6}(\xbc\x98\x10\xdf_\x1e\x0d\xae\xab\xb9\xd5" +
+	"\xa3\x05#\x7f*\xacr;\x00\xc0\xed\xb0\xcc\xef\xd2\xb9" +
+	"\x09\xdf\xa7S\x1f\xae{\x13\xf5\x81{R\xd2x-A" +
+	"\xd8X\xae\x1c\x9a\xab@S\xdb\x05\x8f\xecByo\xdc" +
+	"\x1b\xac;\x01\x155\xe7=\xaek\xa8Y=]\xe4\xf2" +
+	"h\xe8\x8f\x88\x81{\xe67\xf0\x06\x01~\xd1;\x14\xda" +
+	"7r\xbf\x0d\xfb\xb6\xbc-gF\x0b\x1dP2\xa9d" +
+	"\x12OaG\x93\x8f\xdd\xfe\xd4y\xd5\xbe\xaa\xd7z\x1a" +
+	"\xe6xJ\xc2\x17r`|\xa2\xaf\xb1\x88\xeen\xe7\xb5" +
+	"\xed\x9d<\x16\xc0'\xd8\xad\x116\xff\x0d\x00\xcf\x05\xb3" +
+	"\x8c"
+
+func init() {
+	schemas.Register(schema_d0a87f36fa0182f6,
+		0x8d203366f25ee361,
+		0x8e9659a00a0ca1f2,
+		0x9156f251931616b0,
+		0x9df1d04a6cda547f,
+		0xa03cef631e51b1e3,
+		0xa2a99ceeed95cd17,
+		0xa513779fa150f3c0,
+		0xc6cdd9287c95f9b5,
+		0xcc481762ed686ff0,
+		0xe444fbe75eb3fba8,
+		0xeec1aeef74293c21,
+		0xf75a62a9bb718247,
+		0xfda6b31727513be0)
+}
@@ -0,0 +1,180 @@
+package generics
+
+import (
+	"context"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+)
+
+func newThing(t *testing.T, seg *capnp.Segment, name string) Thing {
+	t.Helper()
+	th, err := NewThing(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := th.SetName(name); err != nil {
+		t.Fatal(err)
+	}
+	return th
+}
+
+func TestStructs(t *testing.T) {
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	u, err := NewRootUser(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	box, err := u.NewBox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := box.SetValue(newThing(t, seg, "a")); err != nil {
+		t.Fatal(err)
+	}
+	inner, err := box.NewInner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inner.SetValue(newThing(t, seg, "b")); err != nil {
+		t.Fatal(err)
+	}
+	boxes, err := u.NewBoxes(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := boxes.At(1).SetValue(newThing(t, seg, "c")); err != nil {
+		t.Fatal(err)
+	}
+	pair, err := u.NewPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pair.SetKey(newThing(t, seg, "d")); err != nil {
+		t.Fatal(err)
+	}
+	if err := pair.SetValue(box); err != nil {
+		t.Fatal(err)
+	}
+	textBox, err := u.NewTextBox()
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := capnp.NewText(seg, "e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := textBox.SetValue(text.ToPtr()); err != nil {
+		t.Fatal(err)
+	}
+
+	name := func(th Thing, err error) string {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := th.Name()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	if got := name(box.Value()); got != "a" {
+		t.Errorf("box.value.name = %q; want \"a\"", got)
+	}
+	if got := name(inner.Value()); got != "b" {
+		t.Errorf("box.inner.value.name = %q; want \"b\"", got)
+	}
+	if got := name(boxes.At(1).Value()); got != "c" {
+		t.Errorf("boxes[1].value.name = %q; want \"c\"", got)
+	}
+	if got := name(pair.Key()); got != "d" {
+		t.Errorf("pair.key.name = %q; want \"d\"", got)
+	}
+	pv, err := pair.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := name(pv.Value()); got != "a" {
+		t.Errorf("pair.value.value.name = %q; want \"a\"", got)
+	}
+	tv, err := textBox.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tv.Text(); got != "e" {
+		t.Errorf("textBox.value = %q; want \"e\"", got)
+	}
+}
+
+type holder struct {
+	name string
+}
+
+func (h *holder) Get(ctx context.Context, call Holder_get[Thing]) error {
+	res, err := call.AllocResults()
+	if err != nil {
+		return err
+	}
+	th, err := NewThing(res.Segment())
+	if err != nil {
+		return err
+	}
+	if err := th.SetName(h.name); err != nil {
+		return err
+	}
+	return res.SetValue(th)
+}
+
+func (h *holder) Set(ctx context.Context, call Holder_set[Thing]) error {
+	th, err := call.Args().Value()
+	if err != nil {
+		return err
+	}
+	h.name, err = th.Name()
+	return err
+}
+
+func (h *holder) Reset(ctx context.Context, call ThingHolder_reset) error {
+	h.name = ""
+	return nil
+}
+
+func TestInterfaces(t *testing.T) {
+	ctx := context.Background()
+	h := &holder{}
+	c := ThingHolder_ServerToClient(h)
+	defer c.Release()
+
+	set, release := c.Set(ctx, func(p Holder_set_Params[Thing]) error {
+		th, err := NewThing(p.Segment())
+		if err != nil {
+			return err
+		}
+		if err := th.SetName("x"); err != nil {
+			return err
+		}
+		return p.SetValue(th)
+	})
+	defer release()
+	if _, err := set.Struct(); err != nil {
+		t.Fatal("set:", err)
+	}
+
+	// The same server, seen as the generic superclass.
+	hc := Holder[Thing](c.AddRef())
+	defer hc.Release()
+	get, release := hc.Get(ctx, nil)
+	defer release()
+	res, err := get.Struct()
+	if err != nil {
+		t.Fatal("get:", err)
+	}
+	th, err := res.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := th.Name(); name != "x" {
+		t.Errorf("get returned %q; want \"x\"", name)
+	}
+}
@@ -0,0 +1,3 @@
+package pogs
+
+//go:generate go run capnproto.org/go/capnp/v3/capnpc-go -pogs -I ../../../std pogs.capnp
@@ -0,0 +1,1230 @@
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package pogs
+
+import (
+	capnp "capnproto.org/go/capnp/v3"
+	text "capnproto.org/go/capnp/v3/encoding/text"
+	schemas "capnproto.org/go/capnp/v3/schemas"
+	errors "errors"
+	math "math"
+	strconv "strconv"
+)
+
+type Color uint16
+
+// Color_TypeID is the unique identifier for the type Color.
+const Color_TypeID = 0xbfac535c7b184f64
+
+// Values of Color.
+const (
+	Color_red   Color = 0
+	Color_green Color = 1
+	Color_blue  Color = 2
+)
+
+// String returns the enum's constant name.
+func (c Color) String() string {
+	switch c {
+	case Color_red:
+		return "red"
+	case Color_green:
+		return "green"
+	case Color_blue:
+		return "blue"
+
+	default:
+		return ""
+	}
+}
+
+// ColorFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func ColorFromString(c string) Color {
+	switch c {
+	case "red":
+		return Color_red
+	case "green":
+		return Color_green
+	case "blue":
+		return Color_blue
+
+	default:
+		return 0
+	}
+}
+
+type Color_List = capnp.EnumList[Color]
+
+func NewColor_List(s *capnp.Segment, sz int32) (Color_List, error) {
+	return capnp.NewEnumList[Color](s, sz)
+}
+
+type Point capnp.Struct
+
+// Point_TypeID is the unique identifier for the type Point.
+const Point_TypeID = 0x8c9d487437807529
+
+func NewPoint(s *capnp.Segment) (Point, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Point(st), err
+}
+
+func NewRootPoint(s *capnp.Segment) (Point, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Point(st), err
+}
+
+func ReadRootPoint(msg *capnp.Message) (Point, error) {
+	root, err := msg.Root()
+	return Point(root.Struct()), err
+}
+
+func (s Point) String() string {
+	str, _ := text.Marshal(0x8c9d487437807529, capnp.Struct(s))
+	return str
+}
+
+func (s Point) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Point) DecodeFromPtr(p capnp.Ptr) Point {
+	return Point(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Point) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Point) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Point) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Point) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Point) X() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s Point) SetX(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s Point) Y() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s Point) SetY(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+// Point_List is a list of Point.
+type Point_List = capnp.StructList[Point]
+
+// NewPoint creates a new list of Point.
+func NewPoint_List(s *capnp.Segment, sz int32) (Point_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	return capnp.StructList[Point](l), err
+}
+
+// Point_Future is a wrapper for a Point promised by a client call.
+type Point_Future struct{ *capnp.Future }
+
+func (f Point_Future) Struct() (Point, error) {
+	p, err := f.Future.Ptr()
+	return Point(p.Struct()), err
+}
+
+// Point_Go is a plain Go representation of Point.
+// Unlike Point, it does not refer to a message and remains
+// valid after the message is released, except for AnyPointer fields.
+type Point_Go struct {
+	X int32
+	Y int32
+}
+
+// ToCapnp allocates a new Point in seg and copies p into it.
+func (p *Point_Go) ToCapnp(seg *capnp.Segment) (Point, error) {
+	s, err := NewPoint(seg)
+	if err != nil {
+		return Point{}, err
+	}
+	return s, p.CopyTo(s)
+}
+
+// CopyTo copies p into s, which should be newly allocated.
+// Capabilities are copied with AddRef; nil pointers are left null.
+func (p *Point_Go) CopyTo(s Point) error {
+	s.SetX(p.X)
+	s.SetY(p.Y)
+	return nil
+}
+
+// FromCapnp sets p to a copy of s.  Capabilities are copied with AddRef.
+func (p *Point_Go) FromCapnp(s Point) error {
+	*p = Point_Go{}
+	p.X = s.X()
+	p.Y = s.Y()
+	return nil
+}
+
+type Shape capnp.Struct
+type Shape_square Shape
+type Shape_style Shape
+type Shape_style_fill Shape
+type Shape_Which uint16
+
+const (
+	Shape_Which_circle  Shape_Which = 0
+	Shape_Which_square  Shape_Which = 1
+	Shape_Which_polygon Shape_Which = 2
+	Shape_Which_empty   Shape_Which = 3
+)
+
+func (w Shape_Which) String() string {
+	const s = "circlesquarepolygonempty"
+	switch w {
+	case Shape_Which_circle:
+		return s[0:6]
+	case Shape_Which_square:
+		return s[6:12]
+	case Shape_Which_polygon:
+		return s[12:19]
+	case Shape_Which_empty:
+		return s[19:24]
+
+	}
+	return "Shape_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
+}
+
+type Shape_style_fill_Which uint16
+
+const (
+	Shape_style_fill_Which_none    Shape_style_fill_Which = 0
+	Shape_style_fill_Which_solid   Shape_style_fill_Which = 1
+	Shape_style_fill_Which_pattern Shape_style_fill_Which = 2
+)
+
+func (w Shape_style_fill_Which) String() string {
+	const s = "nonesolidpattern"
+	switch w {
+	case Shape_style_fill_Which_none:
+		return s[0:4]
+	case Shape_style_fill_Which_solid:
+		return s[4:9]
+	case Shape_style_fill_Which_pattern:
+		return s[9:16]
+
+	}
+	return "Shape_style_fill_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
+}
+
+// Shape_TypeID is the unique identifier for the type Shape.
+const Shape_TypeID = 0xbb973b35a3e7d3b0
+
+func NewShape(s *capnp.Segment) (Shape, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 8})
+	return Shape(st), err
+}
+
+func NewRootShape(s *capnp.Segment) (Shape, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 8})
+	return Shape(st), err
+}
+
+func ReadRootShape(msg *capnp.Message) (Shape, error) {
+	root, err := msg.Root()
+	return Shape(root.Struct()), err
+}
+
+func (s Shape) String() string {
+	str, _ := text.Marshal(0xbb973b35a3e7d3b0, capnp.Struct(s))
+	return str
+}
+
+func (s Shape) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Shape) DecodeFromPtr(p capnp.Ptr) Shape {
+	return Shape(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Shape) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+
+func (s Shape) Which() Shape_Which {
+	return Shape_Which(capnp.Struct(s).Uint16(2))
+}
+func (s Shape) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Shape) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Shape) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Shape) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s Shape) HasName() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s Shape) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s Shape) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Shape) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s Shape) SetName(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s Shape) Color() Color {
+	return Color(capnp.Struct(s).Uint16(0))
+}
+
+func (s Shape) SetColor(v Color) {
+	capnp.Struct(s).SetUint16(0, uint16(v))
+}
+
+func (s Shape) Circle() float64 {
+	if capnp.Struct(s).Uint16(2) != 0 {
+		panic("Which() != circle")
+	}
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s Shape) SetCircle(v float64) {
+	capnp.Struct(s).SetUint16(2, 0)
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s Shape) Square() Shape_square { return Shape_square(s) }
+
+func (s Shape) SetSquare() {
+	capnp.Struct(s).SetUint16(2, 1)
+}
+
+func (s Shape_square) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Shape_square) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Shape_square) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Shape_square) Side() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s Shape_square) SetSide(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s Shape_square) Corner() (Point, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return Point(p.Struct()), err
+}
+
+func (s Shape_square) HasCorner() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+// DisownCorner detaches the corner field from s without
+// copying it.  The field is left null.
+func (s Shape_square) DisownCorner() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptCorner sets the corner field to an orphan from
+// s's message without copying it.
+func (s Shape_square) AdoptCorner(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
+func (s Shape_square) SetCorner(v Point) error {
+	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
+}
+
+// NewCorner sets the corner field to a newly
+// allocated Point struct, preferring placement in s's segment.
+func (s Shape_square) NewCorner() (Point, error) {
+	ss, err := NewPoint(capnp.Struct(s).Segment())
+	if err != nil {
+		return Point{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s Shape) Polygon() (Point_List, error) {
+	if capnp.Struct(s).Uint16(2) != 2 {
+		panic("Which() != polygon")
+	}
+	p, err := capnp.Struct(s).Ptr(1)
+	return Point_List(p.List()), err
+}
+
+func (s Shape) HasPolygon() bool {
+	if capnp.Struct(s).Uint16(2) != 2 {
+		return false
+	}
+	return capnp.Struct(s).HasPtr(1)
+}
+
+// DisownPolygon detaches the polygon field from s without
+// copying it.  The field is left null.  It returns an error if
+// polygon is not the union member that is set.
+func (s Shape) DisownPolygon() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(2) != 2 {
+		return capnp.Orphan{}, errors.New("Which() != polygon")
+	}
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptPolygon sets the polygon field to an orphan from
+// s's message without copying it.
+func (s Shape) AdoptPolygon(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(2, 2)
+	return capnp.Struct(s).Adopt(1, o)
+}
+
+func (s Shape) SetPolygon(v Point_List) error {
+	capnp.Struct(s).SetUint16(2, 2)
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewPolygon sets the polygon field to a newly
+// allocated Point_List, preferring placement in s's segment.
+func (s Shape) NewPolygon(n int32) (Point_List, error) {
+	capnp.Struct(s).SetUint16(2, 2)
+	l, err := NewPoint_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Point_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+func (s Shape) SetEmpty() {
+	capnp.Struct(s).SetUint16(2, 3)
+
+}
+
+func (s Shape) Style() Shape_style { return Shape_style(s) }
+
+func (s Shape_style) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Shape_style) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Shape_style) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Shape_style) Dashed() bool {
+	return capnp.Struct(s).Bit(32)
+}
+
+func (s Shape_style) SetDashed(v bool) {
+	capnp.Struct(s).SetBit(32, v)
+}
+
+func (s Shape_style) Width() uint16 {
+	return capnp.Struct(s).Uint16(6)
+}
+
+func (s Shape_style) SetWidth(v uint16) {
+	capnp.Struct(s).SetUint16(6, v)
+}
+
+func (s Shape_style) Fill() Shape_style_fill { return Shape_style_fill(s) }
+
+func (s Shape_style_fill) Which() Shape_style_fill_Which {
+	return Shape_style_fill_Which(capnp.Struct(s).Uint16(16))
+}
+func (s Shape_style_fill) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Shape_style_fill) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Shape_style_fill) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Shape_style_fill) SetNone() {
+	capnp.Struct(s).SetUint16(16, 0)
+
+}
+
+func (s Shape_style_fill) Solid() Color {
+	if capnp.Struct(s).Uint16(16) != 1 {
+		panic("Which() != solid")
+	}
+	return Color(capnp.Struct(s).Uint16(18))
+}
+
+func (s Shape_style_fill) SetSolid(v Color) {
+	capnp.Struct(s).SetUint16(16, 1)
+	capnp.Struct(s).SetUint16(18, uint16(v))
+}
+
+func (s Shape_style_fill) Pattern() ([]byte, error) {
+	if capnp.Struct(s).Uint16(16) != 2 {
+		panic("Which() != pattern")
+	}
+	p, err := capnp.Struct(s).Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s Shape_style_fill) HasPattern() bool {
+	if capnp.Struct(s).Uint16(16) != 2 {
+		return false
+	}
+	return capnp.Struct(s).HasPtr(2)
+}
+
+// DisownPattern detaches the pattern field from s without
+// copying it.  The field is left null.  It returns an error if
+// pattern is not the union member that is set.
+func (s Shape_style_fill) DisownPattern() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(16) != 2 {
+		return capnp.Orphan{}, errors.New("Which() != pattern")
+	}
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptPattern sets the pattern field to an orphan from
+// s's message without copying it.
+func (s Shape_style_fill) AdoptPattern(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(16, 2)
+	return capnp.Struct(s).Adopt(2, o)
+}
+
+func (s Shape_style_fill) SetPattern(v []byte) error {
+	capnp.Struct(s).SetUint16(16, 2)
+	return capnp.Struct(s).SetData(2, v)
+}
+
+func (s Shape) Tags() (capnp.TextList, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return capnp.TextList(p.List()), err
+}
+
+func (s Shape) HasTags() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+// DisownTags detaches the tags field from s without
+// copying it.  The field is left null.
+func (s Shape) DisownTags() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptTags sets the tags field to an orphan from
+// s's message without copying it.
+func (s Shape) AdoptTags(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
+func (s Shape) SetTags(v capnp.TextList) error {
+	return capnp.Struct(s).SetPtr(3, v.ToPtr())
+}
+
+// NewTags sets the tags field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s Shape) NewTags(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(3, l.ToPtr())
+	return l, err
+}
+func (s Shape) Grid() (capnp.PointerList, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return capnp.PointerList(p.List()), err
+}
+
+func (s Shape) HasGrid() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+// DisownGrid detaches the grid field from s without
+// copying it.  The field is left null.
+func (s Shape) DisownGrid() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(4)
+}
+
+// AdoptGrid sets the grid field to an orphan from
+// s's message without copying it.
+func (s Shape) AdoptGrid(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(4, o)
+}
+
+func (s Shape) SetGrid(v capnp.PointerList) error {
+	return capnp.Struct(s).SetPtr(4, v.ToPtr())
+}
+
+// NewGrid sets the grid field to a newly
+// allocated capnp.PointerList, preferring placement in s's segment.
+func (s Shape) NewGrid(n int32) (capnp.PointerList, error) {
+	l, err := capnp.NewPointerList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.PointerList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(4, l.ToPtr())
+	return l, err
+}
+func (s Shape) Paths() (capnp.PointerList, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return capnp.PointerList(p.List()), err
+}
+
+func (s Shape) HasPaths() bool {
+	return capnp.Struct(s).HasPtr(5)
+}
+
+// DisownPaths detaches the paths field from s without
+// copying it.  The field is left null.
+func (s Shape) DisownPaths() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(5)
+}
+
+// AdoptPaths sets the paths field to an orphan from
+// s's message without copying it.
+func (s Shape) AdoptPaths(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(5, o)
+}
+
+func (s Shape) SetPaths(v capnp.PointerList) error {
+	return capnp.Struct(s).SetPtr(5, v.ToPtr())
+}
+
+// NewPaths sets the paths field to a newly
+// allocated capnp.PointerList, preferring placement in s's segment.
+func (s Shape) NewPaths(n int32) (capnp.PointerList, error) {
+	l, err := capnp.NewPointerList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.PointerList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(5, l.ToPtr())
+	return l, err
+}
+func (s Shape) Blobs() (capnp.PointerList, error) {
+	p, err := capnp.Struct(s).Ptr(6)
+	return capnp.PointerList(p.List()), err
+}
+
+func (s Shape) HasBlobs() bool {
+	return capnp.Struct(s).HasPtr(6)
+}
+
+// DisownBlobs detaches the blobs field from s without
+// copying it.  The field is left null.
+func (s Shape) DisownBlobs() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(6)
+}
+
+// AdoptBlobs sets the blobs field to an orphan from
+// s's message without copying it.
+func (s Shape) AdoptBlobs(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(6, o)
+}
+
+func (s Shape) SetBlobs(v capnp.PointerList) error {
+	return capnp.Struct(s).SetPtr(6, v.ToPtr())
+}
+
+// NewBlobs sets the blobs field to a newly
+// allocated capnp.PointerList, preferring placement in s's segment.
+func (s Shape) NewBlobs(n int32) (capnp.PointerList, error) {
+	l, err := capnp.NewPointerList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.PointerList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(6, l.ToPtr())
+	return l, err
+}
+func (s Shape) Children() (Shape_List, error) {
+	p, err := capnp.Struct(s).Ptr(7)
+	return Shape_List(p.List()), err
+}
+
+func (s Shape) HasChildren() bool {
+	return capnp.Struct(s).HasPtr(7)
+}
+
+// DisownChildren detaches the children field from s without
+// copying it.  The field is left null.
+func (s Shape) DisownChildren() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(7)
+}
+
+// AdoptChildren sets the children field to an orphan from
+// s's message without copying it.
+func (s Shape) AdoptChildren(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(7, o)
+}
+
+func (s Shape) SetChildren(v Shape_List) error {
+	return capnp.Struct(s).SetPtr(7, v.ToPtr())
+}
+
+// NewChildren sets the children field to a newly
+// allocated Shape_List, preferring placement in s's segment.
+func (s Shape) NewChildren(n int32) (Shape_List, error) {
+	l, err := NewShape_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Shape_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(7, l.ToPtr())
+	return l, err
+}
+
+// Shape_List is a list of Shape.
+type Shape_List = capnp.StructList[Shape]
+
+// NewShape creates a new list of Shape.
+func NewShape_List(s *capnp.Segment, sz int32) (Shape_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 8}, sz)
+	return capnp.StructList[Shape](l), err
+}
+
+// Shape_Future is a wrapper for a Shape promised by a client call.
+type Shape_Future struct{ *capnp.Future }
+
+func (f Shape_Future) Struct() (Shape, error) {
+	p, err := f.Future.Ptr()
+	return Shape(p.Struct()), err
+}
+func (p Shape_Future) Square() Shape_square_Future { return Shape_square_Future{p.Future} }
+
+// Shape_square_Future is a wrapper for a Shape_square promised by a client call.
+type Shape_square_Future struct{ *capnp.Future }
+
+func (f Shape_square_Future) Struct() (Shape_square, error) {
+	p, err := f.Future.Ptr()
+	return Shape_square(p.Struct()), err
+}
+func (p Shape_square_Future) Corner() Point_Future {
+	return Point_Future{Future: p.Future.Field(1, nil)}
+}
+func (p Shape_Future) Style() Shape_style_Future { return Shape_style_Future{p.Future} }
+
+// Shape_style_Future is a wrapper for a Shape_style promised by a client call.
+type Shape_style_Future struct{ *capnp.Future }
+
+func (f Shape_style_Future) Struct() (Shape_style, error) {
+	p, err := f.Future.Ptr()
+	return Shape_style(p.Struct()), err
+}
+func (p Shape_style_Future) Fill() Shape_style_fill_Future { return Shape_style_fill_Future{p.Future} }
+
+// Shape_style_fill_Future is a wrapper for a Shape_style_fill promised by a client call.
+type Shape_style_fill_Future struct{ *capnp.Future }
+
+func (f Shape_style_fill_Future) Struct() (Shape_style_fill, error) {
+	p, err := f.Future.Ptr()
+	return Shape_style_fill(p.Struct()), err
+}
+
+// Shape_Go is a plain Go representation of Shape.
+// Unlike Shape, it does not refer to a message and remains
+// valid after the message is released, except for AnyPointer fields.
+type Shape_Go struct {
+	Which    Shape_Which
+	Name     string
+	Color    Color
+	Circle   float64
+	Square   Shape_square_Go
+	Polygon  []Point_Go
+	Style    Shape_style_Go
+	Tags     []string
+	Grid     [][]int32
+	Paths    [][]Point_Go
+	Blobs    [][][]byte
+	Children []Shape_Go
+}
+
+// ToCapnp allocates a new Shape in seg and copies p into it.
+func (p *Shape_Go) ToCapnp(seg *capnp.Segment) (Shape, error) {
+	s, err := NewShape(seg)
+	if err != nil {
+		return Shape{}, err
+	}
+	return s, p.CopyTo(s)
+}
+
+// CopyTo copies p into s, which should be newly allocated.
+// Capabilities are copied with AddRef; nil pointers are left null.
+func (p *Shape_Go) CopyTo(s Shape) error {
+	if err := s.SetName(p.Name); err != nil {
+		return err
+	}
+	s.SetColor(p.Color)
+	if err := p.Style.CopyTo(s.Style()); err != nil {
+		return err
+	}
+	if p.Tags != nil {
+		l0, err := capnp.NewTextList(s.Segment(), int32(len(p.Tags)))
+		if err != nil {
+			return err
+		}
+		for i0, x0 := range p.Tags {
+			if err := l0.Set(i0, x0); err != nil {
+				return err
+			}
+		}
+		if err := s.SetTags(l0); err != nil {
+			return err
+		}
+	}
+	if p.Grid != nil {
+		l0, err := capnp.NewPointerList(s.Segment(), int32(len(p.Grid)))
+		if err != nil {
+			return err
+		}
+		for i0, x0 := range p.Grid {
+			if x0 == nil {
+				continue
+			}
+			l1, err := capnp.NewInt32List(l0.Segment(), int32(len(x0)))
+			if err != nil {
+				return err
+			}
+			for i1, x1 := range x0 {
+				l1.Set(i1, x1)
+			}
+			if err := l0.Set(i0, l1.ToPtr()); err != nil {
+				return err
+			}
+		}
+		if err := s.SetGrid(l0); err != nil {
+			return err
+		}
+	}
+	if p.Paths != nil {
+		l0, err := capnp.NewPointerList(s.Segment(), int32(len(p.Paths)))
+		if err != nil {
+			return err
+		}
+		for i0, x0 := range p.Paths {
+			if x0 == nil {
+				continue
+			}
+			l1, err := NewPoint_List(l0.Segment(), int32(len(x0)))
+			if err != nil {
+				return err
+			}
+			for i1 := range x0 {
+				if err := x0[i1].CopyTo(l1.At(i1)); err != nil {
+					return err
+				}
+			}
+			if err := l0.Set(i0, l1.ToPtr()); err != nil {
+				return err
+			}
+		}
+		if err := s.SetPaths(l0); err != nil {
+			return err
+		}
+	}
+	if p.Blobs != nil {
+		l0, err := capnp.NewPointerList(s.Segment(), int32(len(p.Blobs)))
+		if err != nil {
+			return err
+		}
+		for i0, x0 := range p.Blobs {
+			if x0 == nil {
+				continue
+			}
+			l1, err := capnp.NewDataList(l0.Segment(), int32(len(x0)))
+			if err != nil {
+				return err
+			}
+			for i1, x1 := range x0 {
+				if err := l1.Set(i1, x1); err != nil {
+					return err
+				}
+			}
+			if err := l0.Set(i0, l1.ToPtr()); err != nil {
+				return err
+			}
+		}
+		if err := s.SetBlobs(l0); err != nil {
+			return err
+		}
+	}
+	if p.Children != nil {
+		l0, err := NewShape_List(s.Segment(), int32(len(p.Children)))
+		if err != nil {
+			return err
+		}
+		for i0 := range p.Children {
+			if err := p.Children[i0].CopyTo(l0.At(i0)); err != nil {
+				return err
+			}
+		}
+		if err := s.SetChildren(l0); err != nil {
+			return err
+		}
+	}
+	switch p.Which {
+	case Shape_Which_circle:
+		s.SetCircle(p.Circle)
+	case Shape_Which_square:
+		s.SetSquare()
+		if err := p.Square.CopyTo(s.Square()); err != nil {
+			return err
+		}
+	case Shape_Which_polygon:
+		if p.Polygon != nil {
+			l0, err := NewPoint_List(s.Segment(), int32(len(p.Polygon)))
+			if err != nil {
+				return err
+			}
+			for i0 := range p.Polygon {
+				if err := p.Polygon[i0].CopyTo(l0.At(i0)); err != nil {
+					return err
+				}
+			}
+			if err := s.SetPolygon(l0); err != nil {
+				return err
+			}
+		} else if err := s.SetPolygon(Point_List{}); err != nil {
+			return err
+		}
+	case Shape_Which_empty:
+		s.SetEmpty()
+	}
+	return nil
+}
+
+// FromCapnp sets p to a copy of s.  Capabilities are copied with AddRef.
+func (p *Shape_Go) FromCapnp(s Shape) error {
+	*p = Shape_Go{}
+	if v, err := s.Name(); err != nil {
+		return err
+	} else {
+		p.Name = v
+	}
+	p.Color = s.Color()
+	if err := p.Style.FromCapnp(s.Style()); err != nil {
+		return err
+	}
+	if l0, err := s.Tags(); err != nil {
+		return err
+	} else if l0.IsValid() {
+		p.Tags = make([]string, l0.Len())
+		for i0 := range p.Tags {
+			x0, err := l0.At(i0)
+			if err != nil {
+				return err
+			}
+			p.Tags[i0] = x0
+		}
+	}
+	if l0, err := s.Grid(); err != nil {
+		return err
+	} else if l0.IsValid() {
+		p.Grid = make([][]int32, l0.Len())
+		for i0 := range p.Grid {
+			x0, err := l0.At(i0)
+			if err != nil {
+				return err
+			}
+			if l1 := capnp.Int32List(x0.List()); l1.IsValid() {
+				p.Grid[i0] = make([]int32, l1.Len())
+				for i1 := range p.Grid[i0] {
+					p.Grid[i0][i1] = l1.At(i1)
+				}
+			}
+		}
+	}
+	if l0, err := s.Paths(); err != nil {
+		return err
+	} else if l0.IsValid() {
+		p.Paths = make([][]Point_Go, l0.Len())
+		for i0 := range p.Paths {
+			x0, err := l0.At(i0)
+			if err != nil {
+				return err
+			}
+			if l1 := Point_List(x0.List()); l1.IsValid() {
+				p.Paths[i0] = make([]Point_Go, l1.Len())
+				for i1 := range p.Paths[i0] {
+					if err := p.Paths[i0][i1].FromCapnp(l1.At(i1)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if l0, err := s.Blobs(); err != nil {
+		return err
+	} else if l0.IsValid() {
+		p.Blobs = make([][][]byte, l0.Len())
+		for i0 := range p.Blobs {
+			x0, err := l0.At(i0)
+			if err != nil {
+				return err
+			}
+			if l1 := capnp.DataList(x0.List()); l1.IsValid() {
+				p.Blobs[i0] = make([][]byte, l1.Len())
+				for i1 := range p.Blobs[i0] {
+					x1, err := l1.At(i1)
+					if err != nil {
+						return err
+					}
+					if x1 != nil {
+						p.Blobs[i0][i1] = append([]byte{}, x1...)
+					}
+				}
+			}
+		}
+	}
+	if l0, err := s.Children(); err != nil {
+		return err
+	} else if l0.IsValid() {
+		p.Children = make([]Shape_Go, l0.Len())
+		for i0 := range p.Children {
+			if err := p.Children[i0].FromCapnp(l0.At(i0)); err != nil {
+				return err
+			}
+		}
+	}
+	p.Which = s.Which()
+	switch p.Which {
+	case Shape_Which_circle:
+		p.Circle = s.Circle()
+	case Shape_Which_square:
+		if err := p.Square.FromCapnp(s.Square()); err != nil {
+			return err
+		}
+	case Shape_Which_polygon:
+		if l0, err := s.Polygon(); err != nil {
+			return err
+		} else if l0.IsValid() {
+			p.Polygon = make([]Point_Go, l0.Len())
+			for i0 := range p.Polygon {
+				if err := p.Polygon[i0].FromCapnp(l0.At(i0)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Shape_square_Go is a plain Go representation of Shape_square.
+// Unlike Shape_square, it does not refer to a message and remains
+// valid after the message is released, except for AnyPointer fields.
+type Shape_square_Go struct {
+	Side   float64
+	Corner *Point_Go
+}
+
+// CopyTo copies p into s, which should be newly allocated.
+// Capabilities are copied with AddRef; nil pointers are left null.
+func (p *Shape_square_Go) CopyTo(s Shape_square) error {
+	s.SetSide(p.Side)
+	if p.Corner != nil {
+		v, err := s.NewCorner()
+		if err != nil {
+			return err
+		}
+		if err := p.Corner.CopyTo(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FromCapnp sets p to a copy of s.  Capabilities are copied with AddRef.
+func (p *Shape_square_Go) FromCapnp(s Shape_square) error {
+	*p = Shape_square_Go{}
+	p.Side = s.Side()
+	if v, err := s.Corner(); err != nil {
+		return err
+	} else if v.IsValid() {
+		p.Corner = new(Point_Go)
+		if err := p.Corner.FromCapnp(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shape_style_Go is a plain Go representation of Shape_style.
+// Unlike Shape_style, it does not refer to a message and remains
+// valid after the message is released, except for AnyPointer fields.
+type Shape_style_Go struct {
+	Dashed bool
+	Width  uint16
+	Fill   Shape_style_fill_Go
+}
+
+// CopyTo copies p into s, which should be newly allocated.
+// Capabilities are copied with AddRef; nil pointers are left null.
+func (p *Shape_style_Go) CopyTo(s Shape_style) error {
+	s.SetDashed(p.Dashed)
+	s.SetWidth(p.Width)
+	if err := p.Fill.CopyTo(s.Fill()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FromCapnp sets p to a copy of s.  Capabilities are copied with AddRef.
+func (p *Shape_style_Go) FromCapnp(s Shape_style) error {
+	*p = Shape_style_Go{}
+	p.Dashed = s.Dashed()
+	p.Width = s.Width()
+	if err := p.Fill.FromCapnp(s.Fill()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Shape_style_fill_Go is a plain Go representation of Shape_style_fill.
+// Unlike Shape_style_fill, it does not refer to a message and remains
+// valid after the message is released, except for AnyPointer fields.
+type Shape_style_fill_Go struct {
+	Which   Shape_style_fill_Which
+	Solid   Color
+	Pattern []byte
+}
+
+// CopyTo copies p into s, which should be newly allocated.
+// Capabilities are copied with AddRef; nil pointers are left null.
+func (p *Shape_style_fill_Go) CopyTo(s Shape_style_fill) error {
+	switch p.Which {
+	case Shape_style_fill_Which_none:
+		s.SetNone()
+	case Shape_style_fill_Which_solid:
+		s.SetSolid(p.Solid)
+	case Shape_style_fill_Which_pattern:
+		if err := s.SetPattern(p.Pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FromCapnp sets p to a copy of s.  Capabilities are copied with AddRef.
+func (p *Shape_style_fill_Go) FromCapnp(s Shape_style_fill) error {
+	*p = Shape_style_fill_Go{}
+	p.Which = s.Which()
+	switch p.Which {
+	case Shape_style_fill_Which_solid:
+		p.Solid = s.Solid()
+	case Shape_style_fill_Which_pattern:
+		if v, err := s.Pattern(); err != nil {
+			return err
+		} else if v != nil {
+			p.Pattern = append([]byte{}, v...)
+		}
+	}
+	return nil
+}
+
+const schema_e3c9d8a1b7f60e42 = "x\xda|TOh\\\xe5\x17\xbd\xe7{/\xb9o\x9a" +
+	"?\x93\xaf\xdfK\x7f\xbf.\x86\xa9\xae\xccPc2Z" +
+	"\x84Q\xc8\x1f\x1bh$\xa1\xf3x\x04RH\xa0\x93\x99" +
+	"\xd7\xcc\xc0\xcb\xcc8\x7fh\x06\x85\xba\x0en\\HA" +
+	"\x10\x1a\x14\x04\x11\xab+Q\x17\x05\xa1\x0bw\x06]\xb8" +
+	"\xa9\x82\x12\x04wn\x041\xc9\x93;}\xc9Ljt" +
+	"q\x08\xf9\xce\xdc{\xee\xbb\xe7\xde;\xf5\x89\x9a\xb5\xa7" +
+	"G\xee2)ou`0\x9ah\xbf\xf9b\xeb\xda{" +
+	"o\x917\x04D\xf3\xa3\x7f|\xbe\xfb\xc37\xbf\x90\xcd" +
+	"D\xe63k\xc7|aq\x8c\xdbD\xe6\x8a\xcd\xd1\xbd" +
+	"\x1f\xe7.|\xf4S\xe9m\xf2\xfe\x07+z\xf7\xc2\xd1" +
+	"o\x97\x1f\xe6\x1f\xd0\x8a\xc3\xb0\xe0\x98\xa7\xec\x9f\xcd\xb3" +
+	"6\xc7\xb8Od~\xb79\xfa\xf4\xbb_\xdf\xbf\xf2\xd2" +
+	"\xdd\xafD\xc6\xea\xc9,8l\x03\xe6\x91\xbdc\xf6m" +
+	"\x16<\xbfo\x7f\xa8\x88\xcc\xba\xc3\xbd\xdc\x9e\x86\xd5K" +
+	"1\xee0\x88\xcc\xa2\xf3\xa5\xf1\x1c\x8e\xd1\x15r8*" +
+	"]\xff\xff\xebk\xfe\xc7\x0fH\x0f\xa9\x9e\x0e\xc1<r" +
+	"v\xcc\xbe\xc31\xae\x13\x99T\x82\xa3\xe7\xfej[\x97" +
+	"\x1f\xee\xee\x9d-\x91H|mt\x82cH\x03\xdeI" +
+	"0\xdd\x8f\xea\xb5\xcd\xe6d\xb1PG\xb5\x9e\xcb\xd7*" +
+	"\xd5\x16Q\x1e\xc8Cy\x8ee\x13\xd9 \xd2\x13\xe7\xf5" +
+	"\x04{\xcfX\xf0^P\xd0\x80\x0by\x9d>\xaf\xa7\xd9" +
+	"\x9b\xb2\xe0\xbd\xac\x80\xed<\x14l\x12\x00\x9d\xbe\x7ff" +
+	"q\xa2\xa1\xaa\xf5\x9c_.\xd4\x83\xc9f\xab\x13\x06\x93" +
+	"\xb7\xb8\x12\x86\xb1\xda\xb0e\x0fG\x91\x8b\x04\x91^\xc8" +
+	"\xe8\x05\xf6\xaeZ\xf0\xf2\x0a)\x1cE\x09\x17\xe7\x88\xf4" +
+	"rV/\xb3\xb7d\xc1\xbb\xa9\x90R\x87\x91r1D" +
+	"\xa4\xd7\xe7\xf5:{k\x16\xbc\xb2B\xb2Z\xab\x06y" +
+	"(\x1aL7ka\xa5$\xa5${\xbd$\x9a\x85\x06" +
+	"\xe7\x15\x90$\xdc\xa9\x17Z\xad\xa0Q\x95\x1f\x8d\x90\xa0" +
+	"\xbf^\x1c\xd7{\xd2\x93\xa9\xe3\x9e\x989d\xcc\x1c\xd8" +
+	"\x9f\x85\x05\x7f\x09\x0aq[\xcc\"\xb2f\x11\xec_\x13" +
+	"b\x0dRh\x14\xc1\x85\"27\x9037\xc0\xfe\xaa" +
+	"p%\xd1\xb4\x8e\"\xa0g\x9d) g\x0a`B\xca" +
+	">\x94\xa8\x01\"\xb3\x8cy\xb3\x0c\xf6\x97$\xaa.Q" +
+	"\x03\x07\x91\x8bA\"\xb3\x85\xac\xd9\x02\xfb\xa1P\xdbP" +
+	"\xc0 \xd0\x1b6\xd3F\xd6\xb4%\x9df\xcb\xc50\x89" +
+	"@F\x04\xfc\x9b\x12\xf1\x06\x14\xb4c\xbb\x18!2\x1d" +
+	"dL\x07\xeco\x0b\xf3\x810\x89\x01\x17\xa3Df\x17" +
+	"Y\xb3\x0b\xf6\xef\x09\xf3\xbd0\xe7\x06]$\x89\xcc\x1e" +
+	"\xb2f\x0f\xec\x7f+\xcc\x810C\xecb\x8c\xc8\xfc\x89" +
+	"W\xcd!\xd8?\x10\xe6\x92\x12_\x0a[\xe2\x0b\x86I" +
+	"\x80t\xb1\x16\xd6\x1a\xff\xe9\xceL\xb1\xd2(\x86\xdd\xa0" +
+	"!\x12`\xa6\xf9Z\xbb\xd0\x90\x97;\xf5Z\xd8\xd9\xac" +
+	"u\x9d\x1b%\xe4-`\xacw\x01\xfa\xf2\x8c\x12\xd2\xc1" +
+	"V\xbd\xd5\x89gB&/\x0f\x95l\x156\x9b}\xc1" +
+	"R\xd5(!\xb9\xd9\xa8\x94\xfa\x9e\xe3?\xf6c6]" +
+	"/\xb4\xca\xcd\x7f\xd2\xff\xae\xbc\x11\xd66\xce\x08\x18y" +
+	"\x9c/*\x96+a\xa9\x11T\x89\xe8\xd4\x87\x9cl\xef" +
+	"\xe9tg\xcd\xe6\xccd\xfcI\xc7k4v\xc9\x05\xcb" +
+	"\x16\xe5\xfa\xb6H\xc3r\xe1\x9c\xda\xa1U\x05(\xa0w" +
+	"\x04\xf5JF\xaf0a\xa6Th\x96\x83n\x17@\x02" +
+	"\xa4oWJ\xad\xb2<0\x09\x90\xbc\xd5\xdd\\\xf5D" +
+	"A\xaf\x88\xa5'\xcb2\x0cE\xa4SO\xeb\x14\x03\xfa" +
+	"bV_d(=\x9e\xd1\xe3\xcc\x8dn\xfe\xf4f#" +
+	"\x08\xc4\xc2\xe4F\xd8\x0eN\xe7\xeb;\x16]\xcf\xfb\xce" +
+	"\xd2\x18\\Xr\x972O\xde%[\xeeR\xee\xf8." +
+	"]UH6+\xa5S\x03T\xac5\xaaAw\xec\xce" +
+	"6mL\xba\xfc\xf7\x00\\\xcaYv"
+
+func init() {
+	schemas.Register(schema_e3c9d8a1b7f60e42,
+		0x8c9d487437807529,
+		0x9164dfaa1641dea0,
+		0xbb973b35a3e7d3b0,
+		0xbf50c52ceafe169a,
+		0xbfac535c7b184f64,
+		0xd1a1c52c0375fb2f)
+}
@@ -0,0 +1,78 @@
+package pogs
+
+import (
+	"reflect"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []Shape_Go{
+		{},
+		{
+			Which:  Shape_Which_circle,
+			Name:   "circle",
+			Color:  Color_blue,
+			Circle: 1.5,
+			Style: Shape_style_Go{
+				Dashed: true,
+				Width:  3,
+				Fill:   Shape_style_fill_Go{Which: Shape_style_fill_Which_solid, Solid: Color_green},
+			},
+			Tags: []string{"a", "", "c"},
+		},
+		{
+			Which:  Shape_Which_square,
+			Square: Shape_square_Go{Side: 2, Corner: &Point_Go{X: -1, Y: 7}},
+			Style: Shape_style_Go{
+				Fill: Shape_style_fill_Go{Which: Shape_style_fill_Which_pattern, Pattern: []byte{0xf0, 0x0f}},
+			},
+			Grid:  [][]int32{{1, 2, 3}, nil, {4}},
+			Blobs: [][][]byte{{[]byte("x"), nil}, nil},
+		},
+		{
+			Which:   Shape_Which_polygon,
+			Polygon: []Point_Go{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}},
+			Paths:   [][]Point_Go{{{X: 1}}, nil, {{Y: 2}, {X: 3, Y: 4}}},
+			Children: []Shape_Go{
+				{Which: Shape_Which_empty, Name: "child"},
+				{Which: Shape_Which_circle, Circle: 0.25, Tags: []string{"nested"}},
+			},
+		},
+	}
+	for _, want := range tests {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := want.ToCapnp(seg)
+		if err != nil {
+			t.Errorf("ToCapnp(%+v): %v", want, err)
+			continue
+		}
+		if err := seg.Message().SetRoot(s.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		data, err := seg.Message().Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg, err := capnp.Unmarshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root, err := ReadRootShape(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Shape_Go
+		if err := got.FromCapnp(root); err != nil {
+			t.Errorf("FromCapnp of %+v: %v", want, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("round trip of %+v = %+v", want, got)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+package validate
+
+//go:generate go run capnproto.org/go/capnp/v3/capnpc-go -I ../../../std validate.capnp
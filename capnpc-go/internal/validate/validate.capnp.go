@@ -0,0 +1,148 @@
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package validate
+
+import (
+	capnp "capnproto.org/go/capnp/v3"
+	text "capnproto.org/go/capnp/v3/encoding/text"
+	schemas "capnproto.org/go/capnp/v3/schemas"
+	math "math"
+)
+
+type Reading capnp.Struct
+
+// Reading_TypeID is the unique identifier for the type Reading.
+const Reading_TypeID = 0xafe4ac5874c1d4e1
+
+func NewReading(s *capnp.Segment) (Reading, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return Reading(st), err
+}
+
+func NewRootReading(s *capnp.Segment) (Reading, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return Reading(st), err
+}
+
+func ReadRootReading(msg *capnp.Message) (Reading, error) {
+	root, err := msg.Root()
+	return Reading(root.Struct()), err
+}
+
+func (s Reading) String() string {
+	str, _ := text.Marshal(0xafe4ac5874c1d4e1, capnp.Struct(s))
+	return str
+}
+
+func (s Reading) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Reading) DecodeFromPtr(p capnp.Ptr) Reading {
+	return Reading(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Reading) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Reading) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Reading) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Reading) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Reading) Celsius() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(0))
+}
+
+func (s Reading) SetCelsius(v float64) {
+	capnp.Struct(s).SetUint64(0, math.Float64bits(v))
+}
+
+func (s Reading) Ratio() float32 {
+	return math.Float32frombits(capnp.Struct(s).Uint32(8))
+}
+
+func (s Reading) SetRatio(v float32) {
+	capnp.Struct(s).SetUint32(8, math.Float32bits(v))
+}
+
+func (s Reading) Count() uint16 {
+	return capnp.Struct(s).Uint16(12)
+}
+
+func (s Reading) SetCount(v uint16) {
+	capnp.Struct(s).SetUint16(12, v)
+}
+
+// Reading_List is a list of Reading.
+type Reading_List = capnp.StructList[Reading]
+
+// NewReading creates a new list of Reading.
+func NewReading_List(s *capnp.Segment, sz int32) (Reading_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return capnp.StructList[Reading](l), err
+}
+
+// Reading_Future is a wrapper for a Reading promised by a client call.
+type Reading_Future struct{ *capnp.Future }
+
+func (f Reading_Future) Struct() (Reading, error) {
+	p, err := f.Future.Ptr()
+	return Reading(p.Struct()), err
+}
+
+// Validate checks s against the constraints declared on its fields in
+// the schema, returning a *capnp.ValidationError for the first field
+// that does not satisfy them.
+func (s Reading) Validate() error {
+	if v := s.Celsius(); v != v {
+		return &capnp.ValidationError{Field: "celsius", Msg: "is NaN"}
+	}
+	if s.Celsius() < -273.15 {
+		return &capnp.ValidationError{Field: "celsius", Msg: "less than minimum -273.15"}
+	}
+	if s.Celsius() > 1000 {
+		return &capnp.ValidationError{Field: "celsius", Msg: "greater than maximum 1000"}
+	}
+	if v := s.Ratio(); v != v {
+		return &capnp.ValidationError{Field: "ratio", Msg: "is NaN"}
+	}
+	if s.Ratio() < 0 {
+		return &capnp.ValidationError{Field: "ratio", Msg: "less than minimum 0"}
+	}
+	if s.Count() > 100 {
+		return &capnp.ValidationError{Field: "count", Msg: "greater than maximum 100"}
+	}
+	return nil
+}
+
+const schema_c41d6e0a9f3b7582 = "x\xdaT\xcd1Kza\x14\x06\xf0\xe79\xf7\xd5\xfb" +
+	"G\xfdSo:\xd8 \xb7O\x10\xe5\x12\xd4\xd0Ur" +
+	"\xf7m\xaa%\xb8\xa8\xc5\x05\xb9Z^[\x83h\x0b\xa9" +
+	"o\xd0\x17\x10\x9c\x84h2hji\xb1)*\x85\x86" +
+	"\xa6v\xa7\x1b\x86!\x1d8\xc39\xfcx\x9e\xb5,]" +
+	"\xb5\xfe\xff=\x061;\xb1x4\x1e\xde\x87{\xdd\x8f" +
+	"\x1e\xcc\x02%:oo\xdd$\x82\xdc\x03\x94\x0d\xa4\x9f" +
+	"\xe5.\xfd*\xf6l{@zY\xd9\xe8E\xa7^\xdd" +
+	"\xafza\x8d\xab\x15\xaf\x1947w\xb7k^\xd5\x0f" +
+	"\x8e\xcad\x99bR\x96\x02\x14\x01]*\xea\xd2\x86\xb9" +
+	"\xb0h\xae\x85\x9a\x92\xe1\xf4\xdb\xc9\xeb\x8ec\xba\x16\xcd" +
+	"\xadPK<C\x01t?\xaf\xfb\x8e\x19Z4#\xe1" +
+	"Y\xa5Vo\xf9\xed\x96\xf9G\x89\x0e\x1e\xdfr\x9fO" +
+	"\x93/\x98Ea!\xcb\xe8\xb2[|\xb1\xf2\x931L" +
+	"VXX!\x93\xd1\xe1t\x96\x8e\x07\x004\xed\xb2\x90" +
+	"\xc9\x91{\xe5\xce/\x08\x93\xa0s\xe2\x85~\xc3\xa8?" +
+	"\xa9JXHM\x09g8\x01a\x02t*\x8dv\x10" +
+	"\xfe\xe0y\xe3/\x1e\xec\xcf\xc3m\x08m\xd0\xe5\xf7\x00" +
+	"'?Ww"
+
+func init() {
+	schemas.Register(schema_c41d6e0a9f3b7582,
+		0xafe4ac5874c1d4e1)
+}
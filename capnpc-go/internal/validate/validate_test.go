@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"math"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		celsius float64
+		ratio   float32
+		count   uint16
+		field   string
+	}{
+		{celsius: 20, ratio: 0.5, count: 3},
+		{celsius: -273.15, ratio: 0, count: 100},
+		{celsius: -300, field: "celsius"},
+		{celsius: 1001, field: "celsius"},
+		{celsius: math.NaN(), field: "celsius"},
+		{ratio: -1, field: "ratio"},
+		{ratio: float32(math.NaN()), field: "ratio"},
+		{count: 101, field: "count"},
+	}
+	for _, test := range tests {
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		r, err := NewRootReading(seg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.SetCelsius(test.celsius)
+		r.SetRatio(test.ratio)
+		r.SetCount(test.count)
+		err = r.Validate()
+		if test.field == "" {
+			if err != nil {
+				t.Errorf("Validate() of %+v = %v; want nil", test, err)
+			}
+			continue
+		}
+		ve, ok := err.(*capnp.ValidationError)
+		if !ok || ve.Field != test.field {
+			t.Errorf("Validate() of %+v = %v; want ValidationError for %s", test, err, test.field)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"capnproto.org/go/capnp/v3"
@@ -11,10 +12,91 @@ import (
 
 type node struct {
 	schema.Node
-	pkg   string
-	imp   string
-	nodes []*node // only for file nodes
-	Name  string
+	pkg      string
+	imp      string
+	generics bool    // the file has the $generics annotation
+	nodes    []*node // only for file nodes
+	Name     string
+	parent   *node // enclosing scope; the interface for method structs
+	params   []typeParam
+}
+
+// A typeParam is a generic parameter of a node or one of its
+// enclosing scopes, which becomes a Go type parameter.
+type typeParam struct {
+	scope uint64
+	index uint16
+	name  string
+}
+
+// TypeParams returns the Go type parameter list for declaring n,
+// or the empty string if n is not generic.
+func (n *node) TypeParams() string {
+	if len(n.params) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, p := range n.params {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s capnp.TypeParam[%[1]s]", p.name)
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// TypeArgs returns n's type parameters as the type argument list used
+// to refer to n from within its own declarations, or the empty string
+// if n is not generic.
+func (n *node) TypeArgs() string {
+	if len(n.params) == 0 {
+		return ""
+	}
+	names := make([]string, len(n.params))
+	for i, p := range n.params {
+		names[i] = p.name
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// resolveParams populates n.params from the parameters of n and its
+// enclosing scopes, outermost first.  Only structs and interfaces in
+// files with the $generics annotation are generic in Go; elsewhere,
+// parameters are represented as capnp.Ptr.
+func (n *node) resolveParams() error {
+	if !n.IsGeneric() || !n.generics {
+		return nil
+	}
+	if w := n.Which(); w != schema.Node_Which_structNode && w != schema.Node_Which_interface {
+		return nil
+	}
+	var scopes []*node
+	for s := n; s != nil; s = s.parent {
+		scopes = append(scopes, s)
+	}
+	used := make(map[string]bool)
+	for i := len(scopes) - 1; i >= 0; i-- {
+		s := scopes[i]
+		ps, err := s.Parameters()
+		if err != nil {
+			return fmt.Errorf("reading parameters of %s: %v", s, err)
+		}
+		for j := 0; j < ps.Len(); j++ {
+			name, _ := ps.At(j).Name()
+			for base, k := name, 2; used[name]; k++ {
+				name = base + strconv.Itoa(k)
+			}
+			used[name] = true
+			n.params = append(n.params, typeParam{
+				scope: s.Id(),
+				index: uint16(j),
+				name:  name,
+			})
+		}
+	}
+	return nil
 }
 
 func (n *node) codeOrderFields() []field {
@@ -98,9 +180,28 @@ type interfaceMethod struct {
 	OriginalName string
 	Params       *node
 	Results      *node
+
+	// path is the chain of superclasses from the interface being
+	// generated to Interface, used to bind generic parameters.
+	path []schema.Superclass
+
+	// Go types of the method's structs and server call, as seen from
+	// the interface being generated.  Filled in by resolveMethodTypes.
+	ParamsType        string
+	ResultsType       string
+	ResultsFutureType string
+	CallType          string
+
+	// ValidateParams is true if the params struct has a Validate
+	// method that the server should call before the method.
+	ValidateParams bool
 }
 
 func methodSet(methods []interfaceMethod, n *node, nodes nodeMap) ([]interfaceMethod, error) {
+	return appendMethods(methods, n, nil, nodes)
+}
+
+func appendMethods(methods []interfaceMethod, n *node, path []schema.Superclass, nodes nodeMap) ([]interfaceMethod, error) {
 	ms, _ := n.Interface().Methods()
 	for i := 0; i < ms.Len(); i++ {
 		m := ms.At(i)
@@ -122,6 +223,7 @@ func methodSet(methods []interfaceMethod, n *node, nodes nodeMap) ([]interfaceMe
 			Name:         parseAnnotations(mann).Rename(mname),
 			Params:       pn,
 			Results:      rn,
+			path:         path,
 		})
 	}
 	// TODO(light): sort added methods by code order
@@ -133,7 +235,8 @@ func methodSet(methods []interfaceMethod, n *node, nodes nodeMap) ([]interfaceMe
 		if err != nil {
 			return methods, fmt.Errorf("could not find superclass %#x of %s", s.Id(), n)
 		}
-		methods, err = methodSet(methods, sn, nodes)
+		spath := append(path[:len(path):len(path)], s)
+		methods, err = appendMethods(methods, sn, spath, nodes)
 		if err != nil {
 			return methods, err
 		}
@@ -155,6 +258,7 @@ type annotations struct {
 	TagType   int
 	CustomTag string
 	Name      string
+	Generics  bool
 }
 
 func parseAnnotations(list capnp.StructList[schema.Annotation]) *annotations {
@@ -176,6 +280,8 @@ func parseAnnotations(list capnp.StructList[schema.Annotation]) *annotations {
 			ann.TagType = noTag
 		case 0xc2b96012172f8df1: // $name
 			ann.Name, _ = val.Text()
+		case 0xd0f242f6fc401f34: // $generics
+			ann.Generics = true
 		}
 	}
 	return ann
@@ -230,22 +336,29 @@ func buildNodeMap(req schema.CodeGeneratorRequest) (nodeMap, error) {
 		ann := parseAnnotations(fann)
 		f.pkg = ann.Package
 		f.imp = ann.Import
+		f.generics = ann.Generics
 		nnodes, _ := f.NestedNodes()
 		for i := 0; i < nnodes.Len(); i++ {
 			nn := nnodes.At(i)
 			if ni := nodes[nn.Id()]; ni != nil {
 				nname, _ := nn.Name()
-				if err := resolveName(nodes, ni, "", nname, f); err != nil {
+				if err := resolveName(nodes, ni, "", nname, nil, f); err != nil {
 					return nil, err
 				}
 			}
 		}
 	}
+	for _, n := range nodes {
+		if err := n.resolveParams(); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
 // resolveName is called as part of building up a node map to populate the name field of n.
-func resolveName(nodes nodeMap, n *node, base, name string, file *node) error {
+// parent is the enclosing scope of n, or nil at file scope.
+func resolveName(nodes nodeMap, n *node, base, name string, parent, file *node) error {
 	na, err := n.Annotations()
 	if err != nil {
 		return fmt.Errorf("reading annotations for %s: %v", n, err)
@@ -256,8 +369,10 @@ func resolveName(nodes nodeMap, n *node, base, name string, file *node) error {
 	} else {
 		n.Name = base + "_" + name
 	}
+	n.parent = parent
 	n.pkg = file.pkg
 	n.imp = file.imp
+	n.generics = file.generics
 	file.nodes = append(file.nodes, n)
 
 	nnodes, err := n.NestedNodes()
@@ -274,7 +389,7 @@ func resolveName(nodes nodeMap, n *node, base, name string, file *node) error {
 		if err != nil {
 			return fmt.Errorf("reading name of nested node %d in %s: %v", i+1, n, err)
 		}
-		if err := resolveName(nodes, ni, n.Name, nname, file); err != nil {
+		if err := resolveName(nodes, ni, n.Name, nname, n, file); err != nil {
 			return err
 		}
 	}
@@ -294,7 +409,7 @@ func resolveName(nodes nodeMap, n *node, base, name string, file *node) error {
 				return fmt.Errorf("could not find type information for group %s in %s", fname, n)
 			}
 			fname = parseAnnotations(fa).Rename(fname)
-			if err := resolveName(nodes, grp, n.Name, fname, file); err != nil {
+			if err := resolveName(nodes, grp, n.Name, fname, n, file); err != nil {
 				return err
 			}
 		}
@@ -308,7 +423,7 @@ func resolveName(nodes nodeMap, n *node, base, name string, file *node) error {
 			if x.ScopeId() != 0 {
 				return nil
 			}
-			return resolveName(nodes, x, base, name, file)
+			return resolveName(nodes, x, base, name, n, file)
 		}
 		for i := 0; i < m.Len(); i++ {
 			mm := m.At(i)
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"capnproto.org/go/capnp/v3/internal/schema"
+)
+
+// pogsField describes how a struct field is represented in the plain Go
+// struct generated for its node.  Read and Write are Go statements that
+// copy the field from the Cap'n Proto struct s to the Go struct p and
+// back, respectively.
+type pogsField struct {
+	field
+	GoName string
+	GoType string // empty for void fields, which have no Go field
+	Read   string
+	Write  string
+}
+
+// pogsName returns the name of the plain Go struct generated for n.
+func pogsName(name string) string {
+	return name + "_Go"
+}
+
+func (g *generator) definePogs(n, baseNode *node) error {
+	if len(n.params) > 0 {
+		// Generic structs have no plain Go form, since a type parameter
+		// says nothing about how to copy its values.
+		return nil
+	}
+	fields := n.codeOrderFields()
+	pf := make([]pogsField, 0, len(fields))
+	for _, f := range fields {
+		p, err := g.pogsField(n, f)
+		if err != nil {
+			return fmt.Errorf("plain Go struct field %s.%s: %v", n.shortDisplayName(), f.Name, err)
+		}
+		pf = append(pf, p)
+	}
+	err := g.r.Render(structPogsParams{
+		G:        g,
+		Node:     n,
+		BaseNode: baseNode,
+		Fields:   pf,
+	})
+	if err != nil {
+		return fmt.Errorf("plain Go struct for %s: %v", n, err)
+	}
+
+	for _, f := range fields {
+		if f.Which() == schema.Field_Which_group {
+			grp, err := g.nodes.mustFind(f.Group().TypeId())
+			if err != nil {
+				return err
+			}
+			if err := g.definePogs(grp, baseNode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *generator) pogsField(n *node, f field) (pogsField, error) {
+	p := pogsField{
+		field:  f,
+		GoName: strings.Title(f.Name),
+	}
+	name := p.GoName
+	if f.Which() == schema.Field_Which_group {
+		grp, err := g.nodes.mustFind(f.Group().TypeId())
+		if err != nil {
+			return pogsField{}, err
+		}
+		p.GoType = pogsName(grp.Name)
+		if f.HasDiscriminant() {
+			p.Write = fmt.Sprintf("s.Set%s()\n", name)
+		}
+		p.Write += fmt.Sprintf("if err := p.%[1]s.CopyTo(s.%[1]s()); err != nil {\nreturn err\n}\n", name)
+		p.Read = fmt.Sprintf("if err := p.%[1]s.FromCapnp(s.%[1]s()); err != nil {\nreturn err\n}\n", name)
+		return p, nil
+	}
+
+	t, _ := f.Slot().Type()
+	if g.pogsOpaque(t) {
+		// Instances of generic structs are kept as references into the
+		// message they were read from.
+		typ, err := g.RemoteTypeName(t, n)
+		if err != nil {
+			return pogsField{}, err
+		}
+		p.GoType = typ
+		p.Write = fmt.Sprintf("if err := s.Set%[1]s(p.%[1]s); err != nil {\nreturn err\n}\n", name)
+		p.Read = fmt.Sprintf("if v, err := s.%[1]s(); err != nil {\nreturn err\n} else {\np.%[1]s = v\n}\n", name)
+		return p, nil
+	}
+	// clearUnion sets the discriminant when a union member's pointer is
+	// left null.
+	clearUnion := func() (string, error) {
+		if !f.HasDiscriminant() {
+			return "", nil
+		}
+		ctyp, err := g.RemoteTypeName(t, n)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(" else if err := s.Set%s(%s{}); err != nil {\nreturn err\n}", name, ctyp), nil
+	}
+	switch t.Which() {
+	case schema.Type_Which_void:
+		if f.HasDiscriminant() {
+			p.Write = fmt.Sprintf("s.Set%s()\n", name)
+		}
+		return p, nil
+
+	case schema.Type_Which_bool,
+		schema.Type_Which_int8, schema.Type_Which_int16, schema.Type_Which_int32, schema.Type_Which_int64,
+		schema.Type_Which_uint8, schema.Type_Which_uint16, schema.Type_Which_uint32, schema.Type_Which_uint64,
+		schema.Type_Which_float32, schema.Type_Which_float64, schema.Type_Which_enum:
+		typ, err := g.RemoteTypeName(t, n)
+		if err != nil {
+			return pogsField{}, err
+		}
+		p.GoType = typ
+		p.Write = fmt.Sprintf("s.Set%[1]s(p.%[1]s)\n", name)
+		p.Read = fmt.Sprintf("p.%[1]s = s.%[1]s()\n", name)
+
+	case schema.Type_Which_text:
+		p.GoType = "string"
+		p.Write = fmt.Sprintf("if err := s.Set%[1]s(p.%[1]s); err != nil {\nreturn err\n}\n", name)
+		p.Read = fmt.Sprintf("if v, err := s.%[1]s(); err != nil {\nreturn err\n} else {\np.%[1]s = v\n}\n", name)
+
+	case schema.Type_Which_data:
+		p.GoType = "[]byte"
+		p.Write = fmt.Sprintf("if err := s.Set%[1]s(p.%[1]s); err != nil {\nreturn err\n}\n", name)
+		p.Read = fmt.Sprintf("if v, err := s.%[1]s(); err != nil {\nreturn err\n} else if v != nil {\np.%[1]s = append([]byte{}, v...)\n}\n", name)
+
+	case schema.Type_Which_structType:
+		typ, err := g.pogsType(t, n)
+		if err != nil {
+			return pogsField{}, err
+		}
+		unset, err := clearUnion()
+		if err != nil {
+			return pogsField{}, err
+		}
+		p.GoType = "*" + typ
+		p.Write = fmt.Sprintf("if p.%[1]s != nil {\nv, err := s.New%[1]s()\nif err != nil {\nreturn err\n}\nif err := p.%[1]s.CopyTo(v); err != nil {\nreturn err\n}\n}%[2]s\n", name, unset)
+		p.Read = fmt.Sprintf("if v, err := s.%[1]s(); err != nil {\nreturn err\n} else if v.IsValid() {\np.%[1]s = new(%[2]s)\nif err := p.%[1]s.FromCapnp(v); err != nil {\nreturn err\n}\n}\n", name, typ)
+
+	case schema.Type_Which_interface:
+		typ, err := g.RemoteTypeName(t, n)
+		if err != nil {
+			return pogsField{}, err
+		}
+		p.GoType = typ
+		p.Write = fmt.Sprintf("if err := s.Set%[1]s(p.%[1]s.AddRef()); err != nil {\nreturn err\n}\n", name)
+		p.Read = fmt.Sprintf("p.%[1]s = s.%[1]s().AddRef()\n", name)
+
+	case schema.Type_Which_anyPointer:
+		p.GoType = g.pogsAnyPointerType(t)
+		if isAnyCap(t.AnyPointer()) {
+			p.Write = fmt.Sprintf("if err := s.Set%[1]s(p.%[1]s.AddRef()); err != nil {\nreturn err\n}\n", name)
+			p.Read = fmt.Sprintf("p.%[1]s = s.%[1]s().AddRef()\n", name)
+			break
+		}
+		p.Write = fmt.Sprintf("if err := s.Set%[1]s(p.%[1]s); err != nil {\nreturn err\n}\n", name)
+		p.Read = fmt.Sprintf("if v, err := s.%[1]s(); err != nil {\nreturn err\n} else {\np.%[1]s = v\n}\n", name)
+
+	case schema.Type_Which_list:
+		typ, err := g.pogsType(t, n)
+		if err != nil {
+			return pogsField{}, err
+		}
+		unset, err := clearUnion()
+		if err != nil {
+			return pogsField{}, err
+		}
+		p.GoType = typ
+		newList, err := g.pogsNewList(t, n, "l0", "s.Segment()", "p."+name)
+		if err != nil {
+			return pogsField{}, err
+		}
+		write, err := g.pogsWriteList(t, n, "l0", "p."+name, 0)
+		if err != nil {
+			return pogsField{}, err
+		}
+		read, err := g.pogsReadList(t, n, "l0", "p."+name, 0)
+		if err != nil {
+			return pogsField{}, err
+		}
+		p.Write = fmt.Sprintf("if p.%[1]s != nil {\n%[2]s%[3]sif err := s.Set%[1]s(l0); err != nil {\nreturn err\n}\n}%[4]s\n", name, newList, write, unset)
+		p.Read = fmt.Sprintf("if l0, err := s.%[1]s(); err != nil {\nreturn err\n} else if l0.IsValid() {\n%[2]s}\n", name, read)
+
+	default:
+		return pogsField{}, fmt.Errorf("unhandled field type %v", t.Which())
+	}
+	return p, nil
+}
+
+// pogsType returns the Go type used to hold a value of type t in a plain
+// Go struct.  Struct types are returned as values, not pointers.
+func (g *generator) pogsType(t schema.Type, rel *node) (string, error) {
+	switch t.Which() {
+	case schema.Type_Which_void:
+		return "struct{}", nil
+	case schema.Type_Which_structType:
+		typ, err := g.RemoteTypeName(t, rel)
+		if err != nil {
+			return "", err
+		}
+		return pogsName(typ), nil
+	case schema.Type_Which_anyPointer:
+		// Elements of lists of AnyPointer are always read as capnp.Ptr.
+		return g.imports.Capnp() + ".Ptr", nil
+	case schema.Type_Which_list:
+		elem, _ := t.List().ElementType()
+		typ, err := g.pogsType(elem, rel)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + typ, nil
+	default:
+		return g.RemoteTypeName(t, rel)
+	}
+}
+
+// pogsOpaque reports whether t is a generic struct type, or a list
+// that contains one, which plain Go structs hold by reference.
+func (g *generator) pogsOpaque(t schema.Type) bool {
+	switch t.Which() {
+	case schema.Type_Which_structType:
+		return g.isGenericType(t)
+	case schema.Type_Which_list:
+		elem, _ := t.List().ElementType()
+		return g.pogsOpaque(elem)
+	default:
+		return false
+	}
+}
+
+// pogsAnyPointerType returns the Go type of an AnyPointer struct field,
+// which matches the type of its generated accessor.
+func (g *generator) pogsAnyPointerType(t schema.Type) string {
+	ap := t.AnyPointer()
+	if ap.Which() == schema.Type_anyPointer_Which_unconstrained {
+		switch ap.Unconstrained().Which() {
+		case schema.Type_anyPointer_unconstrained_Which_struct:
+			return g.imports.Capnp() + ".Struct"
+		case schema.Type_anyPointer_unconstrained_Which_list:
+			return g.imports.Capnp() + ".List"
+		case schema.Type_anyPointer_unconstrained_Which_capability:
+			return g.imports.Capnp() + ".Client"
+		}
+	}
+	return g.imports.Capnp() + ".Ptr"
+}
+
+// pogsNewList returns statements that allocate a list of type t in seg
+// with the same length as the slice v and assign it to l.
+func (g *generator) pogsNewList(t schema.Type, rel *node, l, seg, v string) (string, error) {
+	if elem, _ := t.List().ElementType(); elem.Which() == schema.Type_Which_void {
+		return fmt.Sprintf("%s := %s.NewVoidList(%s, int32(len(%s)))\n", l, g.imports.Capnp(), seg, v), nil
+	}
+	newfunc, err := g.RemoteTypeNew(t, rel)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s, err := %s(%s, int32(len(%s)))\nif err != nil {\nreturn err\n}\n", l, newfunc, seg, v), nil
+}
+
+// pogsWriteList returns statements that copy the elements of the slice
+// v into the list l of type t.  depth is used to name loop variables.
+func (g *generator) pogsWriteList(t schema.Type, rel *node, l, v string, depth int) (string, error) {
+	elem, _ := t.List().ElementType()
+	i, x := fmt.Sprint("i", depth), fmt.Sprint("x", depth)
+	switch elem.Which() {
+	case schema.Type_Which_void:
+		return "", nil
+	case schema.Type_Which_bool,
+		schema.Type_Which_int8, schema.Type_Which_int16, schema.Type_Which_int32, schema.Type_Which_int64,
+		schema.Type_Which_uint8, schema.Type_Which_uint16, schema.Type_Which_uint32, schema.Type_Which_uint64,
+		schema.Type_Which_float32, schema.Type_Which_float64, schema.Type_Which_enum:
+		return fmt.Sprintf("for %[1]s, %[2]s := range %[3]s {\n%[4]s.Set(%[1]s, %[2]s)\n}\n", i, x, v, l), nil
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_anyPointer:
+		return fmt.Sprintf("for %[1]s, %[2]s := range %[3]s {\nif err := %[4]s.Set(%[1]s, %[2]s); err != nil {\nreturn err\n}\n}\n", i, x, v, l), nil
+	case schema.Type_Which_interface:
+		return fmt.Sprintf("for %[1]s, %[2]s := range %[3]s {\nif err := %[4]s.Set(%[1]s, %[2]s.AddRef()); err != nil {\nreturn err\n}\n}\n", i, x, v, l), nil
+	case schema.Type_Which_structType:
+		return fmt.Sprintf("for %[1]s := range %[2]s {\nif err := %[2]s[%[1]s].CopyTo(%[3]s.At(%[1]s)); err != nil {\nreturn err\n}\n}\n", i, v, l), nil
+	case schema.Type_Which_list:
+		inner := fmt.Sprint("l", depth+1)
+		newList, err := g.pogsNewList(elem, rel, inner, l+".Segment()", x)
+		if err != nil {
+			return "", err
+		}
+		write, err := g.pogsWriteList(elem, rel, inner, x, depth+1)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("for %[1]s, %[2]s := range %[3]s {\nif %[2]s == nil {\ncontinue\n}\n%[5]s%[6]sif err := %[4]s.Set(%[1]s, %[7]s.ToPtr()); err != nil {\nreturn err\n}\n}\n",
+			i, x, v, l, newList, write, inner), nil
+	default:
+		return "", fmt.Errorf("unhandled list element type %v", elem.Which())
+	}
+}
+
+// pogsReadList returns statements that set the slice v to a copy of the
+// elements of the list l of type t.  depth is used to name loop variables.
+func (g *generator) pogsReadList(t schema.Type, rel *node, l, v string, depth int) (string, error) {
+	elem, _ := t.List().ElementType()
+	typ, err := g.pogsType(t, rel)
+	if err != nil {
+		return "", err
+	}
+	i, x := fmt.Sprint("i", depth), fmt.Sprint("x", depth)
+	mk := fmt.Sprintf("%s = make(%s, %s.Len())\n", v, typ, l)
+	switch elem.Which() {
+	case schema.Type_Which_void:
+		return mk, nil
+	case schema.Type_Which_bool,
+		schema.Type_Which_int8, schema.Type_Which_int16, schema.Type_Which_int32, schema.Type_Which_int64,
+		schema.Type_Which_uint8, schema.Type_Which_uint16, schema.Type_Which_uint32, schema.Type_Which_uint64,
+		schema.Type_Which_float32, schema.Type_Which_float64, schema.Type_Which_enum:
+		return mk + fmt.Sprintf("for %[1]s := range %[2]s {\n%[2]s[%[1]s] = %[3]s.At(%[1]s)\n}\n", i, v, l), nil
+	case schema.Type_Which_text, schema.Type_Which_anyPointer:
+		return mk + fmt.Sprintf("for %[1]s := range %[2]s {\n%[4]s, err := %[3]s.At(%[1]s)\nif err != nil {\nreturn err\n}\n%[2]s[%[1]s] = %[4]s\n}\n", i, v, l, x), nil
+	case schema.Type_Which_data:
+		return mk + fmt.Sprintf("for %[1]s := range %[2]s {\n%[4]s, err := %[3]s.At(%[1]s)\nif err != nil {\nreturn err\n}\nif %[4]s != nil {\n%[2]s[%[1]s] = append([]byte{}, %[4]s...)\n}\n}\n", i, v, l, x), nil
+	case schema.Type_Which_interface:
+		return mk + fmt.Sprintf("for %[1]s := range %[2]s {\n%[4]s, err := %[3]s.At(%[1]s)\nif err != nil {\nreturn err\n}\n%[2]s[%[1]s] = %[4]s.AddRef()\n}\n", i, v, l, x), nil
+	case schema.Type_Which_structType:
+		return mk + fmt.Sprintf("for %[1]s := range %[2]s {\nif err := %[2]s[%[1]s].FromCapnp(%[3]s.At(%[1]s)); err != nil {\nreturn err\n}\n}\n", i, v, l), nil
+	case schema.Type_Which_list:
+		ltyp, err := g.RemoteTypeName(elem, rel)
+		if err != nil {
+			return "", err
+		}
+		inner := fmt.Sprint("l", depth+1)
+		read, err := g.pogsReadList(elem, rel, inner, fmt.Sprintf("%s[%s]", v, i), depth+1)
+		if err != nil {
+			return "", err
+		}
+		return mk + fmt.Sprintf("for %[1]s := range %[2]s {\n%[4]s, err := %[3]s.At(%[1]s)\nif err != nil {\nreturn err\n}\nif %[5]s := %[6]s(%[4]s.List()); %[5]s.IsValid() {\n%[7]s}\n}\n",
+			i, v, l, x, inner, ltyp, read), nil
+	default:
+		return "", fmt.Errorf("unhandled list element type %v", elem.Which())
+	}
+}
@@ -64,6 +64,7 @@ type (
 	structFloatFieldParams      structUintFieldParams
 	structInterfaceFieldParams  structFieldParams
 	structCapabilityFieldParams structFieldParams
+	structTypeParamFieldParams  structFieldParams
 	structVoidFieldParams       structFieldParams
 	structListFieldParams       structObjectFieldParams
 	structPointerFieldParams    structObjectFieldParams
@@ -125,6 +126,38 @@ type structListParams struct {
 	StringMethod bool
 }
 
+type structPogsParams struct {
+	G        *generator
+	Node     *node
+	BaseNode *node
+	Fields   []pogsField
+}
+
+func (p structPogsParams) IsBase() bool {
+	return p.Node == p.BaseNode
+}
+
+func (p structPogsParams) HasUnion() bool {
+	return p.Node.StructNode().DiscriminantCount() > 0
+}
+
+type structValidateParams struct {
+	G        *generator
+	Node     *node
+	Fields   []validateField
+	Patterns []validatePattern
+}
+
+// HasUnion reports whether any union member of the struct is checked.
+func (p structValidateParams) HasUnion() bool {
+	for _, f := range p.Fields {
+		if f.HasDiscriminant() && f.Check != "" {
+			return true
+		}
+	}
+	return false
+}
+
 type structEnumsParams struct {
 	G          *generator
 	Node       *node
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/schema"
+)
+
+// constraints holds the validation annotations on a field.
+type constraints struct {
+	min, max       float64
+	hasMin, hasMax bool
+	maxLen         uint32
+	hasMaxLen      bool
+	required       bool
+	pattern        string
+	hasPattern     bool
+}
+
+func parseConstraints(list capnp.StructList[schema.Annotation]) constraints {
+	var c constraints
+	for i, n := 0, list.Len(); i < n; i++ {
+		a := list.At(i)
+		val, _ := a.Value()
+		switch a.Id() {
+		case 0xeef9cfe81ddeca5e: // $min
+			c.min, c.hasMin = val.Float64(), true
+		case 0xe1f93203db42ac8b: // $max
+			c.max, c.hasMax = val.Float64(), true
+		case 0x8baa1a3595b98165: // $maxLen
+			c.maxLen, c.hasMaxLen = val.Uint32(), true
+		case 0x8b2455025d97a887: // $required
+			c.required = true
+		case 0xcffe29b68470b6e0: // $pattern
+			c.pattern, _ = val.Text()
+			c.hasPattern = true
+		}
+	}
+	return c
+}
+
+func (c constraints) isZero() bool {
+	return !c.hasMin && !c.hasMax && !c.hasMaxLen && !c.required && !c.hasPattern
+}
+
+// validateField describes how a struct field is checked by the
+// generated Validate method.  Check is a sequence of Go statements
+// that return an error from Validate if the field of s is invalid.
+type validateField struct {
+	field
+	Check string
+}
+
+// A validatePattern is a regular expression that is compiled into a
+// package-level variable.
+type validatePattern struct {
+	Var     string
+	Pattern string
+}
+
+// validates reports whether a Validate method is generated for the
+// struct n: that is, whether n, one of its groups, or a struct type
+// that it refers to has constraint annotations.
+func (g *generator) validates(n *node) bool {
+	if g.validating == nil {
+		g.validating = g.findValidating()
+	}
+	return g.validating[n.Id()]
+}
+
+// findValidating returns the set of struct IDs that validates reports
+// true for.  Structs can refer to each other recursively, so it
+// iterates until it reaches a fixed point.
+func (g *generator) findValidating() map[uint64]bool {
+	v := make(map[uint64]bool)
+	for id, n := range g.nodes {
+		if n.Which() != schema.Node_Which_structNode {
+			continue
+		}
+		fields, _ := n.StructNode().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			ann, _ := fields.At(i).Annotations()
+			if !parseConstraints(ann).isZero() {
+				v[id] = true
+				break
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for id, n := range g.nodes {
+			if v[id] || n.Which() != schema.Node_Which_structNode {
+				continue
+			}
+			fields, _ := n.StructNode().Fields()
+			for i := 0; i < fields.Len(); i++ {
+				if ref, ok := validatedStruct(fields.At(i)); ok && v[ref] {
+					v[id] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return v
+}
+
+// validatedStruct returns the ID of the struct whose Validate method
+// is called to check f: the group itself, the field's struct type or
+// the element type of a list of structs.
+func validatedStruct(f schema.Field) (id uint64, ok bool) {
+	if f.Which() == schema.Field_Which_group {
+		return f.Group().TypeId(), true
+	}
+	t, _ := f.Slot().Type()
+	switch t.Which() {
+	case schema.Type_Which_structType:
+		return t.StructType().TypeId(), true
+	case schema.Type_Which_list:
+		elem, _ := t.List().ElementType()
+		if elem.Which() == schema.Type_Which_structType {
+			return elem.StructType().TypeId(), true
+		}
+	}
+	return 0, false
+}
+
+func (g *generator) defineValidate(n *node) error {
+	if !g.validates(n) {
+		return nil
+	}
+	fields := n.codeOrderFields()
+	vf := make([]validateField, 0, len(fields))
+	var patterns []validatePattern
+	for _, f := range fields {
+		check, pat, err := g.validateCheck(n, f)
+		if err != nil {
+			return fmt.Errorf("validation of field %s.%s: %v", n.shortDisplayName(), f.Name, err)
+		}
+		if pat.Var != "" {
+			patterns = append(patterns, pat)
+		}
+		vf = append(vf, validateField{field: f, Check: check})
+	}
+	err := g.r.Render(structValidateParams{
+		G:        g,
+		Node:     n,
+		Fields:   vf,
+		Patterns: patterns,
+	})
+	if err != nil {
+		return fmt.Errorf("Validate for %s: %v", n, err)
+	}
+
+	for _, f := range fields {
+		if f.Which() == schema.Field_Which_group {
+			grp, err := g.nodes.mustFind(f.Group().TypeId())
+			if err != nil {
+				return err
+			}
+			if err := g.defineValidate(grp); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateCheck returns the statements that check field f of n, and
+// the regular expression that they use, if any.
+func (g *generator) validateCheck(n *node, f field) (string, validatePattern, error) {
+	sname, _ := f.Field.Name()
+	name := strings.Title(f.Name)
+	fail := func(msg string) string {
+		return fmt.Sprintf("return &%s.ValidationError{Field: %q, Msg: %q}\n", g.imports.Capnp(), sname, msg)
+	}
+	wrap := func(path, err string) string {
+		return fmt.Sprintf("return %s.WrapValidationError(%s, %s)\n", g.imports.Capnp(), path, err)
+	}
+
+	if f.Which() == schema.Field_Which_group {
+		grp, err := g.nodes.mustFind(f.Group().TypeId())
+		if err != nil || !g.validates(grp) {
+			return "", validatePattern{}, err
+		}
+		return fmt.Sprintf("if err := s.%s().Validate(); err != nil {\n%s}\n", name, wrap(strconv.Quote(sname), "err")), validatePattern{}, nil
+	}
+
+	fann, _ := f.Annotations()
+	c := parseConstraints(fann)
+	t, _ := f.Slot().Type()
+	var (
+		sb  strings.Builder
+		pat validatePattern
+	)
+	switch t.Which() {
+	case schema.Type_Which_int8, schema.Type_Which_int16, schema.Type_Which_int32, schema.Type_Which_int64,
+		schema.Type_Which_uint8, schema.Type_Which_uint16, schema.Type_Which_uint32, schema.Type_Which_uint64,
+		schema.Type_Which_float32, schema.Type_Which_float64:
+		if c.hasMaxLen || c.required || c.hasPattern {
+			return "", pat, fmt.Errorf("only min and max apply to %v fields", t.Which())
+		}
+		isFloat := t.Which() == schema.Type_Which_float32 || t.Which() == schema.Type_Which_float64
+		if isFloat && (c.hasMin || c.hasMax) {
+			// NaN compares false against any bound, so reject it
+			// explicitly.
+			fmt.Fprintf(&sb, "if v := s.%s(); v != v {\n%s}\n", name, fail("is NaN"))
+		}
+		if c.hasMin {
+			lit, always, never := numericBound(t.Which(), c.min, true)
+			if never {
+				return "", pat, fmt.Errorf("min %v is out of range for %v", c.min, t.Which())
+			}
+			if !always {
+				fmt.Fprintf(&sb, "if s.%s() < %s {\n%s}\n", name, lit, fail("less than minimum "+lit))
+			}
+		}
+		if c.hasMax {
+			lit, always, never := numericBound(t.Which(), c.max, false)
+			if never {
+				return "", pat, fmt.Errorf("max %v is out of range for %v", c.max, t.Which())
+			}
+			if !always {
+				fmt.Fprintf(&sb, "if s.%s() > %s {\n%s}\n", name, lit, fail("greater than maximum "+lit))
+			}
+		}
+		return sb.String(), pat, nil
+
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list,
+		schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		if c.hasMin || c.hasMax {
+			return "", pat, fmt.Errorf("min and max do not apply to %v fields", t.Which())
+		}
+	default:
+		if !c.isZero() {
+			return "", pat, fmt.Errorf("constraints do not apply to %v fields", t.Which())
+		}
+		return "", pat, nil
+	}
+
+	if c.hasPattern {
+		if t.Which() != schema.Type_Which_text {
+			return "", pat, fmt.Errorf("pattern does not apply to %v fields", t.Which())
+		}
+		if _, err := regexp.Compile(c.pattern); err != nil {
+			return "", pat, fmt.Errorf("pattern: %v", err)
+		}
+		pat = validatePattern{
+			Var:     "pattern_" + n.Name + "_" + f.Name,
+			Pattern: c.pattern,
+		}
+	}
+	if c.hasMaxLen {
+		switch t.Which() {
+		case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list:
+		default:
+			return "", pat, fmt.Errorf("maxLen does not apply to %v fields", t.Which())
+		}
+	}
+	if c.required {
+		fmt.Fprintf(&sb, "if !s.Has%s() {\n%s}\n", name, fail("required"))
+	}
+
+	// Checks that need the field's value.
+	var body strings.Builder
+	switch t.Which() {
+	case schema.Type_Which_text:
+		if c.hasMaxLen {
+			fmt.Fprintf(&body, "if len(v) > %d {\n%s}\n", c.maxLen, fail(fmt.Sprintf("longer than %d bytes", c.maxLen)))
+		}
+		if c.hasPattern {
+			fmt.Fprintf(&body, "if !%s.MatchString(v) {\n%s}\n", pat.Var, fail("does not match pattern "+strconv.Quote(c.pattern)))
+		}
+	case schema.Type_Which_data:
+		if c.hasMaxLen {
+			fmt.Fprintf(&body, "if len(v) > %d {\n%s}\n", c.maxLen, fail(fmt.Sprintf("longer than %d bytes", c.maxLen)))
+		}
+	case schema.Type_Which_list:
+		if c.hasMaxLen {
+			fmt.Fprintf(&body, "if v.Len() > %d {\n%s}\n", c.maxLen, fail(fmt.Sprintf("longer than %d elements", c.maxLen)))
+		}
+		if elem, _ := t.List().ElementType(); elem.Which() == schema.Type_Which_structType {
+			en, err := g.nodes.mustFind(elem.StructType().TypeId())
+			if err != nil {
+				return "", pat, err
+			}
+			if g.validates(en) {
+				path := fmt.Sprintf("%q+%s.Itoa(i)+\"]\"", sname+"[", g.imports.Strconv())
+				fmt.Fprintf(&body, "for i := 0; i < v.Len(); i++ {\nif err := v.At(i).Validate(); err != nil {\n%s}\n}\n", wrap(path, "err"))
+			}
+		}
+	case schema.Type_Which_structType:
+		sn, err := g.nodes.mustFind(t.StructType().TypeId())
+		if err != nil {
+			return "", pat, err
+		}
+		if g.validates(sn) {
+			fmt.Fprintf(&body, "if err := v.Validate(); err != nil {\n%s}\n", wrap(strconv.Quote(sname), "err"))
+		}
+	}
+	switch {
+	case body.Len() == 0:
+	case c.required:
+		fmt.Fprintf(&sb, "if v, err := s.%s(); err != nil {\nreturn err\n} else {\n%s}\n", name, body.String())
+	default:
+		// Null pointers are only checked by $required: their value
+		// is the field's default.
+		fmt.Fprintf(&sb, "if s.Has%[1]s() {\nv, err := s.%[1]s()\nif err != nil {\nreturn err\n}\n%[2]s}\n", name, body.String())
+	}
+	return sb.String(), pat, nil
+}
+
+// numericBound returns the Go literal that a field of numeric type t
+// is compared against for the bound b, which is a lower bound if isMin
+// is true.  always reports that every value of t satisfies the bound,
+// and never that no value does.
+func numericBound(t schema.Type_Which, b float64, isMin bool) (lit string, always, never bool) {
+	if t == schema.Type_Which_float32 || t == schema.Type_Which_float64 {
+		return strconv.FormatFloat(b, 'g', -1, 64), false, false
+	}
+	if isMin {
+		b = math.Ceil(b)
+	} else {
+		b = math.Floor(b)
+	}
+	var lo, hi float64
+	switch t {
+	case schema.Type_Which_int8:
+		lo, hi = math.MinInt8, math.MaxInt8
+	case schema.Type_Which_int16:
+		lo, hi = math.MinInt16, math.MaxInt16
+	case schema.Type_Which_int32:
+		lo, hi = math.MinInt32, math.MaxInt32
+	case schema.Type_Which_int64:
+		lo, hi = math.MinInt64, math.MaxInt64
+	case schema.Type_Which_uint8:
+		hi = math.MaxUint8
+	case schema.Type_Which_uint16:
+		hi = math.MaxUint16
+	case schema.Type_Which_uint32:
+		hi = math.MaxUint32
+	case schema.Type_Which_uint64:
+		hi = math.MaxUint64
+	}
+	if isMin {
+		if b <= lo {
+			return "", true, false
+		}
+		if b > hi {
+			return "", false, true
+		}
+	} else {
+		if b >= hi {
+			return "", true, false
+		}
+		if b < lo {
+			return "", false, true
+		}
+	}
+	return strconv.FormatFloat(b, 'f', 0, 64), false, false
+}
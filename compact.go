@@ -0,0 +1,298 @@
+package capnp
+
+import (
+	"errors"
+
+	"capnproto.org/go/capnp/v3/exc"
+	"capnproto.org/go/capnp/v3/internal/str"
+)
+
+// CompactOptions controls the behavior of Compact.
+// The zero value (or a nil *CompactOptions) is a sensible default.
+type CompactOptions struct {
+	// Arena is the arena used for the new message.  It must be empty.
+	// If nil, Compact allocates an arena that is large enough to hold
+	// the entire tree in a single segment, falling back to a
+	// multi-segment arena if the tree is larger than the maximum
+	// segment size.
+	Arena Arena
+
+	// KeepCapTable copies the source message's entire capability table
+	// into the new message so that capability IDs are preserved.  By
+	// default, only the capabilities reachable from the root are copied,
+	// and they are renumbered in the order in which they are first seen.
+	KeepCapTable bool
+}
+
+// Compact makes a deep copy of the object tree rooted at p into a new
+// message and sets it as the new message's root.  Objects are laid out
+// depth-first in pre-order, so the result is free of the orphaned
+// objects and far pointers that accumulate as a message is edited.
+// Struct and list sizes are preserved, so the copy can still be
+// modified with generated setters.
+//
+// Each capability in the new message's table holds a new reference
+// to the corresponding client in the source message.
+//
+// Compact charges the source message's read limit as much as reading
+// the tree once.  When it sizes the arena itself, it reads the tree
+// twice, and gives the first read back to the limit with Unread.
+func Compact(p Ptr, opts *CompactOptions) (*Message, error) {
+	if opts == nil {
+		opts = new(CompactOptions)
+	}
+	arena := opts.Arena
+	if arena == nil {
+		var z compactSizer
+		sz, err := z.size(p)
+		if err != nil {
+			return nil, exc.WrapError("compact", err)
+		}
+		if src := p.Message(); src != nil {
+			unread(src, z.read)
+		}
+		// One extra word for the root pointer.
+		if sz <= uint64(maxSegmentSize-wordSize) {
+			arena = SingleSegment(make([]byte, 0, sz+uint64(wordSize)))
+		} else {
+			arena = MultiSegment(nil)
+		}
+	}
+	msg, seg, err := NewMessage(arena)
+	if err != nil {
+		return nil, exc.WrapError("compact", err)
+	}
+	c := compactor{
+		caps: make(map[CapabilityID]CapabilityID),
+	}
+	if src := p.Message(); opts.KeepCapTable && src != nil {
+		c.keepCaps = true
+		for _, client := range src.CapTable {
+			msg.AddCap(client.AddRef())
+		}
+	}
+	cp, err := c.copyPtr(seg, p)
+	if err != nil {
+		msg.Reset(nil)
+		return nil, exc.WrapError("compact", err)
+	}
+	if err := msg.SetRoot(cp); err != nil {
+		msg.Reset(nil)
+		return nil, exc.WrapError("compact", err)
+	}
+	return msg, nil
+}
+
+// Compact is shorthand for calling Compact on the message's root.
+func (m *Message) Compact(opts *CompactOptions) (*Message, error) {
+	root, err := m.Root()
+	if err != nil {
+		return nil, exc.WrapError("compact", err)
+	}
+	return Compact(root, opts)
+}
+
+// compactor holds the state of a single Compact call.
+type compactor struct {
+	keepCaps bool
+	caps     map[CapabilityID]CapabilityID
+}
+
+func (c *compactor) copyPtr(dst *Segment, p Ptr) (Ptr, error) {
+	if !p.IsValid() {
+		return Ptr{}, nil
+	}
+	switch p.flags.ptrType() {
+	case structPtrType:
+		s := p.Struct()
+		ss, err := NewStruct(dst, s.size)
+		if err != nil {
+			return Ptr{}, exc.WrapError("struct", err)
+		}
+		if err := c.fillStruct(ss, s); err != nil {
+			return Ptr{}, err
+		}
+		return ss.ToPtr(), nil
+	case listPtrType:
+		ll, err := c.copyList(dst, p.List())
+		if err != nil {
+			return Ptr{}, err
+		}
+		return ll.ToPtr(), nil
+	case interfacePtrType:
+		return NewInterface(dst, c.copyCap(dst.msg, p.Interface())).ToPtr(), nil
+	default:
+		panic("unreachable")
+	}
+}
+
+// copyCap returns the ID in the destination message's capability table
+// for the capability that i references.
+func (c *compactor) copyCap(dst *Message, i Interface) CapabilityID {
+	if c.keepCaps {
+		return i.Capability()
+	}
+	if id, ok := c.caps[i.Capability()]; ok {
+		return id
+	}
+	id := dst.AddCap(i.Client().AddRef())
+	c.caps[i.Capability()] = id
+	return id
+}
+
+func (c *compactor) fillStruct(dst, s Struct) error {
+	copy(dst.seg.slice(dst.off, dst.size.DataSize), s.seg.slice(s.off, s.size.DataSize))
+	for i := uint16(0); i < s.size.PointerCount; i++ {
+		p, err := s.Ptr(i)
+		if err != nil {
+			return exc.WrapError("struct pointer "+str.Utod(i), err)
+		}
+		cp, err := c.copyPtr(dst.seg, p)
+		if err != nil {
+			return exc.WrapError("struct pointer "+str.Utod(i), err)
+		}
+		if err := dst.SetPtr(i, cp); err != nil {
+			return exc.WrapError("struct pointer "+str.Utod(i), err)
+		}
+	}
+	return nil
+}
+
+func (c *compactor) copyList(dst *Segment, l List) (List, error) {
+	if l.flags&isCompositeList != 0 {
+		cl, err := NewCompositeList(dst, l.size, l.length)
+		if err != nil {
+			return List{}, exc.WrapError("list", err)
+		}
+		for i := 0; i < cl.Len(); i++ {
+			if err := c.fillStruct(cl.Struct(i), l.Struct(i)); err != nil {
+				return List{}, exc.WrapError("list element "+str.Itod(i), err)
+			}
+		}
+		return cl, nil
+	}
+	if l.size.PointerCount == 0 {
+		// Data only, just copy over.
+		sz := l.allocSize()
+		newSeg, newAddr, err := alloc(dst, sz)
+		if err != nil {
+			return List{}, exc.WrapError("list", err)
+		}
+		end, _ := l.off.addSize(sz) // list was already validated
+		copy(newSeg.data[newAddr:], l.seg.data[l.off:end])
+		return List{
+			seg:        newSeg,
+			off:        newAddr,
+			length:     l.length,
+			size:       l.size,
+			flags:      l.flags,
+			depthLimit: maxDepth,
+		}, nil
+	}
+	pl, err := NewPointerList(dst, l.length)
+	if err != nil {
+		return List{}, exc.WrapError("list", err)
+	}
+	for i := 0; i < l.Len(); i++ {
+		p, err := PointerList(l).At(i)
+		if err != nil {
+			return List{}, exc.WrapError("list element "+str.Itod(i), err)
+		}
+		cp, err := c.copyPtr(pl.seg, p)
+		if err != nil {
+			return List{}, exc.WrapError("list element "+str.Itod(i), err)
+		}
+		if err := pl.Set(i, cp); err != nil {
+			return List{}, exc.WrapError("list element "+str.Itod(i), err)
+		}
+	}
+	return List(pl), nil
+}
+
+// compactSizer computes the size of the arena that Compact needs.
+type compactSizer struct {
+	read uint64 // bytes charged against the source message's read limit
+}
+
+// size returns the number of bytes needed to store the object tree
+// rooted at p, not including the pointer to p.
+func (z *compactSizer) size(p Ptr) (uint64, error) {
+	if !p.IsValid() {
+		return 0, nil
+	}
+	switch p.flags.ptrType() {
+	case structPtrType:
+		return z.structSize(p.Struct())
+	case listPtrType:
+		l := p.List()
+		total := uint64(l.allocSize().padToWord())
+		switch {
+		case l.flags&isCompositeList != 0:
+			for i := 0; i < l.Len(); i++ {
+				n, err := z.structSize(l.Struct(i))
+				if err != nil {
+					return 0, exc.WrapError("list element "+str.Itod(i), err)
+				}
+				// The element's own storage is counted in allocSize.
+				total += n - uint64(l.size.totalSize())
+			}
+		case l.size.PointerCount != 0:
+			for i := 0; i < l.Len(); i++ {
+				ep, err := PointerList(l).At(i)
+				if err != nil {
+					return 0, exc.WrapError("list element "+str.Itod(i), err)
+				}
+				n, err := z.sizeRead(ep)
+				if err != nil {
+					return 0, exc.WrapError("list element "+str.Itod(i), err)
+				}
+				total += n
+			}
+		}
+		return total, nil
+	case interfacePtrType:
+		return 0, nil
+	default:
+		return 0, errors.New("unknown pointer type")
+	}
+}
+
+func (z *compactSizer) structSize(s Struct) (uint64, error) {
+	total := uint64(s.size.totalSize())
+	for i := uint16(0); i < s.size.PointerCount; i++ {
+		p, err := s.Ptr(i)
+		if err != nil {
+			return 0, exc.WrapError("struct pointer "+str.Utod(i), err)
+		}
+		n, err := z.sizeRead(p)
+		if err != nil {
+			return 0, exc.WrapError("struct pointer "+str.Utod(i), err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// sizeRead is like size for a pointer p that was just read, and counts
+// what reading it charged against the read limit.
+func (z *compactSizer) sizeRead(p Ptr) (uint64, error) {
+	switch p.flags.ptrType() {
+	case structPtrType:
+		z.read += uint64(p.Struct().readSize())
+	case listPtrType:
+		z.read += uint64(p.List().readSize())
+	}
+	return z.size(p)
+}
+
+// unread gives n bytes back to m's read limit.
+func unread(m *Message, n uint64) {
+	for n > 0 {
+		sz := ^Size(0)
+		if n < uint64(sz) {
+			sz = Size(n)
+		}
+		m.Unread(sz)
+		n -= uint64(sz)
+	}
+}
@@ -0,0 +1,218 @@
+package capnp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Null", func(t *testing.T) {
+		t.Parallel()
+
+		msg, err := Compact(Ptr{}, nil)
+		if err != nil {
+			t.Fatal("Compact(Ptr{}):", err)
+		}
+		root, err := msg.Root()
+		if err != nil {
+			t.Fatal("msg.Root():", err)
+		}
+		if root.IsValid() {
+			t.Error("root is valid; want null")
+		}
+	})
+
+	t.Run("RemovesGarbage", func(t *testing.T) {
+		t.Parallel()
+
+		// Build a message with a small arena so that objects are spread
+		// over several segments, then overwrite a few pointers to leave
+		// orphaned objects behind.
+		msg, seg := NewMultiSegmentMessage(nil)
+		root, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		root.SetUint64(0, 0xdeadbeef)
+		for i := 0; i < 3; i++ {
+			if err := root.SetText(0, "garbage garbage garbage garbage"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := root.SetText(0, "hello"); err != nil {
+			t.Fatal(err)
+		}
+		big, err := NewData(seg, make([]byte, 4096))
+		if err != nil {
+			t.Fatal(err)
+		}
+		big.Set(0, 42)
+		if err := root.SetPtr(1, big.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		list, err := NewCompositeList(seg, ObjectSize{DataSize: 8, PointerCount: 1}, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < list.Len(); i++ {
+			list.Struct(i).SetUint64(0, uint64(i))
+			if err := list.Struct(i).SetText(0, "elem"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := root.SetPtr(2, list.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+
+		compacted, err := msg.Compact(nil)
+		if err != nil {
+			t.Fatal("Compact:", err)
+		}
+		if n := compacted.NumSegments(); n != 1 {
+			t.Errorf("compacted.NumSegments() = %d; want 1", n)
+		}
+		before, err := msg.TotalSize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		after, err := compacted.TotalSize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if after >= before {
+			t.Errorf("compacted.TotalSize() = %d; want < %d", after, before)
+		}
+		seg0, _ := compacted.Segment(0)
+		if len(seg0.Data()) != cap(seg0.Data()) {
+			t.Errorf("compacted segment has len=%d, cap=%d; want no spare capacity", len(seg0.Data()), cap(seg0.Data()))
+		}
+
+		p1, _ := msg.Root()
+		p2, err := compacted.Root()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := Equal(p1, p2); err != nil {
+			t.Fatal("Equal:", err)
+		} else if !ok {
+			t.Error("compacted root is not equal to original root")
+		}
+		if got := p2.Struct().Size(); got != root.Size() {
+			t.Errorf("compacted root size = %v; want %v", got, root.Size())
+		}
+	})
+
+	t.Run("Capabilities", func(t *testing.T) {
+		t.Parallel()
+
+		msg, seg := NewSingleSegmentMessage(nil)
+		unused := msg.AddCap(ErrorClient(errors.New("unused")))
+		c := ErrorClient(errors.New("used"))
+		used := msg.AddCap(c)
+		root, err := NewRootStruct(seg, ObjectSize{PointerCount: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		root.SetPtr(0, NewInterface(seg, used).ToPtr())
+		root.SetPtr(1, NewInterface(seg, used).ToPtr())
+		_ = unused
+
+		compacted, err := msg.Compact(nil)
+		if err != nil {
+			t.Fatal("Compact:", err)
+		}
+		if n := len(compacted.CapTable); n != 1 {
+			t.Errorf("len(compacted.CapTable) = %d; want 1", n)
+		}
+		p, _ := compacted.Root()
+		for i := uint16(0); i < 2; i++ {
+			iface, _ := p.Struct().Ptr(i)
+			if id := iface.Interface().Capability(); id != 0 {
+				t.Errorf("pointer %d capability = %d; want 0", i, id)
+			}
+			if !iface.Interface().Client().IsSame(c) {
+				t.Errorf("pointer %d does not reference the original client", i)
+			}
+		}
+
+		kept, err := msg.Compact(&CompactOptions{KeepCapTable: true})
+		if err != nil {
+			t.Fatal("Compact(KeepCapTable):", err)
+		}
+		if n := len(kept.CapTable); n != 2 {
+			t.Errorf("len(kept.CapTable) = %d; want 2", n)
+		}
+		p, _ = kept.Root()
+		iface, _ := p.Struct().Ptr(0)
+		if id := iface.Interface().Capability(); id != used {
+			t.Errorf("kept capability = %d; want %d", id, used)
+		}
+	})
+
+	t.Run("ReadLimit", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg, err := NewMessage(SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		root, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := root.SetText(0, "hello"); err != nil {
+			t.Fatal(err)
+		}
+		list, err := NewCompositeList(seg, ObjectSize{PointerCount: 1}, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < list.Len(); i++ {
+			if err := list.Struct(i).SetText(0, "element"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := root.SetPtr(1, list.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		data, err := seg.Message().Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Measure a single traversal by compacting into a given arena,
+		// which needs no sizing.
+		msg, err := Unmarshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := msg.Root()
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg.ResetReadLimit(1 << 20)
+		if _, err := Compact(p, &CompactOptions{Arena: SingleSegment(nil)}); err != nil {
+			t.Fatal("Compact with arena:", err)
+		}
+		once := 1<<20 - msg.rlimit
+
+		// Sizing the arena must not charge the limit a second time.
+		msg, err = Unmarshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err = msg.Root()
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg.ResetReadLimit(once)
+		if _, err := Compact(p, nil); err != nil {
+			t.Fatalf("Compact with read limit %d: %v", once, err)
+		}
+		if msg.rlimit != 0 {
+			t.Errorf("read limit after Compact = %d; want 0", msg.rlimit)
+		}
+	})
+}
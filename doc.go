@@ -379,5 +379,53 @@ implemented Calculator:
 A note about message ordering: by default, only one method per server will
 be invoked at a time; when implementing a server method which blocks or takes
 a long time, you calling the server.Go function to unblock future calls.
+
+Generics
+
+Generic structs and interfaces in a file with the $Go.generics annotation
+become Go generic types.  Each parameter of the type and of its
+enclosing scopes becomes a type parameter constrained by TypeParam, so
+it can be bound to any struct, interface or list type, or to Ptr.  For
+this schema:
+
+	$Go.generics;
+
+	struct Box(T) {
+		value @0 :T;
+	}
+
+capnpc-go generates:
+
+	type Box[T capnp.TypeParam[T]] capnp.Struct
+
+	func NewBox[T capnp.TypeParam[T]](s *capnp.Segment) (Box[T], error)
+	func (s Box[T]) Value() (T, error)
+	func (s Box[T]) SetValue(v T) error
+
+References to a generic type use the brand's bindings, so a field of
+type Box(Foo) has the Go type Box[Foo].  Parameters that are not bound,
+or that are bound to Text, Data or primitive types, are represented as
+Ptr.  Since Go does not support generic type aliases, lists of generic
+types use StructList or CapList directly instead of a _List type.
+
+Without the annotation, generic types are not generic in Go, and their
+parameters are represented as Ptr.  The standard schemas under
+capnproto.org/go/capnp/v3/std do not use it.
+
+Validation
+
+The go.capnp annotations min, max, maxLen, required and pattern declare
+constraints on struct fields:
+
+	struct Person {
+		name @0 :Text $Go.required $Go.maxLen(64);
+		age @1 :UInt8 $Go.max(150);
+	}
+
+For each struct with constrained fields, or with fields of such struct
+types, capnpc-go generates a Validate method that returns a
+*ValidationError describing the first field that does not satisfy its
+constraints.  Server methods whose params have a Validate method reject
+invalid calls with a failed exception before the method is called.
 */
 package capnp // import "capnproto.org/go/capnp/v3"
@@ -0,0 +1,134 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// A Capability is a client whose interface is described by an
+// InterfaceSchema.
+type Capability struct {
+	client capnp.Client
+	schema InterfaceSchema
+}
+
+// Client returns the underlying client.
+func (c Capability) Client() capnp.Client {
+	return c.client
+}
+
+// Schema returns the capability's interface type.
+func (c Capability) Schema() InterfaceSchema {
+	return c.schema
+}
+
+// IsValid reports whether c holds a valid client.
+func (c Capability) IsValid() bool {
+	return c.client.IsValid()
+}
+
+// Call starts a call to the named method, which may be declared by the
+// interface or one of its superclasses.  If params is not nil, it is
+// called to fill in the method's parameters.  The caller must call the
+// returned ReleaseFunc when it no longer needs the results.
+func (c Capability) Call(ctx context.Context, method string, params func(Struct) error) (Future, capnp.ReleaseFunc) {
+	m, err := c.schema.Method(method)
+	if err != nil {
+		dn, _ := c.schema.node.DisplayName()
+		return errorFuture(capnp.Method{InterfaceID: c.schema.node.Id(), InterfaceName: dn, MethodName: method}, err)
+	}
+	ps, err := m.Params()
+	if err != nil {
+		return errorFuture(m.method(), err)
+	}
+	rs, err := m.Results()
+	if err != nil {
+		return errorFuture(m.method(), err)
+	}
+	s := capnp.Send{
+		Method:   m.method(),
+		ArgsSize: ps.Size(),
+	}
+	if params != nil {
+		s.PlaceArgs = func(s capnp.Struct) error { return params(ps.Wrap(s)) }
+	}
+	ans, release := c.client.SendCall(ctx, s)
+	return Future{f: ans.Future(), schema: rs}, release
+}
+
+func errorFuture(m capnp.Method, err error) (Future, capnp.ReleaseFunc) {
+	return Future{f: capnp.ErrorAnswer(m, err).Future()}, func() {}
+}
+
+// A Future is the pending result of a call or a struct field within it.
+type Future struct {
+	f      *capnp.Future
+	schema StructSchema
+}
+
+// Struct waits until the call completes and returns the result.
+func (f Future) Struct() (Struct, error) {
+	s, err := f.f.Struct()
+	if err != nil {
+		return Struct{}, err
+	}
+	return f.schema.Wrap(s), nil
+}
+
+// Field returns a future for the named struct or group field of the
+// result.  Calls may be pipelined on capabilities within it before the
+// call completes.
+func (f Future) Field(name string) (Future, error) {
+	fd, ok := f.schema.Field(name)
+	if !ok {
+		return Future{}, fmt.Errorf("dynamic: %s has no field %q", f.schema.Name(), name)
+	}
+	if fd.IsGroup() {
+		gs, err := f.schema.types.Struct(fd.proto.Group().TypeId())
+		if err != nil {
+			return Future{}, err
+		}
+		return Future{f: f.f, schema: gs}, nil
+	}
+	typ, err := fd.proto.Slot().Type()
+	if err != nil {
+		return Future{}, err
+	}
+	if typ.Which() != schema.Type_Which_structType {
+		return Future{}, fmt.Errorf("dynamic: %s.%s is a %v, not a struct", f.schema.Name(), name, typ.Which())
+	}
+	ss, err := f.schema.types.Struct(typ.StructType().TypeId())
+	if err != nil {
+		return Future{}, err
+	}
+	return Future{f: f.f.Field(uint16(fd.proto.Slot().Offset()), nil), schema: ss}, nil
+}
+
+// Capability returns the named interface field of the result.  Calls
+// made on it before the call completes are pipelined.  The client
+// reference is borrowed from the result: the caller should not release
+// it.
+func (f Future) Capability(name string) (Capability, error) {
+	fd, ok := f.schema.Field(name)
+	if !ok {
+		return Capability{}, fmt.Errorf("dynamic: %s has no field %q", f.schema.Name(), name)
+	}
+	var typ schema.Type
+	if !fd.IsGroup() {
+		var err error
+		if typ, err = fd.proto.Slot().Type(); err != nil {
+			return Capability{}, err
+		}
+	}
+	if fd.IsGroup() || typ.Which() != schema.Type_Which_interface {
+		return Capability{}, fmt.Errorf("dynamic: %s.%s is not an interface", f.schema.Name(), name)
+	}
+	is, err := f.schema.types.Interface(typ.Interface().TypeId())
+	if err != nil {
+		return Capability{}, err
+	}
+	return is.Wrap(f.f.Field(uint16(fd.proto.Slot().Offset()), nil).Client()), nil
+}
@@ -0,0 +1,292 @@
+package dynamic
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	air "capnproto.org/go/capnp/v3/internal/aircraftlib"
+)
+
+func newStruct(t *testing.T, types *Types, id uint64) Struct {
+	t.Helper()
+	ss, err := types.Struct(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	s, err := ss.New(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestGet(t *testing.T) {
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	pb, _ := air.NewRootPlaneBase(seg)
+	pb.SetName("Bob")
+	pb.SetRating(-42)
+	pb.SetCanFly(true)
+	pb.SetMaxSpeed(876.5)
+	homes, _ := pb.NewHomes(2)
+	homes.Set(0, air.Airport_jfk)
+	homes.Set(1, 99)
+
+	var types Types
+	ss, err := types.Struct(air.PlaneBase_TypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := ss.Name(); name != "PlaneBase" {
+		t.Errorf("Name() = %q; want \"PlaneBase\"", name)
+	}
+	s := ss.Wrap(capnp.Struct(pb))
+
+	tests := []struct {
+		field string
+		want  interface{}
+	}{
+		{"name", "Bob"},
+		{"rating", int64(-42)},
+		{"canFly", true},
+		{"capacity", int64(0)},
+		{"maxSpeed", 876.5},
+	}
+	for _, test := range tests {
+		v, err := s.Get(test.field)
+		if err != nil {
+			t.Errorf("Get(%q): %v", test.field, err)
+			continue
+		}
+		if v != test.want {
+			t.Errorf("Get(%q) = %#v; want %#v", test.field, v, test.want)
+		}
+	}
+
+	v, err := s.Get("homes")
+	if err != nil {
+		t.Fatal("Get(\"homes\"):", err)
+	}
+	l := v.(List)
+	if l.Len() != 2 {
+		t.Fatalf("homes.Len() = %d; want 2", l.Len())
+	}
+	for i, want := range []string{"jfk", "99"} {
+		e, err := l.At(i)
+		if err != nil {
+			t.Errorf("homes.At(%d): %v", i, err)
+			continue
+		}
+		if got := e.(Enum).String(); got != want {
+			t.Errorf("homes.At(%d) = %s; want %s", i, got, want)
+		}
+	}
+
+	if _, err := s.Get("nope"); err == nil {
+		t.Error("Get(\"nope\") did not return an error")
+	}
+}
+
+func TestSet(t *testing.T) {
+	var types Types
+	s := newStruct(t, &types, air.PlaneBase_TypeID)
+	sets := []struct {
+		field string
+		v     interface{}
+	}{
+		{"name", "Alice"},
+		{"rating", 7},
+		{"canFly", true},
+		{"capacity", uint8(200)},
+		{"maxSpeed", float32(0.5)},
+	}
+	for _, set := range sets {
+		if err := s.Set(set.field, set.v); err != nil {
+			t.Errorf("Set(%q, %#v): %v", set.field, set.v, err)
+		}
+	}
+	homes, err := s.NewList("homes", 2)
+	if err != nil {
+		t.Fatal("NewList(\"homes\"):", err)
+	}
+	if err := homes.Set(0, "lax"); err != nil {
+		t.Error("homes.Set(0, \"lax\"):", err)
+	}
+	if err := homes.Set(1, uint16(air.Airport_sfo)); err != nil {
+		t.Error("homes.Set(1, sfo):", err)
+	}
+
+	pb := air.PlaneBase(s.Raw())
+	if name, _ := pb.Name(); name != "Alice" {
+		t.Errorf("name = %q; want \"Alice\"", name)
+	}
+	if pb.Rating() != 7 || !pb.CanFly() || pb.Capacity() != 200 || pb.MaxSpeed() != 0.5 {
+		t.Errorf("PlaneBase = %v", pb)
+	}
+	if h, _ := pb.Homes(); h.Len() != 2 || h.At(0) != air.Airport_lax || h.At(1) != air.Airport_sfo {
+		t.Errorf("homes = %v; want [lax, sfo]", h)
+	}
+
+	errs := []struct {
+		field string
+		v     interface{}
+	}{
+		{"name", 5},
+		{"canFly", "yes"},
+		{"nope", 1},
+	}
+	for _, set := range errs {
+		if err := s.Set(set.field, set.v); err == nil {
+			t.Errorf("Set(%q, %#v) did not return an error", set.field, set.v)
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	var types Types
+	z := newStruct(t, &types, air.Z_TypeID)
+	if f, ok := z.Which(); !ok || f.Name() != "void" {
+		t.Errorf("Which() = %q, %t; want \"void\", true", f.Name(), ok)
+	}
+	if err := z.Set("i8", 300); err == nil {
+		t.Error("Set(\"i8\", 300) did not return an error")
+	}
+	if f, _ := z.Which(); f.Name() != "void" {
+		t.Errorf("after failed Set, Which() = %q; want \"void\"", f.Name())
+	}
+	if err := z.Set("i8", -5); err != nil {
+		t.Fatal("Set(\"i8\", -5):", err)
+	}
+	if f, _ := z.Which(); f.Name() != "i8" {
+		t.Errorf("Which() = %q; want \"i8\"", f.Name())
+	}
+	if zz := air.Z(z.Raw()); zz.Which() != air.Z_Which_i8 || zz.I8() != -5 {
+		t.Errorf("Z = %v; want (i8 = -5)", zz)
+	}
+	if _, err := z.Get("u8"); err == nil {
+		t.Error("Get(\"u8\") on inactive member did not return an error")
+	}
+	if has, _ := z.Has("u8"); has {
+		t.Error("Has(\"u8\") = true; want false")
+	}
+
+	inner, err := z.Init("zz")
+	if err != nil {
+		t.Fatal("Init(\"zz\"):", err)
+	}
+	if err := inner.Set("text", "hi"); err != nil {
+		t.Fatal("zz.Set(\"text\"):", err)
+	}
+	zz, _ := air.Z(z.Raw()).Zz()
+	if text, _ := zz.Text(); zz.Which() != air.Z_Which_text || text != "hi" {
+		t.Errorf("zz = %v; want (text = \"hi\")", zz)
+	}
+	if n := len(z.Schema().UnionFields()); n != len(z.Schema().Fields()) {
+		t.Errorf("len(UnionFields()) = %d; want %d", n, len(z.Schema().Fields()))
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	var types Types
+	s := newStruct(t, &types, air.Defaults_TypeID)
+	tests := []struct {
+		field string
+		want  interface{}
+	}{
+		{"text", "foo"},
+		{"float", float32(3.14)},
+		{"int", int32(-123)},
+		{"uint", uint32(42)},
+	}
+	for _, test := range tests {
+		if v, err := s.Get(test.field); err != nil || v != test.want {
+			t.Errorf("Get(%q) = %#v, %v; want %#v", test.field, v, err, test.want)
+		}
+	}
+	if v, err := s.Get("data"); err != nil || !bytes.Equal(v.([]byte), []byte("bar")) {
+		t.Errorf("Get(\"data\") = %q, %v; want \"bar\"", v, err)
+	}
+	if err := s.Set("int", 5); err != nil {
+		t.Fatal(err)
+	}
+	if got := air.Defaults(s.Raw()).Int(); got != 5 {
+		t.Errorf("after Set(\"int\", 5), Int() = %d", got)
+	}
+}
+
+type echoImpl struct{}
+
+func (echoImpl) Echo(ctx context.Context, call air.Echo_echo) error {
+	in, err := call.Args().In()
+	if err != nil {
+		return err
+	}
+	r, err := call.AllocResults()
+	if err != nil {
+		return err
+	}
+	return r.SetOut(strings.ToUpper(in))
+}
+
+func TestCapability(t *testing.T) {
+	var types Types
+	is, err := types.Interface(air.Echo_TypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo := air.Echo_ServerToClient(echoImpl{})
+	defer echo.Release()
+	c := is.Wrap(capnp.Client(echo))
+
+	f, release := c.Call(context.Background(), "echo", func(p Struct) error {
+		return p.Set("in", "hello")
+	})
+	defer release()
+	res, err := f.Struct()
+	if err != nil {
+		t.Fatal("echo:", err)
+	}
+	if out, err := res.Get("out"); err != nil || out != "HELLO" {
+		t.Errorf("echo result out = %#v, %v; want \"HELLO\"", out, err)
+	}
+
+	f, release = c.Call(context.Background(), "nope", nil)
+	defer release()
+	if _, err := f.Struct(); err == nil {
+		t.Error("call to unknown method did not return an error")
+	}
+
+	// Store the capability in a struct and read it back.
+	hoth := newStruct(t, &types, air.EchoBase_TypeID)
+	if err := hoth.Set("echo", is.Wrap(capnp.Client(echo).AddRef())); err != nil {
+		t.Fatal("Set(\"echo\"):", err)
+	}
+	v, err := hoth.Get("echo")
+	if err != nil {
+		t.Fatal("Get(\"echo\"):", err)
+	}
+	if c := v.(Capability); !c.Client().IsSame(capnp.Client(echo)) {
+		t.Errorf("Get(\"echo\") = %v; want %v", c.Client(), echo)
+	}
+}
+
+func TestInheritedMethod(t *testing.T) {
+	var types Types
+	is, err := types.Interface(air.Pipeliner_TypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := is.Method("getNumber")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := m.Interface().Name(); name != "CallSequence" {
+		t.Errorf("getNumber declared by %s; want CallSequence", name)
+	}
+	if m.method().InterfaceID != air.CallSequence_TypeID {
+		t.Errorf("getNumber interface ID = %#x; want %#x", m.method().InterfaceID, uint64(air.CallSequence_TypeID))
+	}
+}
@@ -0,0 +1,293 @@
+package dynamic
+
+import (
+	"fmt"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// A List is a list whose element type is described by a schema.Type.
+type List struct {
+	l     capnp.List
+	elem  schema.Type
+	types *Types
+}
+
+// NewList allocates a list of n elements of type elem, preferring
+// placement in seg.
+func (t *Types) NewList(seg *capnp.Segment, elem schema.Type, n int32) (List, error) {
+	return t.newList(seg, elem, n)
+}
+
+// WrapList returns a List that accesses l as a list of elem.
+func (t *Types) WrapList(l capnp.List, elem schema.Type) List {
+	return List{l: l, elem: elem, types: t}
+}
+
+func (t *Types) newList(seg *capnp.Segment, elem schema.Type, n int32) (List, error) {
+	var l capnp.List
+	var err error
+	switch elem.Which() {
+	case schema.Type_Which_void:
+		l = capnp.List(capnp.NewVoidList(seg, n))
+	case schema.Type_Which_bool:
+		var bl capnp.BitList
+		bl, err = capnp.NewBitList(seg, n)
+		l = capnp.List(bl)
+	case schema.Type_Which_int8, schema.Type_Which_uint8:
+		var ul capnp.UInt8List
+		ul, err = capnp.NewUInt8List(seg, n)
+		l = capnp.List(ul)
+	case schema.Type_Which_int16, schema.Type_Which_uint16, schema.Type_Which_enum:
+		var ul capnp.UInt16List
+		ul, err = capnp.NewUInt16List(seg, n)
+		l = capnp.List(ul)
+	case schema.Type_Which_int32, schema.Type_Which_uint32, schema.Type_Which_float32:
+		var ul capnp.UInt32List
+		ul, err = capnp.NewUInt32List(seg, n)
+		l = capnp.List(ul)
+	case schema.Type_Which_int64, schema.Type_Which_uint64, schema.Type_Which_float64:
+		var ul capnp.UInt64List
+		ul, err = capnp.NewUInt64List(seg, n)
+		l = capnp.List(ul)
+	case schema.Type_Which_structType:
+		var ss StructSchema
+		if ss, err = t.Struct(elem.StructType().TypeId()); err != nil {
+			return List{}, err
+		}
+		l, err = capnp.NewCompositeList(seg, ss.Size(), n)
+	default:
+		var pl capnp.PointerList
+		pl, err = capnp.NewPointerList(seg, n)
+		l = capnp.List(pl)
+	}
+	if err != nil {
+		return List{}, err
+	}
+	return List{l: l, elem: elem, types: t}, nil
+}
+
+// Raw returns the underlying list.
+func (l List) Raw() capnp.List {
+	return l.l
+}
+
+// ElementType returns the type of the list's elements.
+func (l List) ElementType() schema.Type {
+	return l.elem
+}
+
+// Len returns the number of elements in the list.
+func (l List) Len() int {
+	return l.l.Len()
+}
+
+// At returns the i'th element of the list, using the same Go types as
+// Struct.Get.
+func (l List) At(i int) (interface{}, error) {
+	if i < 0 || i >= l.l.Len() {
+		return nil, fmt.Errorf("dynamic: list index %d out of range [0, %d)", i, l.l.Len())
+	}
+	switch l.elem.Which() {
+	case schema.Type_Which_void:
+		return Void{}, nil
+	case schema.Type_Which_bool:
+		return capnp.BitList(l.l).At(i), nil
+	case schema.Type_Which_int8:
+		return capnp.Int8List(l.l).At(i), nil
+	case schema.Type_Which_int16:
+		return capnp.Int16List(l.l).At(i), nil
+	case schema.Type_Which_int32:
+		return capnp.Int32List(l.l).At(i), nil
+	case schema.Type_Which_int64:
+		return capnp.Int64List(l.l).At(i), nil
+	case schema.Type_Which_uint8:
+		return capnp.UInt8List(l.l).At(i), nil
+	case schema.Type_Which_uint16:
+		return capnp.UInt16List(l.l).At(i), nil
+	case schema.Type_Which_uint32:
+		return capnp.UInt32List(l.l).At(i), nil
+	case schema.Type_Which_uint64:
+		return capnp.UInt64List(l.l).At(i), nil
+	case schema.Type_Which_float32:
+		return capnp.Float32List(l.l).At(i), nil
+	case schema.Type_Which_float64:
+		return capnp.Float64List(l.l).At(i), nil
+	case schema.Type_Which_enum:
+		es, err := l.types.Enum(l.elem.Enum().TypeId())
+		if err != nil {
+			return nil, err
+		}
+		return es.Value(capnp.UInt16List(l.l).At(i)), nil
+	case schema.Type_Which_text:
+		return capnp.TextList(l.l).At(i)
+	case schema.Type_Which_data:
+		return capnp.DataList(l.l).At(i)
+	case schema.Type_Which_structType:
+		ss, err := l.types.Struct(l.elem.StructType().TypeId())
+		if err != nil {
+			return nil, err
+		}
+		return ss.Wrap(l.l.Struct(i)), nil
+	}
+
+	p, err := capnp.PointerList(l.l).At(i)
+	if err != nil {
+		return nil, err
+	}
+	switch l.elem.Which() {
+	case schema.Type_Which_list:
+		elem, err := l.elem.List().ElementType()
+		if err != nil {
+			return nil, err
+		}
+		return List{l: p.List(), elem: elem, types: l.types}, nil
+	case schema.Type_Which_interface:
+		is, err := l.types.Interface(l.elem.Interface().TypeId())
+		if err != nil {
+			return nil, err
+		}
+		return is.Wrap(p.Interface().Client()), nil
+	case schema.Type_Which_anyPointer:
+		return p, nil
+	default:
+		return nil, fmt.Errorf("dynamic: unknown list type %v", l.elem.Which())
+	}
+}
+
+// Set sets the i'th element of the list to v, converting v as
+// Struct.Set does.  Setting an element of a struct list copies v into
+// the list.
+func (l List) Set(i int, v interface{}) error {
+	if i < 0 || i >= l.l.Len() {
+		return fmt.Errorf("dynamic: list index %d out of range [0, %d)", i, l.l.Len())
+	}
+	if err := l.set(i, v); err != nil {
+		return fmt.Errorf("dynamic: set list element %d: %w", i, err)
+	}
+	return nil
+}
+
+func (l List) set(i int, v interface{}) error {
+	typ := l.elem
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		if _, ok := v.(Void); !ok && v != nil {
+			return typeError(typ, v)
+		}
+	case schema.Type_Which_bool:
+		b, ok := v.(bool)
+		if !ok {
+			return typeError(typ, v)
+		}
+		capnp.BitList(l.l).Set(i, b)
+	case schema.Type_Which_int8:
+		x, err := toInt(typ, v, 8)
+		if err != nil {
+			return err
+		}
+		capnp.Int8List(l.l).Set(i, int8(x))
+	case schema.Type_Which_int16:
+		x, err := toInt(typ, v, 16)
+		if err != nil {
+			return err
+		}
+		capnp.Int16List(l.l).Set(i, int16(x))
+	case schema.Type_Which_int32:
+		x, err := toInt(typ, v, 32)
+		if err != nil {
+			return err
+		}
+		capnp.Int32List(l.l).Set(i, int32(x))
+	case schema.Type_Which_int64:
+		x, err := toInt(typ, v, 64)
+		if err != nil {
+			return err
+		}
+		capnp.Int64List(l.l).Set(i, x)
+	case schema.Type_Which_uint8:
+		x, err := toUint(typ, v, 8)
+		if err != nil {
+			return err
+		}
+		capnp.UInt8List(l.l).Set(i, uint8(x))
+	case schema.Type_Which_uint16:
+		x, err := toUint(typ, v, 16)
+		if err != nil {
+			return err
+		}
+		capnp.UInt16List(l.l).Set(i, uint16(x))
+	case schema.Type_Which_uint32:
+		x, err := toUint(typ, v, 32)
+		if err != nil {
+			return err
+		}
+		capnp.UInt32List(l.l).Set(i, uint32(x))
+	case schema.Type_Which_uint64:
+		x, err := toUint(typ, v, 64)
+		if err != nil {
+			return err
+		}
+		capnp.UInt64List(l.l).Set(i, x)
+	case schema.Type_Which_float32:
+		x, err := toFloat(typ, v)
+		if err != nil {
+			return err
+		}
+		capnp.Float32List(l.l).Set(i, float32(x))
+	case schema.Type_Which_float64:
+		x, err := toFloat(typ, v)
+		if err != nil {
+			return err
+		}
+		capnp.Float64List(l.l).Set(i, x)
+	case schema.Type_Which_enum:
+		e, err := l.types.toEnum(typ, v)
+		if err != nil {
+			return err
+		}
+		capnp.UInt16List(l.l).Set(i, e)
+	case schema.Type_Which_structType:
+		var src capnp.Struct
+		switch v := v.(type) {
+		case Struct:
+			if want := typ.StructType().TypeId(); v.schema.node.Id() != want {
+				return fmt.Errorf("cannot use %s as struct @%#x", v.schema.Name(), want)
+			}
+			src = v.s
+		case capnp.Struct:
+			src = v
+		default:
+			return typeError(typ, v)
+		}
+		return l.l.Struct(i).CopyFrom(src)
+	default:
+		p, err := l.types.toPtr(l.l.Segment(), typ, v)
+		if err != nil {
+			return err
+		}
+		return capnp.PointerList(l.l).Set(i, p)
+	}
+	return nil
+}
+
+// NewList sets the i'th element of a list of lists to a newly allocated
+// list of n elements and returns it.
+func (l List) NewList(i int, n int32) (List, error) {
+	if l.elem.Which() != schema.Type_Which_list {
+		return List{}, fmt.Errorf("dynamic: new list: element type is %v, not a list", l.elem.Which())
+	}
+	if i < 0 || i >= l.l.Len() {
+		return List{}, fmt.Errorf("dynamic: list index %d out of range [0, %d)", i, l.l.Len())
+	}
+	elem, err := l.elem.List().ElementType()
+	if err != nil {
+		return List{}, err
+	}
+	nl, err := l.types.newList(l.l.Segment(), elem, n)
+	if err != nil {
+		return List{}, err
+	}
+	return nl, capnp.PointerList(l.l).Set(i, nl.l.ToPtr())
+}
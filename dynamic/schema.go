@@ -0,0 +1,365 @@
+// Package dynamic provides access to Cap'n Proto structs, lists, enums,
+// and capabilities whose types are known only at runtime.
+//
+// It mirrors the C++ DynamicStruct, DynamicList, DynamicEnum, and
+// DynamicCapability APIs: instead of calling generated accessors, a
+// program looks up a type's schema in a schemas.Registry and reads or
+// writes fields by name.
+//
+// Field values are represented as Go values:
+//
+//	Void        Void
+//	Bool        bool
+//	IntN        intN
+//	UIntN       uintN
+//	FloatN      floatN
+//	Text        string
+//	Data        []byte
+//	struct      Struct
+//	group       Struct
+//	List(T)     List
+//	enum        Enum
+//	interface   Capability
+//	AnyPointer  capnp.Ptr
+package dynamic
+
+import (
+	"fmt"
+	"sync"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/nodemap"
+	"capnproto.org/go/capnp/v3/schemas"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// Types looks up schemas in a registry.  It is safe to use from
+// multiple goroutines.  The zero value looks up schemas in the default
+// registry.
+type Types struct {
+	mu    sync.Mutex
+	nodes nodemap.Map
+}
+
+// NewTypes returns a Types that looks up schemas in reg.
+func NewTypes(reg *schemas.Registry) *Types {
+	t := new(Types)
+	t.nodes.UseRegistry(reg)
+	return t
+}
+
+func (t *Types) find(id uint64) (schema.Node, error) {
+	t.mu.Lock()
+	n, err := t.nodes.Find(id)
+	t.mu.Unlock()
+	if err != nil {
+		return schema.Node{}, err
+	}
+	if !n.IsValid() {
+		return schema.Node{}, fmt.Errorf("dynamic: cannot find node %#x", id)
+	}
+	return schema.Node(n), nil
+}
+
+// Struct returns the schema of the struct type with the given ID.
+func (t *Types) Struct(id uint64) (StructSchema, error) {
+	n, err := t.find(id)
+	if err != nil {
+		return StructSchema{}, err
+	}
+	if n.Which() != schema.Node_Which_structNode {
+		return StructSchema{}, fmt.Errorf("dynamic: %s @%#x is a %v, not a struct", shortName(n), id, n.Which())
+	}
+	return StructSchema{types: t, node: n}, nil
+}
+
+// Enum returns the schema of the enum type with the given ID.
+func (t *Types) Enum(id uint64) (EnumSchema, error) {
+	n, err := t.find(id)
+	if err != nil {
+		return EnumSchema{}, err
+	}
+	if n.Which() != schema.Node_Which_enum {
+		return EnumSchema{}, fmt.Errorf("dynamic: %s @%#x is a %v, not an enum", shortName(n), id, n.Which())
+	}
+	return EnumSchema{node: n}, nil
+}
+
+// Interface returns the schema of the interface type with the given ID.
+func (t *Types) Interface(id uint64) (InterfaceSchema, error) {
+	n, err := t.find(id)
+	if err != nil {
+		return InterfaceSchema{}, err
+	}
+	if n.Which() != schema.Node_Which_interface {
+		return InterfaceSchema{}, fmt.Errorf("dynamic: %s @%#x is a %v, not an interface", shortName(n), id, n.Which())
+	}
+	return InterfaceSchema{types: t, node: n}, nil
+}
+
+func shortName(n schema.Node) string {
+	dn, _ := n.DisplayName()
+	return dn[n.DisplayNamePrefixLength():]
+}
+
+// A StructSchema describes a struct or group type.
+type StructSchema struct {
+	types *Types
+	node  schema.Node
+}
+
+// Node returns the schema node of the struct.
+func (ss StructSchema) Node() schema.Node {
+	return ss.node
+}
+
+// Name returns the unqualified name of the struct, such as "Foo" or
+// "Outer.Inner".
+func (ss StructSchema) Name() string {
+	return shortName(ss.node)
+}
+
+// Size returns the size of the struct's data and pointer sections.
+func (ss StructSchema) Size() capnp.ObjectSize {
+	return capnp.ObjectSize{
+		DataSize:     capnp.Size(ss.node.StructNode().DataWordCount()) * 8,
+		PointerCount: ss.node.StructNode().PointerCount(),
+	}
+}
+
+// Fields returns the struct's fields in the order they appear in the
+// schema node, which is ordinal order for most structs.
+func (ss StructSchema) Fields() []Field {
+	list, _ := ss.node.StructNode().Fields()
+	fields := make([]Field, list.Len())
+	for i := range fields {
+		fields[i] = Field{parent: ss, proto: list.At(i)}
+	}
+	return fields
+}
+
+// UnionFields returns the members of the struct's unnamed union, or
+// nil if the struct has no unnamed union.
+func (ss StructSchema) UnionFields() []Field {
+	var fields []Field
+	for _, f := range ss.Fields() {
+		if f.InUnion() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Field returns the field with the given name.
+func (ss StructSchema) Field(name string) (Field, bool) {
+	list, _ := ss.node.StructNode().Fields()
+	for i := 0; i < list.Len(); i++ {
+		f := list.At(i)
+		if n, _ := f.Name(); n == name {
+			return Field{parent: ss, proto: f}, true
+		}
+	}
+	return Field{}, false
+}
+
+// New allocates a new struct of this type, preferring placement in seg.
+func (ss StructSchema) New(seg *capnp.Segment) (Struct, error) {
+	s, err := capnp.NewStruct(seg, ss.Size())
+	if err != nil {
+		return Struct{}, err
+	}
+	return Struct{s: s, schema: ss}, nil
+}
+
+// Wrap returns a Struct that accesses s as this type.
+func (ss StructSchema) Wrap(s capnp.Struct) Struct {
+	return Struct{s: s, schema: ss}
+}
+
+func (ss StructSchema) discriminantOffset() capnp.DataOffset {
+	return capnp.DataOffset(ss.node.StructNode().DiscriminantOffset() * 2)
+}
+
+// A Field is a member of a struct.
+type Field struct {
+	parent StructSchema
+	proto  schema.Field
+}
+
+// Name returns the field's name.
+func (f Field) Name() string {
+	name, _ := f.proto.Name()
+	return name
+}
+
+// Proto returns the field's schema.
+func (f Field) Proto() schema.Field {
+	return f.proto
+}
+
+// Parent returns the schema of the struct that contains the field.
+func (f Field) Parent() StructSchema {
+	return f.parent
+}
+
+// IsGroup reports whether the field is a group.
+func (f Field) IsGroup() bool {
+	return f.proto.Which() == schema.Field_Which_group
+}
+
+// InUnion reports whether the field is a member of its parent's
+// unnamed union.
+func (f Field) InUnion() bool {
+	return f.proto.DiscriminantValue() != schema.Field_noDiscriminant
+}
+
+// An EnumSchema describes an enum type.
+type EnumSchema struct {
+	node schema.Node
+}
+
+// Node returns the schema node of the enum.
+func (es EnumSchema) Node() schema.Node {
+	return es.node
+}
+
+// Name returns the unqualified name of the enum.
+func (es EnumSchema) Name() string {
+	return shortName(es.node)
+}
+
+// Enumerants returns the names of the enum's values in numeric order.
+func (es EnumSchema) Enumerants() []string {
+	list, _ := es.node.Enum().Enumerants()
+	names := make([]string, list.Len())
+	for i := range names {
+		names[i], _ = list.At(i).Name()
+	}
+	return names
+}
+
+// Value returns the enum value with the given number.
+func (es EnumSchema) Value(v uint16) Enum {
+	return Enum{value: v, schema: es}
+}
+
+// Lookup returns the enum value with the given name.
+func (es EnumSchema) Lookup(name string) (Enum, bool) {
+	list, _ := es.node.Enum().Enumerants()
+	for i := 0; i < list.Len(); i++ {
+		if n, _ := list.At(i).Name(); n == name {
+			return Enum{value: uint16(i), schema: es}, true
+		}
+	}
+	return Enum{}, false
+}
+
+// An InterfaceSchema describes an interface type.
+type InterfaceSchema struct {
+	types *Types
+	node  schema.Node
+}
+
+// Node returns the schema node of the interface.
+func (is InterfaceSchema) Node() schema.Node {
+	return is.node
+}
+
+// Name returns the unqualified name of the interface.
+func (is InterfaceSchema) Name() string {
+	return shortName(is.node)
+}
+
+// Methods returns the methods declared by the interface itself, not
+// including those inherited from superclasses.
+func (is InterfaceSchema) Methods() []Method {
+	list, _ := is.node.Interface().Methods()
+	methods := make([]Method, list.Len())
+	for i := range methods {
+		methods[i] = Method{iface: is, index: uint16(i), proto: list.At(i)}
+	}
+	return methods
+}
+
+// Superclasses returns the interfaces that this interface extends.
+func (is InterfaceSchema) Superclasses() ([]InterfaceSchema, error) {
+	list, err := is.node.Interface().Superclasses()
+	if err != nil {
+		return nil, err
+	}
+	supers := make([]InterfaceSchema, list.Len())
+	for i := range supers {
+		if supers[i], err = is.types.Interface(list.At(i).Id()); err != nil {
+			return nil, err
+		}
+	}
+	return supers, nil
+}
+
+// Method returns the method with the given name, searching
+// superclasses if the interface does not declare it.
+func (is InterfaceSchema) Method(name string) (Method, error) {
+	for _, m := range is.Methods() {
+		if m.Name() == name {
+			return m, nil
+		}
+	}
+	supers, err := is.Superclasses()
+	if err != nil {
+		return Method{}, err
+	}
+	for _, sup := range supers {
+		if m, err := sup.Method(name); err == nil {
+			return m, nil
+		}
+	}
+	return Method{}, fmt.Errorf("dynamic: %s has no method %q", is.Name(), name)
+}
+
+// Wrap returns a Capability that makes calls on c as this interface.
+func (is InterfaceSchema) Wrap(c capnp.Client) Capability {
+	return Capability{client: c, schema: is}
+}
+
+// A Method is a method of an interface.
+type Method struct {
+	iface InterfaceSchema
+	index uint16
+	proto schema.Method
+}
+
+// Name returns the method's name.
+func (m Method) Name() string {
+	name, _ := m.proto.Name()
+	return name
+}
+
+// Proto returns the method's schema.
+func (m Method) Proto() schema.Method {
+	return m.proto
+}
+
+// Interface returns the interface that declares the method.
+func (m Method) Interface() InterfaceSchema {
+	return m.iface
+}
+
+// Params returns the schema of the method's parameter struct.
+func (m Method) Params() (StructSchema, error) {
+	return m.iface.types.Struct(m.proto.ParamStructType())
+}
+
+// Results returns the schema of the method's result struct.
+func (m Method) Results() (StructSchema, error) {
+	return m.iface.types.Struct(m.proto.ResultStructType())
+}
+
+func (m Method) method() capnp.Method {
+	dn, _ := m.iface.node.DisplayName()
+	return capnp.Method{
+		InterfaceID:   m.iface.node.Id(),
+		MethodID:      m.index,
+		InterfaceName: dn,
+		MethodName:    m.Name(),
+	}
+}
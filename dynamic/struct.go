@@ -0,0 +1,499 @@
+package dynamic
+
+import (
+	"fmt"
+	"math"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// A Struct is a struct or group whose type is described by a
+// StructSchema.  Fields are read and written by name.
+type Struct struct {
+	s      capnp.Struct
+	schema StructSchema
+}
+
+// Raw returns the underlying struct.  For a group, this is the struct
+// that contains the group.
+func (s Struct) Raw() capnp.Struct {
+	return s.s
+}
+
+// Schema returns the struct's type.
+func (s Struct) Schema() StructSchema {
+	return s.schema
+}
+
+// IsValid reports whether s refers to a struct.
+func (s Struct) IsValid() bool {
+	return s.s.IsValid()
+}
+
+func (s Struct) field(name string) (Field, error) {
+	f, ok := s.schema.Field(name)
+	if !ok {
+		return Field{}, fmt.Errorf("dynamic: %s has no field %q", s.schema.Name(), name)
+	}
+	return f, nil
+}
+
+// Which returns the active member of the struct's unnamed union.  It
+// returns false if the struct has no unnamed union or the discriminant
+// is not known to the schema.
+func (s Struct) Which() (Field, bool) {
+	if s.schema.node.StructNode().DiscriminantCount() == 0 {
+		return Field{}, false
+	}
+	d := s.s.Uint16(s.schema.discriminantOffset())
+	for _, f := range s.schema.Fields() {
+		if f.proto.DiscriminantValue() == d {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+func (s Struct) isActive(f Field) bool {
+	dv := f.proto.DiscriminantValue()
+	return dv == schema.Field_noDiscriminant || s.s.Uint16(s.schema.discriminantOffset()) == dv
+}
+
+func (s Struct) setWhich(f Field) {
+	if dv := f.proto.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+		s.s.SetUint16(s.schema.discriminantOffset(), dv)
+	}
+}
+
+// Has reports whether the named field is set.  A union member is set
+// if it is the active member, and a pointer field is set if it is not
+// null.  Other fields are always set.
+func (s Struct) Has(name string) (bool, error) {
+	f, err := s.field(name)
+	if err != nil {
+		return false, err
+	}
+	if !s.isActive(f) {
+		return false, nil
+	}
+	if f.IsGroup() {
+		return true, nil
+	}
+	typ, err := f.proto.Slot().Type()
+	if err != nil {
+		return false, err
+	}
+	if isPointerType(typ) {
+		return s.s.HasPtr(uint16(f.proto.Slot().Offset())), nil
+	}
+	return true, nil
+}
+
+// Get returns the value of the named field.  It is an error to get a
+// union member that is not active.  Null pointer fields return their
+// schema default.
+func (s Struct) Get(name string) (interface{}, error) {
+	f, err := s.field(name)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isActive(f) {
+		return nil, fmt.Errorf("dynamic: get %s.%s: field is not the active union member", s.schema.Name(), name)
+	}
+	v, err := s.get(f)
+	if err != nil {
+		return nil, fmt.Errorf("dynamic: get %s.%s: %w", s.schema.Name(), name, err)
+	}
+	return v, nil
+}
+
+func (s Struct) get(f Field) (interface{}, error) {
+	types := s.schema.types
+	if f.IsGroup() {
+		gs, err := types.Struct(f.proto.Group().TypeId())
+		if err != nil {
+			return nil, err
+		}
+		return gs.Wrap(s.s), nil
+	}
+	typ, err := f.proto.Slot().Type()
+	if err != nil {
+		return nil, err
+	}
+	dv, err := f.proto.Slot().DefaultValue()
+	if err != nil {
+		return nil, err
+	}
+	if dv.IsValid() && int(typ.Which()) != int(dv.Which()) {
+		return nil, fmt.Errorf("default value is a %v, want %v", dv.Which(), typ.Which())
+	}
+	off := f.proto.Slot().Offset()
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		return Void{}, nil
+	case schema.Type_Which_bool:
+		return s.s.Bit(capnp.BitOffset(off)) != dv.Bool(), nil
+	case schema.Type_Which_int8:
+		return int8(s.s.Uint8(capnp.DataOffset(off)) ^ uint8(dv.Int8())), nil
+	case schema.Type_Which_int16:
+		return int16(s.s.Uint16(capnp.DataOffset(off*2)) ^ uint16(dv.Int16())), nil
+	case schema.Type_Which_int32:
+		return int32(s.s.Uint32(capnp.DataOffset(off*4)) ^ uint32(dv.Int32())), nil
+	case schema.Type_Which_int64:
+		return int64(s.s.Uint64(capnp.DataOffset(off*8)) ^ uint64(dv.Int64())), nil
+	case schema.Type_Which_uint8:
+		return s.s.Uint8(capnp.DataOffset(off)) ^ dv.Uint8(), nil
+	case schema.Type_Which_uint16:
+		return s.s.Uint16(capnp.DataOffset(off*2)) ^ dv.Uint16(), nil
+	case schema.Type_Which_uint32:
+		return s.s.Uint32(capnp.DataOffset(off*4)) ^ dv.Uint32(), nil
+	case schema.Type_Which_uint64:
+		return s.s.Uint64(capnp.DataOffset(off*8)) ^ dv.Uint64(), nil
+	case schema.Type_Which_float32:
+		v := s.s.Uint32(capnp.DataOffset(off*4)) ^ math.Float32bits(dv.Float32())
+		return math.Float32frombits(v), nil
+	case schema.Type_Which_float64:
+		v := s.s.Uint64(capnp.DataOffset(off*8)) ^ math.Float64bits(dv.Float64())
+		return math.Float64frombits(v), nil
+	case schema.Type_Which_enum:
+		es, err := types.Enum(typ.Enum().TypeId())
+		if err != nil {
+			return nil, err
+		}
+		return es.Value(s.s.Uint16(capnp.DataOffset(off*2)) ^ dv.Enum()), nil
+	}
+
+	p, err := s.s.Ptr(uint16(off))
+	if err != nil {
+		return nil, err
+	}
+	switch typ.Which() {
+	case schema.Type_Which_text:
+		if !p.IsValid() {
+			return dv.Text()
+		}
+		return p.Text(), nil
+	case schema.Type_Which_data:
+		if !p.IsValid() {
+			return dv.Data()
+		}
+		return p.Data(), nil
+	case schema.Type_Which_structType:
+		if !p.IsValid() {
+			p, _ = dv.StructValue()
+		}
+		ss, err := types.Struct(typ.StructType().TypeId())
+		if err != nil {
+			return nil, err
+		}
+		return ss.Wrap(p.Struct()), nil
+	case schema.Type_Which_list:
+		if !p.IsValid() {
+			p, _ = dv.List()
+		}
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return nil, err
+		}
+		return List{l: p.List(), elem: elem, types: types}, nil
+	case schema.Type_Which_interface:
+		is, err := types.Interface(typ.Interface().TypeId())
+		if err != nil {
+			return nil, err
+		}
+		return is.Wrap(p.Interface().Client()), nil
+	case schema.Type_Which_anyPointer:
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unknown field type %v", typ.Which())
+	}
+}
+
+// Set sets the named field to v, making it the active union member if
+// it is in a union.  v must be of the Go type that Get returns for the
+// field, except that integer and float fields accept any Go integer or
+// float that fits, enum fields accept an enumerant name or number, Text
+// fields accept []byte, and pointer fields accept nil to clear the
+// field.  Struct and list values are copied if they belong to another
+// message.  Setting an interface field transfers ownership of the
+// client to the message.
+func (s Struct) Set(name string, v interface{}) error {
+	f, err := s.field(name)
+	if err != nil {
+		return err
+	}
+	if f.IsGroup() {
+		return fmt.Errorf("dynamic: set %s.%s: cannot set a group; use Init", s.schema.Name(), name)
+	}
+	if err := s.set(f, v); err != nil {
+		return fmt.Errorf("dynamic: set %s.%s: %w", s.schema.Name(), name, err)
+	}
+	return nil
+}
+
+func (s Struct) set(f Field, v interface{}) error {
+	typ, err := f.proto.Slot().Type()
+	if err != nil {
+		return err
+	}
+	dv, err := f.proto.Slot().DefaultValue()
+	if err != nil {
+		return err
+	}
+	off := f.proto.Slot().Offset()
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		if _, ok := v.(Void); !ok && v != nil {
+			return typeError(typ, v)
+		}
+		s.setWhich(f)
+	case schema.Type_Which_bool:
+		b, ok := v.(bool)
+		if !ok {
+			return typeError(typ, v)
+		}
+		s.setWhich(f)
+		s.s.SetBit(capnp.BitOffset(off), b != dv.Bool())
+	case schema.Type_Which_int8:
+		i, err := toInt(typ, v, 8)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint8(capnp.DataOffset(off), uint8(i)^uint8(dv.Int8()))
+	case schema.Type_Which_int16:
+		i, err := toInt(typ, v, 16)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint16(capnp.DataOffset(off*2), uint16(i)^uint16(dv.Int16()))
+	case schema.Type_Which_int32:
+		i, err := toInt(typ, v, 32)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint32(capnp.DataOffset(off*4), uint32(i)^uint32(dv.Int32()))
+	case schema.Type_Which_int64:
+		i, err := toInt(typ, v, 64)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint64(capnp.DataOffset(off*8), uint64(i)^uint64(dv.Int64()))
+	case schema.Type_Which_uint8:
+		u, err := toUint(typ, v, 8)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint8(capnp.DataOffset(off), uint8(u)^dv.Uint8())
+	case schema.Type_Which_uint16:
+		u, err := toUint(typ, v, 16)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint16(capnp.DataOffset(off*2), uint16(u)^dv.Uint16())
+	case schema.Type_Which_uint32:
+		u, err := toUint(typ, v, 32)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint32(capnp.DataOffset(off*4), uint32(u)^dv.Uint32())
+	case schema.Type_Which_uint64:
+		u, err := toUint(typ, v, 64)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint64(capnp.DataOffset(off*8), u^dv.Uint64())
+	case schema.Type_Which_float32:
+		x, err := toFloat(typ, v)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint32(capnp.DataOffset(off*4), math.Float32bits(float32(x))^math.Float32bits(dv.Float32()))
+	case schema.Type_Which_float64:
+		x, err := toFloat(typ, v)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint64(capnp.DataOffset(off*8), math.Float64bits(x)^math.Float64bits(dv.Float64()))
+	case schema.Type_Which_enum:
+		e, err := s.schema.types.toEnum(typ, v)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		s.s.SetUint16(capnp.DataOffset(off*2), e^dv.Enum())
+	default:
+		p, err := s.schema.types.toPtr(s.s.Segment(), typ, v)
+		if err != nil {
+			return err
+		}
+		s.setWhich(f)
+		return s.s.SetPtr(uint16(off), p)
+	}
+	return nil
+}
+
+// Init sets the named struct field to a newly allocated struct and
+// returns it.  For a group, Init makes the group the active union
+// member if it is in a union and returns it without clearing it.
+func (s Struct) Init(name string) (Struct, error) {
+	f, err := s.field(name)
+	if err != nil {
+		return Struct{}, err
+	}
+	types := s.schema.types
+	if f.IsGroup() {
+		gs, err := types.Struct(f.proto.Group().TypeId())
+		if err != nil {
+			return Struct{}, err
+		}
+		s.setWhich(f)
+		return gs.Wrap(s.s), nil
+	}
+	typ, err := f.proto.Slot().Type()
+	if err != nil {
+		return Struct{}, err
+	}
+	if typ.Which() != schema.Type_Which_structType {
+		return Struct{}, fmt.Errorf("dynamic: init %s.%s: field is a %v, not a struct", s.schema.Name(), name, typ.Which())
+	}
+	ss, err := types.Struct(typ.StructType().TypeId())
+	if err != nil {
+		return Struct{}, err
+	}
+	ns, err := ss.New(s.s.Segment())
+	if err != nil {
+		return Struct{}, err
+	}
+	s.setWhich(f)
+	return ns, s.s.SetPtr(uint16(f.proto.Slot().Offset()), ns.s.ToPtr())
+}
+
+// NewList sets the named list field to a newly allocated list of n
+// elements and returns it.
+func (s Struct) NewList(name string, n int32) (List, error) {
+	f, err := s.field(name)
+	if err != nil {
+		return List{}, err
+	}
+	var typ schema.Type
+	if !f.IsGroup() {
+		typ, err = f.proto.Slot().Type()
+		if err != nil {
+			return List{}, err
+		}
+	}
+	if f.IsGroup() || typ.Which() != schema.Type_Which_list {
+		return List{}, fmt.Errorf("dynamic: new list %s.%s: field is not a list", s.schema.Name(), name)
+	}
+	elem, err := typ.List().ElementType()
+	if err != nil {
+		return List{}, err
+	}
+	l, err := s.schema.types.newList(s.s.Segment(), elem, n)
+	if err != nil {
+		return List{}, err
+	}
+	s.setWhich(f)
+	return l, s.s.SetPtr(uint16(f.proto.Slot().Offset()), l.l.ToPtr())
+}
+
+func isPointerType(typ schema.Type) bool {
+	switch typ.Which() {
+	case schema.Type_Which_text,
+		schema.Type_Which_data,
+		schema.Type_Which_structType,
+		schema.Type_Which_list,
+		schema.Type_Which_interface,
+		schema.Type_Which_anyPointer:
+		return true
+	default:
+		return false
+	}
+}
+
+// toPtr converts v to a pointer value of type typ, allocating it in
+// seg if needed.
+func (t *Types) toPtr(seg *capnp.Segment, typ schema.Type, v interface{}) (capnp.Ptr, error) {
+	if v == nil {
+		return capnp.Ptr{}, nil
+	}
+	switch typ.Which() {
+	case schema.Type_Which_text:
+		switch v := v.(type) {
+		case string:
+			tx, err := capnp.NewText(seg, v)
+			return tx.ToPtr(), err
+		case []byte:
+			tx, err := capnp.NewTextFromBytes(seg, v)
+			return tx.ToPtr(), err
+		}
+	case schema.Type_Which_data:
+		if v, ok := v.([]byte); ok {
+			d, err := capnp.NewData(seg, v)
+			return d.ToPtr(), err
+		}
+	case schema.Type_Which_structType:
+		switch v := v.(type) {
+		case Struct:
+			if want := typ.StructType().TypeId(); v.schema.node.Id() != want {
+				return capnp.Ptr{}, fmt.Errorf("cannot use %s as struct @%#x", v.schema.Name(), want)
+			}
+			return v.s.ToPtr(), nil
+		case capnp.Struct:
+			return v.ToPtr(), nil
+		}
+	case schema.Type_Which_list:
+		switch v := v.(type) {
+		case List:
+			elem, err := typ.List().ElementType()
+			if err != nil {
+				return capnp.Ptr{}, err
+			}
+			if v.elem.Which() != elem.Which() {
+				return capnp.Ptr{}, fmt.Errorf("cannot use list of %v as list of %v", v.elem.Which(), elem.Which())
+			}
+			return v.l.ToPtr(), nil
+		case capnp.List:
+			return v.ToPtr(), nil
+		}
+	case schema.Type_Which_interface:
+		var c capnp.Client
+		switch v := v.(type) {
+		case Capability:
+			c = v.client
+		case capnp.Client:
+			c = v
+		default:
+			return capnp.Ptr{}, typeError(typ, v)
+		}
+		if !c.IsValid() {
+			return capnp.Ptr{}, nil
+		}
+		return capnp.NewInterface(seg, seg.Message().AddCap(c)).ToPtr(), nil
+	case schema.Type_Which_anyPointer:
+		switch v := v.(type) {
+		case capnp.Ptr:
+			return v, nil
+		case Struct:
+			return v.s.ToPtr(), nil
+		case List:
+			return v.l.ToPtr(), nil
+		case capnp.Struct:
+			return v.ToPtr(), nil
+		case capnp.List:
+			return v.ToPtr(), nil
+		}
+	}
+	return capnp.Ptr{}, typeError(typ, v)
+}
@@ -0,0 +1,164 @@
+package dynamic
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// Void is the value of a Void field or list element.
+type Void struct{}
+
+// An Enum is a value of an enum type.
+type Enum struct {
+	value  uint16
+	schema EnumSchema
+}
+
+// Raw returns the enum's numeric value.
+func (e Enum) Raw() uint16 {
+	return e.value
+}
+
+// Schema returns the enum's type.
+func (e Enum) Schema() EnumSchema {
+	return e.schema
+}
+
+// Name returns the name of the enumerant, or false if the value is not
+// declared in the schema, as happens when reading a message written
+// with a newer schema.
+func (e Enum) Name() (string, bool) {
+	list, _ := e.schema.node.Enum().Enumerants()
+	if int(e.value) >= list.Len() {
+		return "", false
+	}
+	name, _ := list.At(int(e.value)).Name()
+	return name, true
+}
+
+// String returns the name of the enumerant or its number if unknown.
+func (e Enum) String() string {
+	if name, ok := e.Name(); ok {
+		return name
+	}
+	return strconv.Itoa(int(e.value))
+}
+
+func typeError(typ schema.Type, v interface{}) error {
+	return fmt.Errorf("cannot use %T as %v", v, typ.Which())
+}
+
+// toInt converts an integer of any Go type to an int64, checking that
+// it fits in a signed integer of the given size.
+func toInt(typ schema.Type, v interface{}, bits uint) (int64, error) {
+	var i int64
+	switch v := v.(type) {
+	case int:
+		i = int64(v)
+	case int8:
+		i = int64(v)
+	case int16:
+		i = int64(v)
+	case int32:
+		i = int64(v)
+	case int64:
+		i = v
+	case uint, uint8, uint16, uint32, uint64:
+		u, _ := toUint(typ, v, 64)
+		if u > math.MaxInt64 {
+			return 0, fmt.Errorf("%d overflows %v", u, typ.Which())
+		}
+		i = int64(u)
+	default:
+		return 0, typeError(typ, v)
+	}
+	if min, max := -int64(1)<<(bits-1), int64(1)<<(bits-1)-1; i < min || i > max {
+		return 0, fmt.Errorf("%d overflows %v", i, typ.Which())
+	}
+	return i, nil
+}
+
+// toUint converts an integer of any Go type to a uint64, checking
+// that it fits in an unsigned integer of the given size.
+func toUint(typ schema.Type, v interface{}, bits uint) (uint64, error) {
+	var u uint64
+	switch v := v.(type) {
+	case uint:
+		u = uint64(v)
+	case uint8:
+		u = uint64(v)
+	case uint16:
+		u = uint64(v)
+	case uint32:
+		u = uint64(v)
+	case uint64:
+		u = v
+	case int, int8, int16, int32, int64:
+		i, _ := toInt(typ, v, 64)
+		if i < 0 {
+			return 0, fmt.Errorf("%d overflows %v", i, typ.Which())
+		}
+		u = uint64(i)
+	default:
+		return 0, typeError(typ, v)
+	}
+	if bits < 64 && u >= uint64(1)<<bits {
+		return 0, fmt.Errorf("%d overflows %v", u, typ.Which())
+	}
+	return u, nil
+}
+
+// toFloat converts a float or integer of any Go type to a float64.
+func toFloat(typ schema.Type, v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case int, int8, int16, int32, int64:
+		i, _ := toInt(typ, v, 64)
+		return float64(i), nil
+	case uint, uint8, uint16, uint32, uint64:
+		u, _ := toUint(typ, v, 64)
+		return float64(u), nil
+	default:
+		return 0, typeError(typ, v)
+	}
+}
+
+// toEnum converts an Enum, an enumerant name, or a number to the raw
+// value of an enum of type typ.
+func (t *Types) toEnum(typ schema.Type, v interface{}) (uint16, error) {
+	id := typ.Enum().TypeId()
+	switch v := v.(type) {
+	case Enum:
+		if got := v.schema.node.Id(); got != id {
+			return 0, fmt.Errorf("cannot use %s as %s", v.schema.Name(), t.enumName(id))
+		}
+		return v.value, nil
+	case string:
+		es, err := t.Enum(id)
+		if err != nil {
+			return 0, err
+		}
+		e, ok := es.Lookup(v)
+		if !ok {
+			return 0, fmt.Errorf("%s has no enumerant %q", es.Name(), v)
+		}
+		return e.value, nil
+	default:
+		u, err := toUint(typ, v, 16)
+		return uint16(u), err
+	}
+}
+
+func (t *Types) enumName(id uint64) string {
+	es, err := t.Enum(id)
+	if err != nil {
+		return fmt.Sprintf("@%#x", id)
+	}
+	return es.Name()
+}
@@ -0,0 +1,3 @@
+package json
+
+//go:generate go run testdata/gen.go
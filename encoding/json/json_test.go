@@ -0,0 +1,244 @@
+package json
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/schemas"
+)
+
+const (
+	testAllTypesID        = 0xa6dd493786c78852
+	testUnionID           = 0xe1d8028371abbbc0
+	testJsonAnnotationsID = 0xa814a516cf478f04
+)
+
+func readTestFile(name string) ([]byte, error) {
+	path := filepath.Join("testdata", name)
+	return ioutil.ReadFile(path)
+}
+
+func testRegistry(t *testing.T) *schemas.Registry {
+	t.Helper()
+	data, err := readTestFile("json.capnp.out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := new(schemas.Registry)
+	err = reg.Register(&schemas.Schema{
+		Bytes: data,
+		Nodes: []uint64{
+			testAllTypesID,
+			0x9e0b8d26a0087011,
+			testUnionID,
+			testJsonAnnotationsID,
+			0xf726f6fa6b58143c,
+			0xd5533065ce7c825f,
+			0xb1cc94eb5141adf8,
+			0xeca76f3df6b22dbb,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Adding to registry: %v", err)
+	}
+	return reg
+}
+
+func decode(t *testing.T, reg *schemas.Registry, typeID uint64, js string) capnp.Struct {
+	t.Helper()
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := NewDecoder(strings.NewReader(js))
+	dec.UseRegistry(reg)
+	s, err := dec.Decode(typeID, seg)
+	if err != nil {
+		t.Fatalf("Decode(%#x, %s): %v", typeID, js, err)
+	}
+	return s
+}
+
+func encode(t *testing.T, reg *schemas.Registry, typeID uint64, s capnp.Struct) string {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	enc := NewEncoder(buf)
+	enc.UseRegistry(reg)
+	if err := enc.Encode(typeID, s); err != nil {
+		t.Fatalf("Encode(%#x): %v", typeID, err)
+	}
+	return buf.String()
+}
+
+const zeroAllTypes = `{"voidField":null,"boolField":false,"int8Field":0,"int16Field":0,"int32Field":0,` +
+	`"int64Field":"0","uInt8Field":0,"uInt16Field":0,"uInt32Field":0,"uInt64Field":"0",` +
+	`"float32Field":0,"float64Field":0,"enumField":"foo"}`
+
+func TestEncode(t *testing.T) {
+	reg := testRegistry(t)
+
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	s, _ := capnp.NewRootStruct(seg, capnp.ObjectSize{DataSize: 48, PointerCount: 8})
+	s.SetBit(0, true)
+	s.SetUint8(1, uint8(0x85))                  // int8Field = -123
+	s.SetUint32(4, uint32(0xff439eb2))          // int32Field = -12345678
+	s.SetUint64(8, uint64(0xffff8fb779f22087))  // int64Field = -123456789012345
+	s.SetUint64(24, 12345678901234567890)       // uInt64Field
+	s.SetUint32(32, math.Float32bits(3.14))     // float32Field
+	s.SetUint64(40, math.Float64bits(-1.23e47)) // float64Field
+	s.SetUint16(36, 2)                          // enumField = baz
+	s.SetText(0, "a\"b\\c\n\x01é")
+	s.SetData(1, []byte("bar"))
+	inner, _ := capnp.NewStruct(seg, capnp.ObjectSize{DataSize: 48, PointerCount: 8})
+	s.SetPtr(2, inner.ToPtr())
+	l, _ := capnp.NewInt32List(seg, 2)
+	l.Set(0, 1)
+	l.Set(1, -2)
+	s.SetPtr(3, l.ToPtr())
+
+	want := `{"voidField":null,"boolField":true,"int8Field":-123,"int16Field":0,"int32Field":-12345678,` +
+		`"int64Field":"-123456789012345","uInt8Field":0,"uInt16Field":0,"uInt32Field":0,` +
+		`"uInt64Field":"12345678901234567890","float32Field":3.1400001049041748,"float64Field":-1.23e+47,` +
+		`"textField":"a\"b\\c\n\u0001é","dataField":[98,97,114],"structField":` + zeroAllTypes + `,` +
+		`"enumField":"baz","int32List":[1,-2]}`
+	if got := encode(t, reg, testAllTypesID, s); got != want {
+		t.Errorf("Encode(TestAllTypes) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		typeID uint64
+		json   string
+	}{
+		{"Zero", testAllTypesID, zeroAllTypes},
+		{
+			"AllTypes",
+			testAllTypesID,
+			`{"voidField":null,"boolField":true,"int8Field":-123,"int16Field":-12345,"int32Field":-12345678,` +
+				`"int64Field":"-123456789012345","uInt8Field":234,"uInt16Field":45678,"uInt32Field":3456789012,` +
+				`"uInt64Field":"12345678901234567890","float32Field":1234.5,"float64Field":-1.23e+47,` +
+				`"textField":"foo","dataField":[98,97,114],"structField":` + zeroAllTypes + `,"enumField":"baz",` +
+				`"int32List":[1,-2,3],"float64List":[0.5,"NaN","Infinity","-Infinity"],"textList":["plugh","xyzzy"],` +
+				`"structList":[` + zeroAllTypes + `],"enumList":["qux","bar"]}`,
+		},
+		{"UnionText", testUnionID, `{"foo":"hello"}`},
+		{"UnionNumber", testUnionID, `{"bar":5}`},
+		{"UnionVoid", testUnionID, `{"baz":null}`},
+		{"UnionDefault", testUnionID, `{}`},
+		{
+			"Annotations",
+			testJsonAnnotationsID,
+			`{"names-can_contain!anything Really":"foo","flatFoo":123,"flatBar":"abc",` +
+				`"renamed-flatBaz":{"hello":true},"flatQux":"cba",` +
+				`"pfx.foo":"this is a long string in order to force multi-line pretty printing",` +
+				`"pfx.renamed-bar":321,"pfx.baz":{"hello":true},"pfx.xfp.qux":"fed",` +
+				`"union-type":"renamed-bar","barMember":789,"multiMember":"ghi",` +
+				`"dependency":{"renamed-foo":"corge"},"simpleGroup":{"renamed-grault":"garply"},` +
+				`"enums":["qux","renamed-bar","foo","renamed-baz"],"testBase64":"ZnJlZA==","testHex":"706c756768",` +
+				`"bUnion":"renamed-bar","bValue":678,"externalUnion":{"type":"bar","value":"cba"},` +
+				`"unionWithVoid":{"type":"voidValue"}}`,
+		},
+		{
+			"AnnotationsOtherMembers",
+			testJsonAnnotationsID,
+			`{"flatFoo":0,"renamed-flatBaz":{"hello":false},"pfx.renamed-bar":0,"pfx.baz":{"hello":false},` +
+				`"union-type":"foo","fooMember":"abc","multiMember":7,"simpleGroup":{},` +
+				`"bUnion":"foo","bValue":"xyz","externalUnion":{"type":"foo","foo":9},` +
+				`"unionWithVoid":{"type":"textValue","textValue":"quux"}}`,
+		},
+	}
+
+	reg := testRegistry(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := decode(t, reg, test.typeID, test.json)
+			if got := encode(t, reg, test.typeID, s); got != test.json {
+				t.Errorf("round trip =\n%s\nwant\n%s", got, test.json)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	reg := testRegistry(t)
+
+	t.Run("AllTypes", func(t *testing.T) {
+		s := decode(t, reg, testAllTypesID, `{"boolField":true,"int16Field":"-2","int32Field":7.0,`+
+			`"uInt64Field":18446744073709551615,"float32Field":"Infinity","textField":"hi","unknown":[1,{}]}`)
+		if !s.Bit(0) {
+			t.Error("boolField = false; want true")
+		}
+		if v := int16(s.Uint16(2)); v != -2 {
+			t.Errorf("int16Field = %d; want -2", v)
+		}
+		if v := int32(s.Uint32(4)); v != 7 {
+			t.Errorf("int32Field = %d; want 7", v)
+		}
+		if v := s.Uint64(24); v != math.MaxUint64 {
+			t.Errorf("uInt64Field = %d; want %d", v, uint64(math.MaxUint64))
+		}
+		if v := math.Float32frombits(s.Uint32(32)); !math.IsInf(float64(v), 1) {
+			t.Errorf("float32Field = %v; want +Inf", v)
+		}
+		if p, _ := s.Ptr(0); p.Text() != "hi" {
+			t.Errorf("textField = %q; want \"hi\"", p.Text())
+		}
+	})
+	t.Run("Annotations", func(t *testing.T) {
+		s := decode(t, reg, testJsonAnnotationsID, `{"flatFoo":1,"pfx.xfp.qux":"q","union-type":"renamed-bar",`+
+			`"barMember":2,"testHex":"cafe","bUnion":"renamed-bar","bValue":3}`)
+		if v := s.Uint32(0); v != 1 {
+			t.Errorf("aGroup.flatFoo = %d; want 1", v)
+		}
+		if p, _ := s.Ptr(4); p.Text() != "q" {
+			t.Errorf("prefixedGroup.morePrefix.qux = %q; want \"q\"", p.Text())
+		}
+		if d := s.Uint16(6); d != 1 {
+			t.Errorf("aUnion discriminant = %d; want 1", d)
+		}
+		if v := s.Uint32(12); v != 2 {
+			t.Errorf("aUnion.bar.barMember = %d; want 2", v)
+		}
+		if p, _ := s.Ptr(10); !bytes.Equal(p.Data(), []byte{0xca, 0xfe}) {
+			t.Errorf("testHex = %x; want cafe", p.Data())
+		}
+		if d, v := s.Uint16(16), s.Uint32(20); d != 1 || v != 3 {
+			t.Errorf("bUnion = (%d, %d); want (1, 3)", d, v)
+		}
+	})
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		typeID uint64
+		json   string
+	}{
+		{testAllTypesID, `[]`},
+		{testAllTypesID, `{"int8Field":300}`},
+		{testAllTypesID, `{"int32Field":1.5}`},
+		{testAllTypesID, `{"uInt8Field":-1}`},
+		{testAllTypesID, `{"boolField":null}`},
+		{testAllTypesID, `{"enumField":"quux"}`},
+		{testAllTypesID, `{"textField":1}`},
+		{testAllTypesID, `{"dataField":[256]}`},
+		{testJsonAnnotationsID, `{"testBase64":"!"}`},
+		{testJsonAnnotationsID, `{"union-type":"baz"}`},
+	}
+
+	reg := testRegistry(t)
+	for _, test := range tests {
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		dec := NewDecoder(strings.NewReader(test.json))
+		dec.UseRegistry(reg)
+		if _, err := dec.Decode(test.typeID, seg); err == nil {
+			t.Errorf("Decode(%#x, %s) did not return an error", test.typeID, test.json)
+		}
+	}
+}
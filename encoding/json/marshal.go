@@ -0,0 +1,556 @@
+// Package json marshals Cap'n Proto structs to and from JSON based on a
+// schema.
+//
+// The mapping matches the C++ JsonCodec.  Structs become objects keyed by
+// field name, 64-bit integers become strings, non-finite floats become
+// the strings "NaN", "Infinity" and "-Infinity", enums become enumerant
+// names, Void becomes null and Data becomes an array of byte values.
+// Null pointer fields are omitted.  Capabilities and AnyPointer values
+// cannot be marshaled.
+//
+// The annotations in std/capnp/compat/json.capnp customize the mapping:
+// json.name renames a field or enumerant, json.flatten merges a group or
+// struct field into its parent, json.discriminator adds a key naming the
+// active union member, and json.base64 and json.hex change the encoding
+// of a Data field.  A struct that uses any of them is encoded the way
+// the C++ JsonCodec encodes types registered with handleByAnnotation.
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/schema"
+	"capnproto.org/go/capnp/v3/schemas"
+)
+
+// Marshal returns the JSON representation of a struct.
+func Marshal(typeID uint64, s capnp.Struct) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := NewEncoder(buf).Encode(typeID, s); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// An Encoder writes the JSON representation of Cap'n Proto messages to
+// an output stream.
+type Encoder struct {
+	w     errWriter
+	tmp   []byte
+	cache infoCache
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: errWriter{w: w}}
+}
+
+// UseRegistry changes the registry that the encoder consults for
+// schemas from the default registry.
+func (enc *Encoder) UseRegistry(reg *schemas.Registry) {
+	enc.cache.useRegistry(reg)
+}
+
+// Encode writes the JSON representation of s to the stream.
+func (enc *Encoder) Encode(typeID uint64, s capnp.Struct) error {
+	if enc.w.err != nil {
+		return enc.w.err
+	}
+	si, err := enc.cache.structInfo(typeID)
+	if err != nil {
+		return err
+	}
+	if err := enc.marshalStruct(si, s); err != nil {
+		return err
+	}
+	return enc.w.err
+}
+
+// A member is a key-value pair of a JSON object.  The value is the
+// field f of s, null, or if f is nil, the string tag.
+type member struct {
+	prefix string
+	name   string
+	f      *fieldInfo
+	s      capnp.Struct
+	null   bool
+	tag    string
+}
+
+func (enc *Encoder) marshalStruct(si *structInfo, s capnp.Struct) error {
+	var members []member
+	if err := enc.gather(si, s, "", &members); err != nil {
+		return err
+	}
+	enc.w.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			enc.w.WriteByte(',')
+		}
+		enc.marshalString(m.prefix + m.name)
+		enc.w.WriteByte(':')
+		if m.null {
+			enc.w.WriteString("null")
+			continue
+		}
+		if m.f == nil {
+			enc.marshalString(m.tag)
+			continue
+		}
+		if err := enc.marshalField(m.s, m.f); err != nil {
+			return err
+		}
+	}
+	enc.w.WriteByte('}')
+	return nil
+}
+
+// gather appends the members of the JSON object for s to members,
+// descending into flattened fields.
+func (enc *Encoder) gather(si *structInfo, s capnp.Struct, prefix string, members *[]member) error {
+	which := si.which(s)
+	for i := range si.fields {
+		fi := &si.fields[i]
+		if fi.inUnion() {
+			if i != which || si.annotated {
+				continue
+			}
+			// Unannotated structs write the union member in index
+			// order, and leave out a null member with the default
+			// discriminant.
+			null := !fieldPresent(s, fi.field)
+			if null && fi.field.DiscriminantValue() == 0 {
+				continue
+			}
+			*members = append(*members, member{prefix: prefix, name: fi.name, f: fi, s: s, null: null})
+			continue
+		}
+		if !fieldPresent(s, fi.field) {
+			continue
+		}
+		if fi.flatten {
+			if err := enc.gatherFlattened(s, fi, prefix, members); err != nil {
+				return err
+			}
+			continue
+		}
+		*members = append(*members, member{prefix: prefix, name: fi.name, f: fi, s: s})
+	}
+	if which < 0 || !si.annotated {
+		return nil
+	}
+	fi := &si.fields[which]
+	if si.tagName != "" {
+		*members = append(*members, member{prefix: prefix, name: si.tagName, tag: fi.name})
+	}
+	if fi.flatten {
+		return enc.gatherFlattened(s, fi, prefix, members)
+	}
+	if si.tagName != "" && isVoid(fi.field) {
+		// The discriminator already identifies the member.
+		return nil
+	}
+	name := fi.name
+	if si.valueName != "" {
+		name = si.valueName
+	}
+	*members = append(*members, member{prefix: prefix, name: name, f: fi, s: s})
+	return nil
+}
+
+func (enc *Encoder) gatherFlattened(s capnp.Struct, fi *fieldInfo, prefix string, members *[]member) error {
+	if fi.group != nil {
+		return enc.gather(fi.group, s, prefix+fi.prefix, members)
+	}
+	typ, err := fi.field.Slot().Type()
+	if err != nil {
+		return err
+	}
+	si, err := enc.cache.structInfo(typ.StructType().TypeId())
+	if err != nil {
+		return err
+	}
+	p, err := s.Ptr(uint16(fi.field.Slot().Offset()))
+	if err != nil {
+		return err
+	}
+	return enc.gather(si, p.Struct(), prefix+fi.prefix, members)
+}
+
+// fieldPresent reports whether a non-union field is written to JSON:
+// pointer fields are omitted when null.
+func fieldPresent(s capnp.Struct, f schema.Field) bool {
+	if f.Which() != schema.Field_Which_slot {
+		return true
+	}
+	typ, err := f.Slot().Type()
+	if err != nil {
+		return false
+	}
+	switch typ.Which() {
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list,
+		schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		return s.HasPtr(uint16(f.Slot().Offset()))
+	default:
+		return true
+	}
+}
+
+func isVoid(f schema.Field) bool {
+	if f.Which() != schema.Field_Which_slot {
+		return false
+	}
+	typ, err := f.Slot().Type()
+	return err == nil && typ.Which() == schema.Type_Which_void
+}
+
+func (enc *Encoder) marshalField(s capnp.Struct, fi *fieldInfo) error {
+	f := fi.field
+	if f.Which() == schema.Field_Which_group {
+		return enc.marshalStruct(fi.group, s)
+	}
+	typ, err := f.Slot().Type()
+	if err != nil {
+		return err
+	}
+	dv, err := f.Slot().DefaultValue()
+	if err != nil {
+		return err
+	}
+	if dv.IsValid() && int(typ.Which()) != int(dv.Which()) {
+		return fmt.Errorf("marshal field %s: default value is a %v, want %v", fi.name, dv.Which(), typ.Which())
+	}
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		enc.w.WriteString("null")
+	case schema.Type_Which_bool:
+		v := s.Bit(capnp.BitOffset(f.Slot().Offset()))
+		enc.marshalBool(v != dv.Bool())
+	case schema.Type_Which_int8:
+		v := s.Uint8(capnp.DataOffset(f.Slot().Offset()))
+		enc.marshalNumber(float64(int8(v ^ uint8(dv.Int8()))))
+	case schema.Type_Which_int16:
+		v := s.Uint16(capnp.DataOffset(f.Slot().Offset() * 2))
+		enc.marshalNumber(float64(int16(v ^ uint16(dv.Int16()))))
+	case schema.Type_Which_int32:
+		v := s.Uint32(capnp.DataOffset(f.Slot().Offset() * 4))
+		enc.marshalNumber(float64(int32(v ^ uint32(dv.Int32()))))
+	case schema.Type_Which_int64:
+		v := s.Uint64(capnp.DataOffset(f.Slot().Offset() * 8))
+		enc.marshalInt64(int64(v ^ uint64(dv.Int64())))
+	case schema.Type_Which_uint8:
+		v := s.Uint8(capnp.DataOffset(f.Slot().Offset()))
+		enc.marshalNumber(float64(v ^ dv.Uint8()))
+	case schema.Type_Which_uint16:
+		v := s.Uint16(capnp.DataOffset(f.Slot().Offset() * 2))
+		enc.marshalNumber(float64(v ^ dv.Uint16()))
+	case schema.Type_Which_uint32:
+		v := s.Uint32(capnp.DataOffset(f.Slot().Offset() * 4))
+		enc.marshalNumber(float64(v ^ dv.Uint32()))
+	case schema.Type_Which_uint64:
+		v := s.Uint64(capnp.DataOffset(f.Slot().Offset() * 8))
+		enc.marshalUint64(v ^ dv.Uint64())
+	case schema.Type_Which_float32:
+		v := s.Uint32(capnp.DataOffset(f.Slot().Offset() * 4))
+		d := math.Float32bits(dv.Float32())
+		enc.marshalFloat(float64(math.Float32frombits(v ^ d)))
+	case schema.Type_Which_float64:
+		v := s.Uint64(capnp.DataOffset(f.Slot().Offset() * 8))
+		d := math.Float64bits(dv.Float64())
+		enc.marshalFloat(math.Float64frombits(v ^ d))
+	case schema.Type_Which_text:
+		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		if err != nil {
+			return err
+		}
+		if !p.IsValid() {
+			b, _ := dv.TextBytes()
+			enc.marshalString(string(b))
+			return nil
+		}
+		enc.marshalString(string(p.TextBytes()))
+	case schema.Type_Which_data:
+		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		if err != nil {
+			return err
+		}
+		b := p.Data()
+		if !p.IsValid() {
+			b, _ = dv.Data()
+		}
+		enc.marshalData(b, fi.data)
+	case schema.Type_Which_structType:
+		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		if err != nil {
+			return err
+		}
+		if !p.IsValid() {
+			p, _ = dv.StructValue()
+		}
+		si, err := enc.cache.structInfo(typ.StructType().TypeId())
+		if err != nil {
+			return err
+		}
+		return enc.marshalStruct(si, p.Struct())
+	case schema.Type_Which_list:
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return err
+		}
+		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		if err != nil {
+			return err
+		}
+		if !p.IsValid() {
+			p, _ = dv.List()
+		}
+		return enc.marshalList(elem, p.List())
+	case schema.Type_Which_enum:
+		v := s.Uint16(capnp.DataOffset(f.Slot().Offset() * 2))
+		return enc.marshalEnum(typ.Enum().TypeId(), v^dv.Enum())
+	case schema.Type_Which_interface:
+		return fmt.Errorf("marshal field %s: cannot encode capabilities as JSON", fi.name)
+	case schema.Type_Which_anyPointer:
+		return fmt.Errorf("marshal field %s: cannot encode AnyPointer as JSON", fi.name)
+	default:
+		return fmt.Errorf("unknown field type %v", typ.Which())
+	}
+	return nil
+}
+
+func (enc *Encoder) marshalList(elem schema.Type, l capnp.List) error {
+	enc.w.WriteByte('[')
+	for i := 0; i < l.Len(); i++ {
+		if i > 0 {
+			enc.w.WriteByte(',')
+		}
+		if err := enc.marshalElem(elem, l, i); err != nil {
+			return err
+		}
+	}
+	enc.w.WriteByte(']')
+	return nil
+}
+
+func (enc *Encoder) marshalElem(elem schema.Type, l capnp.List, i int) error {
+	switch elem.Which() {
+	case schema.Type_Which_void:
+		enc.w.WriteString("null")
+	case schema.Type_Which_bool:
+		enc.marshalBool(capnp.BitList(l).At(i))
+	case schema.Type_Which_int8:
+		enc.marshalNumber(float64(capnp.Int8List(l).At(i)))
+	case schema.Type_Which_int16:
+		enc.marshalNumber(float64(capnp.Int16List(l).At(i)))
+	case schema.Type_Which_int32:
+		enc.marshalNumber(float64(capnp.Int32List(l).At(i)))
+	case schema.Type_Which_int64:
+		enc.marshalInt64(capnp.Int64List(l).At(i))
+	case schema.Type_Which_uint8:
+		enc.marshalNumber(float64(capnp.UInt8List(l).At(i)))
+	case schema.Type_Which_uint16:
+		enc.marshalNumber(float64(capnp.UInt16List(l).At(i)))
+	case schema.Type_Which_uint32:
+		enc.marshalNumber(float64(capnp.UInt32List(l).At(i)))
+	case schema.Type_Which_uint64:
+		enc.marshalUint64(capnp.UInt64List(l).At(i))
+	case schema.Type_Which_float32:
+		enc.marshalFloat(float64(capnp.Float32List(l).At(i)))
+	case schema.Type_Which_float64:
+		enc.marshalFloat(capnp.Float64List(l).At(i))
+	case schema.Type_Which_text:
+		b, err := capnp.TextList(l).BytesAt(i)
+		if err != nil {
+			return err
+		}
+		enc.marshalString(string(b))
+	case schema.Type_Which_data:
+		b, err := capnp.DataList(l).At(i)
+		if err != nil {
+			return err
+		}
+		enc.marshalData(b, dataArray)
+	case schema.Type_Which_structType:
+		si, err := enc.cache.structInfo(elem.StructType().TypeId())
+		if err != nil {
+			return err
+		}
+		return enc.marshalStruct(si, l.Struct(i))
+	case schema.Type_Which_list:
+		ee, err := elem.List().ElementType()
+		if err != nil {
+			return err
+		}
+		p, err := capnp.PointerList(l).At(i)
+		if err != nil {
+			return err
+		}
+		return enc.marshalList(ee, p.List())
+	case schema.Type_Which_enum:
+		return enc.marshalEnum(elem.Enum().TypeId(), capnp.UInt16List(l).At(i))
+	case schema.Type_Which_interface:
+		return fmt.Errorf("cannot encode capabilities as JSON")
+	case schema.Type_Which_anyPointer:
+		return fmt.Errorf("cannot encode AnyPointer as JSON")
+	default:
+		return fmt.Errorf("unknown list type %v", elem.Which())
+	}
+	return nil
+}
+
+func (enc *Encoder) marshalEnum(typ uint64, val uint16) error {
+	ei, err := enc.cache.enumInfo(typ)
+	if err != nil {
+		return err
+	}
+	if int(val) >= len(ei.names) {
+		enc.marshalNumber(float64(val))
+		return nil
+	}
+	enc.marshalString(ei.names[val])
+	return nil
+}
+
+func (enc *Encoder) marshalBool(v bool) {
+	if v {
+		enc.w.WriteString("true")
+	} else {
+		enc.w.WriteString("false")
+	}
+}
+
+// marshalNumber writes f the way the C++ implementation formats a
+// double: with 15 significant digits, or 17 if 15 do not round-trip.
+func (enc *Encoder) marshalNumber(f float64) {
+	enc.tmp = strconv.AppendFloat(enc.tmp[:0], f, 'g', 15, 64)
+	if v, err := strconv.ParseFloat(string(enc.tmp), 64); err != nil || v != f {
+		enc.tmp = strconv.AppendFloat(enc.tmp[:0], f, 'g', 17, 64)
+	}
+	enc.w.Write(enc.tmp)
+}
+
+// marshalFloat writes f as a number, or as a string if it is not finite
+// since JSON has no representation for infinities or NaN.
+func (enc *Encoder) marshalFloat(f float64) {
+	switch {
+	case math.IsNaN(f):
+		enc.w.WriteString(`"NaN"`)
+	case math.IsInf(f, 1):
+		enc.w.WriteString(`"Infinity"`)
+	case math.IsInf(f, -1):
+		enc.w.WriteString(`"-Infinity"`)
+	default:
+		enc.marshalNumber(f)
+	}
+}
+
+// 64-bit integers are written as strings, since many JSON parsers
+// represent all numbers as doubles.
+
+func (enc *Encoder) marshalInt64(i int64) {
+	enc.tmp = append(enc.tmp[:0], '"')
+	enc.tmp = strconv.AppendInt(enc.tmp, i, 10)
+	enc.tmp = append(enc.tmp, '"')
+	enc.w.Write(enc.tmp)
+}
+
+func (enc *Encoder) marshalUint64(i uint64) {
+	enc.tmp = append(enc.tmp[:0], '"')
+	enc.tmp = strconv.AppendUint(enc.tmp, i, 10)
+	enc.tmp = append(enc.tmp, '"')
+	enc.w.Write(enc.tmp)
+}
+
+func (enc *Encoder) marshalData(b []byte, de dataEncoding) {
+	switch de {
+	case dataBase64:
+		enc.marshalString(base64.StdEncoding.EncodeToString(b))
+	case dataHex:
+		enc.marshalString(hex.EncodeToString(b))
+	default:
+		enc.w.WriteByte('[')
+		for i, c := range b {
+			if i > 0 {
+				enc.w.WriteByte(',')
+			}
+			enc.tmp = strconv.AppendUint(enc.tmp[:0], uint64(c), 10)
+			enc.w.Write(enc.tmp)
+		}
+		enc.w.WriteByte(']')
+	}
+}
+
+// marshalString writes s as a JSON string.  Only quotes, backslashes
+// and control characters are escaped; other bytes are copied verbatim.
+func (enc *Encoder) marshalString(s string) {
+	const hexDigits = "0123456789abcdef"
+	enc.tmp = append(enc.tmp[:0], '"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			enc.tmp = append(enc.tmp, '\\', '"')
+		case '\\':
+			enc.tmp = append(enc.tmp, '\\', '\\')
+		case '\b':
+			enc.tmp = append(enc.tmp, '\\', 'b')
+		case '\f':
+			enc.tmp = append(enc.tmp, '\\', 'f')
+		case '\n':
+			enc.tmp = append(enc.tmp, '\\', 'n')
+		case '\r':
+			enc.tmp = append(enc.tmp, '\\', 'r')
+		case '\t':
+			enc.tmp = append(enc.tmp, '\\', 't')
+		default:
+			if c < 0x20 {
+				enc.tmp = append(enc.tmp, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			} else {
+				enc.tmp = append(enc.tmp, c)
+			}
+		}
+	}
+	enc.tmp = append(enc.tmp, '"')
+	enc.w.Write(enc.tmp)
+}
+
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	var n int
+	n, ew.err = ew.w.Write(p)
+	return n, ew.err
+}
+
+func (ew *errWriter) WriteString(s string) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
+	}
+	var n int
+	n, ew.err = io.WriteString(ew.w, s)
+	return n, ew.err
+}
+
+func (ew *errWriter) WriteByte(b byte) error {
+	if ew.err != nil {
+		return ew.err
+	}
+	if bw, ok := ew.w.(io.ByteWriter); ok {
+		ew.err = bw.WriteByte(b)
+	} else {
+		_, ew.err = ew.w.Write([]byte{b})
+	}
+	return ew.err
+}
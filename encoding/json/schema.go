@@ -0,0 +1,319 @@
+package json
+
+import (
+	"fmt"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/nodemap"
+	"capnproto.org/go/capnp/v3/internal/schema"
+	"capnproto.org/go/capnp/v3/schemas"
+	jsoncp "capnproto.org/go/capnp/v3/std/capnp/compat/json"
+)
+
+// A structInfo describes how a struct or group maps to a JSON object.
+type structInfo struct {
+	node   schema.Node
+	fields []fieldInfo // in index order
+
+	// annotated is true if the struct or any of its groups carries a
+	// compat/json annotation.  Annotated structs are encoded the way the
+	// C++ JsonCodec encodes types registered with handleByAnnotation:
+	// the union member comes after all other fields and is always written.
+	annotated bool
+
+	// tagName is the key of the union discriminator, if any, and
+	// valueName is the key under which the union member's value is
+	// written, if it should not use the member's own name.
+	tagName   string
+	valueName string
+}
+
+// hasUnion reports whether the struct has an (anonymous) union.
+func (si *structInfo) hasUnion() bool {
+	return si.node.StructNode().DiscriminantCount() > 0
+}
+
+// which returns the index of the active union member, or -1.
+func (si *structInfo) which(s capnp.Struct) int {
+	if !si.hasUnion() {
+		return -1
+	}
+	d := s.Uint16(capnp.DataOffset(si.node.StructNode().DiscriminantOffset() * 2))
+	for i := range si.fields {
+		if si.fields[i].field.DiscriminantValue() == d {
+			return i
+		}
+	}
+	return -1
+}
+
+// setWhich sets the discriminant of the union to the given member's.
+func (si *structInfo) setWhich(s capnp.Struct, fi *fieldInfo) {
+	s.SetUint16(capnp.DataOffset(si.node.StructNode().DiscriminantOffset()*2), fi.field.DiscriminantValue())
+}
+
+type fieldInfo struct {
+	field schema.Field
+	name  string // JSON key
+
+	// group is the layout of a group field.
+	group *structInfo
+
+	// flatten is true if the fields of a group or struct field are
+	// written into the enclosing object, with prefix prepended to
+	// their keys.
+	flatten bool
+	prefix  string
+
+	data dataEncoding
+}
+
+func (fi *fieldInfo) inUnion() bool {
+	return fi.field.DiscriminantValue() != schema.Field_noDiscriminant
+}
+
+// dataEncoding is the JSON representation of a Data field.
+type dataEncoding int
+
+const (
+	dataArray dataEncoding = iota
+	dataBase64
+	dataHex
+)
+
+// An enumInfo maps enumerants to JSON strings and back.
+type enumInfo struct {
+	names  []string
+	values map[string]uint16
+}
+
+// infoCache loads and caches struct and enum layouts from a registry.
+type infoCache struct {
+	nodes   nodemap.Map
+	structs map[uint64]*structInfo
+	enums   map[uint64]*enumInfo
+}
+
+func (c *infoCache) useRegistry(reg *schemas.Registry) {
+	c.nodes.UseRegistry(reg)
+	c.structs = nil
+	c.enums = nil
+}
+
+func (c *infoCache) structInfo(id uint64) (*structInfo, error) {
+	if si := c.structs[id]; si != nil {
+		return si, nil
+	}
+	n, err := c.nodes.Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
+		return nil, fmt.Errorf("cannot find struct type %#x", id)
+	}
+	var disc jsoncp.DiscriminatorOptions
+	anns, err := n.Annotations()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < anns.Len(); i++ {
+		if a := anns.At(i); a.Id() == jsoncp.Discriminator {
+			if disc, err = discriminatorOptions(a); err != nil {
+				return nil, err
+			}
+		}
+	}
+	si, err := c.newStructInfo(n, disc, "")
+	if err != nil {
+		return nil, err
+	}
+	if si.annotated {
+		markAnnotated(si)
+	}
+	return si, nil
+}
+
+// newStructInfo builds and caches the layout of a struct or group node.
+// Groups cannot be annotated themselves, so the discriminator options
+// and the name of a flattened union come from the group's field.
+func (c *infoCache) newStructInfo(n schema.Node, disc jsoncp.DiscriminatorOptions, unionName string) (*structInfo, error) {
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return nil, err
+	}
+	si := &structInfo{
+		node:   n,
+		fields: make([]fieldInfo, fields.Len()),
+	}
+	if capnp.Struct(disc).IsValid() {
+		si.annotated = true
+		si.tagName = unionName
+		if disc.HasName() {
+			if si.tagName, err = disc.Name(); err != nil {
+				return nil, err
+			}
+		}
+		if si.valueName, err = disc.ValueName(); err != nil {
+			return nil, err
+		}
+	}
+	for i := range si.fields {
+		fi := &si.fields[i]
+		fi.field = fields.At(i)
+		if fi.name, err = fi.field.Name(); err != nil {
+			return nil, err
+		}
+		var subDisc jsoncp.DiscriminatorOptions
+		anns, err := fi.field.Annotations()
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < anns.Len(); j++ {
+			a := anns.At(j)
+			switch a.Id() {
+			case jsoncp.Name:
+				v, err := a.Value()
+				if err != nil {
+					return nil, err
+				}
+				if fi.name, err = v.Text(); err != nil {
+					return nil, err
+				}
+			case jsoncp.Flatten:
+				v, err := a.Value()
+				if err != nil {
+					return nil, err
+				}
+				p, err := v.StructValue()
+				if err != nil {
+					return nil, err
+				}
+				fi.flatten = true
+				if fi.prefix, err = jsoncp.FlattenOptions(p.Struct()).Prefix(); err != nil {
+					return nil, err
+				}
+			case jsoncp.Discriminator:
+				if subDisc, err = discriminatorOptions(a); err != nil {
+					return nil, err
+				}
+			case jsoncp.Base64:
+				fi.data = dataBase64
+			case jsoncp.Hex:
+				fi.data = dataHex
+			default:
+				continue
+			}
+			si.annotated = true
+		}
+		if fi.flatten && !isStructField(fi.field) {
+			return nil, fmt.Errorf("field %s of %#x: only struct types can be flattened", fi.name, n.Id())
+		}
+		if fi.field.Which() != schema.Field_Which_group {
+			continue
+		}
+		gn, err := c.nodes.Find(fi.field.Group().TypeId())
+		if err != nil {
+			return nil, err
+		}
+		if !gn.IsValid() {
+			return nil, fmt.Errorf("cannot find group type %#x", fi.field.Group().TypeId())
+		}
+		var subName string
+		if fi.flatten {
+			subName, _ = fi.field.Name()
+		}
+		if fi.group, err = c.newStructInfo(gn, subDisc, subName); err != nil {
+			return nil, err
+		}
+		si.annotated = si.annotated || fi.group.annotated
+	}
+	if c.structs == nil {
+		c.structs = make(map[uint64]*structInfo)
+	}
+	c.structs[n.Id()] = si
+	return si, nil
+}
+
+// markAnnotated switches a struct and all of its groups to annotated
+// encoding.
+func markAnnotated(si *structInfo) {
+	si.annotated = true
+	for i := range si.fields {
+		if g := si.fields[i].group; g != nil {
+			markAnnotated(g)
+		}
+	}
+}
+
+func discriminatorOptions(a schema.Annotation) (jsoncp.DiscriminatorOptions, error) {
+	v, err := a.Value()
+	if err != nil {
+		return jsoncp.DiscriminatorOptions{}, err
+	}
+	p, err := v.StructValue()
+	if err != nil {
+		return jsoncp.DiscriminatorOptions{}, err
+	}
+	if !p.IsValid() {
+		// An empty struct value still marks the union as discriminated.
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		return jsoncp.NewDiscriminatorOptions(seg)
+	}
+	return jsoncp.DiscriminatorOptions(p.Struct()), nil
+}
+
+func isStructField(f schema.Field) bool {
+	if f.Which() == schema.Field_Which_group {
+		return true
+	}
+	t, err := f.Slot().Type()
+	return err == nil && t.Which() == schema.Type_Which_structType
+}
+
+func (c *infoCache) enumInfo(id uint64) (*enumInfo, error) {
+	if ei := c.enums[id]; ei != nil {
+		return ei, nil
+	}
+	n, err := c.nodes.Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_enum {
+		return nil, fmt.Errorf("cannot find enum type %#x", id)
+	}
+	enums, err := n.Enum().Enumerants()
+	if err != nil {
+		return nil, err
+	}
+	ei := &enumInfo{
+		names:  make([]string, enums.Len()),
+		values: make(map[string]uint16, enums.Len()),
+	}
+	for i := range ei.names {
+		e := enums.At(i)
+		if ei.names[i], err = e.Name(); err != nil {
+			return nil, err
+		}
+		anns, err := e.Annotations()
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < anns.Len(); j++ {
+			if a := anns.At(j); a.Id() == jsoncp.Name {
+				v, err := a.Value()
+				if err != nil {
+					return nil, err
+				}
+				if ei.names[i], err = v.Text(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		ei.values[ei.names[i]] = uint16(i)
+	}
+	if c.enums == nil {
+		c.enums = make(map[uint64]*enumInfo)
+	}
+	c.enums[id] = ei
+	return ei, nil
+}
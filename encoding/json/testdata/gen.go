@@ -0,0 +1,38 @@
+//go:build ignore
+// +build ignore
+
+// This program compiles testdata/json.capnp into testdata/json.capnp.out,
+// the CodeGeneratorRequest that the JSON codec tests load their schemas
+// from.  It writes the same request as
+//
+//	capnp compile -I ../../std --src-prefix=testdata -o- testdata/json.capnp
+//
+// but uses the schemas/compiler package, so that the file can be
+// regenerated without the capnp tool.  Run it with "go generate" in
+// encoding/json.
+package main
+
+import (
+	"log"
+	"os"
+
+	"capnproto.org/go/capnp/v3/schemas/compiler"
+)
+
+func main() {
+	c := &compiler.Compiler{
+		ImportPath:     []string{"../../std"},
+		SourcePrefixes: []string{"testdata"},
+	}
+	req, err := c.Compile("testdata/json.capnp")
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := req.Message().Marshal()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("testdata/json.capnp.out", data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
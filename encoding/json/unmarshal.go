@@ -0,0 +1,714 @@
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	gojson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/schema"
+	"capnproto.org/go/capnp/v3/schemas"
+)
+
+// Unmarshal parses the JSON representation of a struct and allocates
+// the result in seg.  Object keys that do not name a field are ignored,
+// so that JSON produced from a newer schema can still be read.
+func Unmarshal(typeID uint64, data []byte, seg *capnp.Segment) (capnp.Struct, error) {
+	return NewDecoder(bytes.NewReader(data)).Decode(typeID, seg)
+}
+
+// A Decoder reads the JSON representation of Cap'n Proto messages from
+// an input stream.
+type Decoder struct {
+	r     *gojson.Decoder
+	cache infoCache
+}
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: gojson.NewDecoder(r)}
+}
+
+// UseRegistry changes the registry that the decoder consults for
+// schemas from the default registry.
+func (dec *Decoder) UseRegistry(reg *schemas.Registry) {
+	dec.cache.useRegistry(reg)
+}
+
+// Decode reads the next JSON value from the stream and allocates the
+// struct it represents in seg.
+func (dec *Decoder) Decode(typeID uint64, seg *capnp.Segment) (capnp.Struct, error) {
+	var raw gojson.RawMessage
+	if err := dec.r.Decode(&raw); err != nil {
+		return capnp.Struct{}, err
+	}
+	si, err := dec.cache.structInfo(typeID)
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	s, err := newStruct(seg, si.node)
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	if err := dec.unmarshalStruct(si, s, raw); err != nil {
+		return capnp.Struct{}, err
+	}
+	return s, nil
+}
+
+func newStruct(seg *capnp.Segment, n schema.Node) (capnp.Struct, error) {
+	return capnp.NewStruct(seg, structSize(n))
+}
+
+func structSize(n schema.Node) capnp.ObjectSize {
+	return capnp.ObjectSize{
+		DataSize:     capnp.Size(n.StructNode().DataWordCount()) * 8,
+		PointerCount: n.StructNode().PointerCount(),
+	}
+}
+
+func (dec *Decoder) unmarshalStruct(si *structInfo, s capnp.Struct, raw gojson.RawMessage) error {
+	var obj map[string]gojson.RawMessage
+	if err := gojson.Unmarshal(raw, &obj); err != nil {
+		return err
+	}
+	if obj == nil {
+		return errors.New("expected object, got null")
+	}
+	return dec.scatter(si, s, obj, "")
+}
+
+// scatter sets the fields of s from the members of obj, descending into
+// flattened fields.  It is the inverse of Encoder.gather.
+func (dec *Decoder) scatter(si *structInfo, s capnp.Struct, obj map[string]gojson.RawMessage, prefix string) error {
+	for i := range si.fields {
+		fi := &si.fields[i]
+		if fi.inUnion() {
+			continue
+		}
+		if fi.flatten {
+			if err := dec.scatterFlattened(s, fi, obj, prefix); err != nil {
+				return err
+			}
+			continue
+		}
+		if v, ok := obj[prefix+fi.name]; ok {
+			if err := dec.unmarshalField(s, fi, v); err != nil {
+				return fmt.Errorf("unmarshal field %s: %w", prefix+fi.name, err)
+			}
+		}
+	}
+	if !si.hasUnion() {
+		return nil
+	}
+	if si.tagName != "" {
+		return dec.scatterTagged(si, s, obj, prefix)
+	}
+	for i := range si.fields {
+		fi := &si.fields[i]
+		if !fi.inUnion() {
+			continue
+		}
+		if fi.flatten {
+			// Without a discriminator, a flattened member is chosen
+			// if any of its fields are present.
+			if ok, err := dec.present(fi, obj, prefix); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+			si.setWhich(s, fi)
+			return dec.scatterFlattened(s, fi, obj, prefix)
+		}
+		v, ok := obj[prefix+fi.name]
+		if !ok {
+			continue
+		}
+		si.setWhich(s, fi)
+		if err := dec.unmarshalField(s, fi, v); err != nil {
+			return fmt.Errorf("unmarshal field %s: %w", prefix+fi.name, err)
+		}
+		return nil
+	}
+	return nil
+}
+
+func (dec *Decoder) scatterTagged(si *structInfo, s capnp.Struct, obj map[string]gojson.RawMessage, prefix string) error {
+	raw, ok := obj[prefix+si.tagName]
+	if !ok {
+		return nil
+	}
+	var tag string
+	if err := gojson.Unmarshal(raw, &tag); err != nil {
+		return fmt.Errorf("unmarshal discriminator %s: %w", prefix+si.tagName, err)
+	}
+	for i := range si.fields {
+		fi := &si.fields[i]
+		if !fi.inUnion() || fi.name != tag {
+			continue
+		}
+		si.setWhich(s, fi)
+		if fi.flatten {
+			return dec.scatterFlattened(s, fi, obj, prefix)
+		}
+		name := fi.name
+		if si.valueName != "" {
+			name = si.valueName
+		}
+		if v, ok := obj[prefix+name]; ok {
+			if err := dec.unmarshalField(s, fi, v); err != nil {
+				return fmt.Errorf("unmarshal field %s: %w", prefix+name, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unmarshal discriminator %s: unknown union member %q", prefix+si.tagName, tag)
+}
+
+func (dec *Decoder) scatterFlattened(s capnp.Struct, fi *fieldInfo, obj map[string]gojson.RawMessage, prefix string) error {
+	if fi.group != nil {
+		return dec.scatter(fi.group, s, obj, prefix+fi.prefix)
+	}
+	typ, err := fi.field.Slot().Type()
+	if err != nil {
+		return err
+	}
+	si, err := dec.cache.structInfo(typ.StructType().TypeId())
+	if err != nil {
+		return err
+	}
+	if ok, err := dec.present(fi, obj, prefix); err != nil || !ok {
+		// Leave the field null, as the encoder omits null fields.
+		return err
+	}
+	sub, err := newStruct(s.Segment(), si.node)
+	if err != nil {
+		return err
+	}
+	if err := s.SetPtr(uint16(fi.field.Slot().Offset()), sub.ToPtr()); err != nil {
+		return err
+	}
+	return dec.scatter(si, sub, obj, prefix+fi.prefix)
+}
+
+// present reports whether obj has any of the keys that the flattened
+// field fi is written to.
+func (dec *Decoder) present(fi *fieldInfo, obj map[string]gojson.RawMessage, prefix string) (bool, error) {
+	si := fi.group
+	if si == nil {
+		typ, err := fi.field.Slot().Type()
+		if err != nil {
+			return false, err
+		}
+		if si, err = dec.cache.structInfo(typ.StructType().TypeId()); err != nil {
+			return false, err
+		}
+	}
+	prefix += fi.prefix
+	if si.tagName != "" {
+		if _, ok := obj[prefix+si.tagName]; ok {
+			return true, nil
+		}
+	}
+	if si.valueName != "" {
+		if _, ok := obj[prefix+si.valueName]; ok {
+			return true, nil
+		}
+	}
+	for i := range si.fields {
+		sub := &si.fields[i]
+		if !sub.flatten {
+			if _, ok := obj[prefix+sub.name]; ok {
+				return true, nil
+			}
+			continue
+		}
+		if ok, err := dec.present(sub, obj, prefix); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func (dec *Decoder) unmarshalField(s capnp.Struct, fi *fieldInfo, raw gojson.RawMessage) error {
+	f := fi.field
+	if f.Which() == schema.Field_Which_group {
+		return dec.unmarshalStruct(fi.group, s, raw)
+	}
+	typ, err := f.Slot().Type()
+	if err != nil {
+		return err
+	}
+	dv, err := f.Slot().DefaultValue()
+	if err != nil {
+		return err
+	}
+	if dv.IsValid() && int(typ.Which()) != int(dv.Which()) {
+		return fmt.Errorf("default value is a %v, want %v", dv.Which(), typ.Which())
+	}
+	off := f.Slot().Offset()
+	switch typ.Which() {
+	case schema.Type_Which_void:
+	case schema.Type_Which_bool:
+		v, err := unmarshalBool(raw)
+		if err != nil {
+			return err
+		}
+		s.SetBit(capnp.BitOffset(off), v != dv.Bool())
+	case schema.Type_Which_int8:
+		v, err := unmarshalInt(raw, 8)
+		if err != nil {
+			return err
+		}
+		s.SetUint8(capnp.DataOffset(off), uint8(v)^uint8(dv.Int8()))
+	case schema.Type_Which_int16:
+		v, err := unmarshalInt(raw, 16)
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), uint16(v)^uint16(dv.Int16()))
+	case schema.Type_Which_int32:
+		v, err := unmarshalInt(raw, 32)
+		if err != nil {
+			return err
+		}
+		s.SetUint32(capnp.DataOffset(off*4), uint32(v)^uint32(dv.Int32()))
+	case schema.Type_Which_int64:
+		v, err := unmarshalInt(raw, 64)
+		if err != nil {
+			return err
+		}
+		s.SetUint64(capnp.DataOffset(off*8), uint64(v)^uint64(dv.Int64()))
+	case schema.Type_Which_uint8:
+		v, err := unmarshalUint(raw, 8)
+		if err != nil {
+			return err
+		}
+		s.SetUint8(capnp.DataOffset(off), uint8(v)^dv.Uint8())
+	case schema.Type_Which_uint16:
+		v, err := unmarshalUint(raw, 16)
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), uint16(v)^dv.Uint16())
+	case schema.Type_Which_uint32:
+		v, err := unmarshalUint(raw, 32)
+		if err != nil {
+			return err
+		}
+		s.SetUint32(capnp.DataOffset(off*4), uint32(v)^dv.Uint32())
+	case schema.Type_Which_uint64:
+		v, err := unmarshalUint(raw, 64)
+		if err != nil {
+			return err
+		}
+		s.SetUint64(capnp.DataOffset(off*8), v^dv.Uint64())
+	case schema.Type_Which_float32:
+		v, err := unmarshalFloat(raw, 32)
+		if err != nil {
+			return err
+		}
+		s.SetUint32(capnp.DataOffset(off*4), math.Float32bits(float32(v))^math.Float32bits(dv.Float32()))
+	case schema.Type_Which_float64:
+		v, err := unmarshalFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		s.SetUint64(capnp.DataOffset(off*8), math.Float64bits(v)^math.Float64bits(dv.Float64()))
+	case schema.Type_Which_enum:
+		v, err := dec.unmarshalEnum(typ.Enum().TypeId(), raw)
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), v^dv.Enum())
+	default:
+		if isNull(raw) {
+			return nil
+		}
+		p, err := dec.unmarshalPtr(s.Segment(), typ, fi.data, raw)
+		if err != nil {
+			return err
+		}
+		return s.SetPtr(uint16(off), p)
+	}
+	return nil
+}
+
+// unmarshalPtr allocates the value of a pointer type in seg.
+func (dec *Decoder) unmarshalPtr(seg *capnp.Segment, typ schema.Type, de dataEncoding, raw gojson.RawMessage) (capnp.Ptr, error) {
+	switch typ.Which() {
+	case schema.Type_Which_text:
+		var v string
+		if err := gojson.Unmarshal(raw, &v); err != nil {
+			return capnp.Ptr{}, err
+		}
+		t, err := capnp.NewText(seg, v)
+		return t.ToPtr(), err
+	case schema.Type_Which_data:
+		v, err := unmarshalData(raw, de)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		d, err := capnp.NewData(seg, v)
+		return d.ToPtr(), err
+	case schema.Type_Which_structType:
+		si, err := dec.cache.structInfo(typ.StructType().TypeId())
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		s, err := newStruct(seg, si.node)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		if err := dec.unmarshalStruct(si, s, raw); err != nil {
+			return capnp.Ptr{}, err
+		}
+		return s.ToPtr(), nil
+	case schema.Type_Which_list:
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		l, err := dec.unmarshalList(seg, elem, raw)
+		return l.ToPtr(), err
+	case schema.Type_Which_interface:
+		return capnp.Ptr{}, errors.New("cannot decode capabilities from JSON")
+	case schema.Type_Which_anyPointer:
+		return capnp.Ptr{}, errors.New("cannot decode AnyPointer from JSON")
+	default:
+		return capnp.Ptr{}, fmt.Errorf("unknown pointer type %v", typ.Which())
+	}
+}
+
+func (dec *Decoder) unmarshalList(seg *capnp.Segment, elem schema.Type, raw gojson.RawMessage) (capnp.List, error) {
+	var elems []gojson.RawMessage
+	if err := gojson.Unmarshal(raw, &elems); err != nil {
+		return capnp.List{}, err
+	}
+	n := int32(len(elems))
+	switch elem.Which() {
+	case schema.Type_Which_void:
+		return capnp.List(capnp.NewVoidList(seg, n)), nil
+	case schema.Type_Which_bool:
+		l, err := capnp.NewBitList(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalBool(e)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, v)
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_int8:
+		l, err := capnp.NewInt8List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalInt(e, 8)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, int8(v))
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_int16:
+		l, err := capnp.NewInt16List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalInt(e, 16)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, int16(v))
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_int32:
+		l, err := capnp.NewInt32List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalInt(e, 32)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, int32(v))
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_int64:
+		l, err := capnp.NewInt64List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalInt(e, 64)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, v)
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_uint8:
+		l, err := capnp.NewUInt8List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalUint(e, 8)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, uint8(v))
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_uint16:
+		l, err := capnp.NewUInt16List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalUint(e, 16)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, uint16(v))
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_uint32:
+		l, err := capnp.NewUInt32List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalUint(e, 32)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, uint32(v))
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_uint64:
+		l, err := capnp.NewUInt64List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalUint(e, 64)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, v)
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_float32:
+		l, err := capnp.NewFloat32List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalFloat(e, 32)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, float32(v))
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_float64:
+		l, err := capnp.NewFloat64List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := unmarshalFloat(e, 64)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, v)
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_enum:
+		l, err := capnp.NewUInt16List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			v, err := dec.unmarshalEnum(elem.Enum().TypeId(), e)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			l.Set(i, v)
+		}
+		return capnp.List(l), nil
+	case schema.Type_Which_structType:
+		si, err := dec.cache.structInfo(elem.StructType().TypeId())
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l, err := capnp.NewCompositeList(seg, structSize(si.node), n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			if err := dec.unmarshalStruct(si, l.Struct(i), e); err != nil {
+				return capnp.List{}, err
+			}
+		}
+		return l, nil
+	default:
+		l, err := capnp.NewPointerList(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, e := range elems {
+			if isNull(e) {
+				continue
+			}
+			p, err := dec.unmarshalPtr(seg, elem, dataArray, e)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			if err := l.Set(i, p); err != nil {
+				return capnp.List{}, err
+			}
+		}
+		return capnp.List(l), nil
+	}
+}
+
+func (dec *Decoder) unmarshalEnum(typ uint64, raw gojson.RawMessage) (uint16, error) {
+	ei, err := dec.cache.enumInfo(typ)
+	if err != nil {
+		return 0, err
+	}
+	if len(raw) > 0 && raw[0] == '"' {
+		var name string
+		if err := gojson.Unmarshal(raw, &name); err != nil {
+			return 0, err
+		}
+		v, ok := ei.values[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown enumerant %q", name)
+		}
+		return v, nil
+	}
+	v, err := unmarshalUint(raw, 16)
+	return uint16(v), err
+}
+
+func isNull(raw gojson.RawMessage) bool {
+	return string(raw) == "null"
+}
+
+func unmarshalBool(raw gojson.RawMessage) (bool, error) {
+	switch string(raw) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected boolean, got %s", raw)
+	}
+}
+
+// numberText returns the text of a JSON number, or of a JSON string,
+// which is how 64-bit integers and non-finite floats are written.
+func numberText(raw gojson.RawMessage) (string, error) {
+	if len(raw) > 0 && raw[0] == '"' {
+		var s string
+		err := gojson.Unmarshal(raw, &s)
+		return s, err
+	}
+	var n gojson.Number
+	if err := gojson.Unmarshal(raw, &n); err != nil || n == "" {
+		return "", fmt.Errorf("expected number, got %s", raw)
+	}
+	return string(n), nil
+}
+
+func unmarshalInt(raw gojson.RawMessage, bits int) (int64, error) {
+	s, err := numberText(raw)
+	if err != nil {
+		return 0, err
+	}
+	if v, err := strconv.ParseInt(s, 10, bits); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || f < -math.Ldexp(1, bits-1) || f >= math.Ldexp(1, bits-1) {
+		return 0, fmt.Errorf("%s is not a valid %d-bit integer", s, bits)
+	}
+	return int64(f), nil
+}
+
+func unmarshalUint(raw gojson.RawMessage, bits int) (uint64, error) {
+	s, err := numberText(raw)
+	if err != nil {
+		return 0, err
+	}
+	if v, err := strconv.ParseUint(s, 10, bits); err == nil {
+		return v, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != math.Trunc(f) || f < 0 || f >= math.Ldexp(1, bits) {
+		return 0, fmt.Errorf("%s is not a valid unsigned %d-bit integer", s, bits)
+	}
+	return uint64(f), nil
+}
+
+func unmarshalFloat(raw gojson.RawMessage, bits int) (float64, error) {
+	s, err := numberText(raw)
+	if err != nil {
+		return 0, err
+	}
+	switch s {
+	case "NaN":
+		return math.NaN(), nil
+	case "Infinity":
+		return math.Inf(1), nil
+	case "-Infinity":
+		return math.Inf(-1), nil
+	}
+	v, err := strconv.ParseFloat(s, bits)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a valid number", s)
+	}
+	return v, nil
+}
+
+func unmarshalData(raw gojson.RawMessage, de dataEncoding) ([]byte, error) {
+	if de == dataArray {
+		var elems []gojson.RawMessage
+		if err := gojson.Unmarshal(raw, &elems); err != nil {
+			return nil, err
+		}
+		b := make([]byte, len(elems))
+		for i, e := range elems {
+			v, err := unmarshalUint(e, 8)
+			if err != nil {
+				return nil, err
+			}
+			b[i] = byte(v)
+		}
+		return b, nil
+	}
+	var s string
+	if err := gojson.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	if de == dataHex {
+		return hex.DecodeString(s)
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
@@ -1,4 +1,5 @@
-// Package text supports marshaling Cap'n Proto messages as text based on a schema.
+// Package text supports marshaling and unmarshaling Cap'n Proto messages as text
+// based on a schema.
 package text
 
 import (
@@ -42,10 +43,23 @@ func MarshalList(typeID uint64, l capnp.List) (string, error) {
 }
 
 // An Encoder writes the text format of Cap'n Proto messages to an output stream.
+//
+// By default, an Encoder writes each value on a single line in the same
+// form as the schema language.  The Set methods adjust the output for
+// human readers; some of them produce text that Unmarshal cannot parse,
+// as noted in their documentation.
 type Encoder struct {
 	w     errWriter
 	tmp   []byte
 	nodes nodemap.Map
+
+	prefix   string
+	indent   string
+	annotate bool
+	elide    bool
+	maxBytes int
+	maxElems int
+	depth    int
 }
 
 // NewEncoder returns a new encoder that writes to w.
@@ -59,11 +73,45 @@ func (enc *Encoder) UseRegistry(reg *schemas.Registry) {
 	enc.nodes.UseRegistry(reg)
 }
 
+// SetIndent instructs the encoder to write each struct field, and each
+// element of a list of structs or lists, on its own line.  Each such
+// line begins with prefix followed by one copy of indent per level of
+// nesting.  Calling SetIndent("", "") restores single-line output.
+func (enc *Encoder) SetIndent(prefix, indent string) {
+	enc.prefix = prefix
+	enc.indent = indent
+}
+
+// SetTypeAnnotations controls whether the encoder writes the name of
+// each struct's type before its opening parenthesis, as in
+// "Foo(bar = 1)".  A Decoder accepts annotated text as long as the names
+// match the schema.
+func (enc *Encoder) SetTypeAnnotations(on bool) {
+	enc.annotate = on
+}
+
+// SetElideDefaults controls whether the encoder omits fields that are
+// set to their default value.  The active member of a union is always
+// written unless it is the first member and has its default value.
+func (enc *Encoder) SetElideDefaults(on bool) {
+	enc.elide = on
+}
+
+// SetTruncate limits the number of bytes written for a Data value to
+// maxBytes and the number of elements written for a list to maxElems,
+// followed by a summary of what was left out.  A limit of zero or less
+// means no limit.  Truncated output cannot be decoded.
+func (enc *Encoder) SetTruncate(maxBytes, maxElems int) {
+	enc.maxBytes = maxBytes
+	enc.maxElems = maxElems
+}
+
 // Encode writes the text representation of s to the stream.
 func (enc *Encoder) Encode(typeID uint64, s capnp.Struct) error {
 	if enc.w.err != nil {
 		return enc.w.err
 	}
+	enc.depth = 0
 	err := enc.marshalStruct(typeID, s)
 	if err != nil {
 		return err
@@ -77,9 +125,37 @@ func (enc *Encoder) EncodeList(typeID uint64, l capnp.List) error {
 	typ, _ := schema.NewRootType(seg)
 	typ.SetStructType()
 	typ.StructType().SetTypeId(typeID)
+	enc.depth = 0
 	return enc.marshalList(typ, l)
 }
 
+func (enc *Encoder) multiline() bool {
+	return enc.prefix != "" || enc.indent != ""
+}
+
+// beginItem writes the separator that precedes the i'th field of a
+// struct or element of a list.
+func (enc *Encoder) beginItem(i int, multi bool) {
+	if i > 0 {
+		if multi {
+			enc.w.WriteByte(',')
+		} else {
+			enc.w.WriteString(", ")
+		}
+	}
+	if multi {
+		enc.newline()
+	}
+}
+
+func (enc *Encoder) newline() {
+	enc.w.WriteByte('\n')
+	enc.w.WriteString(enc.prefix)
+	for i := 0; i < enc.depth; i++ {
+		enc.w.WriteString(enc.indent)
+	}
+}
+
 func (enc *Encoder) marshalBool(v bool) {
 	if v {
 		enc.w.WriteString("true")
@@ -113,6 +189,15 @@ func (enc *Encoder) marshalText(t []byte) {
 	enc.w.Write(enc.tmp)
 }
 
+func (enc *Encoder) marshalData(b []byte) {
+	if enc.maxBytes <= 0 || len(b) <= enc.maxBytes {
+		enc.marshalText(b)
+		return
+	}
+	enc.marshalText(b[:enc.maxBytes])
+	fmt.Fprintf(&enc.w, " <... %d more bytes>", len(b)-enc.maxBytes)
+}
+
 func (enc *Encoder) marshalStruct(typeID uint64, s capnp.Struct) error {
 	n, err := enc.nodes.Find(typeID)
 	if err != nil {
@@ -121,24 +206,41 @@ func (enc *Encoder) marshalStruct(typeID uint64, s capnp.Struct) error {
 	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
 		return fmt.Errorf("cannot find struct type %#x", typeID)
 	}
+	if enc.annotate && !n.StructNode().IsGroup() {
+		name, err := n.DisplayName()
+		if err != nil {
+			return err
+		}
+		enc.w.WriteString(name[n.DisplayNamePrefixLength():])
+	}
 	var discriminant uint16
 	if n.StructNode().DiscriminantCount() > 0 {
 		discriminant = s.Uint16(capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2))
 	}
+	multi := enc.multiline()
 	enc.w.WriteByte('(')
+	enc.depth++
 	fields := codeOrderFields(n.StructNode())
-	first := true
+	written := 0
 	for _, f := range fields {
 		if !(f.Which() == schema.Field_Which_slot || f.Which() == schema.Field_Which_group) {
 			continue
 		}
-		if dv := f.DiscriminantValue(); !(dv == schema.Field_noDiscriminant || dv == discriminant) {
+		dv := f.DiscriminantValue()
+		if !(dv == schema.Field_noDiscriminant || dv == discriminant) {
 			continue
 		}
-		if !first {
-			enc.w.WriteString(", ")
+		if enc.elide && (dv == schema.Field_noDiscriminant || dv == 0) {
+			def, err := enc.isDefault(s, f)
+			if err != nil {
+				return err
+			}
+			if def {
+				continue
+			}
 		}
-		first = false
+		enc.beginItem(written, multi)
+		written++
 		name, err := f.NameBytes()
 		if err != nil {
 			return err
@@ -156,10 +258,84 @@ func (enc *Encoder) marshalStruct(typeID uint64, s capnp.Struct) error {
 			}
 		}
 	}
+	enc.depth--
+	if multi && written > 0 {
+		enc.newline()
+	}
 	enc.w.WriteByte(')')
 	return nil
 }
 
+// isDefault reports whether field f of s holds its default value.
+func (enc *Encoder) isDefault(s capnp.Struct, f schema.Field) (bool, error) {
+	if f.Which() == schema.Field_Which_group {
+		return enc.isDefaultStruct(f.Group().TypeId(), s)
+	}
+	typ, err := f.Slot().Type()
+	if err != nil {
+		return false, err
+	}
+	// Values are stored XORed with their defaults, so a default value is
+	// always stored as zero.
+	off := f.Slot().Offset()
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		return true, nil
+	case schema.Type_Which_bool:
+		return !s.Bit(capnp.BitOffset(off)), nil
+	case schema.Type_Which_int8, schema.Type_Which_uint8:
+		return s.Uint8(capnp.DataOffset(off)) == 0, nil
+	case schema.Type_Which_int16, schema.Type_Which_uint16, schema.Type_Which_enum:
+		return s.Uint16(capnp.DataOffset(off*2)) == 0, nil
+	case schema.Type_Which_int32, schema.Type_Which_uint32, schema.Type_Which_float32:
+		return s.Uint32(capnp.DataOffset(off*4)) == 0, nil
+	case schema.Type_Which_int64, schema.Type_Which_uint64, schema.Type_Which_float64:
+		return s.Uint64(capnp.DataOffset(off*8)) == 0, nil
+	}
+	p, err := s.Ptr(uint16(off))
+	if err != nil || !p.IsValid() {
+		return !p.IsValid(), err
+	}
+	if dv, _ := f.Slot().DefaultValue(); capnp.Struct(dv).HasPtr(0) {
+		// A non-null value might differ from an explicit default.
+		return false, nil
+	}
+	switch typ.Which() {
+	case schema.Type_Which_text:
+		return len(p.TextBytes()) == 0, nil
+	case schema.Type_Which_data:
+		return len(p.Data()) == 0, nil
+	case schema.Type_Which_list:
+		return p.List().Len() == 0, nil
+	case schema.Type_Which_structType:
+		return enc.isDefaultStruct(typ.StructType().TypeId(), p.Struct())
+	default:
+		return false, nil
+	}
+}
+
+// isDefaultStruct reports whether every field of s, which is of struct
+// or group type typeID, holds its default value.
+func (enc *Encoder) isDefaultStruct(typeID uint64, s capnp.Struct) (bool, error) {
+	n, err := enc.nodes.Find(typeID)
+	if err != nil {
+		return false, err
+	}
+	if n.StructNode().DiscriminantCount() > 0 &&
+		s.Uint16(capnp.DataOffset(n.StructNode().DiscriminantOffset()*2)) != 0 {
+		return false, nil
+	}
+	for _, f := range codeOrderFields(n.StructNode()) {
+		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant && dv != 0 {
+			continue
+		}
+		if def, err := enc.isDefault(s, f); !def || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 func (enc *Encoder) marshalFieldValue(s capnp.Struct, f schema.Field) error {
 	typ, err := f.Slot().Type()
 	if err != nil {
@@ -236,10 +412,10 @@ func (enc *Encoder) marshalFieldValue(s capnp.Struct, f schema.Field) error {
 		}
 		if !p.IsValid() {
 			b, _ := dv.Data()
-			enc.marshalText(b)
+			enc.marshalData(b)
 			return nil
 		}
-		enc.marshalText(p.Data())
+		enc.marshalData(p.Data())
 	case schema.Type_Which_text:
 		p, err := s.Ptr(uint16(f.Slot().Offset()))
 		if err != nil {
@@ -294,85 +470,111 @@ func codeOrderFields(s schema.Node_structNode) []schema.Field {
 }
 
 func (enc *Encoder) marshalList(elem schema.Type, l capnp.List) error {
+	var marshalElem func(i int) error
 	switch elem.Which() {
 	case schema.Type_Which_void:
-		enc.w.WriteString(capnp.VoidList(l).String())
+		marshalElem = func(int) error {
+			enc.w.WriteString(voidMarker)
+			return nil
+		}
 	case schema.Type_Which_bool:
-		enc.w.WriteString(capnp.BitList(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalBool(capnp.BitList(l).At(i))
+			return nil
+		}
 	case schema.Type_Which_int8:
-		enc.w.WriteString(capnp.Int8List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalInt(int64(capnp.Int8List(l).At(i)))
+			return nil
+		}
 	case schema.Type_Which_int16:
-		enc.w.WriteString(capnp.Int16List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalInt(int64(capnp.Int16List(l).At(i)))
+			return nil
+		}
 	case schema.Type_Which_int32:
-		enc.w.WriteString(capnp.Int32List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalInt(int64(capnp.Int32List(l).At(i)))
+			return nil
+		}
 	case schema.Type_Which_int64:
-		enc.w.WriteString(capnp.Int64List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalInt(capnp.Int64List(l).At(i))
+			return nil
+		}
 	case schema.Type_Which_uint8:
-		enc.w.WriteString(capnp.UInt8List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalUint(uint64(capnp.UInt8List(l).At(i)))
+			return nil
+		}
 	case schema.Type_Which_uint16:
-		enc.w.WriteString(capnp.UInt16List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalUint(uint64(capnp.UInt16List(l).At(i)))
+			return nil
+		}
 	case schema.Type_Which_uint32:
-		enc.w.WriteString(capnp.UInt32List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalUint(uint64(capnp.UInt32List(l).At(i)))
+			return nil
+		}
 	case schema.Type_Which_uint64:
-		enc.w.WriteString(capnp.UInt64List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalUint(capnp.UInt64List(l).At(i))
+			return nil
+		}
 	case schema.Type_Which_float32:
-		enc.w.WriteString(capnp.Float32List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalFloat32(capnp.Float32List(l).At(i))
+			return nil
+		}
 	case schema.Type_Which_float64:
-		enc.w.WriteString(capnp.Float64List(l).String())
+		marshalElem = func(i int) error {
+			enc.marshalFloat64(capnp.Float64List(l).At(i))
+			return nil
+		}
 	case schema.Type_Which_data:
-		enc.w.WriteString(capnp.DataList(l).String())
-	case schema.Type_Which_text:
-		enc.w.WriteString(capnp.TextList(l).String())
-	case schema.Type_Which_structType:
-		enc.w.WriteByte('[')
-		for i := 0; i < l.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
+		marshalElem = func(i int) error {
+			b, err := capnp.DataList(l).At(i)
+			if err != nil {
+				return err
 			}
-			err := enc.marshalStruct(elem.StructType().TypeId(), l.Struct(i))
+			enc.marshalData(b)
+			return nil
+		}
+	case schema.Type_Which_text:
+		marshalElem = func(i int) error {
+			b, err := capnp.TextList(l).BytesAt(i)
 			if err != nil {
 				return err
 			}
+			enc.marshalText(b)
+			return nil
+		}
+	case schema.Type_Which_structType:
+		typ := elem.StructType().TypeId()
+		marshalElem = func(i int) error {
+			return enc.marshalStruct(typ, l.Struct(i))
 		}
-		enc.w.WriteByte(']')
 	case schema.Type_Which_list:
-		enc.w.WriteByte('[')
 		ee, err := elem.List().ElementType()
 		if err != nil {
 			return err
 		}
-		for i := 0; i < l.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
-			}
+		marshalElem = func(i int) error {
 			p, err := capnp.PointerList(l).At(i)
 			if err != nil {
 				return err
 			}
-			err = enc.marshalList(ee, p.List())
-			if err != nil {
-				return err
-			}
+			return enc.marshalList(ee, p.List())
 		}
-		enc.w.WriteByte(']')
 	case schema.Type_Which_enum:
-		enc.w.WriteByte('[')
-		il := capnp.UInt16List(l)
 		typ := elem.Enum().TypeId()
 		// TODO(light): only search for node once
-		for i := 0; i < il.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
-			}
-			enc.marshalEnum(typ, il.At(i))
+		marshalElem = func(i int) error {
+			return enc.marshalEnum(typ, capnp.UInt16List(l).At(i))
 		}
-		enc.w.WriteByte(']')
 	case schema.Type_Which_interface:
-		enc.w.WriteByte('[')
-		for i := 0; i < l.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
-			}
+		marshalElem = func(i int) error {
 			p, err := capnp.PointerList(l).At(i)
 			if err != nil {
 				return err
@@ -382,20 +584,40 @@ func (enc *Encoder) marshalList(elem schema.Type, l capnp.List) error {
 			} else {
 				enc.w.WriteString(interfaceNullMarker)
 			}
+			return nil
 		}
-		enc.w.WriteByte(']')
 	case schema.Type_Which_anyPointer:
-		enc.w.WriteByte('[')
-		for i := 0; i < l.Len(); i++ {
-			if i > 0 {
-				enc.w.WriteString(", ")
-			}
+		marshalElem = func(int) error {
 			enc.w.WriteString(anyPointerMarker)
+			return nil
 		}
-		enc.w.WriteByte(']')
 	default:
 		return fmt.Errorf("unknown list type %v", elem.Which())
 	}
+
+	n := l.Len()
+	if enc.maxElems > 0 && n > enc.maxElems {
+		n = enc.maxElems
+	}
+	multi := enc.multiline() && l.Len() > 0 &&
+		(elem.Which() == schema.Type_Which_structType || elem.Which() == schema.Type_Which_list)
+	enc.w.WriteByte('[')
+	enc.depth++
+	for i := 0; i < n; i++ {
+		enc.beginItem(i, multi)
+		if err := marshalElem(i); err != nil {
+			return err
+		}
+	}
+	if n < l.Len() {
+		enc.beginItem(n, multi)
+		fmt.Fprintf(&enc.w, "<... %d more elements>", l.Len()-n)
+	}
+	enc.depth--
+	if multi {
+		enc.newline()
+	}
+	enc.w.WriteByte(']')
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"capnproto.org/go/capnp/v3"
@@ -227,3 +228,157 @@ func TestEncodeList(t *testing.T) {
 		}
 	}
 }
+
+func TestEncodeOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		typeID uint64
+		in     string
+		setup  func(enc *Encoder)
+		want   string
+	}{
+		{
+			name:   "Default",
+			typeID: keyValueID,
+			in:     `(key = "42", value = (int32 = -123))`,
+			setup:  func(*Encoder) {},
+			want:   `(key = "42", value = (int32 = -123))`,
+		},
+		{
+			name:   "Indent",
+			typeID: valueID,
+			in:     `(map = [(key = "foo", value = (int8List = [1, 2])), (key = "bar", value = (map = []))])`,
+			setup:  func(enc *Encoder) { enc.SetIndent("", "  ") },
+			want: "(\n" +
+				"  map = [\n" +
+				"    (\n" +
+				"      key = \"foo\",\n" +
+				"      value = (\n" +
+				"        int8List = [1, 2]\n" +
+				"      )\n" +
+				"    ),\n" +
+				"    (\n" +
+				"      key = \"bar\",\n" +
+				"      value = (\n" +
+				"        map = []\n" +
+				"      )\n" +
+				"    )\n" +
+				"  ]\n" +
+				")",
+		},
+		{
+			name:   "Prefix",
+			typeID: valueID,
+			in:     `(matrix = [[1], [2]])`,
+			setup:  func(enc *Encoder) { enc.SetIndent("> ", "\t") },
+			want:   "(\n> \tmatrix = [\n> \t\t[1],\n> \t\t[2]\n> \t]\n> )",
+		},
+		{
+			name:   "TypeAnnotations",
+			typeID: keyValueID,
+			in:     `(key = "42", value = (int32 = -123))`,
+			setup:  func(enc *Encoder) { enc.SetTypeAnnotations(true) },
+			want:   `KeyValue(key = "42", value = Value(int32 = -123))`,
+		},
+		{
+			name:   "ElideDefaults",
+			typeID: keyValueID,
+			in:     `(key = "", value = (void = void))`,
+			setup:  func(enc *Encoder) { enc.SetElideDefaults(true) },
+			want:   `()`,
+		},
+		{
+			name:   "ElideDefaultsKeepsUnionMember",
+			typeID: keyValueID,
+			in:     `(key = "", value = (int32 = 0))`,
+			setup:  func(enc *Encoder) { enc.SetElideDefaults(true) },
+			want:   `(value = (int32 = 0))`,
+		},
+		{
+			name:   "TruncateData",
+			typeID: valueID,
+			in:     `(dataList = ["abcdefgh", "ab"])`,
+			setup:  func(enc *Encoder) { enc.SetTruncate(4, 0) },
+			want:   `(dataList = ["abcd" <... 4 more bytes>, "ab"])`,
+		},
+		{
+			name:   "TruncateList",
+			typeID: valueID,
+			in:     `(int64List = [1, 2, 3, 4, 5])`,
+			setup:  func(enc *Encoder) { enc.SetTruncate(0, 2) },
+			want:   `(int64List = [1, 2, <... 3 more elements>])`,
+		},
+		{
+			name:   "TruncateIndented",
+			typeID: valueID,
+			in:     `(matrix = [[1], [2], [3]])`,
+			setup: func(enc *Encoder) {
+				enc.SetIndent("", " ")
+				enc.SetTruncate(0, 1)
+			},
+			want: "(\n matrix = [\n  [1],\n  <... 2 more elements>\n ]\n)",
+		},
+	}
+
+	reg := testRegistry(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+			dec := NewDecoder(strings.NewReader(test.in))
+			dec.UseRegistry(reg)
+			s, err := dec.Decode(test.typeID, seg)
+			if err != nil {
+				t.Fatalf("Decode(%q): %v", test.in, err)
+			}
+			buf := new(bytes.Buffer)
+			enc := NewEncoder(buf)
+			enc.UseRegistry(reg)
+			test.setup(enc)
+			if err := enc.Encode(test.typeID, s); err != nil {
+				t.Fatal("Encode:", err)
+			}
+			if got := buf.String(); got != test.want {
+				t.Errorf("Encode(%q) =\n%s\nwant\n%s", test.in, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeFormatted(t *testing.T) {
+	// Indented and annotated output must decode back to the same value.
+	const in = `(key = "42", value = (map = [(key = "a", value = (int32List = [1, 2]))]))`
+	reg := testRegistry(t)
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	s, err := decodeWith(reg, keyValueID, in, seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	enc := NewEncoder(buf)
+	enc.UseRegistry(reg)
+	enc.SetIndent("", "  ")
+	enc.SetTypeAnnotations(true)
+	if err := enc.Encode(keyValueID, s); err != nil {
+		t.Fatal("Encode:", err)
+	}
+	formatted := buf.String()
+	s, err = decodeWith(reg, keyValueID, formatted, seg)
+	if err != nil {
+		t.Fatalf("Decode(%q): %v", formatted, err)
+	}
+	buf.Reset()
+	enc = NewEncoder(buf)
+	enc.UseRegistry(reg)
+	if err := enc.Encode(keyValueID, s); err != nil {
+		t.Fatal("Encode:", err)
+	}
+	if got := buf.String(); got != in {
+		t.Errorf("Encode(Decode(%q)) = %q; want %q", formatted, got, in)
+	}
+}
+
+func decodeWith(reg *schemas.Registry, typeID uint64, text string, seg *capnp.Segment) (capnp.Struct, error) {
+	dec := NewDecoder(strings.NewReader(text))
+	dec.UseRegistry(reg)
+	return dec.Decode(typeID, seg)
+}
@@ -0,0 +1,986 @@
+package text
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/nodemap"
+	"capnproto.org/go/capnp/v3/internal/schema"
+	"capnproto.org/go/capnp/v3/schemas"
+)
+
+// Unmarshal parses the text representation of a struct and allocates
+// the result in seg.
+func Unmarshal(typeID uint64, text string, seg *capnp.Segment) (capnp.Struct, error) {
+	dec := new(Decoder)
+	dec.src = []byte(text)
+	return dec.Decode(typeID, seg)
+}
+
+// UnmarshalList parses the text representation of a struct list and
+// allocates the result in seg.
+func UnmarshalList(typeID uint64, text string, seg *capnp.Segment) (capnp.List, error) {
+	dec := new(Decoder)
+	dec.src = []byte(text)
+	return dec.DecodeList(typeID, seg)
+}
+
+// A SyntaxError describes text that could not be parsed, or that does
+// not match the schema.
+type SyntaxError struct {
+	Line   int // 1-based
+	Column int // 1-based, in bytes
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("text: line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+// A Decoder reads the text format of Cap'n Proto messages from an input
+// stream.
+type Decoder struct {
+	r     io.Reader
+	src   []byte
+	nodes nodemap.Map
+
+	toks  []token
+	pos   int
+	depth int // pointer values being parsed
+}
+
+// maxDepth limits how deeply pointer values can nest in the text, so
+// that deeply nested input cannot exhaust the stack.  It is the default
+// capnp.Message DepthLimit: a message nested any deeper could not be
+// read back with the default limits.
+const maxDepth = 64
+
+// NewDecoder returns a new decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// UseRegistry changes the registry that the decoder consults for
+// schemas from the default registry.
+func (dec *Decoder) UseRegistry(reg *schemas.Registry) {
+	dec.nodes.UseRegistry(reg)
+}
+
+// Decode reads the text representation of a struct from the rest of the
+// stream and allocates the result in seg.
+func (dec *Decoder) Decode(typeID uint64, seg *capnp.Segment) (capnp.Struct, error) {
+	if err := dec.init(); err != nil {
+		return capnp.Struct{}, err
+	}
+	n, err := dec.findStruct(typeID)
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	s, err := capnp.NewStruct(seg, structSize(n))
+	if err != nil {
+		return capnp.Struct{}, err
+	}
+	if err := dec.parseStruct(n, s); err != nil {
+		return capnp.Struct{}, err
+	}
+	if err := dec.expectEOF(); err != nil {
+		return capnp.Struct{}, err
+	}
+	return s, nil
+}
+
+// DecodeList reads the text representation of a struct list from the
+// rest of the stream and allocates the result in seg.
+func (dec *Decoder) DecodeList(typeID uint64, seg *capnp.Segment) (capnp.List, error) {
+	if err := dec.init(); err != nil {
+		return capnp.List{}, err
+	}
+	_, tseg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	typ, _ := schema.NewRootType(tseg)
+	typ.SetStructType()
+	typ.StructType().SetTypeId(typeID)
+	l, err := dec.parseList(seg, typ)
+	if err != nil {
+		return capnp.List{}, err
+	}
+	if err := dec.expectEOF(); err != nil {
+		return capnp.List{}, err
+	}
+	return l, nil
+}
+
+func (dec *Decoder) init() error {
+	if dec.r != nil {
+		src, err := ioutil.ReadAll(dec.r)
+		if err != nil {
+			return err
+		}
+		dec.src, dec.r = src, nil
+	}
+	toks, err := lex(dec.src)
+	if err != nil {
+		return err
+	}
+	dec.toks, dec.pos, dec.depth = toks, 0, 0
+	return nil
+}
+
+func (dec *Decoder) findStruct(id uint64) (schema.Node, error) {
+	n, err := dec.nodes.Find(id)
+	if err != nil {
+		return schema.Node{}, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
+		return schema.Node{}, fmt.Errorf("cannot find struct type %#x", id)
+	}
+	return n, nil
+}
+
+func structSize(n schema.Node) capnp.ObjectSize {
+	return capnp.ObjectSize{
+		DataSize:     capnp.Size(n.StructNode().DataWordCount()) * 8,
+		PointerCount: n.StructNode().PointerCount(),
+	}
+}
+
+func (dec *Decoder) peek() token {
+	return dec.toks[dec.pos]
+}
+
+func (dec *Decoder) next() token {
+	t := dec.toks[dec.pos]
+	if t.kind != tokEOF {
+		dec.pos++
+	}
+	return t
+}
+
+func (dec *Decoder) expect(kind tokenKind) (token, error) {
+	t := dec.next()
+	if t.kind != kind {
+		return t, t.errorf("expected %v, found %v", kind, t)
+	}
+	return t, nil
+}
+
+func (dec *Decoder) expectEOF() error {
+	if t := dec.peek(); t.kind != tokEOF {
+		return t.errorf("unexpected %v after value", t)
+	}
+	return nil
+}
+
+// parseStruct parses a parenthesized field list into s, which has the
+// layout of struct or group node n.
+func (dec *Decoder) parseStruct(n schema.Node, s capnp.Struct) error {
+	if t := dec.peek(); t.kind == tokIdent {
+		// Type annotation, as written by Encoder.SetTypeAnnotations.
+		dec.next()
+		dn, err := n.DisplayName()
+		if err != nil {
+			return err
+		}
+		if name := dn[n.DisplayNamePrefixLength():]; t.text != name {
+			return t.errorf("type annotation %s does not match %s", t.text, name)
+		}
+	}
+	if _, err := dec.expect(tokLParen); err != nil {
+		return err
+	}
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return err
+	}
+	if dec.peek().kind == tokRParen {
+		dec.next()
+		return nil
+	}
+	var unionSet *token
+	set := make(map[uint16]token)
+	for {
+		name, err := dec.expect(tokIdent)
+		if err != nil {
+			return err
+		}
+		f, ok := findField(fields, name.text)
+		if !ok {
+			dn, _ := n.DisplayName()
+			return name.errorf("%s has no field %q", dn, name.text)
+		}
+		if prev, dup := set[f.CodeOrder()]; dup {
+			return name.errorf("field %s already set at line %d, column %d",
+				name.text, prev.line, prev.col)
+		}
+		set[f.CodeOrder()] = name
+		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+			if unionSet != nil {
+				return name.errorf("union member %s conflicts with %s at line %d, column %d",
+					name.text, unionSet.text, unionSet.line, unionSet.col)
+			}
+			unionSet = &name
+			s.SetUint16(capnp.DataOffset(n.StructNode().DiscriminantOffset()*2), dv)
+		}
+		if _, err := dec.expect(tokEquals); err != nil {
+			return err
+		}
+		if err := dec.parseField(s, f); err != nil {
+			return err
+		}
+		t := dec.next()
+		switch t.kind {
+		case tokComma:
+			if dec.peek().kind == tokRParen {
+				dec.next()
+				return nil
+			}
+		case tokRParen:
+			return nil
+		default:
+			return t.errorf("expected ',' or ')', found %v", t)
+		}
+	}
+}
+
+func findField(fields schema.Field_List, name string) (schema.Field, bool) {
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.At(i)
+		if n, _ := f.Name(); n == name {
+			return f, true
+		}
+	}
+	return schema.Field{}, false
+}
+
+func (dec *Decoder) parseField(s capnp.Struct, f schema.Field) error {
+	if f.Which() == schema.Field_Which_group {
+		n, err := dec.findStruct(f.Group().TypeId())
+		if err != nil {
+			return err
+		}
+		return dec.parseStruct(n, s)
+	}
+	typ, err := f.Slot().Type()
+	if err != nil {
+		return err
+	}
+	dv, err := f.Slot().DefaultValue()
+	if err != nil {
+		return err
+	}
+	if dv.IsValid() && int(typ.Which()) != int(dv.Which()) {
+		name, _ := f.Name()
+		return fmt.Errorf("unmarshal field %s: default value is a %v, want %v", name, dv.Which(), typ.Which())
+	}
+	off := f.Slot().Offset()
+	switch typ.Which() {
+	case schema.Type_Which_void:
+		return dec.parseVoid()
+	case schema.Type_Which_bool:
+		v, err := dec.parseBool()
+		if err != nil {
+			return err
+		}
+		s.SetBit(capnp.BitOffset(off), v != dv.Bool())
+	case schema.Type_Which_int8:
+		v, err := dec.parseInt(8)
+		if err != nil {
+			return err
+		}
+		s.SetUint8(capnp.DataOffset(off), uint8(v)^uint8(dv.Int8()))
+	case schema.Type_Which_int16:
+		v, err := dec.parseInt(16)
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), uint16(v)^uint16(dv.Int16()))
+	case schema.Type_Which_int32:
+		v, err := dec.parseInt(32)
+		if err != nil {
+			return err
+		}
+		s.SetUint32(capnp.DataOffset(off*4), uint32(v)^uint32(dv.Int32()))
+	case schema.Type_Which_int64:
+		v, err := dec.parseInt(64)
+		if err != nil {
+			return err
+		}
+		s.SetUint64(capnp.DataOffset(off*8), uint64(v)^uint64(dv.Int64()))
+	case schema.Type_Which_uint8:
+		v, err := dec.parseUint(8)
+		if err != nil {
+			return err
+		}
+		s.SetUint8(capnp.DataOffset(off), uint8(v)^dv.Uint8())
+	case schema.Type_Which_uint16:
+		v, err := dec.parseUint(16)
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), uint16(v)^dv.Uint16())
+	case schema.Type_Which_uint32:
+		v, err := dec.parseUint(32)
+		if err != nil {
+			return err
+		}
+		s.SetUint32(capnp.DataOffset(off*4), uint32(v)^dv.Uint32())
+	case schema.Type_Which_uint64:
+		v, err := dec.parseUint(64)
+		if err != nil {
+			return err
+		}
+		s.SetUint64(capnp.DataOffset(off*8), v^dv.Uint64())
+	case schema.Type_Which_float32:
+		v, err := dec.parseFloat(32)
+		if err != nil {
+			return err
+		}
+		s.SetUint32(capnp.DataOffset(off*4), math.Float32bits(float32(v))^math.Float32bits(dv.Float32()))
+	case schema.Type_Which_float64:
+		v, err := dec.parseFloat(64)
+		if err != nil {
+			return err
+		}
+		s.SetUint64(capnp.DataOffset(off*8), math.Float64bits(v)^math.Float64bits(dv.Float64()))
+	case schema.Type_Which_enum:
+		v, err := dec.parseEnum(typ.Enum().TypeId())
+		if err != nil {
+			return err
+		}
+		s.SetUint16(capnp.DataOffset(off*2), v^dv.Enum())
+	default:
+		p, err := dec.parsePtr(s.Segment(), typ)
+		if err != nil {
+			return err
+		}
+		return s.SetPtr(uint16(off), p)
+	}
+	return nil
+}
+
+// parsePtr parses a value of a pointer type and allocates it in seg.
+func (dec *Decoder) parsePtr(seg *capnp.Segment, typ schema.Type) (capnp.Ptr, error) {
+	if dec.depth >= maxDepth {
+		t := dec.peek()
+		return capnp.Ptr{}, t.errorf("value nested more than %d levels deep", maxDepth)
+	}
+	dec.depth++
+	defer func() { dec.depth-- }()
+	switch typ.Which() {
+	case schema.Type_Which_text:
+		t, err := dec.expect(tokString)
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		v, err := capnp.NewTextFromBytes(seg, t.val)
+		return v.ToPtr(), err
+	case schema.Type_Which_data:
+		t := dec.next()
+		if t.kind != tokString && t.kind != tokHexString {
+			return capnp.Ptr{}, t.errorf("expected string, found %v", t)
+		}
+		v, err := capnp.NewData(seg, t.val)
+		return v.ToPtr(), err
+	case schema.Type_Which_structType:
+		n, err := dec.findStruct(typ.StructType().TypeId())
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		s, err := capnp.NewStruct(seg, structSize(n))
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		if err := dec.parseStruct(n, s); err != nil {
+			return capnp.Ptr{}, err
+		}
+		return s.ToPtr(), nil
+	case schema.Type_Which_list:
+		elem, err := typ.List().ElementType()
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		l, err := dec.parseList(seg, elem)
+		return l.ToPtr(), err
+	case schema.Type_Which_interface:
+		t := dec.next()
+		if t.kind != tokIdent || t.text != "null" {
+			return capnp.Ptr{}, t.errorf("cannot unmarshal capability from text")
+		}
+		return capnp.Ptr{}, nil
+	case schema.Type_Which_anyPointer:
+		t := dec.peek()
+		return capnp.Ptr{}, t.errorf("cannot unmarshal AnyPointer from text")
+	default:
+		return capnp.Ptr{}, fmt.Errorf("unknown field type %v", typ.Which())
+	}
+}
+
+// parseList parses a bracketed list whose elements have type elem.
+func (dec *Decoder) parseList(seg *capnp.Segment, elem schema.Type) (capnp.List, error) {
+	if _, err := dec.expect(tokLBracket); err != nil {
+		return capnp.List{}, err
+	}
+	n, err := dec.countElems()
+	if err != nil {
+		return capnp.List{}, err
+	}
+	var l capnp.List
+	var set func(i int) error
+	switch elem.Which() {
+	case schema.Type_Which_void:
+		l = capnp.List(capnp.NewVoidList(seg, n))
+		set = func(int) error { return dec.parseVoid() }
+	case schema.Type_Which_bool:
+		bl, err := capnp.NewBitList(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(bl)
+		set = func(i int) error {
+			v, err := dec.parseBool()
+			bl.Set(i, v)
+			return err
+		}
+	case schema.Type_Which_int8:
+		il, err := capnp.NewInt8List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(il)
+		set = func(i int) error {
+			v, err := dec.parseInt(8)
+			il.Set(i, int8(v))
+			return err
+		}
+	case schema.Type_Which_int16:
+		il, err := capnp.NewInt16List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(il)
+		set = func(i int) error {
+			v, err := dec.parseInt(16)
+			il.Set(i, int16(v))
+			return err
+		}
+	case schema.Type_Which_int32:
+		il, err := capnp.NewInt32List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(il)
+		set = func(i int) error {
+			v, err := dec.parseInt(32)
+			il.Set(i, int32(v))
+			return err
+		}
+	case schema.Type_Which_int64:
+		il, err := capnp.NewInt64List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(il)
+		set = func(i int) error {
+			v, err := dec.parseInt(64)
+			il.Set(i, v)
+			return err
+		}
+	case schema.Type_Which_uint8:
+		ul, err := capnp.NewUInt8List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(ul)
+		set = func(i int) error {
+			v, err := dec.parseUint(8)
+			ul.Set(i, uint8(v))
+			return err
+		}
+	case schema.Type_Which_uint16:
+		ul, err := capnp.NewUInt16List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(ul)
+		set = func(i int) error {
+			v, err := dec.parseUint(16)
+			ul.Set(i, uint16(v))
+			return err
+		}
+	case schema.Type_Which_uint32:
+		ul, err := capnp.NewUInt32List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(ul)
+		set = func(i int) error {
+			v, err := dec.parseUint(32)
+			ul.Set(i, uint32(v))
+			return err
+		}
+	case schema.Type_Which_uint64:
+		ul, err := capnp.NewUInt64List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(ul)
+		set = func(i int) error {
+			v, err := dec.parseUint(64)
+			ul.Set(i, v)
+			return err
+		}
+	case schema.Type_Which_float32:
+		fl, err := capnp.NewFloat32List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(fl)
+		set = func(i int) error {
+			v, err := dec.parseFloat(32)
+			fl.Set(i, float32(v))
+			return err
+		}
+	case schema.Type_Which_float64:
+		fl, err := capnp.NewFloat64List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(fl)
+		set = func(i int) error {
+			v, err := dec.parseFloat(64)
+			fl.Set(i, v)
+			return err
+		}
+	case schema.Type_Which_enum:
+		el, err := capnp.NewUInt16List(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(el)
+		set = func(i int) error {
+			v, err := dec.parseEnum(elem.Enum().TypeId())
+			el.Set(i, v)
+			return err
+		}
+	case schema.Type_Which_structType:
+		sn, err := dec.findStruct(elem.StructType().TypeId())
+		if err != nil {
+			return capnp.List{}, err
+		}
+		if l, err = capnp.NewCompositeList(seg, structSize(sn), n); err != nil {
+			return capnp.List{}, err
+		}
+		set = func(i int) error {
+			return dec.parseStruct(sn, l.Struct(i))
+		}
+	default:
+		pl, err := capnp.NewPointerList(seg, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		l = capnp.List(pl)
+		set = func(i int) error {
+			p, err := dec.parsePtr(seg, elem)
+			if err != nil {
+				return err
+			}
+			return pl.Set(i, p)
+		}
+	}
+	for i := 0; i < int(n); i++ {
+		if err := set(i); err != nil {
+			return capnp.List{}, err
+		}
+		t := dec.next()
+		if t.kind == tokRBracket && i == int(n)-1 {
+			return l, nil
+		}
+		if t.kind != tokComma {
+			return capnp.List{}, t.errorf("expected ',' or ']', found %v", t)
+		}
+	}
+	if _, err := dec.expect(tokRBracket); err != nil {
+		return capnp.List{}, err
+	}
+	return l, nil
+}
+
+// countElems returns the number of comma-separated elements between the
+// current position and the matching ']', without consuming any tokens.
+func (dec *Decoder) countElems() (int32, error) {
+	depth := 0
+	n := int32(0)
+	empty := true
+	for i := dec.pos; ; i++ {
+		t := dec.toks[i]
+		switch t.kind {
+		case tokEOF:
+			return 0, t.errorf("unexpected end of input in list")
+		case tokLParen, tokLBracket:
+			depth++
+		case tokRParen:
+			if depth == 0 {
+				return 0, t.errorf("unexpected ')' in list")
+			}
+			depth--
+		case tokRBracket:
+			if depth == 0 {
+				if !empty {
+					n++
+				}
+				return n, nil
+			}
+			depth--
+		case tokComma:
+			if depth == 0 {
+				if empty {
+					return 0, t.errorf("unexpected ','")
+				}
+				n++
+				empty = true
+				continue
+			}
+		}
+		empty = false
+	}
+}
+
+func (dec *Decoder) parseVoid() error {
+	t := dec.next()
+	if t.kind != tokIdent || t.text != "void" {
+		return t.errorf("expected void, found %v", t)
+	}
+	return nil
+}
+
+func (dec *Decoder) parseBool() (bool, error) {
+	t := dec.next()
+	if t.kind == tokIdent {
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, t.errorf("expected true or false, found %v", t)
+}
+
+func (dec *Decoder) parseInt(bits int) (int64, error) {
+	t, err := dec.expect(tokNumber)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(t.text, 0, bits)
+	if err != nil {
+		return 0, t.errorf("%s is not a valid %d-bit integer", t.text, bits)
+	}
+	return v, nil
+}
+
+func (dec *Decoder) parseUint(bits int) (uint64, error) {
+	t, err := dec.expect(tokNumber)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(t.text, 0, bits)
+	if err != nil {
+		return 0, t.errorf("%s is not a valid unsigned %d-bit integer", t.text, bits)
+	}
+	return v, nil
+}
+
+func (dec *Decoder) parseFloat(bits int) (float64, error) {
+	t := dec.next()
+	if t.kind != tokNumber && t.kind != tokIdent {
+		return 0, t.errorf("expected number, found %v", t)
+	}
+	// Accept integer literals in any base, as well as the inf and nan
+	// spellings that strconv understands.
+	if v, err := strconv.ParseInt(t.text, 0, 64); err == nil {
+		return float64(v), nil
+	}
+	v, err := strconv.ParseFloat(t.text, bits)
+	if err != nil {
+		return 0, t.errorf("%s is not a valid number", t.text)
+	}
+	return v, nil
+}
+
+func (dec *Decoder) parseEnum(typ uint64) (uint16, error) {
+	t := dec.next()
+	if t.kind == tokNumber {
+		v, err := strconv.ParseUint(t.text, 0, 16)
+		if err != nil {
+			return 0, t.errorf("%s is not a valid enum value", t.text)
+		}
+		return uint16(v), nil
+	}
+	if t.kind != tokIdent {
+		return 0, t.errorf("expected enumerant, found %v", t)
+	}
+	n, err := dec.nodes.Find(typ)
+	if err != nil {
+		return 0, err
+	}
+	if n.Which() != schema.Node_Which_enum {
+		return 0, fmt.Errorf("unmarshaling enum of type @%#x: type is not an enum", typ)
+	}
+	enums, err := n.Enum().Enumerants()
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < enums.Len(); i++ {
+		if name, _ := enums.At(i).Name(); name == t.text {
+			return uint16(i), nil
+		}
+	}
+	dn, _ := n.DisplayName()
+	return 0, t.errorf("%s has no enumerant %q", dn, t.text)
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokHexString
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+	tokEquals
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of input"
+	case tokIdent:
+		return "identifier"
+	case tokNumber:
+		return "number"
+	case tokString:
+		return "string"
+	case tokHexString:
+		return "hex string"
+	case tokLParen:
+		return "'('"
+	case tokRParen:
+		return "')'"
+	case tokLBracket:
+		return "'['"
+	case tokRBracket:
+		return "']'"
+	case tokComma:
+		return "','"
+	case tokEquals:
+		return "'='"
+	default:
+		return "token(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+type token struct {
+	kind      tokenKind
+	text      string // source text of identifiers and numbers
+	val       []byte // value of strings
+	line, col int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokIdent, tokNumber:
+		return strconv.Quote(t.text)
+	default:
+		return t.kind.String()
+	}
+}
+
+func (t token) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Line: t.line, Column: t.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// lex splits src into tokens.  Comments run from '#' to the end of the
+// line, as in schema files.
+func lex(src []byte) ([]token, error) {
+	var toks []token
+	line, lineStart := 1, 0
+	for i := 0; i < len(src); {
+		c := src[i]
+		t := token{line: line, col: i - lineStart + 1}
+		switch {
+		case c == '\n':
+			i++
+			line, lineStart = line+1, i
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			continue
+		case c == '(':
+			t.kind = tokLParen
+			i++
+		case c == ')':
+			t.kind = tokRParen
+			i++
+		case c == '[':
+			t.kind = tokLBracket
+			i++
+		case c == ']':
+			t.kind = tokRBracket
+			i++
+		case c == ',':
+			t.kind = tokComma
+			i++
+		case c == '=':
+			t.kind = tokEquals
+			i++
+		case c == '"':
+			val, n, err := unquote(src[i:], t)
+			if err != nil {
+				return nil, err
+			}
+			t.kind, t.val = tokString, val
+			i += n
+		case c == '0' && i+2 < len(src) && src[i+1] == 'x' && src[i+2] == '"':
+			val, n, err := unquote(src[i+2:], t)
+			if err != nil {
+				return nil, err
+			}
+			t.kind = tokHexString
+			if t.val, err = hex.DecodeString(stripSpace(val)); err != nil {
+				return nil, t.errorf("invalid hex string")
+			}
+			i += 2 + n
+		case isDigit(c) || c == '-' || c == '+':
+			j := i + 1
+			for j < len(src) && isNumberByte(src[j], src[j-1], src[i:j]) {
+				j++
+			}
+			t.kind, t.text = tokNumber, string(src[i:j])
+			if len(t.text) > 1 && !isDigit(t.text[1]) && t.text[1] != '.' && !isDigit(c) {
+				// A signed identifier like -inf or +Inf.
+				t.kind = tokIdent
+			} else if len(t.text) == 1 && !isDigit(c) {
+				return nil, t.errorf("unexpected %q", c)
+			}
+			i = j
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(src) && (isIdentStart(src[j]) || isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			t.kind, t.text = tokIdent, string(src[i:j])
+			i = j
+		default:
+			return nil, t.errorf("unexpected %q", c)
+		}
+		toks = append(toks, t)
+	}
+	toks = append(toks, token{kind: tokEOF, line: line, col: len(src) - lineStart + 1})
+	return toks, nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
+
+// isNumberByte reports whether c continues a number literal whose text
+// so far is lit and whose last byte is prev.
+func isNumberByte(c, prev byte, lit []byte) bool {
+	if isDigit(c) || isIdentStart(c) || c == '.' {
+		return true
+	}
+	isHex := len(lit) >= 2 && (lit[1] == 'x' || len(lit) >= 3 && lit[2] == 'x')
+	return (c == '+' || c == '-') && (prev == 'e' || prev == 'E') && !isHex
+}
+
+// unquote decodes the string literal at the start of src and returns its
+// value and length in bytes.
+func unquote(src []byte, t token) (val []byte, n int, err error) {
+	for i := 1; i < len(src); i++ {
+		c := src[i]
+		switch c {
+		case '"':
+			return val, i + 1, nil
+		case '\n':
+			return nil, 0, t.errorf("unterminated string")
+		case '\\':
+			i++
+			if i >= len(src) {
+				return nil, 0, t.errorf("unterminated string")
+			}
+			switch e := src[i]; e {
+			case 'a':
+				val = append(val, '\a')
+			case 'b':
+				val = append(val, '\b')
+			case 'f':
+				val = append(val, '\f')
+			case 'n':
+				val = append(val, '\n')
+			case 'r':
+				val = append(val, '\r')
+			case 't':
+				val = append(val, '\t')
+			case 'v':
+				val = append(val, '\v')
+			case '\'', '"', '\\', '?':
+				val = append(val, e)
+			case 'x':
+				if i+2 >= len(src) {
+					return nil, 0, t.errorf("invalid \\x escape in string")
+				}
+				b, err := strconv.ParseUint(string(src[i+1:i+3]), 16, 8)
+				if err != nil {
+					return nil, 0, t.errorf("invalid \\x escape in string")
+				}
+				val = append(val, byte(b))
+				i += 2
+			default:
+				if e < '0' || e > '7' {
+					return nil, 0, t.errorf("unknown escape \\%c in string", e)
+				}
+				j := i
+				for j < len(src) && j < i+3 && '0' <= src[j] && src[j] <= '7' {
+					j++
+				}
+				b, err := strconv.ParseUint(string(src[i:j]), 8, 8)
+				if err != nil {
+					return nil, 0, t.errorf("invalid octal escape in string")
+				}
+				val = append(val, byte(b))
+				i = j - 1
+			}
+		default:
+			val = append(val, c)
+		}
+	}
+	return nil, 0, t.errorf("unterminated string")
+}
+
+func stripSpace(b []byte) string {
+	out := make([]byte, 0, len(b))
+	for _, c := range b {
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+			out = append(out, c)
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,286 @@
+package text
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/schema"
+	"capnproto.org/go/capnp/v3/schemas"
+)
+
+const (
+	keyValueID = 0x8df8bc5abdc060a6
+	valueID    = 0xd3602730c572a43b
+)
+
+func testRegistry(t *testing.T) *schemas.Registry {
+	t.Helper()
+	data, err := readTestFile("txt.capnp.out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := new(schemas.Registry)
+	err = reg.Register(&schemas.Schema{
+		Bytes: data,
+		Nodes: []uint64{
+			keyValueID,
+			valueID,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Adding to registry: %v", err)
+	}
+	return reg
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		typeID uint64
+		text   string
+	}{
+		{keyValueID, `(key = "42", value = (int32 = -123))`},
+		{keyValueID, `(key = "float", value = (float64 = 3.14))`},
+		{keyValueID, `(key = "bool", value = (bool = false))`},
+		{valueID, `(map = [(key = "foo", value = (void = void)), (key = "bar", value = (void = void))])`},
+		{valueID, `(map = [])`},
+		{valueID, `(data = "Hi\xde\xad\xbe\xef\xca\xfe")`},
+		{valueID, `(voidList = [void, void])`},
+		{valueID, `(boolList = [true, false, true, false])`},
+		{valueID, `(int8List = [1, -2, 3])`},
+		{valueID, `(int64List = [1, -2, 3])`},
+		{valueID, `(uint8List = [255, 0, 1])`},
+		{valueID, `(uint64List = [1, 2, 3])`},
+		{valueID, `(float32List = [0.5, 3.14, -2])`},
+		{valueID, `(float64List = [1e+21, +Inf, -Inf, NaN])`},
+		{valueID, `(textList = ["foo", "bar", "baz"])`},
+		{valueID, `(dataList = ["\xde\xad\xbe\xef", "\xca\xfe"])`},
+		{valueID, `(cheese = gouda)`},
+		{valueID, `(cheeseList = [gouda, cheddar])`},
+		{valueID, `(matrix = [[1, 2, 3], [4, 5, 6]])`},
+		{valueID, `(text = "tab\there \"quoted\" \\")`},
+		{valueID, `(int64 = -9223372036854775808)`},
+		{valueID, `(uint64 = 18446744073709551615)`},
+		{keyValueID, `(key = "", value = (void = void))`},
+	}
+
+	reg := testRegistry(t)
+	for _, test := range tests {
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		dec := NewDecoder(strings.NewReader(test.text))
+		dec.UseRegistry(reg)
+		s, err := dec.Decode(test.typeID, seg)
+		if err != nil {
+			t.Errorf("Decode(%#x, %q): %v", test.typeID, test.text, err)
+			continue
+		}
+		buf := new(bytes.Buffer)
+		enc := NewEncoder(buf)
+		enc.UseRegistry(reg)
+		if err := enc.Encode(test.typeID, s); err != nil {
+			t.Errorf("Encode(%#x, Decode(%q)): %v", test.typeID, test.text, err)
+			continue
+		}
+		if got := buf.String(); got != test.text {
+			t.Errorf("Encode(%#x, Decode(%q)) = %q", test.typeID, test.text, got)
+		}
+	}
+}
+
+func TestDecodeSchemaSyntax(t *testing.T) {
+	// Values as they are written in txt.capnp.
+	tests := []struct {
+		text string
+		want string
+	}{
+		{`(data = 0x"4869 dead beef cafe")`, `(data = "Hi\xde\xad\xbe\xef\xca\xfe")`},
+		{`(float32List = [0.5, 3.14, -2.0])`, `(float32List = [0.5, 3.14, -2])`},
+		{
+			"(map = [\n  (key = \"foo\", value = (void = void)),  # first\n  (key = \"bar\", value = (void = void)),\n])",
+			`(map = [(key = "foo", value = (void = void)), (key = "bar", value = (void = void))])`,
+		},
+		{`(cheeseList = [1, 0])`, `(cheeseList = [gouda, cheddar])`},
+		{`(uint16 = 0x1f)`, `(uint16 = 31)`},
+		{`(text = "\101\x42\?")`, `(text = "AB?")`},
+		{`(float64 = 5)`, `(float64 = 5)`},
+		{`(float64 = -inf)`, `(float64 = -Inf)`},
+	}
+
+	reg := testRegistry(t)
+	for _, test := range tests {
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		dec := NewDecoder(strings.NewReader(test.text))
+		dec.UseRegistry(reg)
+		s, err := dec.Decode(valueID, seg)
+		if err != nil {
+			t.Errorf("Decode(%q): %v", test.text, err)
+			continue
+		}
+		buf := new(bytes.Buffer)
+		enc := NewEncoder(buf)
+		enc.UseRegistry(reg)
+		if err := enc.Encode(valueID, s); err != nil {
+			t.Errorf("Encode(Decode(%q)): %v", test.text, err)
+			continue
+		}
+		if got := buf.String(); got != test.want {
+			t.Errorf("Encode(Decode(%q)) = %q; want %q", test.text, got, test.want)
+		}
+	}
+}
+
+func TestDecodeList(t *testing.T) {
+	const text = `[(key = "foo", value = (void = void)), (key = "bar", value = (void = void))]`
+	reg := testRegistry(t)
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	dec := NewDecoder(strings.NewReader(text))
+	dec.UseRegistry(reg)
+	l, err := dec.DecodeList(keyValueID, seg)
+	if err != nil {
+		t.Fatalf("DecodeList: %v", err)
+	}
+	buf := new(bytes.Buffer)
+	enc := NewEncoder(buf)
+	enc.UseRegistry(reg)
+	if err := enc.EncodeList(keyValueID, l); err != nil {
+		t.Fatalf("EncodeList: %v", err)
+	}
+	if got := buf.String(); got != text {
+		t.Errorf("EncodeList(DecodeList(%q)) = %q", text, got)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		text      string
+		line, col int
+	}{
+		{`(int8 = 300)`, 1, 9},
+		{`(uint8 = -1)`, 1, 10},
+		{`(bool = 1)`, 1, 9},
+		{"(\n  nope = void)", 2, 3},
+		{`(int8 = 1, bool = true)`, 1, 12},
+		{`(cheese = brie)`, 1, 11},
+		{`(text = "abc`, 1, 9},
+		{`(text = "a\q")`, 1, 9},
+		{"(map = [(key = \"a\"),\n  , ])", 2, 3},
+		{`(void = void`, 1, 13},
+		{`(void = void) extra`, 1, 15},
+		{`(voidList = [void void])`, 1, 19},
+		{`(data = 0x"abc")`, 1, 9},
+		{`(text = $)`, 1, 9},
+		{`(map = [(key = "a", key = "b")])`, 1, 21},
+	}
+
+	reg := testRegistry(t)
+	for _, test := range tests {
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		dec := NewDecoder(strings.NewReader(test.text))
+		dec.UseRegistry(reg)
+		_, err := dec.Decode(valueID, seg)
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("Decode(%q) error = %v; want *SyntaxError", test.text, err)
+			continue
+		}
+		if se.Line != test.line || se.Column != test.col {
+			t.Errorf("Decode(%q) error at line %d, column %d; want line %d, column %d (%v)",
+				test.text, se.Line, se.Column, test.line, test.col, se)
+		}
+	}
+}
+
+func TestDecodeDepthLimit(t *testing.T) {
+	// nest returns a Value with n maps nested inside each other.  Each
+	// map adds two levels: the list and the Value in its element.
+	nest := func(n int) string {
+		return strings.Repeat(`(map = [(key = "k", value = `, n) + `(void = void)` +
+			strings.Repeat(`)])`, n)
+	}
+
+	reg := testRegistry(t)
+	decode := func(text string) error {
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		dec := NewDecoder(strings.NewReader(text))
+		dec.UseRegistry(reg)
+		_, err := dec.Decode(valueID, seg)
+		return err
+	}
+	if err := decode(nest(maxDepth / 2)); err != nil {
+		t.Errorf("Decode(%d levels): %v", maxDepth, err)
+	}
+	for _, n := range []int{maxDepth/2 + 1, 100000} {
+		err := decode(nest(n))
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("Decode(%d levels) error = %v; want *SyntaxError", 2*n, err)
+		}
+	}
+}
+
+func TestRoundTripConsts(t *testing.T) {
+	data, err := readTestFile("txt.capnp.out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		t.Fatal("Unmarshaling txt.capnp.out:", err)
+	}
+	req, err := schema.ReadRootCodeGeneratorRequest(msg)
+	if err != nil {
+		t.Fatal("Reading code generator request txt.capnp.out:", err)
+	}
+	nodes, err := req.Nodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reg := testRegistry(t)
+	for i := 0; i < nodes.Len(); i++ {
+		c := nodes.At(i)
+		if c.Which() != schema.Node_Which_const {
+			continue
+		}
+		dn, _ := c.DisplayName()
+		typ, _ := c.Const().Type()
+		if typ.Which() != schema.Type_Which_structType {
+			continue
+		}
+		tid := typ.StructType().TypeId()
+		v, _ := c.Const().Value()
+		sv, err := v.StructValue()
+		if err != nil {
+			t.Errorf("%s: const.value.struct: %v", dn, err)
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		enc := NewEncoder(buf)
+		enc.UseRegistry(reg)
+		if err := enc.Encode(tid, sv.Struct()); err != nil {
+			t.Errorf("%s: Encode: %v", dn, err)
+			continue
+		}
+		want := buf.String()
+
+		_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		dec := NewDecoder(strings.NewReader(want))
+		dec.UseRegistry(reg)
+		s, err := dec.Decode(tid, seg)
+		if err != nil {
+			t.Errorf("%s: Decode(%q): %v", dn, want, err)
+			continue
+		}
+		buf.Reset()
+		if err := enc.Encode(tid, s); err != nil {
+			t.Errorf("%s: Encode(Decode(%q)): %v", dn, want, err)
+			continue
+		}
+		if got := buf.String(); got != want {
+			t.Errorf("%s: Encode(Decode(%q)) = %q", dn, want, got)
+		}
+	}
+}
@@ -105,9 +105,9 @@ type Writer_Server interface {
 }
 
 // Writer_NewServer creates a new Server from an implementation of Writer_Server.
-func Writer_NewServer(s Writer_Server) *server.Server {
+func Writer_NewServer(s Writer_Server, opts ...server.Option) *server.Server {
 	c, _ := s.(server.Shutdowner)
-	return server.New(Writer_Methods(nil, s), s, c)
+	return server.New(Writer_Methods(nil, s), s, c, opts...)
 }
 
 // Writer_ServerToClient creates a new Client from an implementation of Writer_Server.
@@ -220,6 +220,18 @@ func (s Writer_write_Params) HasData() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownData detaches the data field from s without
+// copying it.  The field is left null.
+func (s Writer_write_Params) DisownData() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptData sets the data field to an orphan from
+// s's message without copying it.
+func (s Writer_write_Params) AdoptData(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Writer_write_Params) SetData(v []byte) error {
 	return capnp.Struct(s).SetData(0, v)
 }
@@ -835,6 +835,82 @@ func (blt bitListTest) makeMessage() (*capnp.Message, error) {
 	return msg, nil
 }
 
+func TestGeneratedOrphan(t *testing.T) {
+	t.Parallel()
+
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err := src.NewPlanebase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pb.SetName("Spirit"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Disowning a union member that isn't set is an error, and leaves
+	// the set member alone.
+	if _, err := src.DisownText(); err == nil {
+		t.Error("DisownText of Z with planebase set succeeded")
+	}
+	if w := src.Which(); w != air.Z_Which_planebase {
+		t.Fatalf("after DisownText, Which() = %v; want planebase", w)
+	}
+
+	o, err := src.DisownPlanebase()
+	if err != nil {
+		t.Fatal("DisownPlanebase:", err)
+	}
+	if src.HasPlanebase() {
+		t.Error("HasPlanebase() = true after DisownPlanebase")
+	}
+
+	// Adopting sets the union tag.
+	dst, err := air.NewZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst.SetI32(5)
+	if err := dst.AdoptPlanebase(o); err != nil {
+		t.Fatal("AdoptPlanebase:", err)
+	}
+	if w := dst.Which(); w != air.Z_Which_planebase {
+		t.Errorf("after AdoptPlanebase, Which() = %v; want planebase", w)
+	}
+	got, err := dst.Planebase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := got.Name(); name != "Spirit" {
+		t.Errorf("adopted planebase name = %q; want \"Spirit\"", name)
+	}
+
+	// A field outside a union moves between structs.
+	o, err = got.DisownName()
+	if err != nil {
+		t.Fatal("DisownName:", err)
+	}
+	other, err := air.NewPlaneBase(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.AdoptName(o); err != nil {
+		t.Fatal("AdoptName:", err)
+	}
+	if got.HasName() {
+		t.Error("HasName() = true after DisownName")
+	}
+	if name, _ := other.Name(); name != "Spirit" {
+		t.Errorf("adopted name = %q; want \"Spirit\"", name)
+	}
+}
+
 func TestBitList(t *testing.T) {
 	t.Parallel()
 	for _, test := range bitListTests {
@@ -2183,6 +2259,26 @@ func TestFuzzedListOutOfBounds(t *testing.T) {
 	}
 }
 
+func TestSetterAllocLimit(t *testing.T) {
+	t.Parallel()
+	msg, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatal("NewMessage:", err)
+	}
+	msg.AllocLimit = 1024
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal("NewRootZ:", err)
+	}
+	err = z.SetBlob(make([]byte, 2048))
+	if !capnp.IsAllocLimit(err) {
+		t.Errorf("z.SetBlob(2048 bytes) = %v; want allocation limit error", err)
+	}
+	if _, err := z.NewF64vec(1024); !capnp.IsAllocLimit(err) {
+		t.Errorf("z.NewF64vec(1024) = _, %v; want allocation limit error", err)
+	}
+}
+
 func benchmarkGrowth(b *testing.B, newArena func() capnp.Arena) {
 	const (
 		fieldValue = "1234567" // carefully chosen to be word-padded
@@ -0,0 +1,146 @@
+package capnp
+
+import (
+	"context"
+	"sync"
+)
+
+// A ClientInterceptor is called in place of sending a call on a client
+// returned by InterceptClient, so that it can act on every outbound
+// call: tracing, retrying, setting deadlines and the like.  It calls
+// next to send the call on, possibly with a different ctx or s, and
+// may do work before and after.  To fail the call without sending it,
+// it returns an ErrorAnswer instead of calling next.
+//
+// The answer returned by next supports pipelining as usual, so an
+// interceptor that passes it back to its caller keeps pipelined calls
+// working.  Those pipelined calls are made on the answer's
+// capabilities, not on the intercepted client, so they don't pass
+// through the interceptors.
+type ClientInterceptor func(ctx context.Context, s Send, next func(context.Context, Send) (*Answer, ReleaseFunc)) (*Answer, ReleaseFunc)
+
+// InterceptClient returns a client that sends calls to c through the
+// interceptors in.  Each call passes through the interceptors in
+// order, so the first interceptor is the outermost.  InterceptClient
+// takes ownership of c: it is released once the returned client and
+// all its references are released.  If c is nil or has resolved to
+// null, InterceptClient returns c.
+//
+// The returned client has the same brand as c, so code that identifies
+// capabilities by brand, such as the RPC system deciding whether a
+// capability it is sending lives on the receiving vat, treats it as
+// c.  In particular, a capability sent back to the vat it came from is
+// called there directly, without passing through the interceptors.  If
+// c is a promise, then so is the returned client, and it resolves when
+// c does.
+func InterceptClient(c Client, in ...ClientInterceptor) Client {
+	if !c.IsValid() {
+		return c
+	}
+	h := &interceptHook{c: c, send: chainClientInterceptors(c, in)}
+	if !c.State().IsPromise {
+		return NewClient(h)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+	ic, p := NewPromisedClient(h)
+	go func() {
+		if err := c.Resolve(ctx); err != nil {
+			return
+		}
+		h.mu.Lock()
+		if h.shutdown {
+			h.mu.Unlock()
+			return
+		}
+		r := InterceptClient(c.AddRef(), in...)
+		h.mu.Unlock()
+		p.Fulfill(r)
+		r.Release()
+	}()
+	return ic
+}
+
+type sendFunc func(context.Context, Send) (*Answer, ReleaseFunc)
+
+// chainClientInterceptors returns a function that sends a call to c
+// through each of in in turn.
+func chainClientInterceptors(c Client, in []ClientInterceptor) sendFunc {
+	next := c.SendCall
+	for i := len(in) - 1; i >= 0; i-- {
+		f, inner := in[i], next
+		next = func(ctx context.Context, s Send) (*Answer, ReleaseFunc) {
+			return f(ctx, s, inner)
+		}
+	}
+	return next
+}
+
+// interceptHook is the ClientHook for a client returned by
+// InterceptClient.
+type interceptHook struct {
+	c    Client
+	send sendFunc
+
+	mu       sync.Mutex
+	cancel   context.CancelFunc // stops waiting for c to resolve; nil if c was resolved
+	shutdown bool
+}
+
+func (h *interceptHook) Send(ctx context.Context, s Send) (*Answer, ReleaseFunc) {
+	return h.send(ctx, s)
+}
+
+func (h *interceptHook) Recv(ctx context.Context, r Recv) PipelineCaller {
+	ans, finish := h.send(ctx, Send{
+		Method:   r.Method,
+		ArgsSize: r.Args.Size(),
+		PlaceArgs: func(s Struct) error {
+			err := s.CopyFrom(r.Args)
+			r.ReleaseArgs()
+			return err
+		},
+	})
+	r.ReleaseArgs()
+	select {
+	case <-ans.Done():
+		returnAnswer(r.Returner, ans, finish)
+		return nil
+	default:
+		go returnAnswer(r.Returner, ans, finish)
+		return ans
+	}
+}
+
+// returnAnswer copies the result of ans into ret once it resolves.
+func returnAnswer(ret Returner, ans *Answer, finish ReleaseFunc) {
+	defer finish()
+	defer ret.ReleaseResults()
+	result, err := ans.Struct()
+	if err == nil {
+		var recvResult Struct
+		recvResult, err = ret.AllocResults(result.Size())
+		if err == nil {
+			err = recvResult.CopyFrom(result)
+		}
+	}
+	ret.PrepareReturn(err)
+	ret.Return()
+}
+
+func (h *interceptHook) Brand() Brand {
+	return h.c.State().Brand
+}
+
+// Shutdown releases c.  It may be called more than once if c was a
+// promise: once when the returned client runs out of references and
+// again when its promise is fulfilled.
+func (h *interceptHook) Shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cancel != nil {
+		h.cancel()
+	}
+	h.shutdown = true
+	h.c.Release()
+}
@@ -0,0 +1,121 @@
+package capnp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInterceptClient(t *testing.T) {
+	ctx := context.Background()
+	var log []string
+	record := func(name string) ClientInterceptor {
+		return func(ctx context.Context, s Send, next func(context.Context, Send) (*Answer, ReleaseFunc)) (*Answer, ReleaseFunc) {
+			log = append(log, name)
+			return next(ctx, s)
+		}
+	}
+	reject := func(ctx context.Context, s Send, next func(context.Context, Send) (*Answer, ReleaseFunc)) (*Answer, ReleaseFunc) {
+		if s.Method.MethodID == 1 {
+			return ErrorAnswer(s.Method, errors.New("rejected")), func() {}
+		}
+		return next(ctx, s)
+	}
+	h := &dummyHook{brand: Brand{Value: int(42)}}
+	c := InterceptClient(NewClient(h), record("a"), record("b"), reject)
+
+	if state := c.State(); state.Brand.Value != int(42) {
+		t.Errorf("c.State().Brand.Value = %#v; want 42", state.Brand.Value)
+	}
+	ans, finish := c.SendCall(ctx, Send{})
+	if _, err := ans.Struct(); err != nil {
+		t.Error("SendCall:", err)
+	}
+	finish()
+	if want := []string{"a", "b"}; !equalStrings(log, want) {
+		t.Errorf("interceptors called in order %q; want %q", log, want)
+	}
+	if h.calls != 1 {
+		t.Errorf("after SendCall, h.calls = %d; want 1", h.calls)
+	}
+
+	ret := new(dummyReturner)
+	c.RecvCall(ctx, Recv{
+		Args:        newEmptyStruct(),
+		ReleaseArgs: func() {},
+		Returner:    ret,
+	})
+	if !ret.returned || ret.err != nil {
+		t.Errorf("RecvCall returned = %t, err = %v; want true, <nil>", ret.returned, ret.err)
+	}
+	if len(log) != 4 || h.calls != 2 {
+		t.Errorf("after RecvCall, %d interceptor calls and h.calls = %d; want 4 and 2", len(log), h.calls)
+	}
+
+	ans, finish = c.SendCall(ctx, Send{Method: Method{MethodID: 1}})
+	if _, err := ans.Struct(); err == nil {
+		t.Error("rejected call succeeded")
+	}
+	finish()
+	if h.calls != 2 {
+		t.Errorf("after rejected call, h.calls = %d; want 2", h.calls)
+	}
+
+	c.Release()
+	if h.shutdowns != 1 {
+		t.Errorf("after Release, h.shutdowns = %d; want 1", h.shutdowns)
+	}
+}
+
+func TestInterceptClient_Promise(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	count := func(ctx context.Context, s Send, next func(context.Context, Send) (*Answer, ReleaseFunc)) (*Answer, ReleaseFunc) {
+		calls++
+		return next(ctx, s)
+	}
+	a := &dummyHook{brand: Brand{Value: int(111)}}
+	b := &dummyHook{brand: Brand{Value: int(222)}}
+	ca, pa := NewPromisedClient(a)
+	c := InterceptClient(ca, count)
+	defer c.Release()
+
+	if !c.State().IsPromise {
+		t.Error("before resolution, c.State().IsPromise = false; want true")
+	}
+	pa.Fulfill(NewClient(b))
+
+	rctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := c.Resolve(rctx); err != nil {
+		t.Fatal("Resolve:", err)
+	}
+	state := c.State()
+	if state.Brand.Value != int(222) {
+		t.Errorf("after resolution, c.State().Brand.Value = %#v; want 222", state.Brand.Value)
+	}
+	if state.IsPromise {
+		t.Error("after resolution, c.State().IsPromise = true; want false")
+	}
+	ans, finish := c.SendCall(ctx, Send{})
+	if _, err := ans.Struct(); err != nil {
+		t.Error("SendCall:", err)
+	}
+	finish()
+	if calls != 1 || b.calls != 1 {
+		t.Errorf("after resolution, %d interceptor calls and b.calls = %d; want 1 and 1", calls, b.calls)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -9,6 +9,7 @@ import (
 	schemas "capnproto.org/go/capnp/v3/schemas"
 	server "capnproto.org/go/capnp/v3/server"
 	context "context"
+	errors "errors"
 	fmt "fmt"
 	math "math"
 	strconv "strconv"
@@ -169,6 +170,18 @@ func (s Zdata) HasData() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownData detaches the data field from s without
+// copying it.  The field is left null.
+func (s Zdata) DisownData() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptData sets the data field to an orphan from
+// s's message without copying it.
+func (s Zdata) AdoptData(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Zdata) SetData(v []byte) error {
 	return capnp.Struct(s).SetData(0, v)
 }
@@ -315,6 +328,18 @@ func (s PlaneBase) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s PlaneBase) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s PlaneBase) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s PlaneBase) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -333,6 +358,18 @@ func (s PlaneBase) HasHomes() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownHomes detaches the homes field from s without
+// copying it.  The field is left null.
+func (s PlaneBase) DisownHomes() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptHomes sets the homes field to an orphan from
+// s's message without copying it.
+func (s PlaneBase) AdoptHomes(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s PlaneBase) SetHomes(v Airport_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -452,6 +489,18 @@ func (s B737) HasBase() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBase detaches the base field from s without
+// copying it.  The field is left null.
+func (s B737) DisownBase() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBase sets the base field to an orphan from
+// s's message without copying it.
+func (s B737) AdoptBase(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s B737) SetBase(v PlaneBase) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -543,6 +592,18 @@ func (s A320) HasBase() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBase detaches the base field from s without
+// copying it.  The field is left null.
+func (s A320) DisownBase() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBase sets the base field to an orphan from
+// s's message without copying it.
+func (s A320) AdoptBase(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s A320) SetBase(v PlaneBase) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -634,6 +695,18 @@ func (s F16) HasBase() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBase detaches the base field from s without
+// copying it.  The field is left null.
+func (s F16) DisownBase() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBase sets the base field to an orphan from
+// s's message without copying it.
+func (s F16) AdoptBase(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s F16) SetBase(v PlaneBase) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -725,6 +798,18 @@ func (s Regression) HasBase() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBase detaches the base field from s without
+// copying it.  The field is left null.
+func (s Regression) DisownBase() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBase sets the base field to an orphan from
+// s's message without copying it.
+func (s Regression) AdoptBase(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Regression) SetBase(v PlaneBase) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -757,6 +842,18 @@ func (s Regression) HasBeta() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownBeta detaches the beta field from s without
+// copying it.  The field is left null.
+func (s Regression) DisownBeta() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptBeta sets the beta field to an orphan from
+// s's message without copying it.
+func (s Regression) AdoptBeta(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Regression) SetBeta(v capnp.Float64List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -780,6 +877,18 @@ func (s Regression) HasPlanes() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
+// DisownPlanes detaches the planes field from s without
+// copying it.  The field is left null.
+func (s Regression) DisownPlanes() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptPlanes sets the planes field to an orphan from
+// s's message without copying it.
+func (s Regression) AdoptPlanes(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
 func (s Regression) SetPlanes(v Aircraft_List) error {
 	return capnp.Struct(s).SetPtr(2, v.ToPtr())
 }
@@ -925,6 +1034,23 @@ func (s Aircraft) HasB737() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownB737 detaches the b737 field from s without
+// copying it.  The field is left null.  It returns an error if
+// b737 is not the union member that is set.
+func (s Aircraft) DisownB737() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 1 {
+		return capnp.Orphan{}, errors.New("Which() != b737")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptB737 sets the b737 field to an orphan from
+// s's message without copying it.
+func (s Aircraft) AdoptB737(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 1)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Aircraft) SetB737(v B737) error {
 	capnp.Struct(s).SetUint16(0, 1)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -957,6 +1083,23 @@ func (s Aircraft) HasA320() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownA320 detaches the a320 field from s without
+// copying it.  The field is left null.  It returns an error if
+// a320 is not the union member that is set.
+func (s Aircraft) DisownA320() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 2 {
+		return capnp.Orphan{}, errors.New("Which() != a320")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptA320 sets the a320 field to an orphan from
+// s's message without copying it.
+func (s Aircraft) AdoptA320(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 2)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Aircraft) SetA320(v A320) error {
 	capnp.Struct(s).SetUint16(0, 2)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -989,6 +1132,23 @@ func (s Aircraft) HasF16() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownF16 detaches the f16 field from s without
+// copying it.  The field is left null.  It returns an error if
+// f16 is not the union member that is set.
+func (s Aircraft) DisownF16() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 3 {
+		return capnp.Orphan{}, errors.New("Which() != f16")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptF16 sets the f16 field to an orphan from
+// s's message without copying it.
+func (s Aircraft) AdoptF16(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 3)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Aircraft) SetF16(v F16) error {
 	capnp.Struct(s).SetUint16(0, 3)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -1263,6 +1423,23 @@ func (s Z) HasZz() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownZz detaches the zz field from s without
+// copying it.  The field is left null.  It returns an error if
+// zz is not the union member that is set.
+func (s Z) DisownZz() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 1 {
+		return capnp.Orphan{}, errors.New("Which() != zz")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptZz sets the zz field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptZz(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 1)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetZz(v Z) error {
 	capnp.Struct(s).SetUint16(0, 1)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -1427,6 +1604,23 @@ func (s Z) HasText() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownText detaches the text field from s without
+// copying it.  The field is left null.  It returns an error if
+// text is not the union member that is set.
+func (s Z) DisownText() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 13 {
+		return capnp.Orphan{}, errors.New("Which() != text")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptText sets the text field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptText(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 13)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) TextBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -1452,6 +1646,23 @@ func (s Z) HasBlob() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBlob detaches the blob field from s without
+// copying it.  The field is left null.  It returns an error if
+// blob is not the union member that is set.
+func (s Z) DisownBlob() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 14 {
+		return capnp.Orphan{}, errors.New("Which() != blob")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBlob sets the blob field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptBlob(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 14)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetBlob(v []byte) error {
 	capnp.Struct(s).SetUint16(0, 14)
 	return capnp.Struct(s).SetData(0, v)
@@ -1472,6 +1683,23 @@ func (s Z) HasF64vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownF64vec detaches the f64vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// f64vec is not the union member that is set.
+func (s Z) DisownF64vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 15 {
+		return capnp.Orphan{}, errors.New("Which() != f64vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptF64vec sets the f64vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptF64vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 15)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetF64vec(v capnp.Float64List) error {
 	capnp.Struct(s).SetUint16(0, 15)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1503,6 +1731,23 @@ func (s Z) HasF32vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownF32vec detaches the f32vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// f32vec is not the union member that is set.
+func (s Z) DisownF32vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 16 {
+		return capnp.Orphan{}, errors.New("Which() != f32vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptF32vec sets the f32vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptF32vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 16)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetF32vec(v capnp.Float32List) error {
 	capnp.Struct(s).SetUint16(0, 16)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1534,6 +1779,23 @@ func (s Z) HasI64vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownI64vec detaches the i64vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// i64vec is not the union member that is set.
+func (s Z) DisownI64vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 17 {
+		return capnp.Orphan{}, errors.New("Which() != i64vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptI64vec sets the i64vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptI64vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 17)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetI64vec(v capnp.Int64List) error {
 	capnp.Struct(s).SetUint16(0, 17)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1565,6 +1827,23 @@ func (s Z) HasI32vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownI32vec detaches the i32vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// i32vec is not the union member that is set.
+func (s Z) DisownI32vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 18 {
+		return capnp.Orphan{}, errors.New("Which() != i32vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptI32vec sets the i32vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptI32vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 18)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetI32vec(v capnp.Int32List) error {
 	capnp.Struct(s).SetUint16(0, 18)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1596,6 +1875,23 @@ func (s Z) HasI16vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownI16vec detaches the i16vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// i16vec is not the union member that is set.
+func (s Z) DisownI16vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 19 {
+		return capnp.Orphan{}, errors.New("Which() != i16vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptI16vec sets the i16vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptI16vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 19)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetI16vec(v capnp.Int16List) error {
 	capnp.Struct(s).SetUint16(0, 19)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1627,6 +1923,23 @@ func (s Z) HasI8vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownI8vec detaches the i8vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// i8vec is not the union member that is set.
+func (s Z) DisownI8vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 20 {
+		return capnp.Orphan{}, errors.New("Which() != i8vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptI8vec sets the i8vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptI8vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 20)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetI8vec(v capnp.Int8List) error {
 	capnp.Struct(s).SetUint16(0, 20)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1658,6 +1971,23 @@ func (s Z) HasU64vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownU64vec detaches the u64vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// u64vec is not the union member that is set.
+func (s Z) DisownU64vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 21 {
+		return capnp.Orphan{}, errors.New("Which() != u64vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptU64vec sets the u64vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptU64vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 21)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetU64vec(v capnp.UInt64List) error {
 	capnp.Struct(s).SetUint16(0, 21)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1689,6 +2019,23 @@ func (s Z) HasU32vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownU32vec detaches the u32vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// u32vec is not the union member that is set.
+func (s Z) DisownU32vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 22 {
+		return capnp.Orphan{}, errors.New("Which() != u32vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptU32vec sets the u32vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptU32vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 22)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetU32vec(v capnp.UInt32List) error {
 	capnp.Struct(s).SetUint16(0, 22)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1720,6 +2067,23 @@ func (s Z) HasU16vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownU16vec detaches the u16vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// u16vec is not the union member that is set.
+func (s Z) DisownU16vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 23 {
+		return capnp.Orphan{}, errors.New("Which() != u16vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptU16vec sets the u16vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptU16vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 23)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetU16vec(v capnp.UInt16List) error {
 	capnp.Struct(s).SetUint16(0, 23)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1751,6 +2115,23 @@ func (s Z) HasU8vec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownU8vec detaches the u8vec field from s without
+// copying it.  The field is left null.  It returns an error if
+// u8vec is not the union member that is set.
+func (s Z) DisownU8vec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 24 {
+		return capnp.Orphan{}, errors.New("Which() != u8vec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptU8vec sets the u8vec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptU8vec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 24)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetU8vec(v capnp.UInt8List) error {
 	capnp.Struct(s).SetUint16(0, 24)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1782,6 +2163,23 @@ func (s Z) HasBoolvec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBoolvec detaches the boolvec field from s without
+// copying it.  The field is left null.  It returns an error if
+// boolvec is not the union member that is set.
+func (s Z) DisownBoolvec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 39 {
+		return capnp.Orphan{}, errors.New("Which() != boolvec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBoolvec sets the boolvec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptBoolvec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 39)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetBoolvec(v capnp.BitList) error {
 	capnp.Struct(s).SetUint16(0, 39)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1813,6 +2211,23 @@ func (s Z) HasDatavec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownDatavec detaches the datavec field from s without
+// copying it.  The field is left null.  It returns an error if
+// datavec is not the union member that is set.
+func (s Z) DisownDatavec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 40 {
+		return capnp.Orphan{}, errors.New("Which() != datavec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptDatavec sets the datavec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptDatavec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 40)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetDatavec(v capnp.DataList) error {
 	capnp.Struct(s).SetUint16(0, 40)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1844,6 +2259,23 @@ func (s Z) HasTextvec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownTextvec detaches the textvec field from s without
+// copying it.  The field is left null.  It returns an error if
+// textvec is not the union member that is set.
+func (s Z) DisownTextvec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 41 {
+		return capnp.Orphan{}, errors.New("Which() != textvec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptTextvec sets the textvec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptTextvec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 41)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetTextvec(v capnp.TextList) error {
 	capnp.Struct(s).SetUint16(0, 41)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1875,6 +2307,23 @@ func (s Z) HasZvec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownZvec detaches the zvec field from s without
+// copying it.  The field is left null.  It returns an error if
+// zvec is not the union member that is set.
+func (s Z) DisownZvec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 25 {
+		return capnp.Orphan{}, errors.New("Which() != zvec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptZvec sets the zvec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptZvec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 25)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetZvec(v Z_List) error {
 	capnp.Struct(s).SetUint16(0, 25)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1906,6 +2355,23 @@ func (s Z) HasZvecvec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownZvecvec detaches the zvecvec field from s without
+// copying it.  The field is left null.  It returns an error if
+// zvecvec is not the union member that is set.
+func (s Z) DisownZvecvec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 26 {
+		return capnp.Orphan{}, errors.New("Which() != zvecvec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptZvecvec sets the zvecvec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptZvecvec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 26)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetZvecvec(v capnp.PointerList) error {
 	capnp.Struct(s).SetUint16(0, 26)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -1937,6 +2403,23 @@ func (s Z) HasZdate() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownZdate detaches the zdate field from s without
+// copying it.  The field is left null.  It returns an error if
+// zdate is not the union member that is set.
+func (s Z) DisownZdate() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 27 {
+		return capnp.Orphan{}, errors.New("Which() != zdate")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptZdate sets the zdate field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptZdate(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 27)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetZdate(v Zdate) error {
 	capnp.Struct(s).SetUint16(0, 27)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -1969,6 +2452,23 @@ func (s Z) HasZdata() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownZdata detaches the zdata field from s without
+// copying it.  The field is left null.  It returns an error if
+// zdata is not the union member that is set.
+func (s Z) DisownZdata() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 28 {
+		return capnp.Orphan{}, errors.New("Which() != zdata")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptZdata sets the zdata field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptZdata(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 28)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetZdata(v Zdata) error {
 	capnp.Struct(s).SetUint16(0, 28)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2001,6 +2501,23 @@ func (s Z) HasAircraftvec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAircraftvec detaches the aircraftvec field from s without
+// copying it.  The field is left null.  It returns an error if
+// aircraftvec is not the union member that is set.
+func (s Z) DisownAircraftvec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 29 {
+		return capnp.Orphan{}, errors.New("Which() != aircraftvec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAircraftvec sets the aircraftvec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptAircraftvec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 29)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetAircraftvec(v Aircraft_List) error {
 	capnp.Struct(s).SetUint16(0, 29)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -2032,6 +2549,23 @@ func (s Z) HasAircraft() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAircraft detaches the aircraft field from s without
+// copying it.  The field is left null.  It returns an error if
+// aircraft is not the union member that is set.
+func (s Z) DisownAircraft() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 30 {
+		return capnp.Orphan{}, errors.New("Which() != aircraft")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAircraft sets the aircraft field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptAircraft(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 30)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetAircraft(v Aircraft) error {
 	capnp.Struct(s).SetUint16(0, 30)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2064,6 +2598,23 @@ func (s Z) HasRegression() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownRegression detaches the regression field from s without
+// copying it.  The field is left null.  It returns an error if
+// regression is not the union member that is set.
+func (s Z) DisownRegression() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 31 {
+		return capnp.Orphan{}, errors.New("Which() != regression")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptRegression sets the regression field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptRegression(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 31)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetRegression(v Regression) error {
 	capnp.Struct(s).SetUint16(0, 31)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2096,6 +2647,23 @@ func (s Z) HasPlanebase() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownPlanebase detaches the planebase field from s without
+// copying it.  The field is left null.  It returns an error if
+// planebase is not the union member that is set.
+func (s Z) DisownPlanebase() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 32 {
+		return capnp.Orphan{}, errors.New("Which() != planebase")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptPlanebase sets the planebase field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptPlanebase(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 32)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetPlanebase(v PlaneBase) error {
 	capnp.Struct(s).SetUint16(0, 32)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2140,6 +2708,23 @@ func (s Z) HasB737() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownB737 detaches the b737 field from s without
+// copying it.  The field is left null.  It returns an error if
+// b737 is not the union member that is set.
+func (s Z) DisownB737() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 34 {
+		return capnp.Orphan{}, errors.New("Which() != b737")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptB737 sets the b737 field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptB737(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 34)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetB737(v B737) error {
 	capnp.Struct(s).SetUint16(0, 34)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2172,6 +2757,23 @@ func (s Z) HasA320() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownA320 detaches the a320 field from s without
+// copying it.  The field is left null.  It returns an error if
+// a320 is not the union member that is set.
+func (s Z) DisownA320() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 35 {
+		return capnp.Orphan{}, errors.New("Which() != a320")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptA320 sets the a320 field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptA320(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 35)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetA320(v A320) error {
 	capnp.Struct(s).SetUint16(0, 35)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2204,6 +2806,23 @@ func (s Z) HasF16() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownF16 detaches the f16 field from s without
+// copying it.  The field is left null.  It returns an error if
+// f16 is not the union member that is set.
+func (s Z) DisownF16() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 36 {
+		return capnp.Orphan{}, errors.New("Which() != f16")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptF16 sets the f16 field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptF16(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 36)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetF16(v F16) error {
 	capnp.Struct(s).SetUint16(0, 36)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2236,6 +2855,23 @@ func (s Z) HasZdatevec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownZdatevec detaches the zdatevec field from s without
+// copying it.  The field is left null.  It returns an error if
+// zdatevec is not the union member that is set.
+func (s Z) DisownZdatevec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 37 {
+		return capnp.Orphan{}, errors.New("Which() != zdatevec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptZdatevec sets the zdatevec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptZdatevec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 37)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetZdatevec(v Zdate_List) error {
 	capnp.Struct(s).SetUint16(0, 37)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -2267,9 +2903,26 @@ func (s Z) HasZdatavec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s Z) SetZdatavec(v Zdata_List) error {
-	capnp.Struct(s).SetUint16(0, 38)
-	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+// DisownZdatavec detaches the zdatavec field from s without
+// copying it.  The field is left null.  It returns an error if
+// zdatavec is not the union member that is set.
+func (s Z) DisownZdatavec() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 38 {
+		return capnp.Orphan{}, errors.New("Which() != zdatavec")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptZdatavec sets the zdatavec field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptZdatavec(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 38)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Z) SetZdatavec(v Zdata_List) error {
+	capnp.Struct(s).SetUint16(0, 38)
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
 
 // NewZdatavec sets the zdatavec field to a newly
@@ -2356,6 +3009,23 @@ func (s Z) HasEchoes() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownEchoes detaches the echoes field from s without
+// copying it.  The field is left null.  It returns an error if
+// echoes is not the union member that is set.
+func (s Z) DisownEchoes() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 44 {
+		return capnp.Orphan{}, errors.New("Which() != echoes")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptEchoes sets the echoes field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptEchoes(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 44)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetEchoes(v Echo_List) error {
 	capnp.Struct(s).SetUint16(0, 44)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -2386,6 +3056,23 @@ func (s Z) HasAnyPtr() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAnyPtr detaches the anyPtr field from s without
+// copying it.  The field is left null.  It returns an error if
+// anyPtr is not the union member that is set.
+func (s Z) DisownAnyPtr() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 45 {
+		return capnp.Orphan{}, errors.New("Which() != anyPtr")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAnyPtr sets the anyPtr field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptAnyPtr(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 45)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetAnyPtr(v capnp.Ptr) error {
 	capnp.Struct(s).SetUint16(0, 45)
 	return capnp.Struct(s).SetPtr(0, v)
@@ -2405,6 +3092,23 @@ func (s Z) HasAnyStruct() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAnyStruct detaches the anyStruct field from s without
+// copying it.  The field is left null.  It returns an error if
+// anyStruct is not the union member that is set.
+func (s Z) DisownAnyStruct() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 46 {
+		return capnp.Orphan{}, errors.New("Which() != anyStruct")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAnyStruct sets the anyStruct field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptAnyStruct(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 46)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetAnyStruct(v capnp.Struct) error {
 	capnp.Struct(s).SetUint16(0, 46)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -2424,6 +3128,23 @@ func (s Z) HasAnyList() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAnyList detaches the anyList field from s without
+// copying it.  The field is left null.  It returns an error if
+// anyList is not the union member that is set.
+func (s Z) DisownAnyList() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 47 {
+		return capnp.Orphan{}, errors.New("Which() != anyList")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAnyList sets the anyList field to an orphan from
+// s's message without copying it.
+func (s Z) AdoptAnyList(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 47)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Z) SetAnyList(v capnp.List) error {
 	capnp.Struct(s).SetUint16(0, 47)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -2586,6 +3307,18 @@ func (s Counter) HasWords() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownWords detaches the words field from s without
+// copying it.  The field is left null.
+func (s Counter) DisownWords() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptWords sets the words field to an orphan from
+// s's message without copying it.
+func (s Counter) AdoptWords(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Counter) WordsBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -2604,6 +3337,18 @@ func (s Counter) HasWordlist() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownWordlist detaches the wordlist field from s without
+// copying it.  The field is left null.
+func (s Counter) DisownWordlist() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptWordlist sets the wordlist field to an orphan from
+// s's message without copying it.
+func (s Counter) AdoptWordlist(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Counter) SetWordlist(v capnp.TextList) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -2627,6 +3372,18 @@ func (s Counter) HasBitlist() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
+// DisownBitlist detaches the bitlist field from s without
+// copying it.  The field is left null.
+func (s Counter) DisownBitlist() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptBitlist sets the bitlist field to an orphan from
+// s's message without copying it.
+func (s Counter) AdoptBitlist(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
 func (s Counter) SetBitlist(v capnp.BitList) error {
 	return capnp.Struct(s).SetPtr(2, v.ToPtr())
 }
@@ -2715,6 +3472,18 @@ func (s Bag) HasCounter() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownCounter detaches the counter field from s without
+// copying it.  The field is left null.
+func (s Bag) DisownCounter() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptCounter sets the counter field to an orphan from
+// s's message without copying it.
+func (s Bag) AdoptCounter(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Bag) SetCounter(v Counter) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -2806,6 +3575,18 @@ func (s Zserver) HasWaitingjobs() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownWaitingjobs detaches the waitingjobs field from s without
+// copying it.  The field is left null.
+func (s Zserver) DisownWaitingjobs() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptWaitingjobs sets the waitingjobs field to an orphan from
+// s's message without copying it.
+func (s Zserver) AdoptWaitingjobs(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Zserver) SetWaitingjobs(v Zjob_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -2894,6 +3675,18 @@ func (s Zjob) HasCmd() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownCmd detaches the cmd field from s without
+// copying it.  The field is left null.
+func (s Zjob) DisownCmd() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptCmd sets the cmd field to an orphan from
+// s's message without copying it.
+func (s Zjob) AdoptCmd(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Zjob) CmdBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -2912,6 +3705,18 @@ func (s Zjob) HasArgs() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownArgs detaches the args field from s without
+// copying it.  The field is left null.
+func (s Zjob) DisownArgs() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptArgs sets the args field to an orphan from
+// s's message without copying it.
+func (s Zjob) AdoptArgs(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Zjob) SetArgs(v capnp.TextList) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -3217,6 +4022,18 @@ func (s VerOnePtr) HasPtr() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownPtr detaches the ptr field from s without
+// copying it.  The field is left null.
+func (s VerOnePtr) DisownPtr() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptPtr sets the ptr field to an orphan from
+// s's message without copying it.
+func (s VerOnePtr) AdoptPtr(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s VerOnePtr) SetPtr(v VerOneData) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -3308,6 +4125,18 @@ func (s VerTwoPtr) HasPtr1() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownPtr1 detaches the ptr1 field from s without
+// copying it.  The field is left null.
+func (s VerTwoPtr) DisownPtr1() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptPtr1 sets the ptr1 field to an orphan from
+// s's message without copying it.
+func (s VerTwoPtr) AdoptPtr1(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s VerTwoPtr) SetPtr1(v VerOneData) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -3332,6 +4161,18 @@ func (s VerTwoPtr) HasPtr2() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownPtr2 detaches the ptr2 field from s without
+// copying it.  The field is left null.
+func (s VerTwoPtr) DisownPtr2() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptPtr2 sets the ptr2 field to an orphan from
+// s's message without copying it.
+func (s VerTwoPtr) AdoptPtr2(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s VerTwoPtr) SetPtr2(v VerOneData) error {
 	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
@@ -3442,6 +4283,18 @@ func (s VerTwoDataTwoPtr) HasPtr1() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownPtr1 detaches the ptr1 field from s without
+// copying it.  The field is left null.
+func (s VerTwoDataTwoPtr) DisownPtr1() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptPtr1 sets the ptr1 field to an orphan from
+// s's message without copying it.
+func (s VerTwoDataTwoPtr) AdoptPtr1(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s VerTwoDataTwoPtr) SetPtr1(v VerOneData) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -3466,6 +4319,18 @@ func (s VerTwoDataTwoPtr) HasPtr2() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownPtr2 detaches the ptr2 field from s without
+// copying it.  The field is left null.
+func (s VerTwoDataTwoPtr) DisownPtr2() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptPtr2 sets the ptr2 field to an orphan from
+// s's message without copying it.
+func (s VerTwoDataTwoPtr) AdoptPtr2(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s VerTwoDataTwoPtr) SetPtr2(v VerOneData) error {
 	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
@@ -3560,6 +4425,18 @@ func (s HoldsVerEmptyList) HasMylist() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMylist detaches the mylist field from s without
+// copying it.  The field is left null.
+func (s HoldsVerEmptyList) DisownMylist() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMylist sets the mylist field to an orphan from
+// s's message without copying it.
+func (s HoldsVerEmptyList) AdoptMylist(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s HoldsVerEmptyList) SetMylist(v VerEmpty_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -3648,6 +4525,18 @@ func (s HoldsVerOneDataList) HasMylist() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMylist detaches the mylist field from s without
+// copying it.  The field is left null.
+func (s HoldsVerOneDataList) DisownMylist() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMylist sets the mylist field to an orphan from
+// s's message without copying it.
+func (s HoldsVerOneDataList) AdoptMylist(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s HoldsVerOneDataList) SetMylist(v VerOneData_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -3736,6 +4625,18 @@ func (s HoldsVerTwoDataList) HasMylist() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMylist detaches the mylist field from s without
+// copying it.  The field is left null.
+func (s HoldsVerTwoDataList) DisownMylist() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMylist sets the mylist field to an orphan from
+// s's message without copying it.
+func (s HoldsVerTwoDataList) AdoptMylist(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s HoldsVerTwoDataList) SetMylist(v VerTwoData_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -3824,6 +4725,18 @@ func (s HoldsVerOnePtrList) HasMylist() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMylist detaches the mylist field from s without
+// copying it.  The field is left null.
+func (s HoldsVerOnePtrList) DisownMylist() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMylist sets the mylist field to an orphan from
+// s's message without copying it.
+func (s HoldsVerOnePtrList) AdoptMylist(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s HoldsVerOnePtrList) SetMylist(v VerOnePtr_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -3912,6 +4825,18 @@ func (s HoldsVerTwoPtrList) HasMylist() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMylist detaches the mylist field from s without
+// copying it.  The field is left null.
+func (s HoldsVerTwoPtrList) DisownMylist() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMylist sets the mylist field to an orphan from
+// s's message without copying it.
+func (s HoldsVerTwoPtrList) AdoptMylist(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s HoldsVerTwoPtrList) SetMylist(v VerTwoPtr_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -4000,6 +4925,18 @@ func (s HoldsVerTwoTwoList) HasMylist() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMylist detaches the mylist field from s without
+// copying it.  The field is left null.
+func (s HoldsVerTwoTwoList) DisownMylist() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMylist sets the mylist field to an orphan from
+// s's message without copying it.
+func (s HoldsVerTwoTwoList) AdoptMylist(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s HoldsVerTwoTwoList) SetMylist(v VerTwoDataTwoPtr_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -4088,6 +5025,18 @@ func (s HoldsVerTwoTwoPlus) HasMylist() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMylist detaches the mylist field from s without
+// copying it.  The field is left null.
+func (s HoldsVerTwoTwoPlus) DisownMylist() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMylist sets the mylist field to an orphan from
+// s's message without copying it.
+func (s HoldsVerTwoTwoPlus) AdoptMylist(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s HoldsVerTwoTwoPlus) SetMylist(v VerTwoTwoPlus_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -4192,6 +5141,18 @@ func (s VerTwoTwoPlus) HasPtr1() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownPtr1 detaches the ptr1 field from s without
+// copying it.  The field is left null.
+func (s VerTwoTwoPlus) DisownPtr1() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptPtr1 sets the ptr1 field to an orphan from
+// s's message without copying it.
+func (s VerTwoTwoPlus) AdoptPtr1(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s VerTwoTwoPlus) SetPtr1(v VerTwoDataTwoPtr) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -4216,6 +5177,18 @@ func (s VerTwoTwoPlus) HasPtr2() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownPtr2 detaches the ptr2 field from s without
+// copying it.  The field is left null.
+func (s VerTwoTwoPlus) DisownPtr2() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptPtr2 sets the ptr2 field to an orphan from
+// s's message without copying it.
+func (s VerTwoTwoPlus) AdoptPtr2(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s VerTwoTwoPlus) SetPtr2(v VerTwoDataTwoPtr) error {
 	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
@@ -4248,6 +5221,18 @@ func (s VerTwoTwoPlus) HasLst3() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
+// DisownLst3 detaches the lst3 field from s without
+// copying it.  The field is left null.
+func (s VerTwoTwoPlus) DisownLst3() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptLst3 sets the lst3 field to an orphan from
+// s's message without copying it.
+func (s VerTwoTwoPlus) AdoptLst3(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
 func (s VerTwoTwoPlus) SetLst3(v capnp.Int64List) error {
 	return capnp.Struct(s).SetPtr(2, v.ToPtr())
 }
@@ -4342,6 +5327,18 @@ func (s HoldsText) HasTxt() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownTxt detaches the txt field from s without
+// copying it.  The field is left null.
+func (s HoldsText) DisownTxt() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptTxt sets the txt field to an orphan from
+// s's message without copying it.
+func (s HoldsText) AdoptTxt(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s HoldsText) TxtBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -4360,6 +5357,18 @@ func (s HoldsText) HasLst() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownLst detaches the lst field from s without
+// copying it.  The field is left null.
+func (s HoldsText) DisownLst() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptLst sets the lst field to an orphan from
+// s's message without copying it.
+func (s HoldsText) AdoptLst(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s HoldsText) SetLst(v capnp.TextList) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -4383,6 +5392,18 @@ func (s HoldsText) HasLstlst() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
+// DisownLstlst detaches the lstlst field from s without
+// copying it.  The field is left null.
+func (s HoldsText) DisownLstlst() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptLstlst sets the lstlst field to an orphan from
+// s's message without copying it.
+func (s HoldsText) AdoptLstlst(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
 func (s HoldsText) SetLstlst(v capnp.PointerList) error {
 	return capnp.Struct(s).SetPtr(2, v.ToPtr())
 }
@@ -4471,6 +5492,18 @@ func (s WrapEmpty) HasMightNotBeReallyEmpty() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMightNotBeReallyEmpty detaches the mightNotBeReallyEmpty field from s without
+// copying it.  The field is left null.
+func (s WrapEmpty) DisownMightNotBeReallyEmpty() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMightNotBeReallyEmpty sets the mightNotBeReallyEmpty field to an orphan from
+// s's message without copying it.
+func (s WrapEmpty) AdoptMightNotBeReallyEmpty(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s WrapEmpty) SetMightNotBeReallyEmpty(v VerEmpty) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -4562,6 +5595,18 @@ func (s Wrap2x2) HasMightNotBeReallyEmpty() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMightNotBeReallyEmpty detaches the mightNotBeReallyEmpty field from s without
+// copying it.  The field is left null.
+func (s Wrap2x2) DisownMightNotBeReallyEmpty() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMightNotBeReallyEmpty sets the mightNotBeReallyEmpty field to an orphan from
+// s's message without copying it.
+func (s Wrap2x2) AdoptMightNotBeReallyEmpty(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Wrap2x2) SetMightNotBeReallyEmpty(v VerTwoDataTwoPtr) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -4653,6 +5698,18 @@ func (s Wrap2x2plus) HasMightNotBeReallyEmpty() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownMightNotBeReallyEmpty detaches the mightNotBeReallyEmpty field from s without
+// copying it.  The field is left null.
+func (s Wrap2x2plus) DisownMightNotBeReallyEmpty() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptMightNotBeReallyEmpty sets the mightNotBeReallyEmpty field to an orphan from
+// s's message without copying it.
+func (s Wrap2x2plus) AdoptMightNotBeReallyEmpty(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Wrap2x2plus) SetMightNotBeReallyEmpty(v VerTwoTwoPlus) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -4840,6 +5897,18 @@ func (s Nester1Capn) HasStrs() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownStrs detaches the strs field from s without
+// copying it.  The field is left null.
+func (s Nester1Capn) DisownStrs() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptStrs sets the strs field to an orphan from
+// s's message without copying it.
+func (s Nester1Capn) AdoptStrs(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Nester1Capn) SetStrs(v capnp.TextList) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -4928,6 +5997,18 @@ func (s RWTestCapn) HasNestMatrix() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownNestMatrix detaches the nestMatrix field from s without
+// copying it.  The field is left null.
+func (s RWTestCapn) DisownNestMatrix() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptNestMatrix sets the nestMatrix field to an orphan from
+// s's message without copying it.
+func (s RWTestCapn) AdoptNestMatrix(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s RWTestCapn) SetNestMatrix(v capnp.PointerList) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -5016,6 +6097,18 @@ func (s ListStructCapn) HasVec() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownVec detaches the vec field from s without
+// copying it.  The field is left null.
+func (s ListStructCapn) DisownVec() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptVec sets the vec field to an orphan from
+// s's message without copying it.
+func (s ListStructCapn) AdoptVec(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s ListStructCapn) SetVec(v Nester1Capn_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -5141,9 +6234,9 @@ type Echo_Server interface {
 }
 
 // Echo_NewServer creates a new Server from an implementation of Echo_Server.
-func Echo_NewServer(s Echo_Server) *server.Server {
+func Echo_NewServer(s Echo_Server, opts ...server.Option) *server.Server {
 	c, _ := s.(server.Shutdowner)
-	return server.New(Echo_Methods(nil, s), s, c)
+	return server.New(Echo_Methods(nil, s), s, c, opts...)
 }
 
 // Echo_ServerToClient creates a new Client from an implementation of Echo_Server.
@@ -5256,6 +6349,18 @@ func (s Echo_echo_Params) HasIn() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownIn detaches the in field from s without
+// copying it.  The field is left null.
+func (s Echo_echo_Params) DisownIn() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptIn sets the in field to an orphan from
+// s's message without copying it.
+func (s Echo_echo_Params) AdoptIn(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Echo_echo_Params) InBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -5338,6 +6443,18 @@ func (s Echo_echo_Results) HasOut() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownOut detaches the out field from s without
+// copying it.  The field is left null.
+func (s Echo_echo_Results) DisownOut() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptOut sets the out field to an orphan from
+// s's message without copying it.
+func (s Echo_echo_Results) AdoptOut(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Echo_echo_Results) OutBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -5420,6 +6537,18 @@ func (s Hoth) HasBase() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBase detaches the base field from s without
+// copying it.  The field is left null.
+func (s Hoth) DisownBase() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBase sets the base field to an orphan from
+// s's message without copying it.
+func (s Hoth) AdoptBase(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Hoth) SetBase(v EchoBase) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -5596,6 +6725,18 @@ func (s StackingRoot) HasA() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownA detaches the a field from s without
+// copying it.  The field is left null.
+func (s StackingRoot) DisownA() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptA sets the a field to an orphan from
+// s's message without copying it.
+func (s StackingRoot) AdoptA(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s StackingRoot) SetA(v StackingA) error {
 	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
@@ -5624,6 +6765,18 @@ func (s StackingRoot) HasAWithDefault() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAWithDefault detaches the aWithDefault field from s without
+// copying it.  The field is left null.
+func (s StackingRoot) DisownAWithDefault() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAWithDefault sets the aWithDefault field to an orphan from
+// s's message without copying it.
+func (s StackingRoot) AdoptAWithDefault(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s StackingRoot) SetAWithDefault(v StackingA) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -5726,6 +6879,18 @@ func (s StackingA) HasB() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownB detaches the b field from s without
+// copying it.  The field is left null.
+func (s StackingA) DisownB() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptB sets the b field to an orphan from
+// s's message without copying it.
+func (s StackingA) AdoptB(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s StackingA) SetB(v StackingB) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -5926,9 +7091,9 @@ type CallSequence_Server interface {
 }
 
 // CallSequence_NewServer creates a new Server from an implementation of CallSequence_Server.
-func CallSequence_NewServer(s CallSequence_Server) *server.Server {
+func CallSequence_NewServer(s CallSequence_Server, opts ...server.Option) *server.Server {
 	c, _ := s.(server.Shutdowner)
-	return server.New(CallSequence_Methods(nil, s), s, c)
+	return server.New(CallSequence_Methods(nil, s), s, c, opts...)
 }
 
 // CallSequence_ServerToClient creates a new Client from an implementation of CallSequence_Server.
@@ -6233,9 +7398,9 @@ type Pipeliner_Server interface {
 }
 
 // Pipeliner_NewServer creates a new Server from an implementation of Pipeliner_Server.
-func Pipeliner_NewServer(s Pipeliner_Server) *server.Server {
+func Pipeliner_NewServer(s Pipeliner_Server, opts ...server.Option) *server.Server {
 	c, _ := s.(server.Shutdowner)
-	return server.New(Pipeliner_Methods(nil, s), s, c)
+	return server.New(Pipeliner_Methods(nil, s), s, c, opts...)
 }
 
 // Pipeliner_ServerToClient creates a new Client from an implementation of Pipeliner_Server.
@@ -6424,6 +7589,18 @@ func (s Pipeliner_newPipeliner_Results) HasExtra() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownExtra detaches the extra field from s without
+// copying it.  The field is left null.
+func (s Pipeliner_newPipeliner_Results) DisownExtra() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptExtra sets the extra field to an orphan from
+// s's message without copying it.
+func (s Pipeliner_newPipeliner_Results) AdoptExtra(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Pipeliner_newPipeliner_Results) SetExtra(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(0, v)
 }
@@ -6524,6 +7701,18 @@ func (s Defaults) HasText() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownText detaches the text field from s without
+// copying it.  The field is left null.
+func (s Defaults) DisownText() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptText sets the text field to an orphan from
+// s's message without copying it.
+func (s Defaults) AdoptText(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Defaults) TextBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytesDefault("foo"), err
@@ -6542,6 +7731,18 @@ func (s Defaults) HasData() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownData detaches the data field from s without
+// copying it.  The field is left null.
+func (s Defaults) DisownData() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptData sets the data field to an orphan from
+// s's message without copying it.
+func (s Defaults) AdoptData(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Defaults) SetData(v []byte) error {
 	if v == nil {
 		v = []byte{}
@@ -6590,105 +7791,757 @@ func (f Defaults_Future) Struct() (Defaults, error) {
 	return Defaults(p.Struct()), err
 }
 
-type BenchmarkA capnp.Struct
+type Attrs capnp.Struct
 
-// BenchmarkA_TypeID is the unique identifier for the type BenchmarkA.
-const BenchmarkA_TypeID = 0xde2a1a960863c11c
+// Attrs_TypeID is the unique identifier for the type Attrs.
+const Attrs_TypeID = 0xbfe4f476e6004f2a
 
-func NewBenchmarkA(s *capnp.Segment) (BenchmarkA, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2})
-	return BenchmarkA(st), err
+func NewAttrs(s *capnp.Segment) (Attrs, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return Attrs(st), err
 }
 
-func NewRootBenchmarkA(s *capnp.Segment) (BenchmarkA, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2})
-	return BenchmarkA(st), err
+func NewRootAttrs(s *capnp.Segment) (Attrs, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return Attrs(st), err
 }
 
-func ReadRootBenchmarkA(msg *capnp.Message) (BenchmarkA, error) {
+func ReadRootAttrs(msg *capnp.Message) (Attrs, error) {
 	root, err := msg.Root()
-	return BenchmarkA(root.Struct()), err
+	return Attrs(root.Struct()), err
 }
 
-func (s BenchmarkA) String() string {
-	str, _ := text.Marshal(0xde2a1a960863c11c, capnp.Struct(s))
+func (s Attrs) String() string {
+	str, _ := text.Marshal(0xbfe4f476e6004f2a, capnp.Struct(s))
 	return str
 }
 
-func (s BenchmarkA) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s Attrs) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (BenchmarkA) DecodeFromPtr(p capnp.Ptr) BenchmarkA {
-	return BenchmarkA(capnp.Struct{}.DecodeFromPtr(p))
+func (Attrs) DecodeFromPtr(p capnp.Ptr) Attrs {
+	return Attrs(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s BenchmarkA) ToPtr() capnp.Ptr {
+func (s Attrs) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s BenchmarkA) IsValid() bool {
+func (s Attrs) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s BenchmarkA) Message() *capnp.Message {
+func (s Attrs) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s BenchmarkA) Segment() *capnp.Segment {
+func (s Attrs) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s BenchmarkA) Name() (string, error) {
+func (s Attrs) Plain() (Attrs_Entry_List, error) {
 	p, err := capnp.Struct(s).Ptr(0)
-	return p.Text(), err
+	return Attrs_Entry_List(p.List()), err
 }
 
-func (s BenchmarkA) HasName() bool {
+func (s Attrs) HasPlain() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s BenchmarkA) NameBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(0)
-	return p.TextBytes(), err
+// DisownPlain detaches the plain field from s without
+// copying it.  The field is left null.
+func (s Attrs) DisownPlain() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
 }
 
-func (s BenchmarkA) SetName(v string) error {
-	return capnp.Struct(s).SetText(0, v)
+// AdoptPlain sets the plain field to an orphan from
+// s's message without copying it.
+func (s Attrs) AdoptPlain(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
 }
 
-func (s BenchmarkA) BirthDay() int64 {
-	return int64(capnp.Struct(s).Uint64(0))
+func (s Attrs) SetPlain(v Attrs_Entry_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
 
-func (s BenchmarkA) SetBirthDay(v int64) {
-	capnp.Struct(s).SetUint64(0, uint64(v))
+// NewPlain sets the plain field to a newly
+// allocated Attrs_Entry_List, preferring placement in s's segment.
+func (s Attrs) NewPlain(n int32) (Attrs_Entry_List, error) {
+	l, err := NewAttrs_Entry_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Attrs_Entry_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
 }
-
-func (s BenchmarkA) Phone() (string, error) {
+func (s Attrs) Annotated() (Attrs_Entry_List, error) {
 	p, err := capnp.Struct(s).Ptr(1)
-	return p.Text(), err
+	return Attrs_Entry_List(p.List()), err
 }
 
-func (s BenchmarkA) HasPhone() bool {
+func (s Attrs) HasAnnotated() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
-func (s BenchmarkA) PhoneBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(1)
-	return p.TextBytes(), err
+// DisownAnnotated detaches the annotated field from s without
+// copying it.  The field is left null.
+func (s Attrs) DisownAnnotated() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
 }
 
-func (s BenchmarkA) SetPhone(v string) error {
-	return capnp.Struct(s).SetText(1, v)
+// AdoptAnnotated sets the annotated field to an orphan from
+// s's message without copying it.
+func (s Attrs) AdoptAnnotated(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
 }
 
-func (s BenchmarkA) Siblings() int32 {
-	return int32(capnp.Struct(s).Uint32(8))
+func (s Attrs) SetAnnotated(v Attrs_Entry_List) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
 
-func (s BenchmarkA) SetSiblings(v int32) {
-	capnp.Struct(s).SetUint32(8, uint32(v))
+// NewAnnotated sets the annotated field to a newly
+// allocated Attrs_Entry_List, preferring placement in s's segment.
+func (s Attrs) NewAnnotated(n int32) (Attrs_Entry_List, error) {
+	l, err := NewAttrs_Entry_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Attrs_Entry_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
 }
-
+func (s Attrs) Nested() (Attrs_Nested_List, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return Attrs_Nested_List(p.List()), err
+}
+
+func (s Attrs) HasNested() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+// DisownNested detaches the nested field from s without
+// copying it.  The field is left null.
+func (s Attrs) DisownNested() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptNested sets the nested field to an orphan from
+// s's message without copying it.
+func (s Attrs) AdoptNested(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
+func (s Attrs) SetNested(v Attrs_Nested_List) error {
+	return capnp.Struct(s).SetPtr(2, v.ToPtr())
+}
+
+// NewNested sets the nested field to a newly
+// allocated Attrs_Nested_List, preferring placement in s's segment.
+func (s Attrs) NewNested(n int32) (Attrs_Nested_List, error) {
+	l, err := NewAttrs_Nested_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Attrs_Nested_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, l.ToPtr())
+	return l, err
+}
+
+// Attrs_List is a list of Attrs.
+type Attrs_List = capnp.StructList[Attrs]
+
+// NewAttrs creates a new list of Attrs.
+func NewAttrs_List(s *capnp.Segment, sz int32) (Attrs_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return capnp.StructList[Attrs](l), err
+}
+
+// Attrs_Future is a wrapper for a Attrs promised by a client call.
+type Attrs_Future struct{ *capnp.Future }
+
+func (f Attrs_Future) Struct() (Attrs, error) {
+	p, err := f.Future.Ptr()
+	return Attrs(p.Struct()), err
+}
+
+type Attrs_Entry capnp.Struct
+
+// Attrs_Entry_TypeID is the unique identifier for the type Attrs_Entry.
+const Attrs_Entry_TypeID = 0xccf2769576f2697f
+
+func NewAttrs_Entry(s *capnp.Segment) (Attrs_Entry, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Attrs_Entry(st), err
+}
+
+func NewRootAttrs_Entry(s *capnp.Segment) (Attrs_Entry, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Attrs_Entry(st), err
+}
+
+func ReadRootAttrs_Entry(msg *capnp.Message) (Attrs_Entry, error) {
+	root, err := msg.Root()
+	return Attrs_Entry(root.Struct()), err
+}
+
+func (s Attrs_Entry) String() string {
+	str, _ := text.Marshal(0xccf2769576f2697f, capnp.Struct(s))
+	return str
+}
+
+func (s Attrs_Entry) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Attrs_Entry) DecodeFromPtr(p capnp.Ptr) Attrs_Entry {
+	return Attrs_Entry(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Attrs_Entry) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Attrs_Entry) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Attrs_Entry) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Attrs_Entry) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Attrs_Entry) Key() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s Attrs_Entry) HasKey() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownKey detaches the key field from s without
+// copying it.  The field is left null.
+func (s Attrs_Entry) DisownKey() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptKey sets the key field to an orphan from
+// s's message without copying it.
+func (s Attrs_Entry) AdoptKey(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Attrs_Entry) KeyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s Attrs_Entry) SetKey(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s Attrs_Entry) Value() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s Attrs_Entry) SetValue(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+// Attrs_Entry_List is a list of Attrs_Entry.
+type Attrs_Entry_List = capnp.StructList[Attrs_Entry]
+
+// NewAttrs_Entry creates a new list of Attrs_Entry.
+func NewAttrs_Entry_List(s *capnp.Segment, sz int32) (Attrs_Entry_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[Attrs_Entry](l), err
+}
+
+// Attrs_Entry_Future is a wrapper for a Attrs_Entry promised by a client call.
+type Attrs_Entry_Future struct{ *capnp.Future }
+
+func (f Attrs_Entry_Future) Struct() (Attrs_Entry, error) {
+	p, err := f.Future.Ptr()
+	return Attrs_Entry(p.Struct()), err
+}
+
+type Attrs_Nested capnp.Struct
+
+// Attrs_Nested_TypeID is the unique identifier for the type Attrs_Nested.
+const Attrs_Nested_TypeID = 0xd62b8f92e21bfbc0
+
+func NewAttrs_Nested(s *capnp.Segment) (Attrs_Nested, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Attrs_Nested(st), err
+}
+
+func NewRootAttrs_Nested(s *capnp.Segment) (Attrs_Nested, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return Attrs_Nested(st), err
+}
+
+func ReadRootAttrs_Nested(msg *capnp.Message) (Attrs_Nested, error) {
+	root, err := msg.Root()
+	return Attrs_Nested(root.Struct()), err
+}
+
+func (s Attrs_Nested) String() string {
+	str, _ := text.Marshal(0xd62b8f92e21bfbc0, capnp.Struct(s))
+	return str
+}
+
+func (s Attrs_Nested) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Attrs_Nested) DecodeFromPtr(p capnp.Ptr) Attrs_Nested {
+	return Attrs_Nested(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Attrs_Nested) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Attrs_Nested) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Attrs_Nested) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Attrs_Nested) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Attrs_Nested) Key() uint16 {
+	return capnp.Struct(s).Uint16(0)
+}
+
+func (s Attrs_Nested) SetKey(v uint16) {
+	capnp.Struct(s).SetUint16(0, v)
+}
+
+func (s Attrs_Nested) Value() (Attrs_Entry_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Attrs_Entry_List(p.List()), err
+}
+
+func (s Attrs_Nested) HasValue() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownValue detaches the value field from s without
+// copying it.  The field is left null.
+func (s Attrs_Nested) DisownValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptValue sets the value field to an orphan from
+// s's message without copying it.
+func (s Attrs_Nested) AdoptValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Attrs_Nested) SetValue(v Attrs_Entry_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewValue sets the value field to a newly
+// allocated Attrs_Entry_List, preferring placement in s's segment.
+func (s Attrs_Nested) NewValue(n int32) (Attrs_Entry_List, error) {
+	l, err := NewAttrs_Entry_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Attrs_Entry_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// Attrs_Nested_List is a list of Attrs_Nested.
+type Attrs_Nested_List = capnp.StructList[Attrs_Nested]
+
+// NewAttrs_Nested creates a new list of Attrs_Nested.
+func NewAttrs_Nested_List(s *capnp.Segment, sz int32) (Attrs_Nested_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[Attrs_Nested](l), err
+}
+
+// Attrs_Nested_Future is a wrapper for a Attrs_Nested promised by a client call.
+type Attrs_Nested_Future struct{ *capnp.Future }
+
+func (f Attrs_Nested_Future) Struct() (Attrs_Nested, error) {
+	p, err := f.Future.Ptr()
+	return Attrs_Nested(p.Struct()), err
+}
+
+type Drawing capnp.Struct
+type Drawing_shape Drawing
+type Drawing_shape_rect Drawing
+type Drawing_shape_Which uint16
+
+const (
+	Drawing_shape_Which_none   Drawing_shape_Which = 0
+	Drawing_shape_Which_circle Drawing_shape_Which = 1
+	Drawing_shape_Which_rect   Drawing_shape_Which = 2
+	Drawing_shape_Which_label  Drawing_shape_Which = 3
+)
+
+func (w Drawing_shape_Which) String() string {
+	const s = "nonecirclerectlabel"
+	switch w {
+	case Drawing_shape_Which_none:
+		return s[0:4]
+	case Drawing_shape_Which_circle:
+		return s[4:10]
+	case Drawing_shape_Which_rect:
+		return s[10:14]
+	case Drawing_shape_Which_label:
+		return s[14:19]
+
+	}
+	return "Drawing_shape_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
+}
+
+// Drawing_TypeID is the unique identifier for the type Drawing.
+const Drawing_TypeID = 0x818bd47d898c14cc
+
+func NewDrawing(s *capnp.Segment) (Drawing, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2})
+	return Drawing(st), err
+}
+
+func NewRootDrawing(s *capnp.Segment) (Drawing, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2})
+	return Drawing(st), err
+}
+
+func ReadRootDrawing(msg *capnp.Message) (Drawing, error) {
+	root, err := msg.Root()
+	return Drawing(root.Struct()), err
+}
+
+func (s Drawing) String() string {
+	str, _ := text.Marshal(0x818bd47d898c14cc, capnp.Struct(s))
+	return str
+}
+
+func (s Drawing) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Drawing) DecodeFromPtr(p capnp.Ptr) Drawing {
+	return Drawing(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Drawing) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Drawing) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Drawing) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Drawing) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Drawing) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s Drawing) HasName() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s Drawing) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s Drawing) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Drawing) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s Drawing) SetName(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s Drawing) Shape() Drawing_shape { return Drawing_shape(s) }
+
+func (s Drawing_shape) Which() Drawing_shape_Which {
+	return Drawing_shape_Which(capnp.Struct(s).Uint16(0))
+}
+func (s Drawing_shape) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Drawing_shape) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Drawing_shape) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Drawing_shape) SetNone() {
+	capnp.Struct(s).SetUint16(0, 0)
+
+}
+
+func (s Drawing_shape) Circle() float64 {
+	if capnp.Struct(s).Uint16(0) != 1 {
+		panic("Which() != circle")
+	}
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s Drawing_shape) SetCircle(v float64) {
+	capnp.Struct(s).SetUint16(0, 1)
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s Drawing_shape) Rect() Drawing_shape_rect { return Drawing_shape_rect(s) }
+
+func (s Drawing_shape) SetRect() {
+	capnp.Struct(s).SetUint16(0, 2)
+}
+
+func (s Drawing_shape_rect) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Drawing_shape_rect) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Drawing_shape_rect) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Drawing_shape_rect) Width() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s Drawing_shape_rect) SetWidth(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s Drawing_shape_rect) Height() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+}
+
+func (s Drawing_shape_rect) SetHeight(v float64) {
+	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+}
+
+func (s Drawing_shape) Label() (string, error) {
+	if capnp.Struct(s).Uint16(0) != 3 {
+		panic("Which() != label")
+	}
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s Drawing_shape) HasLabel() bool {
+	if capnp.Struct(s).Uint16(0) != 3 {
+		return false
+	}
+	return capnp.Struct(s).HasPtr(1)
+}
+
+// DisownLabel detaches the label field from s without
+// copying it.  The field is left null.  It returns an error if
+// label is not the union member that is set.
+func (s Drawing_shape) DisownLabel() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 3 {
+		return capnp.Orphan{}, errors.New("Which() != label")
+	}
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptLabel sets the label field to an orphan from
+// s's message without copying it.
+func (s Drawing_shape) AdoptLabel(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 3)
+	return capnp.Struct(s).Adopt(1, o)
+}
+
+func (s Drawing_shape) LabelBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s Drawing_shape) SetLabel(v string) error {
+	capnp.Struct(s).SetUint16(0, 3)
+	return capnp.Struct(s).SetText(1, v)
+}
+
+// Drawing_List is a list of Drawing.
+type Drawing_List = capnp.StructList[Drawing]
+
+// NewDrawing creates a new list of Drawing.
+func NewDrawing_List(s *capnp.Segment, sz int32) (Drawing_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2}, sz)
+	return capnp.StructList[Drawing](l), err
+}
+
+// Drawing_Future is a wrapper for a Drawing promised by a client call.
+type Drawing_Future struct{ *capnp.Future }
+
+func (f Drawing_Future) Struct() (Drawing, error) {
+	p, err := f.Future.Ptr()
+	return Drawing(p.Struct()), err
+}
+func (p Drawing_Future) Shape() Drawing_shape_Future { return Drawing_shape_Future{p.Future} }
+
+// Drawing_shape_Future is a wrapper for a Drawing_shape promised by a client call.
+type Drawing_shape_Future struct{ *capnp.Future }
+
+func (f Drawing_shape_Future) Struct() (Drawing_shape, error) {
+	p, err := f.Future.Ptr()
+	return Drawing_shape(p.Struct()), err
+}
+func (p Drawing_shape_Future) Rect() Drawing_shape_rect_Future {
+	return Drawing_shape_rect_Future{p.Future}
+}
+
+// Drawing_shape_rect_Future is a wrapper for a Drawing_shape_rect promised by a client call.
+type Drawing_shape_rect_Future struct{ *capnp.Future }
+
+func (f Drawing_shape_rect_Future) Struct() (Drawing_shape_rect, error) {
+	p, err := f.Future.Ptr()
+	return Drawing_shape_rect(p.Struct()), err
+}
+
+type BenchmarkA capnp.Struct
+
+// BenchmarkA_TypeID is the unique identifier for the type BenchmarkA.
+const BenchmarkA_TypeID = 0xde2a1a960863c11c
+
+func NewBenchmarkA(s *capnp.Segment) (BenchmarkA, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2})
+	return BenchmarkA(st), err
+}
+
+func NewRootBenchmarkA(s *capnp.Segment) (BenchmarkA, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2})
+	return BenchmarkA(st), err
+}
+
+func ReadRootBenchmarkA(msg *capnp.Message) (BenchmarkA, error) {
+	root, err := msg.Root()
+	return BenchmarkA(root.Struct()), err
+}
+
+func (s BenchmarkA) String() string {
+	str, _ := text.Marshal(0xde2a1a960863c11c, capnp.Struct(s))
+	return str
+}
+
+func (s BenchmarkA) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (BenchmarkA) DecodeFromPtr(p capnp.Ptr) BenchmarkA {
+	return BenchmarkA(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s BenchmarkA) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s BenchmarkA) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s BenchmarkA) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s BenchmarkA) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s BenchmarkA) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s BenchmarkA) HasName() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s BenchmarkA) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s BenchmarkA) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s BenchmarkA) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s BenchmarkA) SetName(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s BenchmarkA) BirthDay() int64 {
+	return int64(capnp.Struct(s).Uint64(0))
+}
+
+func (s BenchmarkA) SetBirthDay(v int64) {
+	capnp.Struct(s).SetUint64(0, uint64(v))
+}
+
+func (s BenchmarkA) Phone() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s BenchmarkA) HasPhone() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+// DisownPhone detaches the phone field from s without
+// copying it.  The field is left null.
+func (s BenchmarkA) DisownPhone() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptPhone sets the phone field to an orphan from
+// s's message without copying it.
+func (s BenchmarkA) AdoptPhone(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
+func (s BenchmarkA) PhoneBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s BenchmarkA) SetPhone(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s BenchmarkA) Siblings() int32 {
+	return int32(capnp.Struct(s).Uint32(8))
+}
+
+func (s BenchmarkA) SetSiblings(v int32) {
+	capnp.Struct(s).SetUint32(8, uint32(v))
+}
+
 func (s BenchmarkA) Spouse() bool {
 	return capnp.Struct(s).Bit(96)
 }
@@ -6778,6 +8631,18 @@ func (s AllocBenchmark) HasFields() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownFields detaches the fields field from s without
+// copying it.  The field is left null.
+func (s AllocBenchmark) DisownFields() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptFields sets the fields field to an orphan from
+// s's message without copying it.
+func (s AllocBenchmark) AdoptFields(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s AllocBenchmark) SetFields(v AllocBenchmark_Field_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -6866,6 +8731,18 @@ func (s AllocBenchmark_Field) HasStringValue() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownStringValue detaches the stringValue field from s without
+// copying it.  The field is left null.
+func (s AllocBenchmark_Field) DisownStringValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptStringValue sets the stringValue field to an orphan from
+// s's message without copying it.
+func (s AllocBenchmark_Field) AdoptStringValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s AllocBenchmark_Field) StringValueBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -6892,304 +8769,373 @@ func (f AllocBenchmark_Field_Future) Struct() (AllocBenchmark_Field, error) {
 	return AllocBenchmark_Field(p.Struct()), err
 }
 
-const schema_832bcc6686a26d56 = "x\xda\xacZ}t\x14U\x96\xbf\xb7\xaa;\x15\x92t" +
-	"\xba+\xaf\x80\x10\x12#\x11\x14\x1a\xc1\x900\x01\x99u" +
-	"\x93`\xa2\xe0\x82\xa6h\x10ue\xa4\x92T\x92\xc6N" +
-	"w\xd3]\x0d\x89\xcaAv`\x04\x8f\xec\xc8QGQ" +
-	"\x99uX\xdc\xf1\x03\\\xf1cW\x18Dq\xc0\x81\xa8" +
-	"\xab\xb2\xf8\x01\xab\xa8\xb8\xa8\xa0\xec\x883\xae\xa0`\xed" +
-	"\xb9\xaf\xbb\xba*\x9dnQ\xce\xfeC\xaa\xdf\xef\xd6}" +
-	"\xf7\xdd\xefw\x8b\xeaVO\x83k\x82g\xee`\x10\xd4" +
-	"\x13\xee<s\xd7\x89coW\xdf<j\x05\xa8^D" +
-	"\xf3\xea\xee\xf5\xbf\xeaxu\xec/\xc1%\x01\xb05\x05" +
-	"}l]\x01=\xad-\xa8\x074\xe7\xbd\xe2\xfb\xc5\xa0" +
-	"m\xd3n\xcb\xa0u\x8bD\xb2\xa5`+\xdb\xc1\x89\x9f" +
-	"/\xf8\x14\xd0|\xf7\xe9\xef\xab\xcf\xab\xff\xe3m {" +
-	"\x9d\xb4(\x01\xd4>[X\x82lW!\x11\xef($" +
-	"\xceSz\x1a\xea\x9ezm\xc4\xca\x0c\xceM\x92\x00\xc0" +
-	"\x8e\x17\xf6\xb1\xd3\x9c\xf8d\xe1b@s\xfd_\x86\xbf" +
-	"\xf3\xcc\x0dCn\x07YAHq\x9cS$  \xbb" +
-	"\xb6\x88\xb8M\x1c5\xfa\xc8\x8e\xaa\xc0?\x82\xec\x15m" +
-	"f\x80\xac\xb7h=[VD\x9c\x96\x14]\xce\x1e\xa6" +
-	"'\xf3\xce\xdd\x95/7\xde\xfc\xc4\xaf3\xe4\x14\x88j" +
-	"u\xd1!\xb6\x96\xd3\xdfSD;\x9b\xb1W\x8f\xaak" +
-	"\xf7\xdc\xd5\x9f\x96\xeb\xeat\xd1K\xcc\xed\x91@4\x8b" +
-	"\xf6\xfdfg\xc9\xe3\xd5w\x83\xecu\xf5\xdb\xfd\xa3\xa2" +
-	">v\x8c\xb8\x05>+\x121\xe0\xf2\x08\x08`\xae\x1e" +
-	"\x91?\xf1\xc4\x92\x17\xee\xce\xa2'\x86\x9e>\xe6\xf1\xd0" +
-	"\xd3 \x0f\x1d\xec\xc6\xf0|\xa5\xfe\xfb\xed\xf7d\xd3\xe9" +
-	"\x18O\x09\xb2\x8b9\xf1\xcf8\xf1\xea\xdb*_\x9e\xb6" +
-	"\xea\xe3{I\xa7B\xe6\xc9t\xcfK\xac\x9b\x88k\x83" +
-	"\x9eJ\x044\xaf\xdd(<p\xef\xdag\xef\xcf&\xc6" +
-	"\xb2\xe2>\xb6\xba\x98\x9eV\x15\x13\xe7u7\xbf\xbfe" +
-	"\xcc;\x93\x1ep\x1a`sq\x01\x19`\x0b'\xd8=" +
-	"\xe7\x90{\xeb\x05\xbf~`\x80\x0a\xf6\x17\xf7\xb1\xc3\xc4" +
-	")\xf0a\xb1\x88\x81/\x8a\xb9\x0a\x12?w\xfd\xca\xac" +
-	"\xa9^\x97\xe9W|\xf3\xf7\x8b\xfb\xd8\x11\xbe\xf9\xe1b" +
-	"\xb2\xc1\xbd\xe7\xbcyQ\xcbI}\x03\xa8\xe5\x88\xc0\x95" +
-	"_\xdb\xe8\x8d\xd1\xe63\xbd\xb4y\xc7\xb4\xce\xaf\xbeg" +
-	"/<\x92\xed$\xdd\xde\x97X\xc2KO\x0b9\xed\xb9" +
-	"\xbb\x87\xf6\xdc\xf0\xc1\x13\x8f\x0f\xf0\x945\xdeCl\x1d" +
-	"'\\\xeb\xbd\x9c\xed\xa2'\xf3\xf8\x92\x99\xfe\xa9s\xde" +
-	"x<\x9b\xf67y\xcb\x90=\xcf_\xd8\xc29o\xf9" +
-	"\xfc\xc9H\xf3\x81\xdb6e\x93\xe2\x88w=;\xcei" +
-	"\x8fqZ9\xda\xfeN\xd8\xbdqs6Z\x8f\xef+" +
-	"6\xccGO\x83}D\xbb\xb4\xee\x17\xcb\xe7M\xfer" +
-	"3\xe9J\xcc\x8c\xc1f\xdf^\xa6\x12q\xedL\xdf\\" +
-	"\xb2j\xbco\xa2\xf9\xc5\xa1s\xfe-\x9b\x0f\xd4n\x91" +
-	"\x05d\xbbd\x1e\x852\x85\xec\x86\x7f\xd9:\xea\xe5\xee" +
-	"\xb1\xff\x0e\xaa\x8c\xa2\xb9\xff\xa1\xb7\x7f~\xef\x91\xf3\x8f" +
-	"\xc2`\x94\x90B\xa0\xe41@vO\x09\x19\xe1\x93\x05" +
-	"\xcb\x8f=\xd7\xfe\xdds \x9f\x83\xe6\x9c\xab\xb7o\xfb" +
-	"\xed\xdf\x1e\xfd\"\xa5\x8a\xe3%U\xc8\x90\xf1\xa0(!" +
-	"\x91kO\xac\xe9\xdb\xfc\xf4\xef\xb7\x82<\xcc2\xd8\x08" +
-	"\xb6\x00\xc1e~\x8b\x87\xaf\xf8\xbb\x17^\xfbC\x12I" +
-	"J\xe5a\xdc\x96\xc3\x18\x0f\xf5\xc2\xaf\xd6\x9f\xd4\x0f\xfc" +
-	")\x9bfz\xd9cl\x19\xdff\x09\xa3m\xc6\xcd\xbc" +
-	"\xf8\xf9\x8f\x1f\xff\xfb=\xd9\x02y\x1d\xebc\x8fr\xda" +
-	"\x879\xdf\xad\xdf|\xf0\xd6\x0d\x8b\xde{%\x9b)Q" +
-	")C&+\\\xf9\x0a1\xbeo\xe7\xa3\x85\x9f\xc8M" +
-	"\xaff\x13\xe2\x12e+k\xe6\xb4\x8d\x9c\xf6\xc3\xf3'" +
-	"\xcf\xff\xf8\xa9\xa7\xb3\xd2&\x94\xf5l\x09\xa7\xed\xe5\xb4" +
-	"\xd3[\x0e\xed?\xf4D\xd3\x7fd5\xe5Z\xe5({" +
-	"\x98\x88k\x7f\xa7pS\xee\xbeuGy\xdf\xd1\x07^" +
-	"\xcf&\xf2\xe9\xc1%\xc8<Cx\xa2\x18B\xac\xb7\x1f" +
-	"Xy|\xc3wuog\x13\xe3\xe2!\xf7\xb3FN" +
-	"{\x09\xa7\xddv\xe5\x89/\xf3\x1b\xd4\xb7\x07\xc4\xc0\xbc" +
-	"!},\xc8\x09\xf5!\x97\xb35C\x86\x02\x98k&" +
-	"\xfd~~\xf8\xf5m\xef\x92\xcc\xaeL-/\x1b\xd2\xc7" +
-	"V\xd3\x0b\xb5\xab\x86p\x99W\xbf\xb2\x7f\xf1\xca\xf9w" +
-	"\xec\xcf&\xc6\xb1\xa1\xeb\xd9\xd7C\xe9\xe9\xf8P\x12\x83" +
-	"-\xfa.\xd8\xd1\xf8\xe6\xfb\xd9\xcc'\x97\xaeg\xc3J" +
-	"y\x10\x94\x92\xf9\xcaw\xb4\xe5\xff\xa6\xcc\x7f0Ss" +
-	"I)J\xf7\xb2\xd5\xa5\\\x8aR.\xc5\xba\xd9s7" +
-	"\xfd\xe1\x89\x96\x83\xd9J\xdc\xfb\xc3\x1ec\x87\x87\xd1\xd3" +
-	"G\xc3\xfe\x15\xd0\xbc\xab\xfa\x91o\xfff\xdfo\x0ff" +
-	"\xd3roY\x01\xb2UeD\xbc\xa2\x8cD\xde\xb4]" +
-	"\x92\xdfzs\xfdG\xd9\x8e\xf7l\xd9V\xf6<\xa7\xdd" +
-	"\xc2i\xb5@m\xc9\xae\xa3{\xb2\xd2~Tv?;" +
-	"\xc2i\x0fs\xda\x13\xd7>\xf4\xcb\x07\xd7\xe7\x1f\xce&" +
-	"\x84{x\x09\xb2\xc1\xc3\xb9V\x86\x13\xf1\xe6?\xcd9" +
-	"\xf8\x84\xef\xca\xc3\x19\xa7kF\xc9E^9\xfc%6" +
-	"\x9dS7\x0f\xa7\x10\x1fq\xd1\x89\xe1\xa7V\xcc#\xd6" +
-	"B?c\x0f+\xdf\xcaF\x94\x13aE9)\xe2\xbd" +
-	"\xbc\x93\xff\xb4|\xe9\xb2L\x19\xb8o.,\xefcK" +
-	"8m/\xa7\xbd\xb6\xab\xec\xcfS>_\xfeI\xb6\xb3" +
-	"5V\x1c`3+\xe8iz\x05/aom\xdf\xb0" +
-	"\xa9l\xe1\xa7\x03J\xc3\xc2\x8a>\xb6\x84\x08\x03=\x15" +
-	"\"\x06\x96W\xf0\xd2\x90NB\xfd-\xdd\x8c\xd2\x04\xda" +
-	"\xbe\xe2v\xb6\x8c\xde\xa9]R\xf1B\x018RQ\x16" +
-	"IF\xf8\xbfb\xe3\xfcC\xa9>\xfaI\x92\xf2\xfcy" +
-	"S\x07m\xba\xeax6Z\xdd\x7f\x80-\xf4\xf3\x8a\xc1" +
-	"i\xef\x1bVr\xfb_\xff\xe1\xb6\xafA.\xb7R\xd8" +
-	":?Oa\xa7;.\xdf\xd3\xfc\x9e\xfb\x9b\x8c\x0c\xcb" +
-	"\x9dk\x95\x7f/\xbb\x87sY\xe3'\xb7]P\x1a\xba" +
-	"\\i2\xbf\xc9\x1a\x0e\xfe\xbd\xec$\xa7\xfd\x9a\xefx" +
-	"p\xc6\xf6\xbbF\x1b\xff|*\x9b\xd3^<v/k" +
-	"\x1e\xcb\xb5;\xb6\x1e\xc6\x99Z0\xd6\x16\xd3:\x0ca" +
-	"|\x9b\x16\x0dG\xa7\x04\x0c\xad\xed\xc6`\xb8s*@" +
-	"\x0b\xa2\xea\x12]\x00.\x04\x90=U\x00j\xbe\x88\xaa" +
-	"\"\xa0\x14Nt\xa3\x0b\x04t\x91w\xa68`\x8a\xc3" +
-	"\xa5\xf5\x91D\xd8\xd0c\xf4\xba/\xfd\xba\xe6\x07P\xaf" +
-	"\x17Q\xed\x12\x10Q\xa1\xf2+\xeb5\x00\xea|\x11\xd5" +
-	"\x90\x80\xb2\x80\x0a\x0a\x00r\xf0\x0a\x00\xb5KDu\xb9" +
-	"\x80\xb2(((\x02\xc8\xcb\xa6\x02\xa8\xb7\x88\xa8\xde'" +
-	"\xa07\x1e\xbcIG7\x08\xe8\x06\xac\\\x1c\x89\xb5\xc7" +
-	"\xb1\x08\x04,\x024\xe9W(\x187\x00\x00\x8b\x01[" +
-	"D\xe4P1\xe0\xd2\xd6\xa0A\x88\xb5\x8c\xc9\xe5\xb4\xf4" +
-	"bJ\xfai\x91P{\xfcj=6{qd\xf6\xe2" +
-	"HK(\x81\xf1\x0c=LI\xe9a\xa4\x80\xf5\xdd\xbd" +
-	"N\x9e>;-\x03\xf6\xe3ni\xf7\xeaH\xb0}N" +
-	"8\x18\x09'\xb5\x9b/\xba\x8aL\x93\xb3\x1dS\x02\xa0" +
-	"\x8e\x14Q\xad\x16\xd0\x83\xdf\x9bI\x0d\x8d\xa3\xd5\xd1\"" +
-	"\xaa\x13\x05D\x0d\xf2\xb0\x15\xf2\x06\x88\xdc\xdc\xd6\x15\x19" +
-	"\xaf\xb7uEF\xb6h1\xad;\x0eNi\xcbl\xab" +
-	"\x89\xc1pZQ\x99Fk\x96\xda\xba\"\xc9\x83\xba\x01" +
-	"\xd2m2Z\xed\x9a,\xfbA\x90\xdd\x92\x97\xf6i\xc0" +
-	"\x16\xc4\x9c\x9e3K\x8aD\x8c\xd4\xe9\x10]\x88(\x8f" +
-	"Y`\x1f\xc3\x972\xfe\xc5t\xb4\x89\"\xaa\x0d\x02\x9a" +
-	"\xda\xdc\xa0\xd1\xd5\xa4w\x80WK\x84\x0c\xf4\xd9m\x1d" +
-	" \xfa\xb8\xb2\xd0\x0f\x80\xda\x00(\x8b\x8e\xf5Xsw" +
-	"\xd4\xe8\x85lB\xb6E\xc2qc\x06\xf7\x10\xe2\x996" +
-	"\\:\xcfs\xc3\x81\x8cC\xe5|\xf4~ \xe5\x0f\xa7" +
-	"\x7f\xca\xb3mrUXo1b?\x18&Q#\x86" +
-	">;\x1c3\x04\xce\xeer3\x82q4~\x82\xcb\xa5" +
-	"\xdb\xb4\x1c.\xd7\xa4w\x90N\xe3\\N%\xc9\x13Q" +
-	"^B\xf1\xd8\x93\x0a3\x1e\x90\x88\xf2\x0aZ\xbcUD" +
-	"\xf5\x0e\x01QPP@\x94WS\x90\xae\x14Q\xbd\x9b" +
-	"\xe2\x11\x15\x14\x11\xe55t\xca;\x92\xf1(\xbb\x04\x85" +
-	"\xcc,\xdfCo\xdf)\xa2\xfa\xa0\x80^C\xef1R" +
-	"\xde\x062VI\x1d\x91\x88\xb7]34\xf4\x80\x80\x1e" +
-	"Z+\x93Z\xb5XeG(\xa2\x19X\x00\xc2\xf1\x82" +
-	"?~=\xad\x01P\x0a\x86\x0d\xca-\xc7]+L\xd3" +
-	"\x04\xf4&h!\x1f\x049\xdf\x9f\xe5xscZ4" +
-	"i\xeeLC<\x05\xa0\xfaDT\xcb\x054\xbb\x83\x9d" +
-	"]\xc6\x95\x11\x03\xa7\xea\xb3t-\x14\xea\xad\xe4\xef\xa0" +
-	"\xcf\xbe\x96\xe50\x8e\x1d\\\xb3\xf48\xd7#\xe4\xb2v" +
-	"$a\x0c\x88\xaf~~\xd7\x1cNt'\xfd\xcek\x17" +
-	"S@\xb7\x17!w85\xa6SEj\xcf1Uv" +
-	"\xa6\xb02\xa93O8\x933\xb6\xa2\xcf\xbe\xa6g\x1c" +
-	"\xd1e%l-\x14\x0a\xe8\x0b\x13z\xb8M\x1f\xdf\xa9" +
-	"\x1bW&\xba[\xf5\xd8\xc8Yz%?\xb0\xf3\xb8%" +
-	"\xf6q1LF\xc1\xfc,\xa2\x93\xce\xa6jq=\xd3" +
-	"\"~\xfbm\x9eGP\xb6\xaf\xdb\x80(ga\x95\x96" +
-	"M\x0a\xb7\xe9v~\xb2j*Z7:Y\x9e\x05\x82" +
-	"<H2-\xf9\x01c\xfd\xd3Tf\xb4]\x15\xd6\x9b" +
-	"4C\x9b\x11\x14\xe3?%\xdc\x9c\xc1\\\x9c%\x91N" +
-	"\x93\"F\xd7\x0f\x9c\xbbU\x8b\xeb\xe8\xb3/\x9a9\x92" +
-	"\x18\xa5\xa8\x80\x11K\xb4U\x1a\x97j\xd1p\x8e\x1c3" +
-	"R@i\x91\xdefK\x97\xeeKr$\x83Yzg" +
-	"L\x8f\xc7\x83\x11\xe4,K\xd3,\xd7\x92\x8cw\x8b\xa8" +
-	">d;\xd5:\xaa\x1d\xf7\x89\xa8np\x94\xe7\xdf\x11" +
-	"\xe1\x83\"\xaa\xcf9\xca\xf3\xb3\xa4\xb1'ET_\xa5" +
-	"t\x80\x0a\xba\x00\xe4=$\xe5N\x11\xd57\x04\x94\xdd" +
-	"\x82\x82n\x00\xf95Z\xdc-\xa2\xba\xcf\xd6E\xfa^" +
-	"\x90\xd4\x85\xd8Z\x8d\x85 `!\xa0\xb7U74\xeb" +
-	"t\x85\xc9\x8a]\x1f\x0dia=n\x9f9\xdd\xc4&" +
-	"\xcf,\xf5v'\xac\xf7\xa5\xdex\xbb\xf5<\xc0\x11\x92" +
-	"\x19\x97|\x80\x0a\xbd\x11\x83\x8c\x86\xa5\xcanX\xe4t" +
-	"\xc7Rew,<AR\xc3B\x1ai\x17Q\x8dZ" +
-	"\x09\x12@\xee\xf6\xa7\xba\x18\x83L\xa4\x85P\x04\x01E" +
-	"@\xa9=\x11\xb1z\x17o\xd4\x88M\x18X\x1fh\xb9" +
-	"\xe6\x07\xca\x86\xe5i\xd7y\xc7w\xc6\xa2\x94\x18\xa8\xa4" +
-	"\xfa)3\xd4\xd8\x99A&\xf1\xc6Rj\x98b\xa7\x86" +
-	"\xca\x8e`,n\xe0 \x10p\x10`}\\o\x8b\x84" +
-	"\xdb\xad\x9f\x03\x14\xd4\x18\x0aE\xda\xa6\xea\xe1\xb6\xaen" +
-	"-v\xe3\xf8\xcb\x82\x92\x1ej\xcf\xf0\xc4V\x00\xb5H" +
-	"D\xb5T@3n\xc4\x82\xe1\xce\xab5\x90B\x09}" +
-	"@&\xb4\xb2MK0\xaa\x87\x82a=6>\xac/" +
-	"N\xff\x18\xd9\xa2y\xa9w\xf9\xb1\xe4\xe9\xe4\xe4\xc8\x8b" +
-	"\xfdNo%\xc6Y\x00\xea\x85\"\xaa\x93\x05\xac\xd4{" +
-	"\x8c\x98\x86% `\x09\xa0\x19M1\x03\x8c\xa1l\xdf" +
-	"d3\x12\x91\xadm\xaa^?\x10\xd8\x8e\xe2\x96\xb5'" +
-	"I9Yf2\xf7g\x13\xdao\x9b\xec,\x9d$K" +
-	"oq6\xd9.}C\xc9\x91\xed\xae\xab\x8f\xeb\xb1E" +
-	"\xc9^?\x8bW\x8c\x16\xd0\\\xac\x05\x8d`\xb8s\x01" +
-	"H\x91VG\xd4\xa6\xaf\xec98O\x95&\xd5N:" +
-	"s\x1e\xcd\xc8\x1d9\x14?{q\xc4\xdb\x12J\xc43" +
-	"r^\x95\x9d\xf3\xd2\xba_We'=+\xc2\xd39" +
-	"\xef\x11G\x84?L\x8b\x0f\x89\xa8n\xb4Z \x00\xf9" +
-	"Qz{\x83\x88\xea\x93\x8e\x9c\xb7\x89(\x1f\x11Q\xdd" +
-	"y\xe6\\\xe0\xec\xe6\x1cf\xceX\x96\x8cX\xfa\x06\xe4" +
-	"\x0d\xc5\x8dZK\xaf\xee3\xdfjZ\x8c\xd8Om1" +
-	"\xd3\xd3\xb1\\\xb6\x12\xb5\xce\x0cvSmS-mK" +
-	"\xde\x07\xd1g\x7f\x04\xc8a\xadt|[\xcd\x1c\xd5z" +
-	"k\x04\x88\xd6\xc4O\x96\x17$k\xbd\x95\x11\xc0K\xef" +
-	"4\xa0\xeaB\xc7X\x16` \x7f*\x1d\xbc3\x81\xdc" +
-	"\xe5\xcfv\x85\x9a\x94+<c\xbb\xc2f\xd2\xd3F\xab" +
-	"\xfc5d\x94\xbfm\x0eW\xd8B\xf7\xd8\xe7\x92V\x97" +
-	"\xddb\xd2\x15v\xd0\xe2\x8b\xc9B\xe9\x0dk\xdd\xe94" +
-	"Y\xd9\x15\xe9\xb6\x8bZ\xbf\xf6\x90\x17\xbd\x98FAd" +
-	"\xd9\xbc\xbeM\x0b_\x16\xea\xe5\x97X\xea\x1d\xdb\xb4\xa8" +
-	"\xd6\x16\xa4\x1e\x18,\x12\xb3[\xeb\x09Du\xbd\x9d\xd6" +
-	"2K\xa0e\xb8F\xa9\xb6\xa6\xfa\xec\x83,\x9d\x05\xa4" +
-	"\x05\x91\xd6\xdcM\xea\xc0\xbc\xd6$\xa0\xd4\xd6\xddn\x1d" +
-	"\xde\xab\xc5:\xe3\x19\xf7\xf5\x01\xb6K\x17\"l\xfc\x11" +
-	"\xad\xcb\x15\x8e\x88\xb5Z\x97\x87k\x1c\x11k\xb5.\x8f" +
-	"^\x91\x0a\xceg\xc8v\xf3\x93\xb6\xebge+\x8c\x9f" +
-	"\xad\xb1\xad\xdc\xcfvfk0ft5iN\xf5W" +
-	"F\xbb\"a\x9b\"\x1el\x0d\x05\xc3\x9dq\xa2H\xb5" +
-	"\xe6\xf5\xf1h$\x11\xd7-\x1bVvG\xc2zoN" +
-	"K\xf1\xf2\xc3\x1b\xe0\xa2\xf4\xc1\x9b\xe9\xe0\x0d\"\xaa3" +
-	"R\xe5\x9e\x16\xa7\x93\x94M\"\xaa-tr1y\xf2" +
-	"\x99d\x8di\"\xaa\xb3\x05\xf4\xf6\xeaZ\xccJA\xb4" +
-	"\xab\xd1\x85y `\x1e%$\xad\xd7z\xce\x99B\xf8" +
-	"\xc5)}\x83\xfe\xb1)\xc4y\xcd\xca\x96B\xe6\xd6\xc7" +
-	"\xb4hMO\xcdY\xdd\xe12\xb2\xe3\x00\xe6\x97\x89\x13" +
-	"\xea\xce\xde\xcb\xb3\\\x1c\xce\"\x87\xa6?\xd5\xe5\xe8\xcc" +
-	"\x1bS\xbf\xad.\xd4\x9a\x0b9\xe7f\xf6\\H\xf7\xdb" +
-	"}\xa8G8m\x0e\xecD=\xe2)3\xd5\x8aV\xd9" +
-	"\xad\xa8wQ$\xd8\x0ey\xde\xd6I\xb5\x93\xd0g\x7f" +
-	"qHU\x1b\xad\xb6\xa6\x9ad\xb5F\xef\xa9j\xd31" +
-	"\xa1\x0e}\xf6\x14:\x87\x96\x1b\xeb\x83\xb1h$\xc6\x95" +
-	"R\x9et;?\xef\xa6\x9a\xab\x00P\x90/\xa1?\xa2" +
-	"\xfc3\xfa\xe3\x92\xc7\xd1\x1f\xb7<\x8a\xfe\xe4\xc9\x15~" +
-	"\x00o8\x12\xd6\xa5\x05\x1d7J!\xadG\x8awD" +
-	"\xa4Pb\x91\xd4\xde\xb1\xd8k\xe8qc\x80\xc2\xb89" +
-	"f\xeb=)?t\x84E\x953,R\x09azU" +
-	"*,\xe6SX\xa4\x92\xf9<2\xd85\xc9\x19\x88d" +
-	"\xa4\xe7\x15(\x85l\xcb\xa5\xd2Q}(n8V\xcf" +
-	"\x90\xab\xe6&\x9d9\x1a\x12\x13\xf1\xb3\xf2h\xe7\x1c\xd1" +
-	"\x97k\x9c\xd0\xa4\x19\xc9B\x961\xbf\xf2\x01\xf8\x92\xb3" +
-	"\xab\x81i$\xa9\xac\x9di\xffb3\xdd~\x80\xc04" +
-	"\xb7\x88\x81\xd9n\x87\x8b1\xd5]\x06\x10\x98A\xc05" +
-	"n\x01+\x84\xd3f2\x8f\xb29\xee*\x80@\x0b!" +
-	"\xd7\x13\"\x9e2\x93\xc9\x94]\xcb\x91\xd9\x84\xcc'\xc4" +
-	"\xf5\x9d\x99\xbc\x0c\xb2y\x1c\xb9\x86\x90vB\xdc\xdf\x9a" +
-	"\xc9\xb4\xca4\x8e\\OH\x17!y'M\x97\x82y" +
-	"\x00L\xe7\xc8|BB\x84H'\xcc|\x85\x0f\xbc\x83" +
-	"\\\xb6vB\xa2\x84\xe4\x7fC\xfb\xe4\x03\xb0n\xfeN" +
-	"\x17!\x06!\x83\xfe\x97\xf6\x19\x04\xc0\x16r$DH" +
-	"\x0f!\x05_\xd3>\x05\x00,\xc1\x91(!\xb7\x10R" +
-	"\xf8W\xda\xa7\x10\x80\xf5\xf2}\x0cBn%\xa4\xe8/" +
-	"f\x83\x82E\x00l\x09W[\x0f!\xcbIm\x9e\xaf" +
-	"L\x05=\x00l\x19\x07n!`%\x01\xc5\xc7M\x05" +
-	"\x8b\x01\xd8\x0a\x0e\xdcJ\xc0\x1d\x04x\xbf4\x15\xf4\x02" +
-	"\xb0U\xee)\x00\x81\xe5\x04<D\x80\xef\xcf\xa6B6" +
-	"d\xeb8p\x1f\x01\xcf\x10 \xff\x8f\xa9\xa0\x0c\xc06" +
-	"s`#\x01\xbb\x09(9f*X\x02\xc0vq\xe0" +
-	"E\x02\xde#\x80}a*\xc8\x00\xd8~\x0e\xec#\xe0" +
-	"K\x02\x94\xcfM\x05\x15\x00v\xcc]\x03\x10\xf8\x8c\x00" +
-	"W\x9e\x80\x9e\xc1GM\x05\x07\x030\xcc\xa37N\x11" +
-	"PJ\xc0\x90#\xa6\x82C\x00\xd8`\x0e\xf8\xf2D\x0c" +
-	"\\H\xc0\xd0\xcfL\x05\x87\x02\xb01\x1c\x18I@\x03" +
-	"\x01\xa5\x9f\x9a\x0a\x96\x02\xb0K\xf2h\x8f\xc9\x04\xcc&" +
-	"\xa0\xfc\x13S\xc1a\xe4by\xa4\x92\x19\x04t\x11P" +
-	"q\xd8T\xb0\x8c,\x9f7\x95,O\xc0\x1d\x04\x9c\xf3" +
-	"\xdf\xa6\x82\xc3IW\x9c\xd5r\x02\xee$\xa0\xf2cS" +
-	"\xc1r\x00\xb6\x9a\x03+\x09\xb8\x9b\x80s\x0f\x99\x0aV" +
-	"\x00\xb05y\xad\x00\x81;\x09\xd8H\xc0\x88\x8fL\x05" +
-	"\xcf\x01`\x8f\xe6]\x01\x10x\x84\x80g\x08\xa8\xfa\xd0" +
-	"T\xb0\x92\xb4\x9bw\x1d@\xe0I\x02\xb6\x11p\xde\x07" +
-	"\xa6\x82\xe7\x02\xb0-y\xb3\x00\x02\xcf\x11\xb03O\xc0" +
-	"\x8a\x91\x07\xc9\x83F\x00\xb0\x1d\\\xdem\x84\xec\xa6W" +
-	"F\xbdo*XE\x06\xe1'|\x91\x80W\x098\xff" +
-	"=S\xc1\xf3\x00\xd8\x1e\x0e\xec$\xe0\x0d\x02.\xf8/" +
-	"S\xc1\x91\x00\xec\xb5<r\xc6\xdd\x04\xec#`\xf4\x01" +
-	"S\xc1Q\x00\xecM.\xef\x1b\x04|A\xc0\x98\xfd\xa6" +
-	"\x82\xe7\x03\xb0#\x1c\xf8\x8c\x00\x97$\xa0g\xd8\xbb\xa6" +
-	"\x82\x17\x90\x09%\x92\xea\x14\x01\xa5\x04\x94\xbdc*8" +
-	"\x9aL\xc8\x01\x9fD&$`\xf8\xdb\xa6\x82c\xc8\x84" +
-	"\x1c\x18I@\x03\x01\xfe\xb7Lt|\xdag\x97HU" +
-	" x\xc6\xee3\x15\xbc\x10\x80\x8d\x92\xe8\x10\xe7\xa6\xf9" +
-	"\\\xf8\x9f\xa6\x82\xe38\x9f)\xfd\xf8\x8c\xdbk*8" +
-	"\x9e\\\x81\x03\x93\x09h\"`\xfc\x9b\xa6\x82\x17\x01\xb0" +
-	"F\x89t\xdb@\xc0\x0c\x02.z\xc3T\xb0\x1a\x80M" +
-	"\xe7\"5\x11\xd0B@\xf5\xeb\xa6\x82\x13(qI1" +
-	"r\x1e\x02\xae\x91\xd2\x95M\xbc\xe9&\xf4\xd9_\xfe\xac" +
-	"\x02V71=\x12\xea\xa8\xad\xc1\x02\x10\xb0\x00P\x0a" +
-	"\xd6M\xb4\x1a6)X[c\xb5fRpB\x9d\xd5" +
-	"!\x89\xc1\xc9(\x80\x80\x02\xa0\x94\xa8\x9bh\x0dK\xa4" +
-	"Dm\x8d5\xff\x94\x12\x13\xeaP\x02\x01%@11" +
-	"\xd9\xea\xa0\xbc\xad\x91H\xc8j\xef\x9c\xf3p\xf4\xb6\x86" +
-	"\"\xad\xd6\xac\xa0\xbe\xa3n\xa2c\x86gM\xb9:j" +
-	"k\x1c\xab\x05\xa9\xd5`?Z\xb7\xb5\xda\x8f\xd6e\xad" +
-	"N\xa8s\xac\x8a\xc9\xd5\xca\xe0d\xc7\xa2\x90\"M\xf4" +
-	"c;\xc8Z\xed\xc76\xdfZ\xed\xc7VJ\xb1M8" +
-	"\xd9\xe6%\x17\xbd7\xf5\x9bM:\x8dR\x0c\xb8\x94P" +
-	"\x07A.\xba\xca\x9b\xa8\xf3\x1dP\xe2\x92\xeb\x1a\xfa\xec" +
-	"\xff\xe7\x91Q+\xa1\xffh4cLh\x93\x01d\xa0" +
-	"\xc4$\x96\x1a\x94\x82\x18\x09\xa3\xcf\xfe\x9f3)\x98\xcf" +
-	" [\xb58`\x96\xceq\xa9\x96l\x842\xaeq^" +
-	"2\xfc\xffC\xdf\xc5\xf5\xb1Hos|\xe3\xcc\xfc~" +
-	"\x95$\xd22\x89\x9c\x9a\xe2\x9fD#\x91\x90CG\xa9" +
-	"O\xa2KS\xafZ\xcb\x9e\xd42y\xb0c9\xd5\xfb" +
-	"H\x9d\xb1h\x8ea~=-\xdb\x97\xda\xfe8\xb9\x92" +
-	"\x16\xeem1b\xe9\x81\x9c\x16\xee\xe5#o@\x03K" +
-	"\\\x08H\xabK\xb50\xbfm`\x89KH\xae\x10\xdd" +
-	"\xa5ZTk\x85\xca`(h\xf4b\x89K\xb4\x90\xcc" +
-	"\xa6\xda\x9a`V\xf2\x9b#\x9f\x0f\xd8\xff\xfd\x08k*" +
-	"/\x0b\xea\xa1\xf6\\-|\x07\x81\x8e\x91U\xfa\xcd\x1c" +
-	"-\xfc\x95z\xdc\xd0c\x13.\xd5\xc4\x01\x03{\xbf\xcd" +
-	"\xd6\x1b7b9/\xbbg\xf8$\x939$\x152G" +
-	"\xd9\xa8\xfd\x98kx\x95\xe3cQ\x8ey\xd4\xc0/\x07" +
-	"sg\xebq\xe3R-\x8a\x99g\xbb.5\xec\x9b," +
-	"\xa0\x19\xd6\xe3\xc6L\xcd\x88\x81\x18\xec\x19\x10\xd8g\xfa" +
-	"8\x91\xfe(\x83\xda\x0f|Su\x08\xfc\x7f\x01\x00\x00" +
-	"\xff\xffX\x17OX"
+const schema_832bcc6686a26d56 = "x\xda\xbcz\x0dxT\xe5\xf5\xe7{\xee\xcd\xe4\x0cH" +
+	"\x98\xbcyoJ\xc8\x07c\x06\xd00\x0a\xe6\x03\x02\xa4" +
+	"uC\x90(R\xd0\\.hqK\xcbMrC\x06" +
+	"'3a>\x80Xy([\xa8\xda\x85\xad\xddj\x95" +
+	"*]ea+*V\xda\xba\xab\x14\xfc\xaa\xb6Bq" +
+	"+\xaehu\xc5\x0f,\xb6R\xd9\x8a\xd5\x15\xfcz\xff" +
+	"\xcf\x99\xb93sgr\x93\xd8\x8f\xe7\xcf\xf3\x1c\xf2\xcc" +
+	"\xfd\xbd\x9f\xe7\x9c\xf7\x9c\xf3\x9e\xf3\xd6\x7fX:\xa7\xa8" +
+	"\xa1\xe4\x86*\xa6\x18s\xc0S,\x0fk[nZ\xff" +
+	"\xc2\x7f\xde\xc8t\x1f\xa8\xf2\xaa\xbe\x1d\xdf\xed9|\xc1" +
+	"w\x98GA\xc6\xc4\xee\x92}bo\x09\xda\xb4\x961" +
+	"\xc1\xc7\xa2|\xfa\xcc\xa9\x17\xeb\xbf5y3u\x81\\" +
+	"\x97\"\xea\xf1Y\xc9!1j,\xda\xd4\xca\x98h\x1f" +
+	"\x8br\xf9\xefJ\xbf1j\xff\xfc\x1b\x0azxT\xea" +
+	"\xd20v\x9f\x98=\x16m\xfa\x13cb\x99\x0f\xe5\x1f" +
+	"~\xf1y\xfd\xc4\xd6_\xdf\xc0\xb8\xcf\xd9\x03\x90\xb1\xa6" +
+	"v_\x19\x88\xa5>\xb4\x89f\xd9\xedC\xd9\xb2nN" +
+	"\xf3\xcf\x9f\xad\xbd\xb1`\x96y\xa80&n\xf5\x1d\x12" +
+	"w\xfb\xd0&\xdaJM)\xca\x1d\x7f\xabz\xe9\x97\xdf" +
+	"\xfc\xd2\xf7\x18\xd7\x80\xd9\xa3\x8f*U@\x94\x97\xa2M" +
+	"4\xba^\x8ar\xfa\xe4\xbaw\x9e\x08\x18\xff\x85q\x9f" +
+	"\x83O\x0c\xc4\xc5\xa5;D{)\xdat\x99\xd8X\x8a" +
+	"D\xf2\xe6g\xfc\xbfi\xfb\xd6\x03\xdf/\xd8A\x8a\xb1" +
+	"}\xa5\xc7\xc5@)\xda\x94b,G)c\x87O\xea" +
+	"\xdb\x0e\xfe0\xbfG\x9a\xaf\xa5O\x0a\x0f\xc7\x0c1&" +
+	"\x80\xa3\x1c\xf3\xc2\x8f\x9e*\xbb\xaf\xfe\x16\xc6}Ey" +
+	"k:UzH\x9c-E\"\xe3L\xa9\x0aF5W" +
+	"@4p\x94[k\xbd\xd3\xcf\xac\x7f\xec\x16\x17\xbe\x8a" +
+	"\x1a~HL\xe1h\x13m\xbc\x8f\xa3\xbc6\xb2Bk" +
+	"\xfd\xfc\xd1[\xdd$\xb1\x8c\x97\x81\x08q\xb4\x89\xba\x1c" +
+	"\xa0In\xf0\xfff\xfeMo\xddF\x92P\x06)\x15" +
+	"\x7fR\xec\xe5H\xd4\xb4\x97\xfb\x811\xf1a\x19\xcae" +
+	"\xf7+w\xdc\xb6\xed\xa1\x1f\xbb-\xec\xcd\xb2C\xe2T" +
+	"\x19\xdaD\xb3\xcc\x16(\xb7\x7f\xeb\xd8#S^\x9ay" +
+	"\x87Sx\x93\xc5h\x103\x04\xdaDMW\x0b\x94\xcf" +
+	",=\xee\xd9w\xfe\xf7\xef\x18\xc4\xa8\xe5\xe2\x90\x08\x09" +
+	"$2z\x85\x0a\xc6\xf5B\x01\xb1Y\xa0L~\xb9\xe8" +
+	"\xbb\xb2\xb1~{\xa1\xce\xa6\x16\xb4Z\x1c\x12\xeb\x05\xda" +
+	"D\xf2\x03\x0d\xe5m\x13\x8e\\\xd4q\xd6\xda\xc9\xf4j" +
+	"\x00\x96\x12\\\xd3)\x11\x03\x02m\xa2\x05]\xac\xa1\xec" +
+	"\x99\xbf\xf2\xfd\xcf\xc5c\xf7\xb8\xedv\x8a\xf6\xa4h\xd0" +
+	"\xd0&\xea\x91\xd4P\x9e\xfb\xcc\xb8u\xdf|\xfd\x81\xfb" +
+	"\x06\xe9\x9f\xa9\x1d\x17}\x1a\xdat\x99\xd8\xa3!\x91<" +
+	"\xbd~Qp\xee\xd2\xe7\xees\x93\xdb6\xad\x12\xc4n" +
+	"\x0dm\xa29>\xd3P>\xf2\x97\x07\xa3\xed\xaf\xdc\xb0" +
+	"\xc7mU\xefh;\xc4i\x0dm\xa2\x1e3\xcaQ\xf2" +
+	"\xfe\xee\x97\"\x9e\xfb\xf7\xba\xf5\xa8-\x7f_L-G" +
+	"\x9b\xa8\xc7\xc6r\x94\x1b\x9a\xbf\xb1i\xf9\xac\xf7\xf6\x16" +
+	"\x1a\x9c\x94-\xe8+\x7f^\x0c\x94#Q\xd3@\xf9\xd5" +
+	"\xc0\x98\x98<\x0ee\xfc\xd0t\xf9\xee\xf1\x09\xff\xd3M" +
+	"\x9f\x9a\xf88\x05D\xcd8\xb4\x89\x0c\xc8\x94\x0a\x94;" +
+	"\xff\xc7\xbe\xc9\xbf\xe9\xbb\xe0\x7f1\x9d\x83*_\xbe\xeb" +
+	"\xc5/\xdf\xf6\xcey'Y9 \x8dZ^q\xaf\xa8" +
+	"\xa9@\x9bH\x80\xdb*P\xbe\xbdj\xd3\xa9\x87\xbb?" +
+	"y\x98\xf1\x09 \x97^\xf5\xe8\xfe\x9f\xfc\x87\x93\xef\xda" +
+	",\xdb\\\x11\x00qk\x05\xdaD\xdb9Z\x81\xb2\xe9" +
+	"\xcc\x0f\x0e\xed\xfd\xc5O\xf71>>#\xf2'*V" +
+	"\x01a\x19bL\x1c\xa9@\xf91\x9cX\xf0\xd5\xc7\x9e" +
+	"\xfdU\xbaez\xed\x07*b@\xa0M\xb4\x8ee\xe3" +
+	"\xf1\x93\xe0\x95o\xaf\xf9\xe0\x8f\x8f\xe5\xf34\xc5\xa0\xf6" +
+	"\xf1\xf7\x8aE\xe3\xcf\x17\xd6x\x14\xd6\xf8\x9f1&\xb6" +
+	"V\xa2\xdcq\xce\xfb;\xceZ\xaf\xfc\xd6M\x0a\x03\x95" +
+	"\xf7\x8a\x8d\x95hS\xea\x84V\xa2\x9c\xbah\xf6\x81\xb7" +
+	"\xee\xfb\x8f\x07\xdd\x8c\xd3\xee\xcaC\xe2\xa1J\xb4\x89\xd6" +
+	"\xd4V\x85r\xdfG\xaf\x1f\xfd\xe6\x9aW\x7f\xe7\xa6N" +
+	"S\xab*A\\\\\x856\xd1$?\xa8By\xfbS" +
+	"\xbb\xcfy\x9b\xcf;\xec\xb6\xac\xf5U\xfb\xc4\xe6*\xb4" +
+	"\x89z\x1c\xabB\xf9\xc6y\xb3V\xbc\xf5\xf3_\xb8\xf6" +
+	"8X\xb5C\x1c\xa9B\x9b\xa8Gy5\xca\x0d\xa1\xf7" +
+	"\xd7\xdc\xba\xe6\xfd\xc3L/\x07\xc80.\xdd\x01\xaa_" +
+	"\x11%\xd5h\x13\xed\xe3\xa6j\x94\x97w\x1c\x7f\xf9\xf8" +
+	"\x03\xf3\xfe\xb7\xab\xfe%\xabO\x8a\x8d\xd5H\xd4\xb4\xb1" +
+	":\xa5\x7f5\x13P>\xf3\xed'\xaa\x0f\x9d\xbc\xe3\xf7" +
+	"n{\x1f5\xa1\x0c\xc4\xf8\x09hS\xcajN@\xf9" +
+	"\xf8'U\xc7\xff\xeb\xf7/x\xd1e]\xcb&\x1c\x17" +
+	"\xd6\x04\xb4\x89\xd6uv\x02\xcaG_\xb9\xf1\xf4\xceO" +
+	"\x9a_t\xdb\xfa\x89\x09?\x16\xa7&\xa0M4E\x83" +
+	"\x1f\xe5\xfe+\xce\xbc\xe7\x9d\xa3\xbf8\xc8\"\xd4\xf8\x0f" +
+	"\x89)~\xb4\xe92\x11\xf2\x8f\x13\x03~\x94?\x98\xf9" +
+	"\xd3\x15\x91\xdf\xef\xff\x03m\xbd\xa8P\xea\x96\xff\x90X" +
+	"\xedG\xa2\xa6\xd5\xfe\xd4\xd6KjQn\xfd\xdd\xcbk" +
+	"o\\\xb1\xe5e\xb7e\x9d=w\x87\x80Z\xb4)\xe5" +
+	"\xeckQ\x8a5\x9f\x84z\xda\x8e\x1csS\xad\x86\xda" +
+	"\x1dbv-\xdaD[\x7f\xba\x16e\xf5\x13]\xde\x1f" +
+	"U\x06_s\x8dA\xf6\xd6>/\x0e\xd4\"Q\xd3\x81" +
+	"\xda\xd4\xba\xda&\xa2\xdc\xbe\xe4\xea=\xbfz\xa0\xe35" +
+	"\xb7 d\xea\xc4{\xc5\x8c\x89h\x13\x1d\x92\xd3\x13Q" +
+	"\xfe\xb0\xfe\x9e\x8f\xbf\xf2\xc2O^s\x13\xe2\xb1\x89\xa3" +
+	"A\x9c\x9a\x886\xd1V\xda&\xa1\xdc\xf3(\xf2\xa3G" +
+	"v\xbc\xe9\xb6\xf9\xa9\x93\xf6\x89\x19\x93\xd0\xa6\x94u\x9b" +
+	"\x84\xd24\x9a\xca\x9e>y\xd0\xb5G\xdf\xa4\x1f\x8b\xe4" +
+	"$\xb4\x89z<2\x09\xe5\x99ew}\xe7\xce\x1d\xde" +
+	"\x13n\xcb\xda5\xa9\x0c\xc4C\x93\xd0&\xea2j2" +
+	"\xca\xa7k\xab\xaf\xdc\xfd\xe4\xd8\x13L\x1f\x07j.\x82" +
+	"[\xaa \x14\x917\x9dtR\xc0d\xb4\x89\xec\xe1\x9b" +
+	"\x93Q\xee\xfd\xed\xd2\xd7\x1e(\xbd\xe2D\x01\xc3\xda\x01" +
+	"\xa9\xcf\xb3\x93\x9f\x14G'\xa3M\xd4\xe7\x9d\xf3P\xd6" +
+	"^t\xa6\xea\xd3\xcd\xcbimJ\x9e\x86\x1d=o\x9f" +
+	"8v\x1e\xdaD\x1c\xb6\xceG\xf9j\xf1\xd9\xff\xb6i" +
+	"\xc3\xc6\xc2\xad\xa4N\x96~\xfe!\xb1\xfc|\xb4\x89z" +
+	"\\^\x87rYo\xe5_[\xfe\xb2\xe9m7v\xcd" +
+	"\xa8{E\xb4\xd5\xa1M\xb4\xf7\xadu(\xaf=\xfa\xe8" +
+	"\xce=\x95\xab\xff4\xc8\x93\x0f\xd4\x1d\x12\x9b\xeb\x90\xc8" +
+	"\xd8T\xa7\x82q{\x9d\x02bW\x1d\xe6l\x7f\xbez" +
+	"\xb5\x036\x90\xa1\xaa\xfb\x9e\xd8V\x87DM\xdb\xea\x1e" +
+	"\x1b\xcd\x98\xb8\xbb\x19s\xd6\xdfee[\x9b\xdf\x17\xdb" +
+	"\x9a\xc7\x89]\xcd(v5\xd3\xca\xce6\xa3\xfc\xf8\xab" +
+	"Wm\xb8\xf2\xc1s\xfe\xca\xf4\x1aPs2*W\x10" +
+	"\x18k:\xd1\\\x06\xe2\xc3f\xb4\x89T\x7f\xf5L\x94" +
+	"\xd5\xde\xe5sG\xed\xb9\xf2\xb4\xdb4\xcbg\xbe\"B" +
+	"3\xd1&\x9a\xe6\xa1\x99(o\x1f_\xf6\xbd\x0f\xfe\xd3" +
+	"\x0d\x1f2^\x9dq8w\xcf\\\x05\x84e\x88N\xcd" +
+	"L\x94\x9f\xf5\\v\xb0\xfdU\xcfG\x05^3uD" +
+	"\xb6\xcf|^\xec\x9e\x896\xd1j&\xcfB\xb9\xaa\"" +
+	"|\x996O~\xe4\xb6\x1a>\xebyQ3\x0bm\xa2" +
+	"\xd5l\x9e\x85\xf2\xb5\x85\x8f\xfe\xb0.\xf1\xdf?u;" +
+	"\x86\xabg=/\xd6\xcfB\x9bR\xfa>\x0b\xd9\xcf\xa4" +
+	"\x19\x8au\xc5\xcc\x9e\x04L\xeb2\xfb#\xfd-\xf3Z" +
+	"c\xe6\xdaPde\x07@\x07(\xbaW-b\xac\x08" +
+	"\x18\xe3S\x82|\x0a\xeau*\xe8\xd3\x15\xa0\x7f9\xae" +
+	"\xf2\x86F\xde\x80\x0c|\x11\xb3\xcf\xea\x00\x05\xc60\"" +
+	"\xf0\xc7{\xcd~\xfa0\x07\xb2\xf3(\xf6<F\xc2\xec" +
+	"\xba6\x14Y9\x971{\xaa\xa2\xecT%\x01^\x82" +
+	"\xfa\x18\x15\xf4\x0a\x050\x92\xec\xa31\x8b\x18\x11\xcc\x81" +
+	"AK\xbe\xa45\x9a\x8c$\xac\x98=Niv\x1c3" +
+	"\xc8M\xd4W\xa8\xa0\x87\x15\x00\xd0\x80>\x86\x1ay\x08" +
+	"\xf5^\x15\xf4\x84\x02\\\x01\x0d\x14\xc6\xf8\xea\x05<\x89" +
+	"zB\x05\xfdf\x05\xb8\xaah\xa02\xc6\xb7\xce\xe5[" +
+	"Q\xdf\xa2\x82~\x8f\x02\xbex\xe8\xba\xd4\xee<\x8c\x08" +
+	"\xfck\xa3\xb1\xee\xb8c\xbb\x92>\x84C\xf1\x04\xa3=" +
+	")0\x96A\x87\x0a)t,\x83\x0d\x9d\xa1\x04\x81\x0e" +
+	"\x04\xd2\x88cK\xaa\xbd\xa5\xf9\xd1pw\xfc*+\xb6" +
+	"dmt\xc9\xdahG8\x09\xf1\xc1\\j\xc9p\xa9" +
+	"^\x81\xd6\xbe\x81\x82\xc1Ks\xce\x95\xb19\xc0\x01;" +
+	"\x14(\x98.#\x8c\xab\xa2\xa1\xee\xa5\x91P4\x92\x15" +
+	"\x86W-\x1a#eZ\xf0e\x0e\xc1\x97\xc0\xe72\xcd" +
+	"\xc7\x862\xde\x80z\xbd\x0a\xfaW\x14\x00\xb3\x03\x14V" +
+	"\x0c\x9d\xa9?.\x1bj\xef\xea\x8dN\xb3\xbaz\xa3\x93" +
+	":\xcc\x98\xd9\x17w\x11z\xa5C\xe8j(\xe2`\xac" +
+	"\x8b\xcc\xdb\xb1\xab7\x9a\x1d\xc3\xc3X\xf6\xf6\x08\x99\x9b" +
+	"\x08\xe7A\xce\xb1\xad\x14\xda4\xe0\xe3\xd1g\xa5z(" +
+	"iF\xa4\xff\x9f\x03\x1d\x00\xc3(\xe7b\x8cF\x139" +
+	"\x8e\x00\x14\x01\x00\x9f\xb2\x8aOE\xfdB\x15\xf49\x0a" +
+	"\x94\xdaj\xd5^\xc6\xdbQ\x9f\xa7\x82\xfe5\x05\xa4y" +
+	"u(\xd1;\xcf\xeaa>3\x19NI\xa54w\x87" +
+	"q\x88\xa34\xc5p\x082\x06\xe6p\xad\\\x85f\xc5" +
+	"\xda\xfb\xfa\x13\x036+;\xd4\"\x97F]\xd1H<" +
+	"\xb10\xa5\x93\xe9f9\xf5\xc8:\xed<\xf5`\x1c\xc6" +
+	"q/\xf8^Go\x15\xfdW\xed>\xf3\x95\x11\xab#" +
+	"\x11\x1b\xf6\xec\x9e\xab\x00\xf6'b\xe9meM\xd3\x90" +
+	"\xdbrW\xfd\x85\xa18$\xfe~\xd5\xcf\xdeQFT" +
+	"\xfdyV\x0f\x89(\xab\x90Zz\x12\x00\xbe>\xc8\xd7" +
+	"\xa3~\xbd\x0a\xfa\x8d\x0a\xf0\x94\xf9\x00\xe07\x05\xf9M" +
+	"\xa8\xdf\xa8\x82~\x8b\x02\xa0h\xa0\x00\xf0[\x1b\xf9\xad" +
+	"\xa8\xdf\xa2\x82~\x17Y\x0f\xd0@\x05\xe0\xdb\x03|;" +
+	"\xeaw\xa6\xad\x07/R4(\x02\xe0\xbb\x82|\x17\xea" +
+	";U\xd0\x1fT\xc0\x97\xb0\xd6%r\x9a\xce8\x04\xb0" +
+	"'\x1a\xf5u\x9b\x89\x94>\x940\"\xc6\xa1\x12;\xcd" +
+	"\x98\xbf'\x1c5S\xcdG3\xe5\xf4\xe8_\x7f8\x7f" +
+	"\x0e\x03\x0cE\x12\xb6}<]\xb4YJ\xc9\xc0\x97\xb4" +
+	"\xbfy\x99\xc2\xbdA\xd7}_\x1d3\xfb\xd3\xea\xe3\"" +
+	"\xc3\x9f\xf3r\xd45\x15\xf4:\x05d_heo\xe2" +
+	"\x8ah\x02\xe6Z\x8b-3\x1c\x1e\xf0\xa7\xfa\xa5\xe5\x9a" +
+	"\xcd\x92\x8c(\xd7\x9c\x05Xl\xc5S\x1c\x1f\xc9\xf0G" +
+	"\x93\x09w#\x90\xa7\xdc\xed\x91d_f(\xf0\xe5\xa2" +
+	"\xa4\xdc\x82<>`\xc3\x9d\xf26\xa7\xd5\xcbx\xbb@" +
+	"\x9e\xb7+4y\xf3\x06\xf9\xa5\x94\xe9\x83\xd2\\>n" +
+	"H\x86\x14e\xdc\x96\x19\x0e\x1b\xd6\xea\xa4\x15\xe9\xb2\xa6" +
+	"\xad\xb4\x12W$\xfb:\xad\xd8\xa4\xc5\x96?\xc5\x9e\xc1" +
+	"\xcc)s0\x07\"\xb6|\xc1\xeb\xba7\xe2\xf6\\3" +
+	"n\xb9\xf08\xe88\xa0\x19\xa3\x08<\x97Rs,\x9c" +
+	"\xbb\x8e\x9d]8F\xba\xac<\x1b\x9c\x09\x87 \x93|" +
+	"\xe1|1/\xc76\x0d\xda*\x80\xd7\xa0\xccl\x93A" +
+	"l\x04S\\h\x0e\xae\x8cX\xf3\xcc\x84\xb90\xa4\xc6" +
+	"\xff\x01{\xe0f~\xc6\xba:\x96\xf9\x18M\xf4\x8e\xc0" +
+	"\xb4N3n\xa5\xa5\x9d\xcd\x1c\x8dh\xad\xc9\x06\x1b\x89" +
+	"X\xb2\xcb\x9f\xb8\xc4\xec\x8f\x0c\xa3\xfa\xf5\x0a\xe0\x1a\xab" +
+	"+o\xfd\xd9\xc8tD{\xb6\xd8Z\x19\xb3\xe2\xf1P" +
+	"\x142sTd\xe7\xd8\x16\xe4\xdbP\xbf]\x05\xfd\xfe" +
+	"\x9cR\xef\xae\xe4\xbbQ\xbfG\x05\xfd\x97\x8exho" +
+	"\x90\xefE\xfdA\x15\xf4\xc3\x8ex\xe8`\x0b?\x88\xfa" +
+	"3*\xe8\xef\x92E\x03\x0d\x8a\x18\xe3\xef\x04\xf8;\xa8" +
+	"\xffY\x05\xfd\x03\x05\xb8G\xd1\xc0\xc3\x18?\x1d\xe0\xa7" +
+	"Q\x7fO\x05\xfd\xd3<\x96e\xef\xbd\xf9,S;\xeb" +
+	"\x09?\x87\x11\x81\xaf\xd3J\x98\x0e\x0e\x9c\x93\x8e\x94Z" +
+	"\xfb\xc3f\xc4\x8a\xe7\xb1&{\x95\xcag\x0d\x0e\xf4%" +
+	"\x1d\x03\xe2@\xbc\xdb\xf1\xd3E\xd1\xd2.\x87t\x8c\"" +
+	"\xaeD\x8c\x0d\x0e'\x03\x8ep\x92g\xe3\xc9\x80#\x9e" +
+	"\x04\xc5\x0e'\x83|5\xea\xfd*\xe8\x9b2\x0e\x811" +
+	"\xbe1\xc87\xa2\xfe\xed\xb4\xef\xc05f\x98V\xa42" +
+	"\"\xc0\xeed\xd4\x11\\\xfa\xfa\x13\xb1\x86a]'\xb5" +
+	"h\xfc\xa2\xce5\xa3\xdd\xd7\xf8\xa6\xad\x8c\xf5\xe7\xac\x1d" +
+	"E/A2w\x8d\x0es\xc7i\x17\x17\x90\xbdkq" +
+	"\x84x\xfe\x9eP,}\xaaF1\"h\x8d[]\xd1" +
+	"H\xb7\xe3\x8b\x0bW\xdb\xc2\xe1h\xd7\\+\xd2\xd5\xdb" +
+	"g\xc6\xae\x9dvi\x08\xadp\xf7`\xe5\xef\xe4\x1c\xf5" +
+	"R\x15\xf4j\x05d<\x11\x0bEV^e2\x0c'" +
+	"-w\x0f\x90\xb1\xa1\x1d\xa1~+\x1c\x8aX\xb1i\x11" +
+	"km\xf6\xc7\xa4\x0e\xd3GA\xa6[`4B\xcf\x02" +
+	"\xeb\xebp\x08\xf9\x1c\xcax\x84\xc5|\x06\xea\xd3U\xd0" +
+	"\xe7+\xe0\xb7\xd6%b)\xad-cD \xfb\xedq" +
+	"\xd3\xe6\x0ex.K4\xa4\x91\xcdH\xaa\xcd\x97H\xc4" +
+	"\xe2\xba\x17 \x97U\xe3\xa3\x1as\xa9,\xeei\xf1\xb7" +
+	"G\x12\xb1\x81\xd6+\xacx\xc2\"9\xe8c\xb2\xcbm" +
+	"o\xcc\x84\xa3\xbd\x8e\xe5Z\x8by\xc8\x9f\x0a^\xee\xa7" +
+	"\xb3n+\xeb\xee\x96\x8c\x058\xac\x80\xbf?l\x86\"" +
+	"y',;\x7f\xfe\x09\x93f$\x12M\x98\x09\x8bA" +
+	"\xb7^\x04\x8a\\\xb2\xf8\xf8w\x9f\xbe\xa1\xe1oL/" +
+	"R\xa0m\x0c0%\xd7x\xd8\x91Z#\x99\x1d\xe4\x9a" +
+	"f\xf79\xa2\xc5\xbe\xc6\x8e\x96\x864\xd9\x15\x0a\x14\x04" +
+	"TC\x84\xd3\xf6\xc1g\xc3\xdc}\xbf\xe2\xe0\xe6\xec " +
+	"\x9f\x8d\xfa,\x15\xf4\x85\xca\xbf\xfa\xc0\xbaD\xc3\xff\x84" +
+	"\xfb\xcb&\x1fFffk\xdc\x8a\xad\xb1b\x83\xe7\xc8" +
+	"\x1e\xd1\xe9\x0a\xc8\xb5f(\x11\x8a\xac\\\xc50\xda\x99" +
+	"o\x8e\xb3\x09\xc7\x11\xa7\x9a\x8b3\x9bf~aO;" +
+	"\x84\xdbp\x11d\x1b\x1d\x9ci\xed\x91\x84\x1a\x1b\xf8\x82" +
+	"q]\xa3\xc3\xce\xe1\xb5\xd6\x803\x87\xb1\xc6\xb4\xad\xd0" +
+	"\xe0\x04D\xbe\xea,Y\x1b\xf5u\x84\x93\xf1\xc1n7" +
+	"\x90q\xbb;\x1d\xdasw\x80\xdf\x8d\xfa]\xb63\xce" +
+	"\x1c\xc5`\xe6(\xeew\xf8\x8dG\x82\xfc\x11\xd4\x1f\xb6" +
+	"\x9dq\xea\"A\xce8\x90q\xc6/8\xdc\xee\x91 " +
+	"?\x82\xfasi\x17\xfd\x85}\x8c\xdb})_e\x87" +
+	"h\x81\x89\x983'\xe2\x0b\xc7\x13M\x0e}\xf0|\x91" +
+	"\x0cGG\"\xf6\x0f^\xf3\xb2\x85\x93\x11\xc3\xa2\xb4R" +
+	"\\a\xc51eh\xfe>\xadX\x92\xd3\x0adD\x0e" +
+	"\xad\x18\xc9\xb6\xb9)\xbej\xae\x1c\xbc\xd7\xb9\x0e\xbd\xdf" +
+	"\xd0\x95\xc9h\x11\xeb\xb3\x05\xfb\x11U?\xeb\xc7\x18\xcb" +
+	"\x8b\xcb3u1\xc8\x94\xbd8_\x95\x17\x97g| " +
+	"\xf3Q\xefA\xa1\xb9^\x04\x90\xaby\xce\xc9~\x1f\xbc" +
+	"\x00\x8a\xcfR\xf7\x0e6L\xf4\x99w\x0c\x1a3\xc7\xe0" +
+	"\xa9\xdc1x\xa2\x85?\x81\xfa\xe3\x99\xe8sNa\xf4" +
+	"\xf9\x82\xe3\x18\x1cY\xc0\x8f\xa2\xfe\x82\x0a\xfa\x1bt\x0c" +
+	"\xd4\xf418\xb6\x80\xbf\x89\xfa\x1b\xe9c0(/\xd9" +
+	"\x1b\xed\xcb\x8b\"]\xaf\x8d)\xcf\x143\x13\xa9dh" +
+	"V\xc3[\xbb\xcc\xc8\xa5\xe1\x94.\x00#\x02\xd9e\xf6" +
+	"\x9b]\xa1\xc4\x80\x9d\xf6\xb3\x1b\xca>s\x9d\xd1oY" +
+	"\xdd\xf6\xe7\xc1\x91g\xd6\xd5cSc\xfd\xbf\xd0\x10f" +
+	"m9\xae\x8av\x0e\xaf\xeb\xb9@&\x98Q\xf6\x85\x0a" +
+	"`W_\xb7\x83]>3\xb62>8\xa1\xe9\"\xfe" +
+	"l\xa0\x07m\xc3\x8b?+\xfd\x05\x8edH\xf6\xf2\xb1" +
+	"\xa7\x91\xefA\xfd~\x15\xf4\x87\x1d\x97\x8f\x87\x16d\xac" +
+	"\xe0S$\xfe\x15i\xf1\xe7\xabJ\xc6\x0a\x1elt\xa8" +
+	"J\xa1\xf8eg(\x96\xe8\x9dg\x16\x08\xcc\xdf\xdf\x1b" +
+	"\x8d\xe4\xb5\x8b\x87:\xc3\xa1\xc8\xca\xb8\xdd\xcev\x00\xad" +
+	"\xf1\xfeh2n94\xc0\xdf\x17\x8dX\x03\xc3\x0b9" +
+	"\x15\xa5dn\xcb\x8e8-\x98\x89\xd3:\xec\xc0\x9b\xbe" +
+	".j\xe4\x8bP_\x98N&rEM\xb3ei\x80" +
+	"/E}\x89\x0a\xfa\x0a\x05|\x03\x96\x19s\xd8vZ" +
+	"\x03]\\\x15(fD\x80\xdd\xe6\x80\xe3\xe70&8" +
+	"\x95\xceq\xe6\x08\xff\x1e\x13\xec\x96\x00r7{W\xb7" +
+	"\xc6\xcc\xfe\xc6u\x8d\xffL\xbai\x08/\xe42\xdb\xa5" +
+	"jC\xf3\xbf\xf0L\xb9\xa4#\xfeq\xb7\x95}\xf63" +
+	"rv2]\x8c\x99\x16\xf7\xa5+)\x99\x1b\xe9\x18i" +
+	"g\xe1\xf3J\x1c5\xf0\xb9\xb4\x8fP\xa8\xc5q+-" +
+	"Q>\x93\x00\xb9\x9aX\xfaz\xca\xa0F\xfdTB\xfa" +
+	"\xc0,o\xe4\xcbQ\xffz\xfa\xbe\xe0\x8b\xa4\x0f\x02+" +
+	"n\xed\x0a\xc5\xba\xc2\x96\xf3f\x1e\xb3\xbah7\xfe\xb0" +
+	"\xd9i\x85\x87\xcf\xcf\xb5\xd9\xbfY\xde\xc2\x07\x97fr" +
+	"E\x85P0\xb3\xec\xeb\xd3\xcbN\xeff \xc8\x07P" +
+	"_\xa7\x82\xbeE\x81\x12\xf5S\x99\xb6\x087\x052\xb9" +
+	"\xd8;\x15\xf0\xad\x89\x86\xbaS\x8b\xf6u\xa6\x02K\x12" +
+	"l\xf6\x09CA\\c\xa6,.\xb5\xc8\x96\xd4\xf3[" +
+	"`OCs\xbaA\xb6\x88<\xa2\xca\xb5\xb5\x86b\xfd" +
+	"\xd1XF!\xaaSK_\x14\xe4\x8b\x10\x80_\x1e\xe0" +
+	"\x97#(\xbc=\xc0\xdb\x11T\xde\x16\xe0m\x08E\xfc" +
+	"\xe2\x00\xbf\x18\xc1\xc3g\x07\xf8l\x84b>#\xc8g" +
+	"`F\x00\xb8\xaa\xe7Z\xfa\x136\xd7\xd1\x9fxO4" +
+	"\xf5+\xb9\x86\xfet\xf7\xac\xed\x00\xc5\x97\xb0R\xda\xe5" +
+	"\xc2\xfe\x94\xae.\xb1\xd6\xe5\xce\xb5\xc3\xee\x04\xf2\xec\x8e" +
+	"m\x8e\x17\x052v\xa7\xd7q?\xb4Z\xb8\x85zw" +
+	"\xba6\x86\x09g\xc2\x1a0\x9c\xa7\xdb\xb6kh\x0d\xc7" +
+	"\x13\xf9\xc0\x88\xae\xe3\xea\xb4q\xe8\x0f\xab\xc9\xf8?c" +
+	"!\xdc\x8a^\xa5Cg\x8f\xe7\xd1\xfd\xd5f\xcf\x105" +
+	"\x91R\xc6J\xd3\xf5\x107\xb3\x9e\xe5\xedSY\xe5\x16" +
+	"\x8b<A\xb1\xc8\x83\xc6B\x8f\x0a\xc6\xd7<\x0e\x05\x17" +
+	"K=\x95b\xa9\x07\x8d%\x04\xf5z\x14\xa8\xa1\xb3\x99" +
+	"\xe2\xb3\xb0<\x01ay\xd0\xe8&\xac\x9f0\xf5S\x99" +
+	"v~\xa2\xcf\x13\x10}\x1e4\xc2\x84\xad#\xac\xe8\x13" +
+	"\x99\xce\xbf\x89\xa4' \x92\x1e4\x12\x84}\x9b0\xcf" +
+	"\xc72\xed\x08\xc5zO@\xac\xf7\xa0q=a7\x12" +
+	"V|V\x16iPL%cO@l\xf6\xa0\xb1\x89" +
+	"\xb0\x9b\x09\xc33\xd2\xab\xa5\xab\xea\x9eJ\xb1\xd5\x83\xc6" +
+	"\x16\xc2n'\xcc\xfb\x11\xcd\xe7\xa5\xd7\x9b\x9e\x80\xb8\xd5" +
+	"\x83\xc6-\x84\xddE\xd8\xa8\xffO\xf3\x8d\xa2R\xb6'" +
+	" \xb6{\xd0\xb8\x93\xb0{\x08\x1b\xfd!\xcdG\x95\xfc" +
+	"]\x9e\x80\xd8\xe5Ac'a\x0f\x12v\xce\x074\xdf" +
+	"9\x8c\x89=\x9eJ\xb1\xc7\x83\xc6\xfd\x84=L\xd8\x98" +
+	"\xbf\xc99\x1a\x8c\xa1B\xbb'(\x1e\xf2\xa0\xf1K\xc2" +
+	"\x1e'v\x96\xbc/5(\xa1\xd7S\x9e\xa08\xe0A" +
+	"c?A\xcf\x104\xf6\xb4\xd4`,\xbde\xf1\x04\xc5" +
+	"\xd3\x1e4\x9e\"\xe89\x82|\xefI\x0d|\xf4\xbe\xc2" +
+	"\xd3\"\x9e\xf5\xa0q\x98\xa0?\x13T\xfaW\xa9\x91\xa0" +
+	"\xc5\x09O\x8b8\xe1A\xe3\x8f\x1e\x15\x16\x17+P\xc2" +
+	"\xff\x9f\xd4\x80\xd3\x93<O\x8b\xf8\xcc\x83\xc6\xa7\xd4\xa9" +
+	"\x82\xa0\xb2SR\x832z\xfaT\xdc\"\xca\x8b\xd1\xd0" +
+	"\x8aU0\xea\x09\x12\xefJ\x0d\x04=L)n\x11S" +
+	"\x8b\xd1\xb8\x90\xa0\xf9\x04i\x7f\x91\x1ah\xf4<\xa7\xb8" +
+	"Q\xb4\x17\xa31\x8f\xa0\x15\x04\x95\x9f\x94\x1a\x94\xd3c" +
+	"\x83\xe2\x16\xb1\xbc\x18\x8d\xaf\x13\xb4\x8e\xa0/\xbd#5" +
+	"\xf8\x12\x09\xbb\xb8E$\x8b\xd1H\x10t3A\xe3\xfe" +
+	",5\x18G2+n\x11[\x8b\xd1\xd8B\xd0=\x04" +
+	"U\xfcIjPA\xac/n\x14\xbb\x8a\xd1\xd8I\xd0" +
+	"\xe3\x04U\xbf-5\x18O,,\x0e\x8a\x03\xc5h\xec" +
+	"'\xe8\x0d\x82jNH\x0d*\xe9\x11X\xf1\\q\xac" +
+	"\x18\x8dW\x09*E\x05J&\xfcQjP\xc5\x98(" +
+	"\xc1FQ\x82h\x8cA\x15\x8cs\x09\xf2\xbf%5\xa8" +
+	"\xa67Z\xd8(j\x10\x8dj\x82\xea\x09:\xf7\xb8\xd4" +
+	"\xa0\x86\xb8\x81\x9d\xa2\x01\xd1\xa8'h\x09A\xb5oJ" +
+	"\x0d&0&t\\ \x96\"\x1aK\x08\xea%(\xf0" +
+	"\x86\xd4\xc0O\xe7\x02\xaf\x11!D\xa3\x97\xa0\xeb\x09\x9a" +
+	"\xf8\xba\xd4\xe0\\\xc6\xc4\x00.\x16\xeb\x11\x8d\xeb\x09\xba" +
+	"\x19\x15\xa8\x99\xf4\x1a\xa9[-\xb1\x03\xe7\x8a\xad\x88\xc6" +
+	"\x16\xc2\xee\xa2n\x93\x8fI\x0d\x02\xa4\xa5\x18\x14\xdb\x11" +
+	"\x8d;\x09z\x90\xa0\xf3^\x95\x1aL$E\xc4\xa0\xd8" +
+	"\x83h\xdcO\xd0\xe3\x04\x9d\xff\x7f\xa5\x06\x93\x88S\x18" +
+	"\x10\x07\x10\x8d\xfd\x04=GP\xdd+R\x83\xc9\xa4Q" +
+	"\xb8@\x1cA4\x9e#\xe8\x03\x82\xa6\xbc,58\x8f" +
+	"^;\xe1\x02\xf1!\xa2\xf1\x01A\x15^\x05J\xc6\xff" +
+	"Ajp>\xe9\x8dw\xae(\xf7\xa2\xa1y\x89S\x04" +
+	"U\xbe$5\xa8#Ny\xe7\x8a\xa9^4.$h" +
+	">AU/J\x0d\xa6\x90\xdex\xe7\x8av/\x1a\xf3" +
+	"\x08ZAP\xf0\xa8\x04\xc8\xbd\xb1\x14\xcb\xbd\x01\xb1\xdc" +
+	"\x8b\x0cJ.xAjp!\xbd\xef\xf1\x06\xc5\xe5^" +
+	"4\xe6S\xa7\xafS\xa7\x0b\xff\x8f\xd4`*\xbd\x80\xf3" +
+	"\xb6\x88e^4\xbeF\xd0\xf5\x04M}^j0\x8d" +
+	"\xd8\xebm\x11\x03^4\xd6\x11\xb4\x89\xa0iG\xa4\x06" +
+	"\x17\xd1\xfb*\xefb\xb1\xd9\x8b\xc6&\x82n&\xe8\xa2" +
+	"\xe7\xa4\x06\xf5\xc4x\xef\\\xb1\xd5\x8b\xc6\x16\x82n'" +
+	"\xa8\xfe\xf7R\x83\x062\x1d\xde\x98\xd8\xe6E\xe3v\x82" +
+	"vz\x9d^[\xbd\xee\xba\xb4\x0d\xce\xbe\x10*\xf4\xc8" +
+	"\xcd\xd3\x9d\x09\xfd\x9e\xa6F\xbb\x12\x0a\xa3\x19`\xa8y" +
+	"\xba#\x96\xc7PS\xa3#d\xc7PC\xb3#VV" +
+	"C\xb3\xe8\x97\xc2\x88\x00\x93\xcd\xd3\x1dIlL65" +
+	":jk\x98lhv\\\xfc\xd5\xe4,GP\xed\xeb" +
+	"\x8cF\xc3\x8e\x8b@A9\x17|\x9d\xe1h\xa7#\xed" +
+	"\xd8\xda\xd3<=\xbf\xb4\x93)l\xf445\xe6\x03\xa3" +
+	"m T\xd8\xc3\x93\x01\x0a{\x14e\x80\x86\xe6|@" +
+	"M\x03\xfe\xd0\xac\xfc\xef\x8a\xdd!Y8\xc5\xa8\x0cP" +
+	"8\x857\x03\x14N\x81\xf6\x14\xc9\x82)\x8a\xd3\xdf}" +
+	"\xd7\x15\x16\xb4\xdc\x84L\x8fd\xa8a~\xdb\x91\xba\xf8" +
+	"\xafK_\xac\x86v\xdf\xe9&\xf6\xfb\x8a\xec\xeb\xdc\xfc" +
+	"&Y\xc7\xce\x06\xd5\xde\x86(0\xe5z\xa4o\x87\xee" +
+	"\x0di\xe8\x98]\x92cj4\x92n\x99}v]\xd0" +
+	"2U\xde\xea4\xe3\x0c\x86\xbf\x98l03\x91\xe6\x10" +
+	"%o\xf01\xf8w\x09\x83e\x8a\xfbk\xac\xae\xfc7" +
+	"OC\xbd.I\xb77]\xda\xbb\xc9%\xf5j*\x1a" +
+	"\x0d\xe7K\xc4~5\xb5\xc1\x1e\xc8\x81\x94\xd8\x08\x9d\xc2" +
+	"|\xc4\x8e;1U\xfcRF\xaez\xb7R\x8b\xbc\x14" +
+	"\x91{S:\x0cfd\xa0#\x11s\x96}\xcc\xc8@" +
+	"\xaa\xd4\xcb %\xa1\xb2\"`@\xc0\x063\x92\xbag" +
+	"\xa7?*\x0c2\xad/1\xfb\xcdN\xe6\x0f\x85C\xe9" +
+	"0\xb6\xacHM\x83n\x97\xaaLE\xcd\x9f\xca\xb4\xa4" +
+	"Rs\xb9\xa7\xf2\xd0\xe8\xbf4\x94*\xb1\x0d}#\xed" +
+	"\xa1\x06\xf9Y\xfb\xec\x00C\xdeH\xd5\xc2\x1b)]H" +
+	"\xa7\xc5\xac.H\xe4\x97\x13U\xd7rb\xd1\xa0r\xe2" +
+	"\xdaPw:_a\x1b\xf4\xd6^\x8b\xe2z\xf7,J" +
+	"f\xf3\xa9RW\xac\xe1\x12Su+\xa1go\xf7u" +
+	"\xf4Z/\x11\x1b>i5\xc2;\x8caj\x88Ja" +
+	"\xc9\x18\xcc/\x9af\x0b8+\x0d\xc3f\xe7\xddj\xfb" +
+	"W/\xb1\xe2\xf4|\x00\\6\x7fM\xa6>3O\x01" +
+	"I\x05\xb5Ef\"\xc6\xd4\xd0:7[\xfa\xc5\xdf\x13" +
+	"d\x9f^\x809\xc2s\x9d\xfc\xdd\xcc\x81\x7f\x1b\x00\xcd" +
+	"z\xb6\x14"
 
 func init() {
 	schemas.Register(schema_832bcc6686a26d56,
+		0x818bd47d898c14cc,
 		0x85257b30d6edf8c5,
 		0x8748bc095e10cb5d,
 		0x87c33f2330feb3d8,
@@ -7218,13 +9164,16 @@ func init() {
 		0xb8fb64b8ed846ae6,
 		0xbaa7b3b1ca91f833,
 		0xbbcdbf4b4ae501fa,
+		0xbfe4f476e6004f2a,
 		0xc7da65f9a2f20ba2,
 		0xc95babe3bd394d2d,
 		0xcbdc765fd5dff7ba,
 		0xcc4411e60ba9c498,
 		0xccb3b2e3603826e0,
+		0xccf2769576f2697f,
 		0xce44aee2d9e25049,
 		0xcf9beaca1cc180c8,
+		0xd62b8f92e21bfbc0,
 		0xd636fba4f188dabe,
 		0xd6514008f0f84ebc,
 		0xd8bccf6e60a73791,
@@ -7236,6 +9185,7 @@ func init() {
 		0xe1a2d1d51107bead,
 		0xe1c9eac512335361,
 		0xe508a29c83a059f8,
+		0xe50ec2a94f1c21c5,
 		0xe54e10aede55c7b1,
 		0xe55d85fc1bf82f21,
 		0xe5817f849ff906dc,
@@ -7243,6 +9193,7 @@ func init() {
 		0xe7711aada4bed56b,
 		0xea26e9973bd6a0d9,
 		0xecea3e9ebcbe5655,
+		0xef0bb04f7f564bfa,
 		0xf14fad09425d081c,
 		0xf58782f48a121998,
 		0xf705dc45c94766fd,
@@ -0,0 +1,20 @@
+//go:build go1.20
+// +build go1.20
+
+package ctxutil
+
+import "context"
+
+// A CancelCauseFunc cancels a Context and records why.  Only the first
+// call has any effect.  A nil cause is recorded as context.Canceled.
+type CancelCauseFunc = context.CancelCauseFunc
+
+// WithCancelCause is context.WithCancelCause.
+func WithCancelCause(parent context.Context) (context.Context, CancelCauseFunc) {
+	return context.WithCancelCause(parent)
+}
+
+// Cause is context.Cause.
+func Cause(ctx context.Context) error {
+	return context.Cause(ctx)
+}
@@ -0,0 +1,78 @@
+//go:build !go1.20
+// +build !go1.20
+
+package ctxutil
+
+import (
+	"context"
+	"sync"
+)
+
+// A CancelCauseFunc cancels a Context and records why.  Only the first
+// call has any effect.  A nil cause is recorded as context.Canceled.
+type CancelCauseFunc func(cause error)
+
+// WithCancelCause is like context.WithCancel, except that the returned
+// function records the cause of the cancellation, which Cause reports.
+func WithCancelCause(parent context.Context) (context.Context, CancelCauseFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	c := &causeCtx{Context: ctx, parent: parent}
+	return c, func(cause error) {
+		if cause == nil {
+			cause = context.Canceled
+		}
+		c.mu.Lock()
+		if c.cause == nil && ctx.Err() == nil {
+			c.cause = cause
+		}
+		c.mu.Unlock()
+		cancel()
+	}
+}
+
+// Cause returns why ctx was canceled.  If ctx or the ancestor it was
+// canceled through was made by WithCancelCause, that is the cause given
+// to its CancelCauseFunc.  Otherwise it is ctx.Err().  If ctx hasn't
+// been canceled, Cause returns nil.
+func Cause(ctx context.Context) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+	for {
+		c, ok := ctx.Value(causeCtxKey{}).(*causeCtx)
+		if !ok {
+			return err
+		}
+		c.mu.Lock()
+		cause := c.cause
+		c.mu.Unlock()
+		switch {
+		case cause != nil:
+			return cause
+		case c.Context.Err() == nil:
+			// Canceled below c, without a cause.
+			return err
+		}
+		// Canceled through c's parent.
+		ctx = c.parent
+	}
+}
+
+type causeCtxKey struct{}
+
+// causeCtx is a Context made by WithCancelCause.
+type causeCtx struct {
+	context.Context
+	parent context.Context
+
+	mu    sync.Mutex
+	cause error
+}
+
+func (c *causeCtx) Value(key any) any {
+	if key == (causeCtxKey{}) {
+		return c
+	}
+	return c.Context.Value(key)
+}
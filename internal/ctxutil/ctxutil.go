@@ -0,0 +1,18 @@
+// Package ctxutil records why a Context was canceled.
+//
+// It stands in for context.WithCancelCause and context.Cause, which
+// need Go 1.20, and goes away once the module requires it.  On Go 1.20
+// and later it uses the standard library, so callers outside this
+// module can read a cause with context.Cause.
+package ctxutil
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrCallCanceled is the cancellation cause of a server method's
+// Context when the caller canceled the call before it returned, for
+// example by sending a Finish message over RPC.  It wraps
+// context.Canceled.
+var ErrCallCanceled = fmt.Errorf("call canceled by caller: %w", context.Canceled)
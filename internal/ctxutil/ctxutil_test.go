@@ -0,0 +1,75 @@
+package ctxutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCancelCause(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	t.Run("NotCanceled", func(t *testing.T) {
+		ctx, cancel := WithCancelCause(context.Background())
+		defer cancel(nil)
+		if err := Cause(ctx); err != nil {
+			t.Errorf("Cause = %v; want <nil>", err)
+		}
+	})
+	t.Run("FirstWins", func(t *testing.T) {
+		ctx, cancel := WithCancelCause(context.Background())
+		cancel(errA)
+		cancel(errB)
+		if err := Cause(ctx); err != errA {
+			t.Errorf("Cause = %v; want %v", err, errA)
+		}
+		if ctx.Err() != context.Canceled {
+			t.Errorf("ctx.Err() = %v; want %v", ctx.Err(), context.Canceled)
+		}
+	})
+	t.Run("Nil", func(t *testing.T) {
+		ctx, cancel := WithCancelCause(context.Background())
+		cancel(nil)
+		if err := Cause(ctx); err != context.Canceled {
+			t.Errorf("Cause = %v; want %v", err, context.Canceled)
+		}
+	})
+	t.Run("FromParent", func(t *testing.T) {
+		parent, cancelParent := WithCancelCause(context.Background())
+		child, cancelChild := WithCancelCause(parent)
+		defer cancelChild(nil)
+		plain, cancelPlain := context.WithCancel(child)
+		defer cancelPlain()
+		cancelParent(errA)
+		<-plain.Done()
+		if err := Cause(plain); err != errA {
+			t.Errorf("Cause(grandchild) = %v; want %v", err, errA)
+		}
+		if err := Cause(child); err != errA {
+			t.Errorf("Cause(child) = %v; want %v", err, errA)
+		}
+		cancelChild(errB)
+		if err := Cause(child); err != errA {
+			t.Errorf("after canceling child, Cause(child) = %v; want %v", err, errA)
+		}
+	})
+	t.Run("Below", func(t *testing.T) {
+		parent, cancelParent := WithCancelCause(context.Background())
+		defer cancelParent(nil)
+		ctx, cancel := context.WithTimeout(parent, time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+		if err := Cause(ctx); err != context.DeadlineExceeded {
+			t.Errorf("Cause = %v; want %v", err, context.DeadlineExceeded)
+		}
+	})
+	t.Run("Plain", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := Cause(ctx); err != context.Canceled {
+			t.Errorf("Cause = %v; want %v", err, context.Canceled)
+		}
+	})
+}
@@ -64,6 +64,18 @@ func (s Book) HasTitle() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownTitle detaches the title field from s without
+// copying it.  The field is left null.
+func (s Book) DisownTitle() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptTitle sets the title field to an orphan from
+// s's message without copying it.
+func (s Book) AdoptTitle(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Book) TitleBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
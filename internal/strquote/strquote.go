@@ -2,7 +2,9 @@
 // Cap'n Proto string literal.
 package strquote
 
-// Append appends a Cap'n Proto string literal of s to buf.
+// Append appends a Cap'n Proto string literal of s to buf.  Quotes,
+// backslashes and bytes outside printable ASCII are escaped, so the
+// literal parses back to s.
 func Append(buf []byte, s []byte) []byte {
 	buf = append(buf, '"')
 	last := 0
@@ -43,7 +45,7 @@ func Append(buf []byte, s []byte) []byte {
 }
 
 func needsEscape(b byte) bool {
-	return b < 0x20 || b >= 0x7f
+	return b < 0x20 || b >= 0x7f || b == '"' || b == '\\'
 }
 
 func hexDigit(b byte) byte {
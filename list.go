@@ -430,6 +430,8 @@ func (l TextList) Set(i int, v string) error {
 }
 
 // String returns the list in Cap'n Proto schema format (e.g. `["foo", "bar"]`).
+// Quotes and backslashes in elements are escaped, so the result parses
+// back to the same list.
 func (l TextList) String() string {
 	var buf []byte
 	buf = append(buf, '[')
@@ -492,6 +494,8 @@ func (l DataList) Set(i int, v []byte) error {
 }
 
 // String returns the list in Cap'n Proto schema format (e.g. `["foo", "bar"]`).
+// Quotes and backslashes in elements are escaped, so the result parses
+// back to the same list.
 func (l DataList) String() string {
 	var buf []byte
 	buf = append(buf, '[')
@@ -81,6 +81,47 @@ func TestTextListBytesAt(t *testing.T) {
 	}
 }
 
+func TestTextListString(t *testing.T) {
+	tests := []struct {
+		elems []string
+		want  string
+	}{
+		{nil, `[]`},
+		{[]string{"foo", "bar"}, `["foo", "bar"]`},
+		{[]string{`say "hi"`}, `["say \"hi\""]`},
+		{[]string{`C:\dir\`, `\"`}, `["C:\\dir\\", "\\\""]`},
+		{[]string{"tab\there\n"}, `["tab\there\n"]`},
+	}
+	for _, test := range tests {
+		_, seg, err := NewMessage(SingleSegment(nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tl, err := NewTextList(seg, int32(len(test.elems)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		dl, err := NewDataList(seg, int32(len(test.elems)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, e := range test.elems {
+			if err := tl.Set(i, e); err != nil {
+				t.Fatal(err)
+			}
+			if err := dl.Set(i, []byte(e)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := tl.String(); got != test.want {
+			t.Errorf("TextList%q.String() = %s; want %s", test.elems, got, test.want)
+		}
+		if got := dl.String(); got != test.want {
+			t.Errorf("DataList%q.String() = %s; want %s", test.elems, got, test.want)
+		}
+	}
+}
+
 func TestListRaw(t *testing.T) {
 	_, seg, err := NewMessage(SingleSegment(nil))
 	if err != nil {
@@ -30,9 +30,10 @@ const maxDepth = ^uint(0)
 // segments of contiguous memory.  The only required field is Arena.
 // A Message is safe to read from multiple goroutines.
 type Message struct {
-	// rlimit must be first so that it is 64-bit aligned.
-	// See sync/atomic docs.
+	// rlimit and allocated must be first so that they are 64-bit
+	// aligned.  See sync/atomic docs.
 	rlimit     uint64
+	allocated  uint64
 	rlimitInit sync.Once
 
 	Arena Arena
@@ -65,6 +66,31 @@ type Message struct {
 	// If not set, this defaults to 64.
 	DepthLimit uint
 
+	// AllocLimit limits how many total bytes of object data can be
+	// allocated while building the message.  Allocations that would
+	// exceed the limit fail with an *AllocLimitError, which is
+	// propagated by NewStruct, SetPtr, generated setters, and so on.
+	// This bounds the amount of memory a message can consume when its
+	// contents are derived from untrusted input.  Only allocations made
+	// through the Message are counted: data that was already in the
+	// Arena when the Message was created or Reset, such as the contents
+	// of an unmarshaled message, is not.
+	//
+	// If not set, allocations are not limited.
+	AllocLimit uint64
+
+	// SegmentLimit limits how many segments the message can have while
+	// building.  An allocation that would require creating a segment
+	// past the limit fails with an *AllocLimitError, before the Arena
+	// allocates anything, so the message is left as it was.  For an
+	// Arena other than the ones in this package, an allocation that
+	// doesn't fit in any existing segment is taken to need a new one.
+	// Unlike AllocLimit, segments that were already in the Arena count
+	// towards the limit.
+	//
+	// If not set, the number of segments is not limited.
+	SegmentLimit uint64
+
 	// mu protects the following fields:
 	mu       sync.Mutex
 	segs     map[SegmentID]*Segment
@@ -137,6 +163,7 @@ func (m *Message) Reset(arena Arena) {
 	m.mu.Unlock()
 
 	m.Arena = arena
+	atomic.StoreUint64(&m.allocated, 0)
 	for _, c := range m.CapTable {
 		c.Release()
 	}
@@ -322,16 +349,70 @@ func (m *Message) allocSegment(sz Size) (*Segment, error) {
 		m.segs = make(map[SegmentID]*Segment)
 		m.segs[0] = &m.firstSeg
 	}
+	if err := m.checkSegmentLimit(sz); err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
 	id, data, err := m.Arena.Allocate(sz, m.segs)
 	if err != nil {
 		m.mu.Unlock()
 		return nil, exc.WrapError("allocation", err)
 	}
+	if m.SegmentLimit > 0 && uint64(id) >= m.SegmentLimit {
+		// Only an Arena that doesn't follow its contract gets here.
+		m.mu.Unlock()
+		return nil, m.segmentLimitError(uint64(id) + 1)
+	}
 	seg := m.setSegment(id, data)
 	m.mu.Unlock()
 	return seg, nil
 }
 
+// checkSegmentLimit returns an error if allocating sz bytes would need
+// a segment past m.SegmentLimit.  It is checked before calling
+// m.Arena.Allocate, since the arena may already have created the
+// segment by the time Allocate returns.  The caller must be holding
+// onto m.mu.
+func (m *Message) checkSegmentLimit(sz Size) error {
+	if m.SegmentLimit == 0 {
+		return nil
+	}
+	switch a := m.Arena.(type) {
+	case *SingleSegmentArena, roSingleSegment:
+		// Never creates a segment.
+		return nil
+	case *MultiSegmentArena:
+		if id, _, _ := a.find(sz, m.segs); uint64(id) >= m.SegmentLimit {
+			return m.segmentLimitError(uint64(id) + 1)
+		}
+		return nil
+	}
+	n := uint64(m.Arena.NumSegments())
+	if n < m.SegmentLimit {
+		return nil
+	}
+	for id := SegmentID(0); uint64(id) < n; id++ {
+		var data []byte
+		if s := m.segs[id]; s != nil {
+			data = s.data
+		} else if d, err := m.Arena.Data(id); err == nil {
+			data = d
+		}
+		if hasCapacity(data, sz) {
+			return nil
+		}
+	}
+	return m.segmentLimitError(n + 1)
+}
+
+func (m *Message) segmentLimitError(want uint64) error {
+	return &AllocLimitError{
+		Segments: true,
+		Limit:    m.SegmentLimit,
+		Want:     want,
+	}
+}
+
 // alloc allocates sz zero-filled bytes.  It prefers using s, but may
 // use a different segment in the same message if there's not sufficient
 // capacity.
@@ -340,11 +421,16 @@ func alloc(s *Segment, sz Size) (*Segment, address, error) {
 		return nil, 0, errors.New("allocation: too large")
 	}
 	sz = sz.padToWord()
+	if err := s.msg.reserve(sz); err != nil {
+		return nil, 0, err
+	}
 
 	if !hasCapacity(s.data, sz) {
+		msg := s.msg
 		var err error
-		s, err = s.msg.allocSegment(sz)
+		s, err = msg.allocSegment(sz)
 		if err != nil {
+			msg.unreserve(sz)
 			return nil, 0, err
 		}
 	}
@@ -362,6 +448,59 @@ func alloc(s *Segment, sz Size) (*Segment, address, error) {
 	return s, addr, nil
 }
 
+// reserve accounts for sz bytes being allocated in the message,
+// returning an error if that would exceed m.AllocLimit.
+func (m *Message) reserve(sz Size) error {
+	if m.AllocLimit == 0 {
+		atomic.AddUint64(&m.allocated, uint64(sz))
+		return nil
+	}
+	for {
+		curr := atomic.LoadUint64(&m.allocated)
+		want := curr + uint64(sz)
+		if want > m.AllocLimit {
+			return &AllocLimitError{Limit: m.AllocLimit, Want: want}
+		}
+		if atomic.CompareAndSwapUint64(&m.allocated, curr, want) {
+			return nil
+		}
+	}
+}
+
+// unreserve undoes a previous call to reserve.
+func (m *Message) unreserve(sz Size) {
+	atomic.AddUint64(&m.allocated, ^(uint64(sz) - 1))
+}
+
+// An AllocLimitError is returned when building a message would exceed
+// the message's AllocLimit or SegmentLimit.
+type AllocLimitError struct {
+	// Segments is true if the segment limit was exceeded and false if
+	// the allocation size limit was exceeded.
+	Segments bool
+
+	// Limit is the limit that was exceeded.
+	Limit uint64
+
+	// Want is the total size or segment count that the failed
+	// allocation would have resulted in.
+	Want uint64
+}
+
+func (e *AllocLimitError) Error() string {
+	if e.Segments {
+		return "allocation: segment limit (" + str.Utod(e.Limit) + ") exceeded"
+	}
+	return "allocation: message size limit (" + str.Utod(e.Limit) + " bytes) exceeded"
+}
+
+// IsAllocLimit reports whether err indicates that a message's
+// allocation limit was exceeded.
+func IsAllocLimit(err error) bool {
+	var e *AllocLimitError
+	return errors.As(err, &e)
+}
+
 func (m *Message) WriteTo(w io.Writer) (int64, error) {
 	wc := &writeCounter{Writer: w}
 	err := NewEncoder(wc).Encode(m)
@@ -607,33 +746,48 @@ func (msa *MultiSegmentArena) Data(id SegmentID) ([]byte, error) {
 }
 
 func (msa *MultiSegmentArena) Allocate(sz Size, segs map[SegmentID]*Segment) (SegmentID, []byte, error) {
-	var total int64
-	for i := 0; i < cap(*msa); i++ {
-		if i == len(*msa) {
-			(*msa) = (*msa)[:i+1]
+	id, data, total := msa.find(sz, segs)
+	if int(id) < cap(*msa) {
+		if int(id) >= len(*msa) {
+			*msa = (*msa)[:id+1]
 		}
-		data := (*msa)[i]
+		return id, data, nil
+	}
+	if total < 0 {
+		// Overflow.
+		return 0, nil, errors.New("alloc " + str.Utod(sz) + " bytes: message too large")
+	}
+	n, err := nextAlloc(total, 1<<63-1, sz)
+	if err != nil {
+		return 0, nil, err
+	}
+	buf := make([]byte, 0, n)
+	*msa = append((*msa)[:cap(*msa)], buf)
+	return id, buf, nil
+}
+
+// find returns the first segment with room for sz more bytes, including
+// segments hidden by Release, along with its data.  If there is none,
+// find returns the ID that Allocate gives the segment it creates and
+// the total capacity of the existing segments, which is negative if it
+// overflows.
+func (msa *MultiSegmentArena) find(sz Size, segs map[SegmentID]*Segment) (SegmentID, []byte, int64) {
+	all := (*msa)[:cap(*msa)]
+	var total int64
+	for i, data := range all {
 		id := SegmentID(i)
 		if s := segs[id]; s != nil {
 			data = s.data
 		}
 		if hasCapacity(data, sz) {
-			return id, data, nil
+			return id, data, 0
 		}
 		total += int64(cap(data))
 		if total < 0 {
-			// Overflow.
-			return 0, nil, errors.New("alloc " + str.Utod(sz) + " bytes: message too large")
+			break
 		}
 	}
-	n, err := nextAlloc(total, 1<<63-1, sz)
-	if err != nil {
-		return 0, nil, err
-	}
-	buf := make([]byte, 0, n)
-	id := SegmentID(len(*msa))
-	*msa = append(*msa, buf)
-	return id, buf, nil
+	return SegmentID(len(all)), nil, total
 }
 
 func (msa *MultiSegmentArena) String() string {
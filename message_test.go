@@ -1022,6 +1022,113 @@ func TestTotalSize(t *testing.T) {
 	assert.Nil(t, err, "quick.Check returned an error")
 }
 
+func TestAllocLimit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Size", func(t *testing.T) {
+		t.Parallel()
+
+		msg, seg := NewSingleSegmentMessage(nil)
+		msg.AllocLimit = 64
+		root, err := NewRootStruct(seg, ObjectSize{DataSize: 8, PointerCount: 1})
+		require.NoError(t, err, "NewRootStruct")
+		err = root.SetData(0, make([]byte, 32))
+		require.NoError(t, err, "SetData within limit")
+
+		err = root.SetData(0, make([]byte, 32))
+		require.Error(t, err, "SetData past limit")
+		assert.True(t, IsAllocLimit(err), "IsAllocLimit(%v)", err)
+		var e *AllocLimitError
+		require.True(t, errors.As(err, &e))
+		assert.False(t, e.Segments)
+		assert.Equal(t, uint64(64), e.Limit)
+		assert.Equal(t, uint64(88), e.Want)
+
+		// A failed allocation does not use up any of the limit.
+		_, err = NewStruct(seg, ObjectSize{DataSize: 8})
+		assert.NoError(t, err, "NewStruct after failed allocation")
+	})
+
+	t.Run("Segments", func(t *testing.T) {
+		t.Parallel()
+
+		msg, seg := NewMultiSegmentMessage(nil)
+		msg.SegmentLimit = 1
+		_, err := NewRootStruct(seg, ObjectSize{DataSize: 8})
+		require.NoError(t, err, "NewRootStruct")
+		before, err := msg.Marshal()
+		require.NoError(t, err, "Marshal")
+
+		_, err = NewData(seg, make([]byte, 4096))
+		require.Error(t, err, "NewData in new segment")
+		var e *AllocLimitError
+		require.True(t, errors.As(err, &e), "errors.As(%v)", err)
+		assert.True(t, e.Segments)
+		assert.Equal(t, uint64(1), e.Limit)
+		assert.Equal(t, uint64(2), e.Want)
+
+		// The arena must not have created the segment anyway.
+		assert.Equal(t, int64(1), msg.NumSegments())
+		after, err := msg.Marshal()
+		require.NoError(t, err, "Marshal after failed allocation")
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("SegmentsCustomArena", func(t *testing.T) {
+		t.Parallel()
+
+		arena := &countingArena{Arena: MultiSegment(nil)}
+		msg, seg, err := NewMessage(arena)
+		require.NoError(t, err, "NewMessage")
+		msg.SegmentLimit = 1
+		_, err = NewRootStruct(seg, ObjectSize{DataSize: 8})
+		require.NoError(t, err, "NewRootStruct")
+		n := arena.allocs
+
+		_, err = NewData(seg, make([]byte, 4096))
+		assert.True(t, IsAllocLimit(err), "NewData in new segment: %v", err)
+		assert.Equal(t, n, arena.allocs, "Allocate calls")
+		assert.Equal(t, int64(1), msg.NumSegments())
+	})
+
+	t.Run("ExistingData", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg := NewSingleSegmentMessage(nil)
+		root, err := NewRootStruct(seg, ObjectSize{PointerCount: 1})
+		require.NoError(t, err, "NewRootStruct")
+		require.NoError(t, root.SetData(0, make([]byte, 1024)), "SetData")
+
+		// Data already in the arena is not counted.
+		msg := &Message{Arena: SingleSegment(seg.Data()), AllocLimit: 64}
+		seg, err = msg.Segment(0)
+		require.NoError(t, err, "Segment(0)")
+		_, err = NewStruct(seg, ObjectSize{DataSize: 64})
+		assert.NoError(t, err, "NewStruct within limit")
+		_, err = NewStruct(seg, ObjectSize{DataSize: 8})
+		assert.True(t, IsAllocLimit(err), "NewStruct past limit: %v", err)
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg := NewMultiSegmentMessage(nil)
+		_, err := NewData(seg, make([]byte, 1<<20))
+		assert.NoError(t, err)
+	})
+}
+
+// countingArena counts calls to Allocate.
+type countingArena struct {
+	Arena
+	allocs int
+}
+
+func (a *countingArena) Allocate(sz Size, segs map[SegmentID]*Segment) (SegmentID, []byte, error) {
+	a.allocs++
+	return a.Arena.Allocate(sz, segs)
+}
+
 type arenaAllocTest struct {
 	name string
 
@@ -0,0 +1,193 @@
+package capnp
+
+import (
+	"errors"
+
+	"capnproto.org/go/capnp/v3/exc"
+	"capnproto.org/go/capnp/v3/internal/str"
+)
+
+// An Orphan is an object that has been detached from the message tree
+// without being copied.  It still lives in its original message and can
+// be attached to any pointer in that message with Adopt, which links
+// to the existing object rather than making a deep copy the way SetPtr
+// does across messages.
+//
+// An Orphan must be adopted at most once.  If an orphan is never
+// adopted, its storage is simply left unused until the message is
+// compacted (see Compact).  Call Discard to zero it instead.
+type Orphan struct {
+	p Ptr
+}
+
+// NewOrphan returns an orphan that refers to p.  p is usually a newly
+// allocated object that has not been attached to the message tree.
+func NewOrphan(p Ptr) Orphan {
+	return Orphan{p}
+}
+
+// IsValid reports whether the orphan refers to an object.
+func (o Orphan) IsValid() bool {
+	return o.p.IsValid()
+}
+
+// Message returns the message that owns the orphan or nil if the
+// orphan is invalid.
+func (o Orphan) Message() *Message {
+	return o.p.Message()
+}
+
+// Ptr returns a pointer to the orphaned object.
+func (o Orphan) Ptr() Ptr {
+	return o.p
+}
+
+// Struct returns the orphaned object as a struct.
+func (o Orphan) Struct() Struct {
+	return o.p.Struct()
+}
+
+// List returns the orphaned object as a list.
+func (o Orphan) List() List {
+	return o.p.List()
+}
+
+// Discard zeroes the orphaned object and everything it points to.
+// Pointers inside the object are followed within its message, so any
+// other reference to the same objects will read as zero afterwards.
+func (o Orphan) Discard() error {
+	if err := zeroObject(o.p); err != nil {
+		return exc.WrapError("discard orphan", err)
+	}
+	return nil
+}
+
+// Disown detaches the i'th pointer in the struct and returns it as an
+// orphan.  The pointer is set to null, but the object is not copied or
+// zeroed.
+func (p Struct) Disown(i uint16) (Orphan, error) {
+	ptr, err := p.Ptr(i)
+	if err != nil {
+		return Orphan{}, exc.WrapError("disown", err)
+	}
+	if ptr.IsValid() {
+		p.seg.writeRawPointer(p.pointerAddress(i), 0)
+	}
+	return Orphan{ptr}, nil
+}
+
+// Adopt sets the i'th pointer in the struct to the orphan o.  o must
+// belong to the same message as p; the object is linked in place
+// without being copied.
+func (p Struct) Adopt(i uint16, o Orphan) error {
+	if p.seg == nil || i >= p.size.PointerCount {
+		panic("capnp: set field outside struct boundaries")
+	}
+	if err := checkAdopt(p.seg, o); err != nil {
+		return err
+	}
+	return p.seg.writePtr(p.pointerAddress(i), o.p, false)
+}
+
+// Disown detaches the i'th pointer in the list and returns it as an
+// orphan.  The pointer is set to null, but the object is not copied or
+// zeroed.
+func (p PointerList) Disown(i int) (Orphan, error) {
+	ptr, err := p.At(i)
+	if err != nil {
+		return Orphan{}, exc.WrapError("disown", err)
+	}
+	if ptr.IsValid() {
+		addr, _ := p.primitiveElem(i, ObjectSize{PointerCount: 1}) // checked by At
+		p.seg.writeRawPointer(addr, 0)
+	}
+	return Orphan{ptr}, nil
+}
+
+// Adopt sets the i'th pointer in the list to the orphan o.  o must
+// belong to the same message as p; the object is linked in place
+// without being copied.
+func (p PointerList) Adopt(i int, o Orphan) error {
+	addr, err := p.primitiveElem(i, ObjectSize{PointerCount: 1})
+	if err != nil {
+		return err
+	}
+	if err := checkAdopt(p.seg, o); err != nil {
+		return err
+	}
+	return p.seg.writePtr(addr, o.p, false)
+}
+
+func checkAdopt(s *Segment, o Orphan) error {
+	if !o.IsValid() {
+		return nil
+	}
+	if o.p.seg.msg != s.msg {
+		return errors.New("adopt: orphan belongs to a different message")
+	}
+	if o.p.flags.ptrType() == structPtrType && o.p.flags.structFlags()&isListMember != 0 {
+		return errors.New("adopt: orphan is a list element")
+	}
+	return nil
+}
+
+// zeroObject zeroes the object that p points to, recursively.
+func zeroObject(p Ptr) error {
+	if !p.IsValid() {
+		return nil
+	}
+	switch p.flags.ptrType() {
+	case structPtrType:
+		return zeroStruct(p.Struct())
+	case listPtrType:
+		l := p.List()
+		switch {
+		case l.flags&isCompositeList != 0:
+			for i := 0; i < l.Len(); i++ {
+				if err := zeroStruct(l.Struct(i)); err != nil {
+					return exc.WrapError("list element "+str.Itod(i), err)
+				}
+			}
+			// Zero the tag word too.
+			l.seg.writeRawPointer(l.off-address(wordSize), 0)
+		case l.size.PointerCount != 0:
+			for i := 0; i < l.Len(); i++ {
+				o, err := PointerList(l).Disown(i)
+				if err != nil {
+					return exc.WrapError("list element "+str.Itod(i), err)
+				}
+				if err := zeroObject(o.p); err != nil {
+					return exc.WrapError("list element "+str.Itod(i), err)
+				}
+			}
+		default:
+			sz := l.allocSize()
+			zeroBytes(l.seg.slice(l.off, sz))
+		}
+		return nil
+	case interfacePtrType:
+		return nil
+	default:
+		panic("unreachable")
+	}
+}
+
+func zeroStruct(s Struct) error {
+	for i := uint16(0); i < s.size.PointerCount; i++ {
+		o, err := s.Disown(i)
+		if err != nil {
+			return exc.WrapError("struct pointer "+str.Utod(i), err)
+		}
+		if err := zeroObject(o.p); err != nil {
+			return exc.WrapError("struct pointer "+str.Utod(i), err)
+		}
+	}
+	zeroBytes(s.seg.slice(s.off, s.size.DataSize))
+	return nil
+}
+
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
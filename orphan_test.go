@@ -0,0 +1,133 @@
+package capnp
+
+import (
+	"testing"
+)
+
+func TestOrphan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("MoveWithinMessage", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg := NewSingleSegmentMessage(nil)
+		root, err := NewRootStruct(seg, ObjectSize{PointerCount: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		child, err := NewStruct(seg, ObjectSize{DataSize: 8})
+		if err != nil {
+			t.Fatal(err)
+		}
+		child.SetUint64(0, 42)
+		if err := root.SetPtr(0, child.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		before := len(seg.Data())
+
+		o, err := root.Disown(0)
+		if err != nil {
+			t.Fatal("Disown:", err)
+		}
+		if root.HasPtr(0) {
+			t.Error("pointer 0 is still set after Disown")
+		}
+		if got := o.Struct().Uint64(0); got != 42 {
+			t.Errorf("orphan value = %d; want 42", got)
+		}
+		if err := root.Adopt(1, o); err != nil {
+			t.Fatal("Adopt:", err)
+		}
+		if len(seg.Data()) != before {
+			t.Errorf("Adopt allocated %d bytes; want 0", len(seg.Data())-before)
+		}
+		p, err := root.Ptr(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.Struct().off != child.off {
+			t.Error("adopted pointer does not reference the original object")
+		}
+	})
+
+	t.Run("PointerList", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg := NewSingleSegmentMessage(nil)
+		l, err := NewPointerList(seg, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		text, err := NewText(seg, "hello")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := l.Set(0, text.ToPtr()); err != nil {
+			t.Fatal(err)
+		}
+		o, err := l.Disown(0)
+		if err != nil {
+			t.Fatal("Disown:", err)
+		}
+		if err := l.Adopt(1, o); err != nil {
+			t.Fatal("Adopt:", err)
+		}
+		p0, _ := l.At(0)
+		p1, _ := l.At(1)
+		if p0.IsValid() {
+			t.Error("element 0 is still set after Disown")
+		}
+		if got := p1.Text(); got != "hello" {
+			t.Errorf("element 1 = %q; want \"hello\"", got)
+		}
+	})
+
+	t.Run("DifferentMessage", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg1 := NewSingleSegmentMessage(nil)
+		_, seg2 := NewSingleSegmentMessage(nil)
+		root, err := NewRootStruct(seg1, ObjectSize{PointerCount: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := NewStruct(seg2, ObjectSize{DataSize: 8})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := root.Adopt(0, NewOrphan(s.ToPtr())); err == nil {
+			t.Error("Adopt of orphan from another message succeeded; want error")
+		}
+	})
+
+	t.Run("Discard", func(t *testing.T) {
+		t.Parallel()
+
+		_, seg := NewSingleSegmentMessage(nil)
+		root, err := NewRootStruct(seg, ObjectSize{PointerCount: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		child, err := root.Disown(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if child.IsValid() {
+			t.Fatal("disowned null pointer is valid")
+		}
+		s, err := NewStruct(seg, ObjectSize{DataSize: 8, PointerCount: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.SetUint64(0, 0xdeadbeef)
+		if err := s.SetText(0, "secret"); err != nil {
+			t.Fatal(err)
+		}
+		if err := NewOrphan(s.ToPtr()).Discard(); err != nil {
+			t.Fatal("Discard:", err)
+		}
+		if data := seg.Data()[s.off:]; !isZeroFilled(data) {
+			t.Errorf("orphan not zeroed after Discard:\n% x", data)
+		}
+	})
+}
@@ -67,6 +67,52 @@ func BenchmarkInsert(b *testing.B) {
 	}
 }
 
+func BenchmarkCodecExtract(b *testing.B) {
+	codec, err := NewCodec[A](air.BenchmarkA_TypeID)
+	if err != nil {
+		b.Fatal(err)
+	}
+	r := rand.New(rand.NewSource(12345))
+	data := make([][]byte, 1000)
+	for i := range data {
+		a := generateA(r)
+		msg, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+		root, _ := air.NewRootBenchmarkA(seg)
+		codec.Insert(capnp.Struct(root), a)
+		data[i], _ = msg.Marshal()
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		msg, _ := capnp.Unmarshal(data[r.Intn(len(data))])
+		root, _ := msg.Root()
+		var a A
+		codec.Extract(&a, root.Struct())
+	}
+}
+
+func BenchmarkCodecInsert(b *testing.B) {
+	codec, err := NewCodec[A](air.BenchmarkA_TypeID)
+	if err != nil {
+		b.Fatal(err)
+	}
+	r := rand.New(rand.NewSource(12345))
+	data := make([]*A, 1000)
+	for i := range data {
+		data[i] = generateA(r)
+	}
+	arena := make([]byte, 0, 512)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a := data[r.Intn(len(data))]
+		msg, seg, _ := capnp.NewMessage(capnp.SingleSegment(arena[:0]))
+		root, _ := air.NewRootBenchmarkA(seg)
+		codec.Insert(capnp.Struct(root), a)
+		msg.Marshal()
+	}
+}
+
 func randString(r *rand.Rand, n int) string {
 	b := make([]byte, (n+1)/2)
 	// Go 1.6 adds a Rand.Read method, but since we want to be compatible with Go 1.4...
@@ -0,0 +1,454 @@
+package pogs
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/nodemap"
+	"capnproto.org/go/capnp/v3/internal/schema"
+)
+
+// Insert and Extract work from plans compiled once per schema node and
+// once per pair of Go type and schema node, rather than looking up the
+// schema and mapping Go fields on every call.  Plans hold decoded
+// copies of everything they need from the schema, so Insert and Extract
+// don't read schema messages, whose read limits would eventually run
+// out.
+
+// A nodePlan is the compiled form of a schema struct or group node.
+type nodePlan struct {
+	id         uint64
+	name       string // short display name, for errors
+	size       capnp.ObjectSize
+	hasDiscrim bool
+	discrimOff capnp.DataOffset
+	fields     []fieldInfo // in schema order
+}
+
+// fieldInfo is the compiled form of a field in a struct or group.
+type fieldInfo struct {
+	name    string
+	discrim uint16
+	mapAnn  bool   // has the $Go.map annotation
+	groupID uint64 // zero for slots
+	slot    slotInfo
+}
+
+func (fi *fieldInfo) isGroup() bool {
+	return fi.groupID != 0
+}
+
+// slotInfo is the compiled form of a slot field.
+type slotInfo struct {
+	name     string
+	typ      *typeInfo
+	which    schema.Type_Which
+	offset   uint32
+	defBits  uint64 // default value of a data field, which values are XORed with
+	defBytes []byte // default value of a text or data field
+	defPtr   []byte // default value of another pointer field, as a message
+	defEmpty bool   // default value is an empty pointer value
+}
+
+// typeInfo is the compiled form of a schema type.
+type typeInfo struct {
+	which schema.Type_Which
+	id    uint64    // type ID of a struct
+	elem  *typeInfo // element type of a list
+
+	// anyType is the Go type besides capnp.Ptr that an AnyPointer
+	// constrained to structs, lists or capabilities matches, or nil.
+	anyType reflect.Type
+}
+
+func compileType(t schema.Type) (*typeInfo, error) {
+	ti := &typeInfo{which: t.Which()}
+	switch ti.which {
+	case schema.Type_Which_structType:
+		ti.id = t.StructType().TypeId()
+	case schema.Type_Which_list:
+		elem, err := t.List().ElementType()
+		if err != nil {
+			return nil, err
+		}
+		if ti.elem, err = compileType(elem); err != nil {
+			return nil, err
+		}
+	case schema.Type_Which_anyPointer:
+		if t.AnyPointer().Which() != schema.Type_anyPointer_Which_unconstrained {
+			// TODO(someday): handle generic parameters, see
+			// https://github.com/capnproto/go-capnproto2/issues/94
+			break
+		}
+		switch t.AnyPointer().Unconstrained().Which() {
+		case schema.Type_anyPointer_unconstrained_Which_struct:
+			ti.anyType = structType
+		case schema.Type_anyPointer_unconstrained_Which_list:
+			ti.anyType = listType
+		case schema.Type_anyPointer_unconstrained_Which_capability:
+			ti.anyType = clientType
+		}
+	}
+	return ti, nil
+}
+
+// A structPlan is the compiled mapping between a Go struct type and a
+// schema struct or group.
+type structPlan struct {
+	*nodePlan
+	gen    uint64 // plans.gen when compiled
+	goType reflect.Type
+	props  structProps
+	fields []goField // parallel to nodePlan.fields
+}
+
+// goField describes the Go field mapped to a schema field.
+type goField struct {
+	loc       fieldLoc // invalid if no Go field maps to this field
+	typ       reflect.Type
+	isMap     bool
+	marshal   bool // has a marshal hook
+	unmarshal bool // has an unmarshal hook
+	match     bool // typ matches the schema type
+}
+
+type planKey struct {
+	t   reflect.Type
+	id  uint64
+	gen uint64 // plans.gen when the plan was compiled
+}
+
+var plans struct {
+	nodePlans   sync.Map // uint64 -> *nodePlan
+	structPlans sync.Map // planKey -> *structPlan
+	gen         uint64   // incremented by RegisterConverter; atomic
+
+	mu    sync.Mutex // guards nodes
+	nodes nodemap.Map
+}
+
+// clearStructPlans discards the compiled struct plans, which depend on
+// the registered converters.  Plans being compiled concurrently are
+// keyed by the old generation, so they are never used.
+func clearStructPlans() {
+	gen := atomic.AddUint64(&plans.gen, 1)
+	plans.structPlans.Range(func(k, _ any) bool {
+		if k.(planKey).gen != gen {
+			plans.structPlans.Delete(k)
+		}
+		return true
+	})
+}
+
+func findNode(id uint64) (schema.Node, error) {
+	plans.mu.Lock()
+	defer plans.mu.Unlock()
+	return plans.nodes.Find(id)
+}
+
+// nodePlanFor returns the compiled plan for the struct or group typeID.
+func nodePlanFor(typeID uint64) (*nodePlan, error) {
+	if np, ok := plans.nodePlans.Load(typeID); ok {
+		return np.(*nodePlan), nil
+	}
+	np, err := compileNode(typeID)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := plans.nodePlans.LoadOrStore(typeID, np)
+	return actual.(*nodePlan), nil
+}
+
+// planFor returns the compiled plan for copying between the Go struct
+// type t and the schema struct or group typeID.
+func planFor(t reflect.Type, typeID uint64) (*structPlan, error) {
+	k := planKey{t, typeID, atomic.LoadUint64(&plans.gen)}
+	if p, ok := plans.structPlans.Load(k); ok {
+		return p.(*structPlan), nil
+	}
+	np, err := nodePlanFor(typeID)
+	if err != nil {
+		return nil, err
+	}
+	props, err := mapStruct(t, np)
+	if err != nil {
+		return nil, err
+	}
+	p := &structPlan{
+		nodePlan: np,
+		gen:      k.gen,
+		goType:   t,
+		props:    props,
+		fields:   make([]goField, len(np.fields)),
+	}
+	for i := range p.fields {
+		gf := &p.fields[i]
+		gf.loc = props.fields[i]
+		if !gf.loc.isValid() {
+			continue
+		}
+		gf.typ = typeFieldByLoc(t, gf.loc).Type
+		fi := &np.fields[i]
+		if fi.isGroup() {
+			continue
+		}
+		gf.isMap = isMapField(t, gf.loc, fi)
+		gf.marshal = hasMarshalHook(gf.typ)
+		gf.unmarshal = hasUnmarshalHook(gf.typ)
+		gf.match = isTypeMatch(gf.typ, fi.slot.typ)
+	}
+	actual, _ := plans.structPlans.LoadOrStore(k, p)
+	return actual.(*structPlan), nil
+}
+
+func compileNode(typeID uint64) (*nodePlan, error) {
+	n, err := findNode(typeID)
+	if err != nil {
+		return nil, err
+	}
+	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
+		return nil, fmt.Errorf("cannot find struct type %#x", typeID)
+	}
+	fields, err := n.StructNode().Fields()
+	if err != nil {
+		return nil, err
+	}
+	np := &nodePlan{
+		id:   typeID,
+		name: string(shortDisplayName(n)),
+		size: capnp.ObjectSize{
+			DataSize:     capnp.Size(n.StructNode().DataWordCount()) * 8,
+			PointerCount: n.StructNode().PointerCount(),
+		},
+		hasDiscrim: hasDiscriminant(n),
+		discrimOff: capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2),
+		fields:     make([]fieldInfo, fields.Len()),
+	}
+	for i := range np.fields {
+		f := fields.At(i)
+		fi := &np.fields[i]
+		if fi.name, err = f.Name(); err != nil {
+			return nil, err
+		}
+		fi.discrim = f.DiscriminantValue()
+		if fi.mapAnn, err = hasMapAnnotation(f); err != nil {
+			return nil, err
+		}
+		switch f.Which() {
+		case schema.Field_Which_group:
+			fi.groupID = f.Group().TypeId()
+		case schema.Field_Which_slot:
+			if err := compileSlot(&fi.slot, f); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return np, nil
+}
+
+func compileSlot(sl *slotInfo, f schema.Field) error {
+	var err error
+	if sl.name, err = f.Name(); err != nil {
+		return err
+	}
+	typ, err := f.Slot().Type()
+	if err != nil {
+		return err
+	}
+	if sl.typ, err = compileType(typ); err != nil {
+		return err
+	}
+	dv, err := f.Slot().DefaultValue()
+	if err != nil {
+		return err
+	}
+	sl.which = sl.typ.which
+	sl.offset = f.Slot().Offset()
+	if !dv.IsValid() {
+		return nil
+	}
+	if int(sl.which) != int(dv.Which()) {
+		return fmt.Errorf("field %s: default value is a %v, want %v", sl.name, dv.Which(), sl.which)
+	}
+	switch sl.which {
+	case schema.Type_Which_bool:
+		if dv.Bool() {
+			sl.defBits = 1
+		}
+	case schema.Type_Which_int8:
+		sl.defBits = uint64(uint8(dv.Int8()))
+	case schema.Type_Which_int16:
+		sl.defBits = uint64(uint16(dv.Int16()))
+	case schema.Type_Which_int32:
+		sl.defBits = uint64(uint32(dv.Int32()))
+	case schema.Type_Which_int64:
+		sl.defBits = uint64(dv.Int64())
+	case schema.Type_Which_uint8:
+		sl.defBits = uint64(dv.Uint8())
+	case schema.Type_Which_uint16:
+		sl.defBits = uint64(dv.Uint16())
+	case schema.Type_Which_enum:
+		sl.defBits = uint64(dv.Enum())
+	case schema.Type_Which_uint32:
+		sl.defBits = uint64(dv.Uint32())
+	case schema.Type_Which_uint64:
+		sl.defBits = dv.Uint64()
+	case schema.Type_Which_float32:
+		sl.defBits = uint64(math.Float32bits(dv.Float32()))
+	case schema.Type_Which_float64:
+		sl.defBits = math.Float64bits(dv.Float64())
+	case schema.Type_Which_text:
+		b, err := dv.TextBytes()
+		if err != nil {
+			return err
+		}
+		sl.defBytes = append([]byte(nil), b...)
+	case schema.Type_Which_data:
+		b, err := dv.Data()
+		if err != nil {
+			return err
+		}
+		sl.defBytes = append([]byte(nil), b...)
+	case schema.Type_Which_structType, schema.Type_Which_list, schema.Type_Which_anyPointer:
+		var p capnp.Ptr
+		switch sl.which {
+		case schema.Type_Which_structType:
+			p, err = dv.StructValue()
+		case schema.Type_Which_list:
+			p, err = dv.List()
+		default:
+			p, err = dv.AnyPointer()
+		}
+		if err != nil {
+			return err
+		}
+		if sl.defPtr, err = copyPtr(p); err != nil {
+			return fmt.Errorf("field %s: default value: %v", sl.name, err)
+		}
+	}
+	sl.defEmpty = isEmptyValue(dv)
+	return nil
+}
+
+// copyPtr returns a message holding a copy of p as its root, or nil if
+// p is null.
+func copyPtr(p capnp.Ptr) ([]byte, error) {
+	if !p.IsValid() {
+		return nil, nil
+	}
+	msg, _, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+	if err := msg.SetRoot(p); err != nil {
+		return nil, err
+	}
+	return msg.Marshal()
+}
+
+// defaultPtr returns the default value of the pointer slot sl, read
+// from a fresh copy so that callers may keep and modify it.
+func defaultPtr(sl *slotInfo) (capnp.Ptr, error) {
+	if sl.defPtr == nil {
+		return capnp.Ptr{}, nil
+	}
+	msg, err := capnp.Unmarshal(append([]byte(nil), sl.defPtr...))
+	if err != nil {
+		return capnp.Ptr{}, err
+	}
+	return msg.Root()
+}
+
+// A Codec copies values of the Go struct type T to and from a schema
+// struct.  It is equivalent to calling Insert and Extract, but maps T
+// to the schema once, when the Codec is created, and again only if
+// RegisterConverter is called afterward.  A Codec is safe to use from
+// multiple goroutines.
+type Codec[T any] struct {
+	plan atomic.Value // *structPlan
+}
+
+// NewCodec returns a Codec for copying T to and from the schema struct
+// typeID.  It returns an error if T is not a struct type or can't be
+// mapped to typeID.
+func NewCodec[T any](typeID uint64) (*Codec[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("pogs: new codec @%#x: %v is not a struct", typeID, t)
+	}
+	p, err := planFor(t, typeID)
+	if err == nil {
+		err = p.props.unknown
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pogs: new codec @%#x: %v", typeID, err)
+	}
+	c := new(Codec[T])
+	c.plan.Store(p)
+	return c, nil
+}
+
+// currentPlan returns c's plan, compiling it again if a converter has
+// been registered since.
+func (c *Codec[T]) currentPlan() (*structPlan, error) {
+	p := c.plan.Load().(*structPlan)
+	if p.gen == atomic.LoadUint64(&plans.gen) {
+		return p, nil
+	}
+	p, err := planFor(p.goType, p.id)
+	if err != nil {
+		return nil, err
+	}
+	c.plan.Store(p)
+	return p, nil
+}
+
+// Insert copies val into s.
+func (c *Codec[T]) Insert(s capnp.Struct, val *T) error {
+	return c.InsertWithOptions(s, val, nil)
+}
+
+// InsertWithOptions copies val into s, as adjusted by opts.
+func (c *Codec[T]) InsertWithOptions(s capnp.Struct, val *T, opts *InsertOptions) error {
+	ins := new(inserter)
+	if opts != nil {
+		ins.opts = *opts
+	}
+	p, err := c.currentPlan()
+	if err == nil {
+		err = ins.insertPlan(p, s, reflect.ValueOf(val).Elem())
+	}
+	if err != nil {
+		return fmt.Errorf("pogs: insert @%#x: %v", c.typeID(), err)
+	}
+	return nil
+}
+
+// Extract copies s into val.
+func (c *Codec[T]) Extract(val *T, s capnp.Struct) error {
+	return c.ExtractWithOptions(val, s, nil)
+}
+
+// ExtractWithOptions copies s into val, as adjusted by opts.
+func (c *Codec[T]) ExtractWithOptions(val *T, s capnp.Struct, opts *ExtractOptions) error {
+	e := newExtracter(opts)
+	p, err := c.currentPlan()
+	if err == nil {
+		err = e.extractPlan(p, reflect.ValueOf(val).Elem(), s)
+	}
+	if err != nil {
+		return fmt.Errorf("pogs: extract @%#x: %v", c.typeID(), err)
+	}
+	if err := e.finish(); err != nil {
+		return fmt.Errorf("pogs: extract @%#x: %w", c.typeID(), err)
+	}
+	return nil
+}
+
+func (c *Codec[T]) typeID() uint64 {
+	return c.plan.Load().(*structPlan).id
+}
@@ -0,0 +1,94 @@
+package pogs
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	air "capnproto.org/go/capnp/v3/internal/aircraftlib"
+)
+
+func TestCodec(t *testing.T) {
+	codec, err := NewCodec[A](air.BenchmarkA_TypeID)
+	if err != nil {
+		t.Fatalf("NewCodec: %v", err)
+	}
+	r := rand.New(rand.NewSource(1))
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		in := generateA(r)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+				if err != nil {
+					t.Errorf("NewMessage: %v", err)
+					return
+				}
+				root, err := air.NewRootBenchmarkA(seg)
+				if err != nil {
+					t.Errorf("NewRootBenchmarkA: %v", err)
+					return
+				}
+				if err := codec.Insert(capnp.Struct(root), in); err != nil {
+					t.Errorf("Insert: %v", err)
+					return
+				}
+				var out A
+				if err := codec.Extract(&out, capnp.Struct(root)); err != nil {
+					t.Errorf("Extract: %v", err)
+					return
+				}
+				if out != *in {
+					t.Errorf("Extract(Insert(%+v)) = %+v", *in, out)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCodec_Errors(t *testing.T) {
+	if _, err := NewCodec[int](air.BenchmarkA_TypeID); err == nil {
+		t.Error("NewCodec[int] succeeded")
+	}
+	type extra struct {
+		Name       string
+		ExtraField int
+	}
+	if _, err := NewCodec[extra](air.BenchmarkA_TypeID); err == nil {
+		t.Error("NewCodec of struct with unknown field succeeded")
+	}
+	if _, err := NewCodec[A](0xdeadbeef); err == nil {
+		t.Error("NewCodec of unknown type ID succeeded")
+	}
+}
+
+func TestPlanCache(t *testing.T) {
+	// Plans are shared between calls, so a second use of the same Go
+	// type and schema struct must not see state from the first.
+	for i := 0; i < 2; i++ {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			t.Fatalf("NewMessage: %v", err)
+		}
+		z, err := air.NewRootZ(seg)
+		if err != nil {
+			t.Fatalf("NewRootZ: %v", err)
+		}
+		in := &Z{Which: air.Z_Which_planebase, Planebase: &PlaneBase{Name: "x", Rating: int64(i)}}
+		if err := Insert(air.Z_TypeID, capnp.Struct(z), in); err != nil {
+			t.Fatalf("Insert: %v", err)
+		}
+		out := new(Z)
+		if err := Extract(out, air.Z_TypeID, capnp.Struct(z)); err != nil {
+			t.Fatalf("Extract: %v", err)
+		}
+		if out.Planebase == nil || out.Planebase.Rating != int64(i) {
+			t.Errorf("round %d: Extract produced %+v", i, out.Planebase)
+		}
+	}
+}
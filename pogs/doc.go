@@ -32,7 +32,9 @@ We can copy the Go struct into a Cap'n Proto struct like this:
 
 Note that if any field names in our Go struct don't match to a field in
 the Cap'n Proto struct, Insert returns an error.  We'll see how to fix
-that in a moment.
+that in a moment.  Alternatively, InsertWithOptions with
+IgnoreUnknownFields skips such fields, which is useful when inserting
+into an older version of a schema.
 
 Extracting
 
@@ -42,6 +44,16 @@ pass a pointer to our Go struct to Extract.
 	m := new(Message)
 	err := pogs.Extract(m, myschema.Message_TypeID, root.Struct)
 
+Extract ignores fields in the Cap'n Proto struct that have no
+corresponding Go field.  To notice when a peer with a newer schema
+sends data that would be dropped, use ExtractWithOptions: with Strict
+set, any such field with a non-default value makes it return an
+*UnmappedFieldsError, and ReportUnmapped is given the list of those
+fields either way.
+
+	err := pogs.ExtractWithOptions(m, myschema.Message_TypeID, root.Struct,
+		&pogs.ExtractOptions{Strict: true})
+
 Types
 
 The mapping between Cap'n Proto types and underlying Go types is as
@@ -64,6 +76,58 @@ Note that the unsized int and uint type can't be used: int and float
 types must match in size.  For Data and Text fields using []byte, the
 filled-in byte slice will point to original segment.
 
+Custom Types
+
+A Go type that implements Marshaler and Unmarshaler is inserted and
+extracted as another Go value that does fit the field, much like
+encoding/json's Marshaler and Unmarshaler.  For example, a date type
+could be stored in an Int64 field:
+
+	type Date struct{ t time.Time }
+
+	func (d Date) MarshalCapnp() (any, error) {
+		return d.t.Unix(), nil
+	}
+
+	func (d *Date) UnmarshalCapnp(extract func(any) error) error {
+		var sec int64
+		if err := extract(&sec); err != nil {
+			return err
+		}
+		d.t = time.Unix(sec, 0)
+		return nil
+	}
+
+For types we don't own, RegisterConverter registers the same pair of
+conversions as functions.  Pointers to these types are converted too: a
+nil pointer leaves the field unset.
+
+Maps
+
+Schemas usually model a map as a list of entry structs with key and
+value fields:
+
+	struct Headers {
+		entries @0 :List(Entry) $Go.map;
+		struct Entry {
+			key @0 :Text;
+			value @1 :Text;
+		}
+	}
+
+A Go map field is copied to and from such a list if the schema field has
+the $Go.map annotation from go.capnp, or if the Go field has the "map"
+tag option:
+
+	type Headers struct {
+		Entries map[string]string `capnp:",map"`
+	}
+
+Insert adds entries in Go's map iteration order.  InsertWithOptions
+with SortMapKeys set adds them in ascending key order instead, so that
+equal maps produce identical messages.  When extracting, a later entry
+replaces an earlier entry with the same key.
+
 Renaming and Omitting Fields
 
 By default, the Go field name is the same as the Cap'n Proto schema
@@ -139,6 +203,35 @@ which field in the union is set.
 		// ...
 	}
 
+Alternatively, a union can be a Go interface field, with a distinct Go
+type for each union member.  Each member type is a struct with a single
+field holding that member's value, and the member types are registered
+with RegisterUnion:
+
+	type Shape struct {
+		Area float64
+		Kind ShapeKind `capnp:",union"`
+	}
+
+	type ShapeKind interface{ isShapeKind() }
+
+	type Circle struct{ Circle float64 }
+	type Square struct{ Square float64 }
+
+	func (Circle) isShapeKind() {}
+	func (Square) isShapeKind() {}
+
+	func init() {
+		pogs.RegisterUnion[ShapeKind](Circle{}, Square{})
+	}
+
+Insert sets the union member for the type held by the interface, and
+Extract sets the interface to a new value of the type registered for the
+member that is set, or to nil if there is none.  The "union" tag option
+marks the interface field that holds the struct's unnamed union; a named
+union is matched by name, like any other field.  Such a struct can't
+also have a Which field.
+
 Embedding
 
 Anonymous struct fields are usually extracted or inserted as if their
@@ -160,5 +253,23 @@ otherwise conflict.
 rule), that is selected.
 3) Otherwise, there are multiple fields, and all are ignored; no error
 occurs.
+
+Codecs
+
+Insert and Extract map a Go struct type to a schema struct the first
+time the pair is used and cache the result, so later calls only copy
+values.  NewCodec does the mapping up front, reporting any mismatch
+between the Go type and the schema when the Codec is created:
+
+	codec, err := pogs.NewCodec[Book](books.Book_TypeID)
+	...
+	err = codec.Insert(capnp.Struct(b), &Book{Title: "War and Peace"})
+
+Generated structs
+
+Insert and Extract use reflection.  Where that cost matters, running
+capnpc-go with -pogs generates a Go struct named Foo_Go for each schema
+struct Foo, with ToCapnp, CopyTo and FromCapnp methods that convert
+without reflection.
 */
 package pogs // import "capnproto.org/go/capnp/v3/pogs"
@@ -5,24 +5,98 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strings"
 
 	"capnproto.org/go/capnp/v3"
-	"capnproto.org/go/capnp/v3/internal/nodemap"
 	"capnproto.org/go/capnp/v3/internal/schema"
 )
 
-// Extract copies s into val, a pointer to a Go struct.
+// Extract copies s into val, a pointer to a Go struct.  Fields of s
+// that have no corresponding Go field are ignored.
 func Extract(val any, typeID uint64, s capnp.Struct) error {
-	e := new(extracter)
-	err := e.extractStruct(reflect.ValueOf(val), typeID, s)
+	return ExtractWithOptions(val, typeID, s, nil)
+}
+
+// ExtractOptions adjusts how ExtractWithOptions copies Cap'n Proto
+// data.  The zero value (or a nil *ExtractOptions) behaves like Extract.
+type ExtractOptions struct {
+	// Strict makes extraction fail with an *UnmappedFieldsError if any
+	// schema field has a non-default value but no Go field to hold it.
+	// The Go value may have been partially filled in.
+	Strict bool
+
+	// ReportUnmapped, if not nil, is called once at the end of an
+	// extraction with the paths of the schema fields that had
+	// non-default values but no Go field to hold them.  It is not
+	// called if there are no such fields.
+	ReportUnmapped func(fields []string)
+}
+
+// An UnmappedFieldsError is returned by a strict extraction when
+// schema fields with non-default values have no Go field to hold them.
+type UnmappedFieldsError struct {
+	// Fields lists the paths of the fields, relative to the extracted
+	// struct, such as "name", "group.field" or "list[2].field".
+	Fields []string
+}
+
+func (e *UnmappedFieldsError) Error() string {
+	return "unmapped fields with values: " + strings.Join(e.Fields, ", ")
+}
+
+// ExtractWithOptions copies s into val, a pointer to a Go struct.
+func ExtractWithOptions(val any, typeID uint64, s capnp.Struct, opts *ExtractOptions) error {
+	e := newExtracter(opts)
+	v := reflect.ValueOf(val)
+	var err error
+	if v.Kind() == reflect.Ptr && !v.IsNil() && hasUnmarshalHook(v.Type().Elem()) {
+		_, err = unmarshalHook(v.Elem(), func(p reflect.Value) error {
+			return e.extractStruct(p, typeID, s)
+		})
+	} else {
+		err = e.extractStruct(v, typeID, s)
+	}
 	if err != nil {
 		return fmt.Errorf("pogs: extract @%#x: %v", typeID, err)
 	}
+	if err := e.finish(); err != nil {
+		return fmt.Errorf("pogs: extract @%#x: %w", typeID, err)
+	}
 	return nil
 }
 
 type extracter struct {
-	nodes nodemap.Map
+	opts ExtractOptions
+
+	// track is set if unmapped fields are being collected.  prefix is
+	// the path of the struct currently being extracted, ending in a
+	// dot if not empty.
+	track    bool
+	prefix   string
+	unmapped []string
+}
+
+func newExtracter(opts *ExtractOptions) *extracter {
+	e := new(extracter)
+	if opts != nil {
+		e.opts = *opts
+		e.track = opts.Strict || opts.ReportUnmapped != nil
+	}
+	return e
+}
+
+// finish reports the unmapped fields collected during extraction.
+func (e *extracter) finish() error {
+	if len(e.unmapped) == 0 {
+		return nil
+	}
+	if e.opts.ReportUnmapped != nil {
+		e.opts.ReportUnmapped(e.unmapped)
+	}
+	if e.opts.Strict {
+		return &UnmappedFieldsError{Fields: e.unmapped}
+	}
+	return nil
 }
 
 var (
@@ -55,128 +129,123 @@ func (e *extracter) extractStruct(val reflect.Value, typeID uint64, s capnp.Stru
 	if !val.CanSet() {
 		return errors.New("can't modify struct, did you pass in a pointer to your struct?")
 	}
-	n, err := e.nodes.Find(typeID)
-	if err != nil {
-		return err
-	}
-	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
-		return fmt.Errorf("cannot find struct type %#x", typeID)
+	p, err := planFor(val.Type(), typeID)
+	if err == nil {
+		err = p.props.unknown
 	}
-	props, err := mapStruct(val.Type(), n)
 	if err != nil {
 		return fmt.Errorf("can't extract %s: %v", val.Type(), err)
 	}
+	return e.extractPlan(p, val, s)
+}
+
+// extractPlan extracts s into val, a settable struct of type p.goType.
+func (e *extracter) extractPlan(p *structPlan, val reflect.Value, s capnp.Struct) error {
 	var discriminant uint16
 	hasWhich := false
-	if hasDiscriminant(n) {
-		discriminant = s.Uint16(capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2))
-		if err := props.setWhich(val, discriminant); err == nil {
+	if p.hasDiscrim {
+		discriminant = s.Uint16(p.discrimOff)
+		if err := p.props.setWhich(val, discriminant); err == nil {
 			hasWhich = true
 		} else if !isNoWhichError(err) {
 			return err
 		}
 	}
-	fields, err := n.StructNode().Fields()
-	if err != nil {
-		return err
-	}
-	for i := 0; i < fields.Len(); i++ {
-		f := fields.At(i)
-		vf := props.makeFieldByOrdinal(val, i)
-		if !vf.IsValid() {
+	for i := range p.fields {
+		gf := &p.fields[i]
+		fi := &p.nodePlan.fields[i]
+		if !gf.loc.isValid() {
 			// Don't have a field for this.
+			// Union members are only set if active, and belong to the
+			// union interface field if there is one.
+			if e.track && (fi.discrim == schema.Field_noDiscriminant || fi.discrim == discriminant && !p.props.unionLoc.isValid()) {
+				if err := e.checkUnmapped(fi, s); err != nil {
+					return err
+				}
+			}
 			continue
 		}
-		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+		if fi.discrim != schema.Field_noDiscriminant {
 			if !hasWhich {
-				return fmt.Errorf("can't extract %s into %v: has union field but no Which field", shortDisplayName(n), val.Type())
+				return fmt.Errorf("can't extract %s into %v: has union field but no Which field", p.name, val.Type())
 			}
-			if dv != discriminant {
+			if fi.discrim != discriminant {
 				continue
 			}
 		}
-		switch f.Which() {
-		case schema.Field_Which_slot:
-			if err := e.extractField(vf, s, f); err != nil {
-				return err
-			}
-		case schema.Field_Which_group:
-			if err := e.extractStruct(vf, f.Group().TypeId(), s); err != nil {
-				return err
-			}
+		vf := fieldByLoc(val, gf.loc, true)
+		prefix := e.prefix
+		if e.track {
+			e.prefix = prefix + fi.name + "."
+		}
+		var err error
+		switch {
+		case fi.isGroup() && vf.Kind() == reflect.Interface:
+			err = e.extractUnion(vf, fi.groupID, s)
+		case fi.isGroup():
+			err = e.extractStruct(vf, fi.groupID, s)
+		case gf.isMap:
+			err = e.extractMap(vf, s, &fi.slot)
+		case gf.unmarshal:
+			err = e.extractField(vf, s, &fi.slot)
+		default:
+			err = e.extractValue(vf, s, &fi.slot, gf.match)
 		}
+		e.prefix = prefix
+		if err != nil {
+			return err
+		}
+	}
+	if p.props.unionLoc.isValid() {
+		return e.extractUnion(fieldByLoc(val, p.props.unionLoc, true), p.id, s)
 	}
 	return nil
 }
 
-func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f schema.Field) error {
-	typ, err := f.Slot().Type()
-	if err != nil {
-		return err
-	}
-	dv, err := f.Slot().DefaultValue()
-	if err != nil {
-		return err
-	}
-	if dv.IsValid() && int(typ.Which()) != int(dv.Which()) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("extract field %s: default value is a %v, want %v", name, dv.Which(), typ.Which())
+// extractField extracts the slot sl into val, through val's unmarshal
+// hook if it has one.
+func (e *extracter) extractField(val reflect.Value, s capnp.Struct, sl *slotInfo) error {
+	if ok, err := unmarshalHook(val, func(p reflect.Value) error { return e.extractField(p, s, sl) }); ok {
+		if err != nil {
+			return fmt.Errorf("extract field %s: %v", sl.name, err)
+		}
+		return nil
 	}
-	if !isTypeMatch(val.Type(), typ) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("can't extract field %s of type %v into a Go %v", name, typ.Which(), val.Type())
+	return e.extractValue(val, s, sl, isTypeMatch(val.Type(), sl.typ))
+}
+
+// extractValue extracts the slot sl into val.  match reports whether
+// val's type matches the slot's type.
+func (e *extracter) extractValue(val reflect.Value, s capnp.Struct, sl *slotInfo, match bool) error {
+	if !match {
+		return fmt.Errorf("can't extract field %s of type %v into a Go %v", sl.name, sl.which, val.Type())
 	}
-	switch typ.Which() {
+	off, d := sl.offset, sl.defBits
+	switch sl.which {
 	case schema.Type_Which_bool:
-		v := s.Bit(capnp.BitOffset(f.Slot().Offset()))
-		d := dv.Bool()
-		val.SetBool(v != d) // != acts as XOR
+		val.SetBool(s.Bit(capnp.BitOffset(off)) != (d != 0)) // != acts as XOR
 	case schema.Type_Which_int8:
-		v := int8(s.Uint8(capnp.DataOffset(f.Slot().Offset())))
-		d := dv.Int8()
-		val.SetInt(int64(v ^ d))
+		val.SetInt(int64(int8(s.Uint8(capnp.DataOffset(off)) ^ uint8(d))))
 	case schema.Type_Which_int16:
-		v := int16(s.Uint16(capnp.DataOffset(f.Slot().Offset() * 2)))
-		d := dv.Int16()
-		val.SetInt(int64(v ^ d))
+		val.SetInt(int64(int16(s.Uint16(capnp.DataOffset(off*2)) ^ uint16(d))))
 	case schema.Type_Which_int32:
-		v := int32(s.Uint32(capnp.DataOffset(f.Slot().Offset() * 4)))
-		d := dv.Int32()
-		val.SetInt(int64(v ^ d))
+		val.SetInt(int64(int32(s.Uint32(capnp.DataOffset(off*4)) ^ uint32(d))))
 	case schema.Type_Which_int64:
-		v := int64(s.Uint64(capnp.DataOffset(f.Slot().Offset() * 8)))
-		d := dv.Int64()
-		val.SetInt(v ^ d)
+		val.SetInt(int64(s.Uint64(capnp.DataOffset(off*8)) ^ d))
 	case schema.Type_Which_uint8:
-		v := s.Uint8(capnp.DataOffset(f.Slot().Offset()))
-		d := dv.Uint8()
-		val.SetUint(uint64(v ^ d))
-	case schema.Type_Which_uint16:
-		v := s.Uint16(capnp.DataOffset(f.Slot().Offset() * 2))
-		d := dv.Uint16()
-		val.SetUint(uint64(v ^ d))
-	case schema.Type_Which_enum:
-		v := s.Uint16(capnp.DataOffset(f.Slot().Offset() * 2))
-		d := dv.Enum()
-		val.SetUint(uint64(v ^ d))
+		val.SetUint(uint64(s.Uint8(capnp.DataOffset(off)) ^ uint8(d)))
+	case schema.Type_Which_uint16, schema.Type_Which_enum:
+		val.SetUint(uint64(s.Uint16(capnp.DataOffset(off*2)) ^ uint16(d)))
 	case schema.Type_Which_uint32:
-		v := s.Uint32(capnp.DataOffset(f.Slot().Offset() * 4))
-		d := dv.Uint32()
-		val.SetUint(uint64(v ^ d))
+		val.SetUint(uint64(s.Uint32(capnp.DataOffset(off*4)) ^ uint32(d)))
 	case schema.Type_Which_uint64:
-		v := s.Uint64(capnp.DataOffset(f.Slot().Offset() * 8))
-		d := dv.Uint64()
-		val.SetUint(v ^ d)
+		val.SetUint(s.Uint64(capnp.DataOffset(off*8)) ^ d)
 	case schema.Type_Which_float32:
-		v := s.Uint32(capnp.DataOffset(f.Slot().Offset() * 4))
-		d := math.Float32bits(dv.Float32())
-		val.SetFloat(float64(math.Float32frombits(v ^ d)))
+		val.SetFloat(float64(math.Float32frombits(s.Uint32(capnp.DataOffset(off*4)) ^ uint32(d))))
 	case schema.Type_Which_float64:
-		v := s.Uint64(capnp.DataOffset(f.Slot().Offset() * 8))
-		d := math.Float64bits(dv.Float64())
-		val.SetFloat(math.Float64frombits(v ^ d))
+		val.SetFloat(math.Float64frombits(s.Uint64(capnp.DataOffset(off*8)) ^ d))
 	case schema.Type_Which_text:
-		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		p, err := s.Ptr(uint16(off))
 		if err != nil {
 			return err
 		}
@@ -184,7 +253,7 @@ func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f schema.Fie
 		if p.IsValid() {
 			b = p.TextBytes()
 		} else {
-			b, _ = dv.TextBytes()
+			b = sl.defBytes
 		}
 		if val.Kind() == reflect.String {
 			val.SetString(string(b))
@@ -193,53 +262,54 @@ func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f schema.Fie
 			val.SetBytes(b)
 		}
 	case schema.Type_Which_data:
-		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		p, err := s.Ptr(uint16(off))
 		if err != nil {
 			return err
 		}
 		var b []byte
 		if p.IsValid() {
 			b = p.Data()
-		} else {
-			b, _ = dv.Data()
+		} else if sl.defBytes != nil {
+			// Copied, so that changes to val don't change the default.
+			b = append([]byte{}, sl.defBytes...)
 		}
 		val.SetBytes(b)
 	case schema.Type_Which_structType:
-		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		p, err := s.Ptr(uint16(off))
 		if err != nil {
 			return err
 		}
 		ss := p.Struct()
 		if !ss.IsValid() {
-			p, _ = dv.StructValue()
+			p, _ = defaultPtr(sl)
 			ss = p.Struct()
 		}
-		return e.extractStruct(val, typ.StructType().TypeId(), ss)
+		return e.extractStruct(val, sl.typ.id, ss)
 	case schema.Type_Which_list:
-		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		p, err := s.Ptr(uint16(off))
 		if err != nil {
 			return err
 		}
 		l := p.List()
 		if !l.IsValid() {
-			p, _ = dv.List()
+			p, _ = defaultPtr(sl)
 			l = p.List()
 		}
-		return e.extractList(val, typ, l)
+		return e.extractList(val, sl.typ, l)
 	case schema.Type_Which_interface:
-		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		p, err := s.Ptr(uint16(off))
 		if err != nil {
 			return err
 		}
 		client := p.Interface().Client()
 		val.Set(reflect.ValueOf(client).Convert(val.Type()))
 	case schema.Type_Which_anyPointer:
-		p, err := s.Ptr(uint16(f.Slot().Offset()))
+		p, err := s.Ptr(uint16(off))
 		if err != nil {
 			return err
 		}
 		if !p.IsValid() {
-			p, err = dv.AnyPointer()
+			p, err = defaultPtr(sl)
 			if err != nil {
 				return err
 			}
@@ -257,20 +327,17 @@ func (e *extracter) extractField(val reflect.Value, s capnp.Struct, f schema.Fie
 			panic("unreachable")
 		}
 	default:
-		return fmt.Errorf("unknown field type %v", typ.Which())
+		return fmt.Errorf("unknown field type %v", sl.which)
 	}
 	return nil
 }
 
-func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List) error {
+func (e *extracter) extractList(val reflect.Value, typ *typeInfo, l capnp.List) error {
 	vt := val.Type()
-	elem, err := typ.List().ElementType()
-	if err != nil {
-		return err
-	}
+	elem := typ.elem
 	if !isTypeMatch(vt, typ) {
 		// TODO(light): the error won't be that useful for nested lists.
-		return fmt.Errorf("can't extract %v list into a Go %v", elem.Which(), vt)
+		return fmt.Errorf("can't extract %v list into a Go %v", elem.which, vt)
 	}
 	if !l.IsValid() {
 		val.Set(reflect.Zero(vt))
@@ -278,7 +345,10 @@ func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List
 	}
 	n := l.Len()
 	val.Set(reflect.MakeSlice(vt, n, n))
-	switch elem.Which() {
+	if hasUnmarshalHook(vt.Elem()) {
+		return e.unmarshalList(val, typ, l)
+	}
+	switch elem.which {
 	case schema.Type_Which_bool:
 		for i := 0; i < n; i++ {
 			val.Index(i).SetBool(capnp.BitList(l).At(i))
@@ -364,18 +434,22 @@ func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List
 			}
 		}
 	case schema.Type_Which_structType:
+		prefix := e.prefix
+		defer func() { e.prefix = prefix }()
 		if val.Type().Elem().Kind() == reflect.Struct {
 			for i := 0; i < n; i++ {
-				err := e.extractStruct(val.Index(i), elem.StructType().TypeId(), l.Struct(i))
+				e.enterElem(prefix, i)
+				err := e.extractStruct(val.Index(i), elem.id, l.Struct(i))
 				if err != nil {
 					return err
 				}
 			}
 		} else {
 			for i := 0; i < n; i++ {
+				e.enterElem(prefix, i)
 				newval := reflect.New(val.Type().Elem().Elem())
 				val.Index(i).Set(newval)
-				err := e.extractStruct(newval, elem.StructType().TypeId(), l.Struct(i))
+				err := e.extractStruct(newval, elem.id, l.Struct(i))
 				if err != nil {
 					return err
 				}
@@ -398,7 +472,34 @@ func (e *extracter) extractList(val reflect.Value, typ schema.Type, l capnp.List
 		// See https://groups.google.com/d/topic/capnproto/BVk3m7Nc-4s/discussion
 		return errors.New("List(AnyPointer) not allowed in schema")
 	default:
-		return fmt.Errorf("unknown list type %v", elem.Which())
+		return fmt.Errorf("unknown list type %v", elem.which)
+	}
+	return nil
+}
+
+// unmarshalList extracts l into val, whose elements have unmarshal
+// hooks.  The first time an element asks for a proxy type, the whole
+// list is extracted into a slice of that type, and each element then
+// takes its value from that slice.
+func (e *extracter) unmarshalList(val reflect.Value, typ *typeInfo, l capnp.List) error {
+	proxies := make(map[reflect.Type]reflect.Value)
+	for i := 0; i < val.Len(); i++ {
+		i := i
+		_, err := unmarshalHook(val.Index(i), func(p reflect.Value) error {
+			slice, ok := proxies[p.Type()]
+			if !ok {
+				slice = reflect.New(reflect.SliceOf(p.Type())).Elem()
+				if err := e.extractList(slice, typ, l); err != nil {
+					return err
+				}
+				proxies[p.Type()] = slice
+			}
+			p.Set(slice.Index(i))
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("index %d: %v", i, err)
+		}
 	}
 	return nil
 }
@@ -418,8 +519,8 @@ var typeMap = map[schema.Type_Which]reflect.Kind{
 	schema.Type_Which_enum:    reflect.Uint16,
 }
 
-func isTypeMatch(r reflect.Type, s schema.Type) bool {
-	switch s.Which() {
+func isTypeMatch(r reflect.Type, s *typeInfo) bool {
+	switch s.which {
 	case schema.Type_Which_text:
 		return r.Kind() == reflect.String || r.Kind() == reflect.Slice && r.Elem().Kind() == reflect.Uint8
 	case schema.Type_Which_data:
@@ -427,30 +528,95 @@ func isTypeMatch(r reflect.Type, s schema.Type) bool {
 	case schema.Type_Which_structType:
 		return isStructOrStructPtr(r)
 	case schema.Type_Which_list:
-		e, _ := s.List().ElementType()
-		return r.Kind() == reflect.Slice && isTypeMatch(r.Elem(), e)
+		if r.Kind() != reflect.Slice {
+			return false
+		}
+		// Elements with hooks are checked once they are converted.
+		return isTypeMatch(r.Elem(), s.elem) || hasMarshalHook(r.Elem()) || hasUnmarshalHook(r.Elem())
 	case schema.Type_Which_interface:
 		return reflect.Zero(clientType).CanConvert(r)
 	case schema.Type_Which_anyPointer:
-		if r == ptrType {
-			return true
+		return r == ptrType || s.anyType != nil && r == s.anyType
+	}
+	k, ok := typeMap[s.which]
+	return ok && k == r.Kind()
+}
+
+// enterElem sets the path prefix to that of element i of the list at
+// prefix, if unmapped fields are being collected.
+func (e *extracter) enterElem(prefix string, i int) {
+	if e.track {
+		e.prefix = fmt.Sprintf("%s[%d].", strings.TrimSuffix(prefix, "."), i)
+	}
+}
+
+// checkUnmapped records fi, a field of s with no Go field, if it holds
+// a non-default value.  The fields of a group are checked individually.
+func (e *extracter) checkUnmapped(fi *fieldInfo, s capnp.Struct) error {
+	if !fi.isGroup() {
+		if hasValue(s, &fi.slot) {
+			e.unmapped = append(e.unmapped, e.prefix+fi.name)
 		}
-		if s.AnyPointer().Which() != schema.Type_anyPointer_Which_unconstrained {
-			// TODO(someday): handle generic parameters, see
-			// https://github.com/capnproto/go-capnproto2/issues/94
-			return false
+		return nil
+	}
+	np, err := nodePlanFor(fi.groupID)
+	if err != nil {
+		return err
+	}
+	var discrim uint16
+	if np.hasDiscrim {
+		discrim = s.Uint16(np.discrimOff)
+	}
+	prefix := e.prefix
+	e.prefix = prefix + fi.name + "."
+	defer func() { e.prefix = prefix }()
+	for i := range np.fields {
+		f := &np.fields[i]
+		if f.discrim != schema.Field_noDiscriminant && f.discrim != discrim {
+			continue
 		}
-		switch s.AnyPointer().Unconstrained().Which() {
-		case schema.Type_anyPointer_unconstrained_Which_struct:
-			return r == structType
-		case schema.Type_anyPointer_unconstrained_Which_list:
-			return r == listType
-		case schema.Type_anyPointer_unconstrained_Which_capability:
-			return r == clientType
-		default:
-			return false
+		if err := e.checkUnmapped(f, s); err != nil {
+			return err
 		}
 	}
-	k, ok := typeMap[s.Which()]
-	return ok && k == r.Kind()
+	return nil
+}
+
+// hasValue reports whether the slot sl of s holds a non-default value.
+func hasValue(s capnp.Struct, sl *slotInfo) bool {
+	off := sl.offset
+	switch sl.which {
+	case schema.Type_Which_void:
+		return false
+	case schema.Type_Which_bool:
+		return s.Bit(capnp.BitOffset(off))
+	case schema.Type_Which_int8, schema.Type_Which_uint8:
+		return s.Uint8(capnp.DataOffset(off)) != 0
+	case schema.Type_Which_int16, schema.Type_Which_uint16, schema.Type_Which_enum:
+		return s.Uint16(capnp.DataOffset(off*2)) != 0
+	case schema.Type_Which_int32, schema.Type_Which_uint32, schema.Type_Which_float32:
+		return s.Uint32(capnp.DataOffset(off*4)) != 0
+	case schema.Type_Which_int64, schema.Type_Which_uint64, schema.Type_Which_float64:
+		return s.Uint64(capnp.DataOffset(off*8)) != 0
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list:
+		// An empty value is the same as an empty default.
+		p, err := s.Ptr(uint16(off))
+		if err != nil || !p.IsValid() {
+			return err != nil
+		}
+		return !sl.defEmpty || !isEmptyPtr(p, sl.which)
+	default:
+		return s.HasPtr(uint16(off))
+	}
+}
+
+func isEmptyPtr(p capnp.Ptr, which schema.Type_Which) bool {
+	switch which {
+	case schema.Type_Which_text:
+		return len(p.TextBytes()) == 0
+	case schema.Type_Which_data:
+		return len(p.Data()) == 0
+	default:
+		return p.List().Len() == 0
+	}
 }
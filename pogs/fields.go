@@ -12,6 +12,7 @@ type fieldProps struct {
 	schemaName string // empty if doesn't map to schema
 	typ        fieldType
 	tagged     bool
+	isMap      bool // has the "map" tag option
 }
 
 type fieldType int
@@ -20,13 +21,29 @@ const (
 	mappedField fieldType = iota
 	whichField
 	embedField
+	unionField
 )
 
 func parseField(f reflect.StructField, hasDiscrim bool) fieldProps {
 	var p fieldProps
 	tag := f.Tag.Get("capnp")
 	p.tagged = tag != ""
-	tname, _ := nextOpt(tag)
+	tname, opts := nextOpt(tag)
+	isUnion := false
+	for opts != "" {
+		var opt string
+		opt, opts = nextOpt(opts)
+		switch opt {
+		case "map":
+			p.isMap = true
+		case "union":
+			isUnion = true
+		}
+	}
+	if isUnion && tname == "" {
+		p.typ = unionField
+		return p
+	}
 	switch tname {
 	case "-":
 		// omitted field
@@ -91,16 +108,15 @@ type structProps struct {
 	fields     []fieldLoc
 	whichLoc   fieldLoc // i == -1: none; i == -2: fixed
 	fixedWhich uint16
+	unionLoc   fieldLoc // interface field holding the union; i == -1: none
+	unknown    error    // Go field with no schema field, if any
 }
 
-func mapStruct(t reflect.Type, n schema.Node) (structProps, error) {
-	fields, err := n.StructNode().Fields()
-	if err != nil {
-		return structProps{}, err
-	}
+func mapStruct(t reflect.Type, np *nodePlan) (structProps, error) {
 	sp := structProps{
-		fields:   make([]fieldLoc, fields.Len()),
+		fields:   make([]fieldLoc, len(np.fields)),
 		whichLoc: fieldLoc{i: -1},
+		unionLoc: fieldLoc{i: -1},
 	}
 	for i := range sp.fields {
 		sp.fields[i] = fieldLoc{i: -1}
@@ -108,8 +124,8 @@ func mapStruct(t reflect.Type, n schema.Node) (structProps, error) {
 	sm := structMapper{
 		sp:         &sp,
 		t:          t,
-		hasDiscrim: hasDiscriminant(n),
-		fields:     fields,
+		hasDiscrim: np.hasDiscrim,
+		fields:     np.fields,
 	}
 	if err := sm.visit(fieldLoc{i: -1}); err != nil {
 		return structProps{}, err
@@ -123,25 +139,26 @@ func mapStruct(t reflect.Type, n schema.Node) (structProps, error) {
 		}
 	}
 	if sm.hasDiscrim && sp.whichLoc.i == -1 {
-		var prevName []byte
+		var prevName string
 		for i, loc := range sp.fields {
 			if !loc.isValid() {
 				continue
 			}
-			f := sm.fields.At(i)
-			dv := f.DiscriminantValue()
-			if dv == schema.Field_noDiscriminant {
+			f := &sm.fields[i]
+			if f.discrim == schema.Field_noDiscriminant {
 				continue
 			}
 			if sp.whichLoc.i == -2 {
-				name, _ := f.NameBytes()
-				return structProps{}, fmt.Errorf("%v has multiple union fields (%s and %s) but no Which field", sm.t, prevName, name)
+				return structProps{}, fmt.Errorf("%v has multiple union fields (%s and %s) but no Which field", sm.t, prevName, f.name)
 			}
 			sp.whichLoc = fieldLoc{i: -2}
-			sp.fixedWhich = dv
-			prevName, _ = f.NameBytes()
+			sp.fixedWhich = f.discrim
+			prevName = f.name
 		}
 	}
+	if sp.unionLoc.isValid() && sp.whichLoc.i != -1 {
+		return structProps{}, fmt.Errorf("%v has a union interface field and also a Which field or union member fields", sm.t)
+	}
 	return sp, nil
 }
 
@@ -149,7 +166,7 @@ type structMapper struct {
 	sp         *structProps
 	t          reflect.Type
 	hasDiscrim bool
-	fields     schema.Field_List
+	fields     []fieldInfo
 	embedQueue []fieldLoc
 }
 
@@ -188,7 +205,11 @@ func (sm *structMapper) visitField(loc fieldLoc, f reflect.StructField, p fieldP
 		}
 		fi := fieldIndex(sm.fields, p.schemaName)
 		if fi < 0 {
-			return fmt.Errorf("%v has unknown field %s, maps to %s", sm.t, f.Name, p.schemaName)
+			// Only an error for some uses, so record it and go on.
+			if sm.sp.unknown == nil {
+				sm.sp.unknown = fmt.Errorf("%v has unknown field %s, maps to %s", sm.t, f.Name, p.schemaName)
+			}
+			return nil
 		}
 		switch oldloc := sm.sp.fields[fi]; {
 		case oldloc.i == -2:
@@ -220,24 +241,21 @@ func (sm *structMapper) visitField(loc fieldLoc, f reflect.StructField, p fieldP
 			return fmt.Errorf("%v.Which is type %v, not uint16", sm.t, f.Type)
 		}
 		sm.sp.whichLoc = loc
+	case unionField:
+		if !sm.hasDiscrim {
+			return fmt.Errorf("%v has union field %s, but the schema struct has no union", sm.t, f.Name)
+		}
+		if f.Type.Kind() != reflect.Interface {
+			return fmt.Errorf("%v.%s is type %v, not an interface", sm.t, f.Name, f.Type)
+		}
+		if sm.sp.unionLoc.isValid() {
+			return fmt.Errorf("%v has multiple union fields", sm.t)
+		}
+		sm.sp.unionLoc = loc
 	}
 	return nil
 }
 
-// fieldBySchemaName returns the field for the given name.
-// Returns an invalid value if the field was not found or it is
-// contained inside a nil anonymous struct pointer.
-func (sp structProps) fieldByOrdinal(val reflect.Value, i int) reflect.Value {
-	return fieldByLoc(val, sp.fields[i], false)
-}
-
-// makeFieldBySchemaName returns the field for the given name, creating
-// its parent anonymous structs if necessary.  Returns an invalid value
-// if the field was not found.
-func (sp structProps) makeFieldByOrdinal(val reflect.Value, i int) reflect.Value {
-	return fieldByLoc(val, sp.fields[i], true)
-}
-
 // which returns the value of the discriminator field.
 func (sp structProps) which(val reflect.Value) (discrim uint16, ok bool) {
 	if sp.whichLoc.i == -2 {
@@ -321,28 +339,15 @@ func shortDisplayName(n schema.Node) []byte {
 	return dn[n.DisplayNamePrefixLength():]
 }
 
-func fieldIndex(fields schema.Field_List, name string) int {
-	for i := 0; i < fields.Len(); i++ {
-		b, _ := fields.At(i).NameBytes()
-		if bytesStrEqual(b, name) {
+func fieldIndex(fields []fieldInfo, name string) int {
+	for i := range fields {
+		if fields[i].name == name {
 			return i
 		}
 	}
 	return -1
 }
 
-func bytesStrEqual(b []byte, s string) bool {
-	if len(b) != len(s) {
-		return false
-	}
-	for i := range b {
-		if b[i] != s[i] {
-			return false
-		}
-	}
-	return true
-}
-
 func isStructOrStructPtr(t reflect.Type) bool {
 	return t.Kind() == reflect.Struct || t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
 }
@@ -7,14 +7,46 @@ import (
 	"reflect"
 
 	"capnproto.org/go/capnp/v3"
-	"capnproto.org/go/capnp/v3/internal/nodemap"
 	"capnproto.org/go/capnp/v3/internal/schema"
 )
 
 // Insert copies val, a pointer to a Go struct, into s.
 func Insert(typeID uint64, s capnp.Struct, val any) error {
+	return InsertWithOptions(typeID, s, val, nil)
+}
+
+// InsertOptions adjusts how InsertWithOptions copies Go values.  The
+// zero value (or a nil *InsertOptions) behaves like Insert.
+type InsertOptions struct {
+	// SortMapKeys inserts map entries in ascending key order, so that
+	// inserting equal maps produces identical messages.  Otherwise
+	// entries are inserted in Go's map iteration order.
+	SortMapKeys bool
+
+	// IgnoreUnknownFields skips Go fields that have no corresponding
+	// field in the schema struct, rather than returning an error.  This
+	// lets a Go type be inserted into an older version of its schema.
+	// Extract and NewCodec always reject such Go types.
+	IgnoreUnknownFields bool
+}
+
+// InsertWithOptions copies val, a pointer to a Go struct, into s.
+func InsertWithOptions(typeID uint64, s capnp.Struct, val any, opts *InsertOptions) error {
 	ins := new(inserter)
-	err := ins.insertStruct(typeID, s, reflect.ValueOf(val))
+	if opts != nil {
+		ins.opts = *opts
+	}
+	v := reflect.ValueOf(val)
+	proxy, ok, err := marshalHook(v)
+	if ok && err == nil {
+		if !proxy.IsValid() {
+			return fmt.Errorf("pogs: insert @%#x: %v marshaled to nil", typeID, v.Type())
+		}
+		v = proxy
+	}
+	if err == nil {
+		err = ins.insertStruct(typeID, s, v)
+	}
 	if err != nil {
 		return fmt.Errorf("pogs: insert @%#x: %v", typeID, err)
 	}
@@ -22,7 +54,7 @@ func Insert(typeID uint64, s capnp.Struct, val any) error {
 }
 
 type inserter struct {
-	nodes nodemap.Map
+	opts InsertOptions
 }
 
 func (ins *inserter) insertStruct(typeID uint64, s capnp.Struct, val reflect.Value) error {
@@ -33,137 +65,138 @@ func (ins *inserter) insertStruct(typeID uint64, s capnp.Struct, val reflect.Val
 	if val.Kind() != reflect.Struct {
 		return fmt.Errorf("can't insert %v into a struct", val.Kind())
 	}
-	n, err := ins.nodes.Find(typeID)
+	p, err := ins.planFor(val.Type(), typeID)
 	if err != nil {
-		return err
-	}
-	if !n.IsValid() || n.Which() != schema.Node_Which_structNode {
-		return fmt.Errorf("cannot find struct type %#x", typeID)
+		return fmt.Errorf("can't insert into %v: %v", val.Type(), err)
 	}
-	props, err := mapStruct(val.Type(), n)
+	return ins.insertPlan(p, s, val)
+}
+
+// planFor returns the plan for inserting a t into the struct typeID.
+func (ins *inserter) planFor(t reflect.Type, typeID uint64) (*structPlan, error) {
+	p, err := planFor(t, typeID)
 	if err != nil {
-		return fmt.Errorf("can't insert into %v: %v", val.Type(), err)
+		return nil, err
 	}
+	if p.props.unknown != nil && !ins.opts.IgnoreUnknownFields {
+		return nil, p.props.unknown
+	}
+	return p, nil
+}
+
+// insertPlan inserts val, a struct of type p.goType, into s.
+func (ins *inserter) insertPlan(p *structPlan, s capnp.Struct, val reflect.Value) error {
 	var discriminant uint16
 	hasWhich := false
-	if hasDiscriminant(n) {
-		discriminant, hasWhich = props.which(val)
+	if p.hasDiscrim {
+		discriminant, hasWhich = p.props.which(val)
 		if hasWhich {
-			off := capnp.DataOffset(n.StructNode().DiscriminantOffset() * 2)
-			if s.Size().DataSize < capnp.Size(off+2) {
-				return fmt.Errorf("can't set discriminant for %s: allocated struct is too small", shortDisplayName(n))
+			if s.Size().DataSize < capnp.Size(p.discrimOff+2) {
+				return fmt.Errorf("can't set discriminant for %s: allocated struct is too small", p.name)
 			}
-			s.SetUint16(off, discriminant)
+			s.SetUint16(p.discrimOff, discriminant)
 		}
 	}
-	fields, err := n.StructNode().Fields()
-	if err != nil {
-		return err
-	}
-	for i := 0; i < fields.Len(); i++ {
-		f := fields.At(i)
-		vf := props.fieldByOrdinal(val, i)
-		if !vf.IsValid() {
+	for i := range p.fields {
+		gf := &p.fields[i]
+		if !gf.loc.isValid() {
 			// Don't have a field for this.
 			continue
 		}
-		if dv := f.DiscriminantValue(); dv != schema.Field_noDiscriminant {
+		vf := fieldByLoc(val, gf.loc, false)
+		if !vf.IsValid() {
+			continue
+		}
+		fi := &p.nodePlan.fields[i]
+		if fi.discrim != schema.Field_noDiscriminant {
 			if !hasWhich {
-				sname, _ := f.NameBytes()
-				return fmt.Errorf("can't insert %s from %v: has union field %s but no Which field", shortDisplayName(n), val.Type(), sname)
+				return fmt.Errorf("can't insert %s from %v: has union field %s but no Which field", p.name, val.Type(), fi.name)
 			}
-			if dv != discriminant {
+			if fi.discrim != discriminant {
 				continue
 			}
 		}
-		switch f.Which() {
-		case schema.Field_Which_slot:
-			if err := ins.insertField(s, f, vf); err != nil {
-				return err
-			}
-		case schema.Field_Which_group:
-			if err := ins.insertStruct(f.Group().TypeId(), s, vf); err != nil {
-				return err
-			}
+		var err error
+		switch {
+		case fi.isGroup() && vf.Kind() == reflect.Interface:
+			err = ins.insertUnion(fi.groupID, s, vf)
+		case fi.isGroup():
+			err = ins.insertStruct(fi.groupID, s, vf)
+		case gf.isMap:
+			err = ins.insertField(s, &fi.slot, mapEntries(vf, ins.opts.SortMapKeys))
+		case gf.marshal:
+			err = ins.insertField(s, &fi.slot, vf)
+		default:
+			err = ins.insertValue(s, &fi.slot, vf, gf.match)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if p.props.unionLoc.isValid() {
+		if vf := fieldByLoc(val, p.props.unionLoc, false); vf.IsValid() {
+			return ins.insertUnion(p.id, s, vf)
 		}
 	}
 	return nil
 }
 
-func (ins *inserter) insertField(s capnp.Struct, f schema.Field, val reflect.Value) error {
-	typ, err := f.Slot().Type()
-	if err != nil {
-		return err
-	}
-	dv, err := f.Slot().DefaultValue()
-	if err != nil {
-		return err
-	}
-	if dv.IsValid() && int(typ.Which()) != int(dv.Which()) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("insert field %s: default value is a %v, want %v", name, dv.Which(), typ.Which())
+// insertField inserts val into the slot sl, converting it first if it
+// has a marshal hook.
+func (ins *inserter) insertField(s capnp.Struct, sl *slotInfo, val reflect.Value) error {
+	if proxy, ok, err := marshalHook(val); ok {
+		if err != nil {
+			return fmt.Errorf("insert field %s: %v", sl.name, err)
+		}
+		if !proxy.IsValid() {
+			// Leave the field at its default value.
+			if isPointerType(sl.typ) {
+				return s.SetPtr(uint16(sl.offset), capnp.Ptr{})
+			}
+			return nil
+		}
+		return ins.insertField(s, sl, proxy)
 	}
-	if !isTypeMatch(val.Type(), typ) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("can't insert field %s of type Go %v into a %v", name, val.Type(), typ.Which())
+	return ins.insertValue(s, sl, val, isTypeMatch(val.Type(), sl.typ))
+}
+
+// insertValue inserts val into the slot sl.  match reports whether
+// val's type matches the slot's type.
+func (ins *inserter) insertValue(s capnp.Struct, sl *slotInfo, val reflect.Value, match bool) error {
+	if !match {
+		return fmt.Errorf("can't insert field %s of type Go %v into a %v", sl.name, val.Type(), sl.which)
 	}
-	if !isFieldInBounds(s.Size(), f.Slot().Offset(), typ) {
-		name, _ := f.NameBytes()
-		return fmt.Errorf("can't insert field %s: allocated struct is too small", name)
+	if !isFieldInBounds(s.Size(), sl.offset, sl.typ) {
+		return fmt.Errorf("can't insert field %s: allocated struct is too small", sl.name)
 	}
-	switch typ.Which() {
+	off, d := sl.offset, sl.defBits
+	switch sl.which {
 	case schema.Type_Which_bool:
-		v := val.Bool()
-		d := dv.Bool()
-		s.SetBit(capnp.BitOffset(f.Slot().Offset()), v != d) // != acts as XOR
+		s.SetBit(capnp.BitOffset(off), val.Bool() != (d != 0)) // != acts as XOR
 	case schema.Type_Which_int8:
-		v := int8(val.Int())
-		d := dv.Int8()
-		s.SetUint8(capnp.DataOffset(f.Slot().Offset()), uint8(v^d))
+		s.SetUint8(capnp.DataOffset(off), uint8(val.Int())^uint8(d))
 	case schema.Type_Which_int16:
-		v := int16(val.Int())
-		d := dv.Int16()
-		s.SetUint16(capnp.DataOffset(f.Slot().Offset()*2), uint16(v^d))
+		s.SetUint16(capnp.DataOffset(off*2), uint16(val.Int())^uint16(d))
 	case schema.Type_Which_int32:
-		v := int32(val.Int())
-		d := dv.Int32()
-		s.SetUint32(capnp.DataOffset(f.Slot().Offset()*4), uint32(v^d))
+		s.SetUint32(capnp.DataOffset(off*4), uint32(val.Int())^uint32(d))
 	case schema.Type_Which_int64:
-		v := val.Int()
-		d := dv.Int64()
-		s.SetUint64(capnp.DataOffset(f.Slot().Offset()*8), uint64(v^d))
+		s.SetUint64(capnp.DataOffset(off*8), uint64(val.Int())^d)
 	case schema.Type_Which_uint8:
-		v := uint8(val.Uint())
-		d := dv.Uint8()
-		s.SetUint8(capnp.DataOffset(f.Slot().Offset()), v^d)
-	case schema.Type_Which_uint16:
-		v := uint16(val.Uint())
-		d := dv.Uint16()
-		s.SetUint16(capnp.DataOffset(f.Slot().Offset()*2), v^d)
-	case schema.Type_Which_enum:
-		v := uint16(val.Uint())
-		d := dv.Enum()
-		s.SetUint16(capnp.DataOffset(f.Slot().Offset()*2), v^d)
+		s.SetUint8(capnp.DataOffset(off), uint8(val.Uint())^uint8(d))
+	case schema.Type_Which_uint16, schema.Type_Which_enum:
+		s.SetUint16(capnp.DataOffset(off*2), uint16(val.Uint())^uint16(d))
 	case schema.Type_Which_uint32:
-		v := uint32(val.Uint())
-		d := dv.Uint32()
-		s.SetUint32(capnp.DataOffset(f.Slot().Offset()*4), v^d)
+		s.SetUint32(capnp.DataOffset(off*4), uint32(val.Uint())^uint32(d))
 	case schema.Type_Which_uint64:
-		v := val.Uint()
-		d := dv.Uint64()
-		s.SetUint64(capnp.DataOffset(f.Slot().Offset()*8), v^d)
+		s.SetUint64(capnp.DataOffset(off*8), val.Uint()^d)
 	case schema.Type_Which_float32:
-		v := math.Float32bits(float32(val.Float()))
-		d := math.Float32bits(dv.Float32())
-		s.SetUint32(capnp.DataOffset(f.Slot().Offset()*4), v^d)
+		s.SetUint32(capnp.DataOffset(off*4), math.Float32bits(float32(val.Float()))^uint32(d))
 	case schema.Type_Which_float64:
-		v := math.Float64bits(val.Float())
-		d := uint64(math.Float64bits(dv.Float64()))
-		s.SetUint64(capnp.DataOffset(f.Slot().Offset()*8), v^d)
+		s.SetUint64(capnp.DataOffset(off*8), math.Float64bits(val.Float())^d)
 	case schema.Type_Which_text:
-		off := uint16(f.Slot().Offset())
+		off := uint16(off)
 		if val.Len() == 0 {
-			if !isEmptyValue(dv) {
+			if !sl.defEmpty {
 				return s.SetNewText(off, "")
 			}
 			return s.SetText(off, "")
@@ -175,13 +208,12 @@ func (ins *inserter) insertField(s capnp.Struct, f schema.Field, val reflect.Val
 
 	case schema.Type_Which_data:
 		b := val.Bytes()
-		if b == nil && !isEmptyValue(dv) {
+		if b == nil && !sl.defEmpty {
 			b = []byte{}
 		}
-		off := uint16(f.Slot().Offset())
-		return s.SetData(off, b)
+		return s.SetData(uint16(off), b)
 	case schema.Type_Which_structType:
-		off := uint16(f.Slot().Offset())
+		off := uint16(off)
 		sval := val
 		if val.Kind() == reflect.Ptr {
 			if val.IsNil() {
@@ -189,44 +221,38 @@ func (ins *inserter) insertField(s capnp.Struct, f schema.Field, val reflect.Val
 			}
 			sval = val.Elem()
 		}
-		id := typ.StructType().TypeId()
-		sz, err := ins.structSize(id)
+		p, err := ins.planFor(sval.Type(), sl.typ.id)
 		if err != nil {
-			return err
+			return fmt.Errorf("can't insert into %v: %v", sval.Type(), err)
 		}
-		ss, err := capnp.NewStruct(s.Segment(), sz)
+		ss, err := capnp.NewStruct(s.Segment(), p.size)
 		if err != nil {
 			return err
 		}
 		if err := s.SetPtr(off, ss.ToPtr()); err != nil {
 			return err
 		}
-		return ins.insertStruct(id, ss, sval)
+		return ins.insertPlan(p, ss, sval)
 	case schema.Type_Which_list:
-		off := uint16(f.Slot().Offset())
-		if val.IsNil() && isEmptyValue(dv) {
+		off := uint16(off)
+		if val.IsNil() && sl.defEmpty {
 			return s.SetPtr(off, capnp.Ptr{})
 		}
-		elem, err := typ.List().ElementType()
-		if err != nil {
-			return err
-		}
-		l, err := ins.newList(s.Segment(), elem, int32(val.Len()))
+		l, err := ins.newList(s.Segment(), sl.typ.elem, int32(val.Len()))
 		if err != nil {
 			return err
 		}
 		if err := s.SetPtr(off, l.ToPtr()); err != nil {
 			return err
 		}
-		return ins.insertList(l, typ, val)
+		return ins.insertList(l, sl.typ, val)
 	case schema.Type_Which_interface:
-		off := uint16(f.Slot().Offset())
 		ptr := capPtr(s.Segment(), val)
-		if err := s.SetPtr(off, ptr); err != nil {
+		if err := s.SetPtr(uint16(off), ptr); err != nil {
 			return err
 		}
 	case schema.Type_Which_anyPointer:
-		off := uint16(f.Slot().Offset())
+		off := uint16(off)
 		switch val.Type() {
 		case ptrType:
 			return s.SetPtr(off, val.Interface().(capnp.Ptr))
@@ -245,7 +271,7 @@ func (ins *inserter) insertField(s capnp.Struct, f schema.Field, val reflect.Val
 			panic("unreachable")
 		}
 	default:
-		return fmt.Errorf("unknown field type %v", typ.Which())
+		return fmt.Errorf("unknown field type %v", sl.which)
 	}
 	return nil
 }
@@ -260,17 +286,25 @@ func capPtr(seg *capnp.Segment, val reflect.Value) capnp.Ptr {
 	return iface.ToPtr()
 }
 
-func (ins *inserter) insertList(l capnp.List, typ schema.Type, val reflect.Value) error {
-	elem, err := typ.List().ElementType()
-	if err != nil {
-		return err
+func (ins *inserter) insertList(l capnp.List, typ *typeInfo, val reflect.Value) error {
+	elem := typ.elem
+	if hasMarshalHook(val.Type().Elem()) {
+		proxies, err := marshalList(val)
+		if err != nil {
+			return fmt.Errorf("can't insert Go %v into a %v list: %v", val.Type(), elem.which, err)
+		}
+		if !proxies.IsValid() {
+			// Every element is nil, so leave the list zeroed.
+			return nil
+		}
+		return ins.insertList(l, typ, proxies)
 	}
 	if !isTypeMatch(val.Type(), typ) {
 		// TODO(light): the error won't be that useful for nested lists.
-		return fmt.Errorf("can't insert Go %v into a %v list", val.Type(), elem.Which())
+		return fmt.Errorf("can't insert Go %v into a %v list", val.Type(), elem.which)
 	}
 	n := val.Len()
-	switch elem.Which() {
+	switch elem.which {
 	case schema.Type_Which_void:
 	case schema.Type_Which_bool:
 		for i := 0; i < n; i++ {
@@ -373,11 +407,7 @@ func (ins *inserter) insertList(l capnp.List, typ schema.Type, val reflect.Value
 				}
 				continue
 			}
-			ee, err := elem.List().ElementType()
-			if err != nil {
-				return err
-			}
-			li, err := ins.newList(l.Segment(), ee, int32(vi.Len()))
+			li, err := ins.newList(l.Segment(), elem.elem, int32(vi.Len()))
 			if err != nil {
 				return err
 			}
@@ -389,10 +419,27 @@ func (ins *inserter) insertList(l capnp.List, typ schema.Type, val reflect.Value
 			}
 		}
 	case schema.Type_Which_structType:
-		id := elem.StructType().TypeId()
+		if n == 0 {
+			break
+		}
+		et := val.Type().Elem()
+		if et.Kind() == reflect.Ptr {
+			et = et.Elem()
+		}
+		p, err := ins.planFor(et, elem.id)
+		if err != nil {
+			return fmt.Errorf("can't insert into %v: %v", et, err)
+		}
 		for i := 0; i < n; i++ {
-			err := ins.insertStruct(id, l.Struct(i), val.Index(i))
-			if err != nil {
+			vi := val.Index(i)
+			if vi.Kind() == reflect.Ptr {
+				// TODO(light): ignore if nil?
+				vi = vi.Elem()
+				if !vi.IsValid() {
+					return fmt.Errorf("can't insert %v into a struct", vi.Kind())
+				}
+			}
+			if err := ins.insertPlan(p, l.Struct(i), vi); err != nil {
 				// TODO(light): collect errors and finish
 				return err
 			}
@@ -411,13 +458,47 @@ func (ins *inserter) insertList(l capnp.List, typ schema.Type, val reflect.Value
 		// See https://groups.google.com/d/topic/capnproto/BVk3m7Nc-4s/discussion
 		return errors.New("List(AnyPointer) not allowed in schema")
 	default:
-		return fmt.Errorf("unknown list type %v", elem.Which())
+		return fmt.Errorf("unknown list type %v", elem.which)
 	}
 	return nil
 }
 
-func (ins *inserter) newList(s *capnp.Segment, t schema.Type, len int32) (capnp.List, error) {
-	switch t.Which() {
+// marshalList returns a slice of the values that the elements of val
+// marshal to, which must all have the same type.  Nil elements become
+// zero values.  The result is invalid if every element is nil.
+func marshalList(val reflect.Value) (reflect.Value, error) {
+	n := val.Len()
+	proxies := make([]reflect.Value, n)
+	var pt reflect.Type
+	for i := 0; i < n; i++ {
+		p, _, err := marshalHook(val.Index(i))
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("index %d: %v", i, err)
+		}
+		if !p.IsValid() {
+			continue
+		}
+		if pt == nil {
+			pt = p.Type()
+		} else if p.Type() != pt {
+			return reflect.Value{}, fmt.Errorf("index %d marshaled to %v, earlier elements to %v", i, p.Type(), pt)
+		}
+		proxies[i] = p
+	}
+	if pt == nil {
+		return reflect.Value{}, nil
+	}
+	slice := reflect.MakeSlice(reflect.SliceOf(pt), n, n)
+	for i, p := range proxies {
+		if p.IsValid() {
+			slice.Index(i).Set(p)
+		}
+	}
+	return slice, nil
+}
+
+func (ins *inserter) newList(s *capnp.Segment, t *typeInfo, len int32) (capnp.List, error) {
+	switch t.which {
 	case schema.Type_Which_void:
 		l := capnp.NewVoidList(s, len)
 		return capnp.List(l), nil
@@ -440,32 +521,26 @@ func (ins *inserter) newList(s *capnp.Segment, t schema.Type, len int32) (capnp.
 		l, err := capnp.NewPointerList(s, len)
 		return capnp.List(l), err
 	case schema.Type_Which_structType:
-		sz, err := ins.structSize(t.StructType().TypeId())
+		sz, err := ins.structSize(t.id)
 		if err != nil {
 			return capnp.List{}, err
 		}
 		return capnp.NewCompositeList(s, sz, len)
 	default:
-		return capnp.List{}, fmt.Errorf("new list: unknown element type: %v", t.Which())
+		return capnp.List{}, fmt.Errorf("new list: unknown element type: %v", t.which)
 	}
 }
 
 func (ins *inserter) structSize(id uint64) (capnp.ObjectSize, error) {
-	n, err := ins.nodes.Find(id)
+	np, err := nodePlanFor(id)
 	if err != nil {
-		return capnp.ObjectSize{}, err
-	}
-	if n.Which() != schema.Node_Which_structNode {
-		return capnp.ObjectSize{}, fmt.Errorf("insert struct: sizing: node @%#x is not a struct", id)
+		return capnp.ObjectSize{}, fmt.Errorf("insert struct: sizing: %v", err)
 	}
-	return capnp.ObjectSize{
-		DataSize:     capnp.Size(n.StructNode().DataWordCount()) * 8,
-		PointerCount: n.StructNode().PointerCount(),
-	}, nil
+	return np.size, nil
 }
 
-func isFieldInBounds(sz capnp.ObjectSize, off uint32, t schema.Type) bool {
-	switch t.Which() {
+func isFieldInBounds(sz capnp.ObjectSize, off uint32, t *typeInfo) bool {
+	switch t.which {
 	case schema.Type_Which_void:
 		return true
 	case schema.Type_Which_bool:
@@ -485,6 +560,15 @@ func isFieldInBounds(sz capnp.ObjectSize, off uint32, t schema.Type) bool {
 	}
 }
 
+func isPointerType(t *typeInfo) bool {
+	switch t.which {
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list, schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		return true
+	default:
+		return false
+	}
+}
+
 func isEmptyValue(v schema.Value) bool {
 	if !v.IsValid() {
 		return false
@@ -0,0 +1,112 @@
+package pogs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/schema"
+)
+
+// mapAnnotation is the ID of the $Go.map annotation in go.capnp.
+const mapAnnotation = 0xf33187c586e25254
+
+// isMapField reports whether the Go field at loc in t is a map that is
+// converted to and from fi, a list of key/value structs.  This is chosen
+// by the "map" tag option or the $Go.map annotation on fi.
+func isMapField(t reflect.Type, loc fieldLoc, fi *fieldInfo) bool {
+	sf := typeFieldByLoc(t, loc)
+	if sf.Type.Kind() != reflect.Map || hasMarshalHook(sf.Type) || hasUnmarshalHook(sf.Type) {
+		return false
+	}
+	return parseField(sf, false).isMap || fi.mapAnn
+}
+
+// hasMapAnnotation reports whether f has the $Go.map annotation.
+func hasMapAnnotation(f schema.Field) (bool, error) {
+	anns, err := f.Annotations()
+	if err != nil {
+		return false, err
+	}
+	for i := 0; i < anns.Len(); i++ {
+		if anns.At(i).Id() == mapAnnotation {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mapEntryType returns the Go struct type that a single entry of a
+// map of type t is copied through.
+func mapEntryType(t reflect.Type) reflect.Type {
+	valueTag := `capnp:"value"`
+	if t.Elem().Kind() == reflect.Map {
+		valueTag = `capnp:"value,map"`
+	}
+	return reflect.StructOf([]reflect.StructField{
+		{Name: "Key", Type: t.Key(), Tag: `capnp:"key"`},
+		{Name: "Value", Type: t.Elem(), Tag: reflect.StructTag(valueTag)},
+	})
+}
+
+// mapEntries returns the entries of the map val as a slice of
+// mapEntryType structs.  A nil map returns a nil slice.
+func mapEntries(val reflect.Value, sortKeys bool) reflect.Value {
+	st := reflect.SliceOf(mapEntryType(val.Type()))
+	if val.IsNil() {
+		return reflect.Zero(st)
+	}
+	keys := val.MapKeys()
+	if sortKeys {
+		sort.Slice(keys, func(i, j int) bool {
+			return lessKey(keys[i], keys[j])
+		})
+	}
+	entries := reflect.MakeSlice(st, len(keys), len(keys))
+	for i, k := range keys {
+		e := entries.Index(i)
+		e.Field(0).Set(k)
+		e.Field(1).Set(val.MapIndex(k))
+	}
+	return entries
+}
+
+// lessKey orders map keys.  Keys that aren't numbers, strings or bools
+// are ordered by their printed form.
+func lessKey(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	default:
+		return fmt.Sprint(a) < fmt.Sprint(b)
+	}
+}
+
+// extractMap extracts the slot sl, a list of key/value structs, into
+// the map val.  Later entries replace earlier entries with the same key.
+func (e *extracter) extractMap(val reflect.Value, s capnp.Struct, sl *slotInfo) error {
+	entries := reflect.New(reflect.SliceOf(mapEntryType(val.Type()))).Elem()
+	if err := e.extractField(entries, s, sl); err != nil {
+		return err
+	}
+	if entries.IsNil() {
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	}
+	m := reflect.MakeMapWithSize(val.Type(), entries.Len())
+	for i := 0; i < entries.Len(); i++ {
+		ent := entries.Index(i)
+		m.SetMapIndex(ent.Field(0), ent.Field(1))
+	}
+	val.Set(m)
+	return nil
+}
@@ -0,0 +1,126 @@
+package pogs
+
+import (
+	"reflect"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	air "capnproto.org/go/capnp/v3/internal/aircraftlib"
+)
+
+type attrs struct {
+	Plain     map[string]int32 `capnp:",map"`
+	Annotated map[string]int32
+	Nested    map[uint16]map[string]int32 `capnp:",map"`
+}
+
+type attrEntry struct {
+	Key   string
+	Value int32
+}
+
+type attrsList struct {
+	Plain []attrEntry
+}
+
+func newAttrs(t *testing.T) (uint64, capnp.Struct) {
+	t.Helper()
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	a, err := air.NewRootAttrs(seg)
+	if err != nil {
+		t.Fatalf("NewRootAttrs: %v", err)
+	}
+	return air.Attrs_TypeID, capnp.Struct(a)
+}
+
+func TestMap(t *testing.T) {
+	id, s := newAttrs(t)
+	in := &attrs{
+		Plain:     map[string]int32{"a": 1, "b": 2},
+		Annotated: map[string]int32{"c": 3},
+		Nested: map[uint16]map[string]int32{
+			7: {"d": 4},
+			9: nil,
+		},
+	}
+	if err := Insert(id, s, in); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	out := new(attrs)
+	if err := Extract(out, id, s); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	want := &attrs{
+		Plain:     in.Plain,
+		Annotated: in.Annotated,
+		Nested: map[uint16]map[string]int32{
+			7: {"d": 4},
+			9: nil,
+		},
+	}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("Extract(Insert(%+v)) = %+v", in, out)
+	}
+
+	// The entries can still be extracted into a slice.
+	list := new(attrsList)
+	if err := Extract(list, id, s); err != nil {
+		t.Fatalf("Extract list: %v", err)
+	}
+	if len(list.Plain) != 2 {
+		t.Errorf("Extract list produced %d entries; want 2", len(list.Plain))
+	}
+}
+
+func TestMap_Nil(t *testing.T) {
+	id, s := newAttrs(t)
+	if err := Insert(id, s, &attrs{}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if s.HasPtr(0) || s.HasPtr(1) || s.HasPtr(2) {
+		t.Error("Insert of nil maps set pointer fields")
+	}
+	out := &attrs{Plain: map[string]int32{"stale": 1}}
+	if err := Extract(out, id, s); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if out.Plain != nil || out.Annotated != nil || out.Nested != nil {
+		t.Errorf("Extract of null lists = %+v; want nil maps", out)
+	}
+}
+
+func TestMap_SortKeys(t *testing.T) {
+	id, s := newAttrs(t)
+	in := &attrs{Plain: map[string]int32{}}
+	for _, k := range []string{"q", "w", "e", "r", "t", "y", "u", "i", "o", "p"} {
+		in.Plain[k] = int32(len(in.Plain))
+	}
+	if err := InsertWithOptions(id, s, in, &InsertOptions{SortMapKeys: true}); err != nil {
+		t.Fatalf("InsertWithOptions: %v", err)
+	}
+	list := new(attrsList)
+	if err := Extract(list, id, s); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	var keys []string
+	for _, e := range list.Plain {
+		keys = append(keys, e.Key)
+	}
+	want := []string{"e", "i", "o", "p", "q", "r", "t", "u", "w", "y"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("inserted keys = %q; want %q", keys, want)
+	}
+}
+
+func TestMap_Untagged(t *testing.T) {
+	type untagged struct {
+		Plain map[string]int32
+	}
+	id, s := newAttrs(t)
+	if err := Insert(id, s, &untagged{Plain: map[string]int32{"a": 1}}); err == nil {
+		t.Error("Insert of untagged map into a field without $Go.map succeeded")
+	}
+}
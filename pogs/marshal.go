@@ -0,0 +1,201 @@
+package pogs
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Marshaler is the interface implemented by types that insert
+// themselves as another Go value.  MarshalCapnp returns a value that
+// pogs can insert into the field's Cap'n Proto type, such as an int64
+// for an Int64 field or a Go struct for a struct field.
+type Marshaler interface {
+	MarshalCapnp() (any, error)
+}
+
+// Unmarshaler is the interface implemented by types that extract
+// themselves from another Go value.  UnmarshalCapnp calls extract with
+// a pointer to a Go value that pogs can extract the field's Cap'n Proto
+// type into, then sets the receiver from that value.
+type Unmarshaler interface {
+	UnmarshalCapnp(extract func(any) error) error
+}
+
+var (
+	marshalerType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+)
+
+// A converter maps a Go type to and from a proxy type that pogs
+// knows how to insert and extract.
+type converter struct {
+	proxy     reflect.Type
+	marshal   func(reflect.Value) (reflect.Value, error)
+	unmarshal func(reflect.Value) (reflect.Value, error)
+}
+
+var converters struct {
+	mu sync.RWMutex
+	m  map[reflect.Type]*converter
+}
+
+// RegisterConverter registers functions that convert values of type T,
+// which pogs doesn't know how to map, to and from values of type P,
+// which it does.  This is how types that can't be given Marshaler and
+// Unmarshaler methods, such as time.Time, are mapped:
+//
+//	pogs.RegisterConverter(
+//		func(t time.Time) (int64, error) { return t.UnixNano(), nil },
+//		func(ns int64) (time.Time, error) { return time.Unix(0, ns), nil },
+//	)
+//
+// A converter takes precedence over T's own methods.  Registering a
+// converter for a type replaces any previous one.  RegisterConverter is
+// safe to call from multiple goroutines, but is usually called during
+// init: it discards the cached mappings of Go struct types, which are
+// compiled again on their next use.
+func RegisterConverter[T, P any](marshal func(T) (P, error), unmarshal func(P) (T, error)) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	c := &converter{
+		proxy: reflect.TypeOf((*P)(nil)).Elem(),
+		marshal: func(v reflect.Value) (reflect.Value, error) {
+			p, err := marshal(v.Interface().(T))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(&p).Elem(), nil
+		},
+		unmarshal: func(v reflect.Value) (reflect.Value, error) {
+			x, err := unmarshal(v.Interface().(P))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(&x).Elem(), nil
+		},
+	}
+	converters.mu.Lock()
+	defer converters.mu.Unlock()
+	if converters.m == nil {
+		converters.m = make(map[reflect.Type]*converter)
+	}
+	converters.m[t] = c
+	clearStructPlans()
+}
+
+func findConverter(t reflect.Type) *converter {
+	converters.mu.RLock()
+	defer converters.mu.RUnlock()
+	return converters.m[t]
+}
+
+// hasMarshalHook reports whether values of type t are inserted through
+// a registered converter or their own MarshalCapnp method.  Pointers to
+// such types are too.
+func hasMarshalHook(t reflect.Type) bool {
+	return isMarshaler(t) || t.Kind() == reflect.Ptr && isMarshaler(t.Elem())
+}
+
+// hasUnmarshalHook reports whether values of type t are extracted
+// through a registered converter or their own UnmarshalCapnp method.
+// Pointers to such types are too.
+func hasUnmarshalHook(t reflect.Type) bool {
+	return isUnmarshaler(t) || t.Kind() == reflect.Ptr && isUnmarshaler(t.Elem())
+}
+
+func isMarshaler(t reflect.Type) bool {
+	if findConverter(t) != nil {
+		return true
+	}
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		return false
+	}
+	return t.Implements(marshalerType) || reflect.PtrTo(t).Implements(marshalerType)
+}
+
+func isUnmarshaler(t reflect.Type) bool {
+	if findConverter(t) != nil {
+		return true
+	}
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		return false
+	}
+	return reflect.PtrTo(t).Implements(unmarshalerType)
+}
+
+// marshalHook returns the proxy value to insert in place of val.
+// ok is false if val has no hook, and the proxy is invalid if val is a
+// nil pointer.
+func marshalHook(val reflect.Value) (proxy reflect.Value, ok bool, err error) {
+	if !hasMarshalHook(val.Type()) {
+		return reflect.Value{}, false, nil
+	}
+	if !isMarshaler(val.Type()) {
+		if val.IsNil() {
+			return reflect.Value{}, true, nil
+		}
+		val = val.Elem()
+	}
+	if c := findConverter(val.Type()); c != nil {
+		proxy, err = c.marshal(val)
+		return proxy, true, err
+	}
+	var m Marshaler
+	switch {
+	case val.Type().Implements(marshalerType):
+		m = val.Interface().(Marshaler)
+	case val.CanAddr() && val.Addr().Type().Implements(marshalerType):
+		m = val.Addr().Interface().(Marshaler)
+	default:
+		// Only *T has the method and val isn't addressable, so marshal
+		// a copy.
+		cp := reflect.New(val.Type())
+		cp.Elem().Set(val)
+		m = cp.Interface().(Marshaler)
+	}
+	v, err := m.MarshalCapnp()
+	if err != nil {
+		return reflect.Value{}, true, err
+	}
+	if v == nil {
+		return reflect.Value{}, true, nil
+	}
+	return reflect.ValueOf(v), true, nil
+}
+
+// unmarshalHook sets val by calling extract with a proxy value to fill
+// in.  ok is false if val has no hook.
+func unmarshalHook(val reflect.Value, extract func(reflect.Value) error) (ok bool, err error) {
+	if !hasUnmarshalHook(val.Type()) {
+		return false, nil
+	}
+	if !isUnmarshaler(val.Type()) {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	if c := findConverter(val.Type()); c != nil {
+		proxy := reflect.New(c.proxy).Elem()
+		if err := extract(proxy); err != nil {
+			return true, err
+		}
+		v, err := c.unmarshal(proxy)
+		if err != nil {
+			return true, err
+		}
+		val.Set(v)
+		return true, nil
+	}
+	if !val.CanAddr() {
+		return true, fmt.Errorf("can't unmarshal into unaddressable %v", val.Type())
+	}
+	u := val.Addr().Interface().(Unmarshaler)
+	return true, u.UnmarshalCapnp(func(v any) error {
+		p := reflect.ValueOf(v)
+		if p.Kind() != reflect.Ptr || p.IsNil() {
+			return fmt.Errorf("%v.UnmarshalCapnp: extract needs a non-nil pointer, got %T", val.Type(), v)
+		}
+		return extract(p.Elem())
+	})
+}
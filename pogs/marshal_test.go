@@ -0,0 +1,303 @@
+package pogs
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+	air "capnproto.org/go/capnp/v3/internal/aircraftlib"
+)
+
+// date marshals to the Zdate struct.
+type date struct {
+	t time.Time
+}
+
+type zdate struct {
+	Year  int16
+	Month uint8
+	Day   uint8
+}
+
+func (d date) MarshalCapnp() (any, error) {
+	y, m, dd := d.t.Date()
+	if y < -32768 || y > 32767 {
+		return nil, errors.New("year out of range")
+	}
+	return zdate{Year: int16(y), Month: uint8(m), Day: uint8(dd)}, nil
+}
+
+func (d *date) UnmarshalCapnp(extract func(any) error) error {
+	var z zdate
+	if err := extract(&z); err != nil {
+		return err
+	}
+	if z.Month < 1 || z.Month > 12 {
+		return errors.New("bad month")
+	}
+	d.t = time.Date(int(z.Year), time.Month(z.Month), int(z.Day), 0, 0, 0, 0, time.UTC)
+	return nil
+}
+
+// size marshals to an Int64 through a pointer receiver.
+type size struct {
+	n int
+}
+
+func (s *size) MarshalCapnp() (any, error) { return int64(s.n), nil }
+
+func (s *size) UnmarshalCapnp(extract func(any) error) error {
+	var n int64
+	err := extract(&n)
+	s.n = int(n)
+	return err
+}
+
+type dateHolder struct {
+	Zdate date
+}
+
+type dateVecHolder struct {
+	Zdatevec []date
+}
+
+type datePtrHolder struct {
+	Zdate *date
+}
+
+type counter struct {
+	Size  size
+	Words net.IP
+}
+
+func init() {
+	RegisterConverter(
+		func(ip net.IP) (string, error) { return ip.String(), nil },
+		func(s string) (net.IP, error) {
+			if s == "" {
+				return nil, nil
+			}
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, errors.New("bad IP address " + s)
+			}
+			return ip, nil
+		},
+	)
+}
+
+func TestMarshaler(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	want := time.Date(2015, time.August, 27, 0, 0, 0, 0, time.UTC)
+	if err := Insert(air.Z_TypeID, capnp.Struct(z), &dateHolder{Zdate: date{want}}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	zd, _ := z.Zdate()
+	if zd.Year() != 2015 || zd.Month() != 8 || zd.Day() != 27 {
+		t.Errorf("Insert produced %d-%d-%d; want 2015-8-27", zd.Year(), zd.Month(), zd.Day())
+	}
+
+	var out dateHolder
+	if err := Extract(&out, air.Z_TypeID, capnp.Struct(z)); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if !out.Zdate.t.Equal(want) {
+		t.Errorf("Extract produced %v; want %v", out.Zdate.t, want)
+	}
+
+	var ptr datePtrHolder
+	if err := Extract(&ptr, air.Z_TypeID, capnp.Struct(z)); err != nil {
+		t.Fatalf("Extract pointer: %v", err)
+	}
+	if ptr.Zdate == nil || !ptr.Zdate.t.Equal(want) {
+		t.Errorf("Extract pointer produced %v; want %v", ptr.Zdate, want)
+	}
+	if err := Insert(air.Z_TypeID, capnp.Struct(z), &datePtrHolder{}); err != nil {
+		t.Fatalf("Insert nil pointer: %v", err)
+	}
+	if z.HasZdate() {
+		t.Error("Insert nil pointer left the Zdate field set")
+	}
+
+	// Extract and Insert also work on the top-level value.
+	zd.SetYear(1999)
+	var d date
+	if err := Extract(&d, air.Zdate_TypeID, capnp.Struct(zd)); err != nil {
+		t.Fatalf("Extract top level: %v", err)
+	}
+	if d.t.Year() != 1999 {
+		t.Errorf("Extract top level produced %v; want year 1999", d.t)
+	}
+	if err := Insert(air.Zdate_TypeID, capnp.Struct(zd), date{want}); err != nil {
+		t.Fatalf("Insert top level: %v", err)
+	}
+	if zd.Year() != 2015 {
+		t.Errorf("Insert top level produced year %d; want 2015", zd.Year())
+	}
+}
+
+func TestMarshaler_List(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	in := &dateVecHolder{Zdatevec: []date{
+		{time.Date(2015, time.August, 27, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2016, time.January, 2, 0, 0, 0, 0, time.UTC)},
+	}}
+	if err := Insert(air.Z_TypeID, capnp.Struct(z), in); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	l, _ := z.Zdatevec()
+	if l.Len() != 2 || l.At(1).Year() != 2016 || l.At(1).Day() != 2 {
+		t.Errorf("Insert produced list of length %d", l.Len())
+	}
+
+	var out dateVecHolder
+	if err := Extract(&out, air.Z_TypeID, capnp.Struct(z)); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if len(out.Zdatevec) != 2 {
+		t.Fatalf("Extract produced %d dates; want 2", len(out.Zdatevec))
+	}
+	for i := range in.Zdatevec {
+		if !out.Zdatevec[i].t.Equal(in.Zdatevec[i].t) {
+			t.Errorf("Extract produced Zdatevec[%d] = %v; want %v", i, out.Zdatevec[i].t, in.Zdatevec[i].t)
+		}
+	}
+}
+
+func TestMarshaler_Converter(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	c, err := air.NewRootCounter(seg)
+	if err != nil {
+		t.Fatalf("NewRootCounter: %v", err)
+	}
+	in := &counter{Size: size{42}, Words: net.ParseIP("192.0.2.1")}
+	if err := Insert(air.Counter_TypeID, capnp.Struct(c), in); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if words, _ := c.Words(); c.Size() != 42 || words != "192.0.2.1" {
+		t.Errorf("Insert produced size = %d, words = %q; want 42, \"192.0.2.1\"", c.Size(), words)
+	}
+
+	var out counter
+	if err := Extract(&out, air.Counter_TypeID, capnp.Struct(c)); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if out.Size.n != 42 || !out.Words.Equal(in.Words) {
+		t.Errorf("Extract produced size = %d, words = %v; want 42, %v", out.Size.n, out.Words, in.Words)
+	}
+}
+
+// lateName is only given a converter in TestMarshaler_LateConverter.
+type lateName struct {
+	s string
+}
+
+type lateNamePlane struct {
+	Name lateName
+}
+
+func TestMarshaler_LateConverter(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	pb, err := air.NewRootPlaneBase(seg)
+	if err != nil {
+		t.Fatalf("NewRootPlaneBase: %v", err)
+	}
+	codec, err := NewCodec[lateNamePlane](air.PlaneBase_TypeID)
+	if err != nil {
+		t.Fatalf("NewCodec: %v", err)
+	}
+	in := &lateNamePlane{Name: lateName{"x"}}
+	if err := Insert(air.PlaneBase_TypeID, capnp.Struct(pb), in); err == nil {
+		t.Fatal("Insert without a converter succeeded")
+	}
+
+	// The mapping compiled above must not hide the new converter.
+	RegisterConverter(
+		func(n lateName) (string, error) { return n.s, nil },
+		func(s string) (lateName, error) { return lateName{s}, nil },
+	)
+	if err := Insert(air.PlaneBase_TypeID, capnp.Struct(pb), in); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if name, _ := pb.Name(); name != "x" {
+		t.Errorf("Insert produced name = %q; want \"x\"", name)
+	}
+	in.Name.s = "y"
+	if err := codec.Insert(capnp.Struct(pb), in); err != nil {
+		t.Fatalf("Codec.Insert: %v", err)
+	}
+	var out lateNamePlane
+	if err := codec.Extract(&out, capnp.Struct(pb)); err != nil {
+		t.Fatalf("Codec.Extract: %v", err)
+	}
+	if out.Name.s != "y" {
+		t.Errorf("Codec.Extract produced name = %q; want \"y\"", out.Name.s)
+	}
+}
+
+func TestMarshaler_Errors(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	err = Insert(air.Z_TypeID, capnp.Struct(z), &dateHolder{Zdate: date{time.Date(40000, 1, 1, 0, 0, 0, 0, time.UTC)}})
+	if err == nil || !strings.Contains(err.Error(), "year out of range") {
+		t.Errorf("Insert out of range date: error = %v; want year out of range", err)
+	}
+
+	zd, _ := z.NewZdate()
+	zd.SetMonth(13)
+	var out dateHolder
+	err = Extract(&out, air.Z_TypeID, capnp.Struct(z))
+	if err == nil || !strings.Contains(err.Error(), "bad month") {
+		t.Errorf("Extract bad month: error = %v; want bad month", err)
+	}
+
+	c, err := air.NewCounter(seg)
+	if err != nil {
+		t.Fatalf("NewCounter: %v", err)
+	}
+	c.SetWords("not an address")
+	var cout counter
+	err = Extract(&cout, air.Counter_TypeID, capnp.Struct(c))
+	if err == nil || !strings.Contains(err.Error(), "bad IP address") {
+		t.Errorf("Extract bad address: error = %v; want bad IP address", err)
+	}
+
+	// A proxy type that doesn't match the field is reported like any
+	// other mismatch.
+	type badCounter struct {
+		Size date
+	}
+	err = Insert(air.Counter_TypeID, capnp.Struct(c), &badCounter{Size: date{time.Now()}})
+	if err == nil || !strings.Contains(err.Error(), "can't insert field size") {
+		t.Errorf("Insert mismatched proxy: error = %v; want can't insert field size", err)
+	}
+}
@@ -0,0 +1,177 @@
+package pogs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	air "capnproto.org/go/capnp/v3/internal/aircraftlib"
+)
+
+type planeName struct {
+	Name string
+}
+
+type zLite struct {
+	Which     air.Z_Which
+	Zvec      []*zLite
+	Planebase *planeName
+}
+
+func newRootZ(t *testing.T, in *Z) capnp.Struct {
+	t.Helper()
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	if err := Insert(air.Z_TypeID, capnp.Struct(z), in); err != nil {
+		t.Fatalf("Insert(%+v): %v", in, err)
+	}
+	return capnp.Struct(z)
+}
+
+func TestExtract_Strict(t *testing.T) {
+	s := newRootZ(t, &Z{Which: air.Z_Which_planebase, Planebase: &PlaneBase{
+		Name:     "x",
+		Rating:   5,
+		MaxSpeed: 1.5,
+	}})
+	p, err := s.Ptr(0)
+	if err != nil {
+		t.Fatalf("Ptr: %v", err)
+	}
+	pb := p.Struct()
+
+	// Without options, unmapped fields are ignored.
+	if err := Extract(new(planeName), air.PlaneBase_TypeID, pb); err != nil {
+		t.Errorf("Extract: %v", err)
+	}
+
+	out := new(planeName)
+	err = ExtractWithOptions(out, air.PlaneBase_TypeID, pb, &ExtractOptions{Strict: true})
+	var uerr *UnmappedFieldsError
+	if !errors.As(err, &uerr) {
+		t.Fatalf("strict Extract error = %v; want *UnmappedFieldsError", err)
+	}
+	if want := []string{"rating", "maxSpeed"}; !reflect.DeepEqual(uerr.Fields, want) {
+		t.Errorf("strict Extract unmapped fields = %q; want %q", uerr.Fields, want)
+	}
+	if out.Name != "x" {
+		t.Errorf("strict Extract set Name = %q; want \"x\"", out.Name)
+	}
+
+	// Every field mapped: no error.
+	if err := ExtractWithOptions(new(PlaneBase), air.PlaneBase_TypeID, pb, &ExtractOptions{Strict: true}); err != nil {
+		t.Errorf("strict Extract into full struct: %v", err)
+	}
+}
+
+func TestExtract_ReportUnmapped(t *testing.T) {
+	tests := []struct {
+		in   *Z
+		want []string
+	}{
+		{
+			in: &Z{Which: air.Z_Which_zvec, Zvec: []*Z{
+				{Which: air.Z_Which_planebase, Planebase: &PlaneBase{Name: "a"}},
+				{Which: air.Z_Which_planebase, Planebase: &PlaneBase{Name: "b", CanFly: true}},
+			}},
+			want: []string{"zvec[1].planebase.canFly"},
+		},
+		{
+			in:   &Z{Which: air.Z_Which_grp, Grp: &ZGroup{Second: 2}},
+			want: []string{"grp.second"},
+		},
+		{
+			in:   &Z{Which: air.Z_Which_i32, I32: 7},
+			want: []string{"i32"},
+		},
+		{
+			// Default values need not go anywhere.
+			in: &Z{Which: air.Z_Which_text, Text: ""},
+		},
+	}
+	for _, test := range tests {
+		s := newRootZ(t, test.in)
+		var got []string
+		opts := &ExtractOptions{ReportUnmapped: func(fields []string) {
+			if got != nil {
+				t.Error("ReportUnmapped called more than once")
+			}
+			got = fields
+		}}
+		if err := ExtractWithOptions(new(zLite), air.Z_TypeID, s, opts); err != nil {
+			t.Errorf("Extract(%+v): %v", test.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Extract(%+v) reported %q; want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestExtract_StrictUnion(t *testing.T) {
+	s := newRootZ(t, &Z{Which: air.Z_Which_f64, F64: 3.5})
+	err := ExtractWithOptions(new(zUnion), air.Z_TypeID, s, &ExtractOptions{Strict: true})
+	if err != nil {
+		t.Errorf("Extract of registered member: %v", err)
+	}
+
+	// An active member with no registered Go type has nowhere to go.
+	s = newRootZ(t, &Z{Which: air.Z_Which_i32, I32: 7})
+	err = ExtractWithOptions(new(zUnion), air.Z_TypeID, s, &ExtractOptions{Strict: true})
+	var uerr *UnmappedFieldsError
+	if !errors.As(err, &uerr) || !reflect.DeepEqual(uerr.Fields, []string{"i32"}) {
+		t.Errorf("Extract of unregistered member error = %v; want unmapped i32", err)
+	}
+}
+
+func TestInsert_IgnoreUnknownFields(t *testing.T) {
+	type newerPlane struct {
+		Name     string
+		Wingspan float64
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	pb, err := air.NewRootPlaneBase(seg)
+	if err != nil {
+		t.Fatalf("NewRootPlaneBase: %v", err)
+	}
+	in := &newerPlane{Name: "x", Wingspan: 30}
+	if err := Insert(air.PlaneBase_TypeID, capnp.Struct(pb), in); err == nil {
+		t.Error("Insert of struct with unknown field succeeded")
+	}
+	opts := &InsertOptions{IgnoreUnknownFields: true}
+	if err := InsertWithOptions(air.PlaneBase_TypeID, capnp.Struct(pb), in, opts); err != nil {
+		t.Fatalf("InsertWithOptions: %v", err)
+	}
+	if name, _ := pb.Name(); name != "x" {
+		t.Errorf("inserted name = %q; want \"x\"", name)
+	}
+
+	// Nested structs are lenient too.
+	type newerZ struct {
+		Which     air.Z_Which
+		Planebase *newerPlane
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	nz := &newerZ{Which: air.Z_Which_planebase, Planebase: in}
+	if err := InsertWithOptions(air.Z_TypeID, capnp.Struct(z), nz, opts); err != nil {
+		t.Fatalf("InsertWithOptions nested: %v", err)
+	}
+
+	// Extraction still requires every Go field to be in the schema.
+	if err := Extract(new(newerPlane), air.PlaneBase_TypeID, capnp.Struct(pb)); err == nil {
+		t.Error("Extract into struct with unknown field succeeded")
+	}
+}
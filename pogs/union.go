@@ -0,0 +1,240 @@
+package pogs
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/schema"
+)
+
+var unions struct {
+	mu sync.RWMutex
+	m  map[reflect.Type][]unionMember // keyed by interface type
+}
+
+// A unionMember is a Go type registered as a member of a union
+// interface.
+type unionMember struct {
+	typ        reflect.Type // as registered, possibly a pointer
+	schemaName string       // name of the union field it holds
+}
+
+// RegisterUnion registers Go types as the members of a union that is
+// mapped to the interface type I.  Each member is a struct with exactly
+// one exported field, which holds the value of the union field with the
+// same name (or the name given in its capnp tag):
+//
+//	type Shape interface{ isShape() }
+//
+//	type Shape_Circle struct{ Circle float64 }
+//	type Shape_Square struct{ Square float64 }
+//
+//	func (Shape_Circle) isShape() {}
+//	func (Shape_Square) isShape() {}
+//
+//	func init() {
+//		pogs.RegisterUnion[Shape](Shape_Circle{}, Shape_Square{})
+//	}
+//
+// Members may be registered as values or pointers; Extract sets the
+// interface to the form that was registered.  A Void union field is
+// held by a field of type struct{}.  RegisterUnion panics if a member is
+// not a struct with one exported field.
+func RegisterUnion[I any](members ...I) {
+	it := reflect.TypeOf((*I)(nil)).Elem()
+	if it.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("pogs: RegisterUnion: %v is not an interface type", it))
+	}
+	var ms []unionMember
+	for _, m := range members {
+		t := reflect.TypeOf(m)
+		if t == nil {
+			panic("pogs: RegisterUnion: nil member")
+		}
+		f, ok := memberField(t)
+		if !ok {
+			panic(fmt.Sprintf("pogs: RegisterUnion: %v is not a struct with one exported field", t))
+		}
+		p := parseField(f, false)
+		if p.schemaName == "" {
+			panic(fmt.Sprintf("pogs: RegisterUnion: %v field %s is omitted", t, f.Name))
+		}
+		ms = append(ms, unionMember{typ: t, schemaName: p.schemaName})
+	}
+	unions.mu.Lock()
+	defer unions.mu.Unlock()
+	if unions.m == nil {
+		unions.m = make(map[reflect.Type][]unionMember)
+	}
+	for _, m := range ms {
+		dup := false
+		for _, old := range unions.m[it] {
+			dup = dup || old.typ == m.typ
+		}
+		if !dup {
+			unions.m[it] = append(unions.m[it], m)
+		}
+	}
+}
+
+// memberField returns the single exported field of the member struct
+// type t, or *t.
+func memberField(t reflect.Type) (reflect.StructField, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.NumField() != 1 || t.Field(0).PkgPath != "" {
+		return reflect.StructField{}, false
+	}
+	return t.Field(0), true
+}
+
+func findUnionMembers(it reflect.Type) []unionMember {
+	unions.mu.RLock()
+	defer unions.mu.RUnlock()
+	return unions.m[it]
+}
+
+// unionPlan returns the plan for the struct or group typeID, which must
+// have a union.
+func unionPlan(typeID uint64) (*nodePlan, error) {
+	np, err := nodePlanFor(typeID)
+	if err != nil {
+		return nil, err
+	}
+	if !np.hasDiscrim {
+		return nil, fmt.Errorf("%s has no union", np.name)
+	}
+	return np, nil
+}
+
+// insertUnion inserts val, an interface holding a registered union
+// member, into the union of the struct or group typeID.  A nil
+// interface leaves the union unset.
+func (ins *inserter) insertUnion(typeID uint64, s capnp.Struct, val reflect.Value) error {
+	if val.IsNil() {
+		return nil
+	}
+	np, err := unionPlan(typeID)
+	if err != nil {
+		return err
+	}
+	mv := val.Elem()
+	var member *unionMember
+	for _, m := range findUnionMembers(val.Type()) {
+		if m.typ == mv.Type() {
+			m := m
+			member = &m
+			break
+		}
+	}
+	if member == nil {
+		return fmt.Errorf("can't insert %v into %s: not a registered member of %v", mv.Type(), np.name, val.Type())
+	}
+	if mv.Kind() == reflect.Ptr {
+		if mv.IsNil() {
+			return fmt.Errorf("can't insert nil %v into %s", mv.Type(), np.name)
+		}
+		mv = mv.Elem()
+	}
+	fi := np.unionField(member.schemaName)
+	if fi == nil {
+		return fmt.Errorf("can't insert %v: %s has no union field %s", mv.Type(), np.name, member.schemaName)
+	}
+	if s.Size().DataSize < capnp.Size(np.discrimOff+2) {
+		return fmt.Errorf("can't set discriminant for %s: allocated struct is too small", np.name)
+	}
+	s.SetUint16(np.discrimOff, fi.discrim)
+	vf := mv.Field(0)
+	switch {
+	case fi.isGroup():
+		return ins.insertStruct(fi.groupID, s, vf)
+	case fi.slot.which == schema.Type_Which_void:
+		return nil
+	case isMapField(mv.Type(), fieldLoc{i: 0}, fi):
+		return ins.insertField(s, &fi.slot, mapEntries(vf, ins.opts.SortMapKeys))
+	default:
+		return ins.insertField(s, &fi.slot, vf)
+	}
+}
+
+// extractUnion sets val, a union interface, to the registered member
+// for the union field that is set in the struct or group typeID.  If
+// no member is registered for that field, val is set to nil.
+func (e *extracter) extractUnion(val reflect.Value, typeID uint64, s capnp.Struct) error {
+	np, err := unionPlan(typeID)
+	if err != nil {
+		return err
+	}
+	discrim := s.Uint16(np.discrimOff)
+	var fi *fieldInfo
+	for i := range np.fields {
+		if np.fields[i].discrim == discrim {
+			fi = &np.fields[i]
+			break
+		}
+	}
+	var member *unionMember
+	if fi != nil {
+		for _, m := range findUnionMembers(val.Type()) {
+			if fi.name == m.schemaName {
+				m := m
+				member = &m
+				break
+			}
+		}
+	}
+	if member == nil {
+		val.Set(reflect.Zero(val.Type()))
+		if fi != nil && e.track {
+			return e.checkUnmapped(fi, s)
+		}
+		return nil
+	}
+	st := member.typ
+	if st.Kind() == reflect.Ptr {
+		st = st.Elem()
+	}
+	mv := reflect.New(st)
+	vf := mv.Elem().Field(0)
+	prefix := e.prefix
+	if e.track {
+		e.prefix = prefix + fi.name + "."
+	}
+	switch {
+	case fi.isGroup():
+		err = e.extractStruct(vf, fi.groupID, s)
+	case fi.slot.which == schema.Type_Which_void:
+	case isMapField(st, fieldLoc{i: 0}, fi):
+		err = e.extractMap(vf, s, &fi.slot)
+	default:
+		err = e.extractField(vf, s, &fi.slot)
+	}
+	e.prefix = prefix
+	if err != nil {
+		return err
+	}
+	if member.typ.Kind() == reflect.Ptr {
+		val.Set(mv)
+	} else {
+		val.Set(mv.Elem())
+	}
+	return nil
+}
+
+// unionField returns the union member field with the given name, or
+// nil if there is none.
+func (np *nodePlan) unionField(name string) *fieldInfo {
+	for i := range np.fields {
+		fi := &np.fields[i]
+		if fi.discrim == schema.Field_noDiscriminant {
+			continue
+		}
+		if fi.name == name {
+			return fi
+		}
+	}
+	return nil
+}
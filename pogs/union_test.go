@@ -0,0 +1,182 @@
+package pogs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	air "capnproto.org/go/capnp/v3/internal/aircraftlib"
+)
+
+type zValue interface{ isZValue() }
+
+type zVoid struct{ Void struct{} }
+type zF64 struct{ F64 float64 }
+type zText struct {
+	Body string `capnp:"text"`
+}
+type zGrp struct{ Grp ZGroup }
+type zZdate struct{ Zdate *zdate }
+type zI32 struct{ I32 int32 } // not registered
+
+func (zVoid) isZValue()  {}
+func (zF64) isZValue()   {}
+func (*zText) isZValue() {}
+func (zGrp) isZValue()   {}
+func (zZdate) isZValue() {}
+func (zI32) isZValue()   {}
+
+type zUnion struct {
+	Value zValue `capnp:",union"`
+}
+
+type shape interface{ isShape() }
+
+type shapeNone struct{ None struct{} }
+type shapeCircle struct{ Circle float64 }
+type shapeRect struct {
+	Rect struct {
+		Width  float64
+		Height float64
+	}
+}
+
+func (shapeNone) isShape()   {}
+func (shapeCircle) isShape() {}
+func (shapeRect) isShape()   {}
+
+type drawing struct {
+	Name  string
+	Shape shape
+}
+
+func init() {
+	RegisterUnion[zValue](zVoid{}, zF64{}, &zText{}, zGrp{}, zZdate{})
+	RegisterUnion[shape](shapeNone{}, shapeCircle{}, shapeRect{})
+}
+
+func TestUnion(t *testing.T) {
+	tests := []struct {
+		val   zValue
+		which air.Z_Which
+	}{
+		{zVoid{}, air.Z_Which_void},
+		{zF64{3.5}, air.Z_Which_f64},
+		{&zText{"hi"}, air.Z_Which_text},
+		{zGrp{ZGroup{First: 1, Second: 2}}, air.Z_Which_grp},
+		{zZdate{&zdate{Year: 2015, Month: 8, Day: 27}}, air.Z_Which_zdate},
+	}
+	for _, test := range tests {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			t.Fatalf("NewMessage: %v", err)
+		}
+		z, err := air.NewRootZ(seg)
+		if err != nil {
+			t.Fatalf("NewRootZ: %v", err)
+		}
+		// Start from a different member, so that void is really set.
+		z.SetI32(7)
+		if err := Insert(air.Z_TypeID, capnp.Struct(z), &zUnion{test.val}); err != nil {
+			t.Errorf("Insert(%#v): %v", test.val, err)
+			continue
+		}
+		if z.Which() != test.which {
+			t.Errorf("Insert(%#v) set %v; want %v", test.val, z.Which(), test.which)
+		}
+		out := new(zUnion)
+		if err := Extract(out, air.Z_TypeID, capnp.Struct(z)); err != nil {
+			t.Errorf("Extract(%#v): %v", test.val, err)
+			continue
+		}
+		if !reflect.DeepEqual(out.Value, test.val) {
+			t.Errorf("Extract(Insert(%#v)) = %#v", test.val, out.Value)
+		}
+	}
+}
+
+func TestUnion_Unregistered(t *testing.T) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	err = Insert(air.Z_TypeID, capnp.Struct(z), &zUnion{zI32{7}})
+	if err == nil || !strings.Contains(err.Error(), "not a registered member") {
+		t.Errorf("Insert of unregistered member: error = %v; want not a registered member", err)
+	}
+
+	// Extracting a member without a registered type leaves nil.
+	z.SetI32(7)
+	out := &zUnion{zF64{1}}
+	if err := Extract(out, air.Z_TypeID, capnp.Struct(z)); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if out.Value != nil {
+		t.Errorf("Extract of unregistered member = %#v; want nil", out.Value)
+	}
+
+	// A nil interface leaves the union alone.
+	if err := Insert(air.Z_TypeID, capnp.Struct(z), &zUnion{}); err != nil {
+		t.Fatalf("Insert nil: %v", err)
+	}
+	if z.Which() != air.Z_Which_i32 {
+		t.Errorf("Insert nil changed union to %v", z.Which())
+	}
+}
+
+func TestUnion_Named(t *testing.T) {
+	tests := []shape{
+		shapeNone{},
+		shapeCircle{2},
+		shapeRect{Rect: struct {
+			Width  float64
+			Height float64
+		}{3, 4}},
+	}
+	for _, test := range tests {
+		_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+		if err != nil {
+			t.Fatalf("NewMessage: %v", err)
+		}
+		d, err := air.NewRootDrawing(seg)
+		if err != nil {
+			t.Fatalf("NewRootDrawing: %v", err)
+		}
+		in := &drawing{Name: "d", Shape: test}
+		if err := Insert(air.Drawing_TypeID, capnp.Struct(d), in); err != nil {
+			t.Errorf("Insert(%#v): %v", test, err)
+			continue
+		}
+		out := new(drawing)
+		if err := Extract(out, air.Drawing_TypeID, capnp.Struct(d)); err != nil {
+			t.Errorf("Extract(%#v): %v", test, err)
+			continue
+		}
+		if !reflect.DeepEqual(out, in) {
+			t.Errorf("Extract(Insert(%#v)) = %#v", in, out)
+		}
+	}
+}
+
+func TestUnion_WithWhich(t *testing.T) {
+	type both struct {
+		Which air.Z_Which
+		Value zValue `capnp:",union"`
+	}
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		t.Fatalf("NewMessage: %v", err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatalf("NewRootZ: %v", err)
+	}
+	if err := Insert(air.Z_TypeID, capnp.Struct(z), &both{Value: zF64{1}}); err == nil {
+		t.Error("Insert of struct with both Which and union field succeeded")
+	}
+}
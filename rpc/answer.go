@@ -1,12 +1,12 @@
 package rpc
 
 import (
-	"context"
 	"errors"
 	"sync"
 
 	"capnproto.org/go/capnp/v3"
 	"capnproto.org/go/capnp/v3/exc"
+	"capnproto.org/go/capnp/v3/internal/ctxutil"
 	"capnproto.org/go/capnp/v3/internal/rc"
 	"capnproto.org/go/capnp/v3/internal/syncutil"
 	rpccp "capnproto.org/go/capnp/v3/std/capnp/rpc"
@@ -21,9 +21,9 @@ type answer struct {
 	c  *Conn
 	id answerID
 
-	// cancel cancels the Context used in the received method call.
-	// May be nil.
-	cancel context.CancelFunc
+	// cancel cancels the Context used in the received method call,
+	// with the reason as its cause.  May be nil.
+	cancel ctxutil.CancelCauseFunc
 
 	// ret is the outgoing Return struct.  ret is valid iff there was no
 	// error creating the message.  If ret is invalid, then this answer
@@ -106,9 +106,9 @@ type PingPong_Server interface {
 }
 
 // PingPong_NewServer creates a new Server from an implementation of PingPong_Server.
-func PingPong_NewServer(s PingPong_Server) *server.Server {
+func PingPong_NewServer(s PingPong_Server, opts ...server.Option) *server.Server {
 	c, _ := s.(server.Shutdowner)
-	return server.New(PingPong_Methods(nil, s), s, c)
+	return server.New(PingPong_Methods(nil, s), s, c, opts...)
 }
 
 // PingPong_ServerToClient creates a new Client from an implementation of PingPong_Server.
@@ -402,9 +402,9 @@ type StreamTest_Server interface {
 }
 
 // StreamTest_NewServer creates a new Server from an implementation of StreamTest_Server.
-func StreamTest_NewServer(s StreamTest_Server) *server.Server {
+func StreamTest_NewServer(s StreamTest_Server, opts ...server.Option) *server.Server {
 	c, _ := s.(server.Shutdowner)
-	return server.New(StreamTest_Methods(nil, s), s, c)
+	return server.New(StreamTest_Methods(nil, s), s, c, opts...)
 }
 
 // StreamTest_ServerToClient creates a new Client from an implementation of StreamTest_Server.
@@ -517,6 +517,18 @@ func (s StreamTest_push_Params) HasData() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownData detaches the data field from s without
+// copying it.  The field is left null.
+func (s StreamTest_push_Params) DisownData() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptData sets the data field to an orphan from
+// s's message without copying it.
+func (s StreamTest_push_Params) AdoptData(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s StreamTest_push_Params) SetData(v []byte) error {
 	return capnp.Struct(s).SetData(0, v)
 }
@@ -649,9 +661,9 @@ type CapArgsTest_Server interface {
 }
 
 // CapArgsTest_NewServer creates a new Server from an implementation of CapArgsTest_Server.
-func CapArgsTest_NewServer(s CapArgsTest_Server) *server.Server {
+func CapArgsTest_NewServer(s CapArgsTest_Server, opts ...server.Option) *server.Server {
 	c, _ := s.(server.Shutdowner)
-	return server.New(CapArgsTest_Methods(nil, s), s, c)
+	return server.New(CapArgsTest_Methods(nil, s), s, c, opts...)
 }
 
 // CapArgsTest_ServerToClient creates a new Client from an implementation of CapArgsTest_Server.
@@ -1130,9 +1142,9 @@ type PingPongProvider_Server interface {
 }
 
 // PingPongProvider_NewServer creates a new Server from an implementation of PingPongProvider_Server.
-func PingPongProvider_NewServer(s PingPongProvider_Server) *server.Server {
+func PingPongProvider_NewServer(s PingPongProvider_Server, opts ...server.Option) *server.Server {
 	c, _ := s.(server.Shutdowner)
-	return server.New(PingPongProvider_Methods(nil, s), s, c)
+	return server.New(PingPongProvider_Methods(nil, s), s, c, opts...)
 }
 
 // PingPongProvider_ServerToClient creates a new Client from an implementation of PingPongProvider_Server.
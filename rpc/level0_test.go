@@ -15,6 +15,7 @@ import (
 
 	"capnproto.org/go/capnp/v3"
 	"capnproto.org/go/capnp/v3/exc"
+	"capnproto.org/go/capnp/v3/internal/ctxutil"
 	"capnproto.org/go/capnp/v3/pogs"
 	"capnproto.org/go/capnp/v3/rpc"
 	testcp "capnproto.org/go/capnp/v3/rpc/internal/testcapnp"
@@ -1449,10 +1450,12 @@ func TestRecvCancel(t *testing.T) {
 
 	callCancel := make(chan struct{})
 	retcapShutdown := make(chan struct{})
+	var cause error
 	srv := newServer(func(ctx context.Context, call *server.Call) error {
 		// Wait until canceled
 		call.Go()
 		<-ctx.Done()
+		cause = ctxutil.Cause(ctx)
 		close(callCancel)
 
 		// Return a capability
@@ -1582,6 +1585,9 @@ func TestRecvCancel(t *testing.T) {
 		}
 	}
 	<-callCancel
+	if !errors.Is(cause, ctxutil.ErrCallCanceled) {
+		t.Errorf("call context cancel cause = %v; want %v", cause, ctxutil.ErrCallCanceled)
+	}
 
 	// 6. Read call return
 	{
@@ -1615,6 +1621,129 @@ func TestRecvCancel(t *testing.T) {
 	}
 }
 
+// TestRecvDisconnect makes a call, then shuts down the caller's
+// connection before it returns, and checks that the call's Context was
+// canceled with a disconnected cause.
+func TestRecvDisconnect(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	causes := make(chan error, 1)
+	srv := newServer(func(ctx context.Context, call *server.Call) error {
+		close(started)
+		<-ctx.Done()
+		causes <- ctxutil.Cause(ctx)
+		return ctx.Err()
+	}, nil)
+	left, right := transport.NewPipe(1)
+	p1, p2 := rpc.NewTransport(left), rpc.NewTransport(right)
+	srvConn := rpc.NewConn(p1, &rpc.Options{
+		BootstrapClient: srv,
+		ErrorReporter:   testErrorReporter{tb: t},
+	})
+	defer srvConn.Close()
+	cliConn := rpc.NewConn(p2, nil)
+
+	ctx := context.Background()
+	client := cliConn.Bootstrap(ctx)
+	ans, finish := client.SendCall(ctx, capnp.Send{
+		Method: capnp.Method{InterfaceID: interfaceID, MethodID: methodID},
+	})
+	defer finish()
+	<-started
+
+	client.Release()
+	cliConn.Close()
+	select {
+	case cause := <-causes:
+		if exc.TypeOf(cause) != exc.Disconnected {
+			t.Errorf("call context cancel cause = %v; want disconnected", cause)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("call context not canceled after caller disconnected")
+	}
+	if _, err := ans.Struct(); err == nil {
+		t.Error("call succeeded after connection closed")
+	}
+}
+
+// TestRecvTransportError makes a call, then makes the callee's
+// transport fail before the call returns, and checks that the call's
+// Context was canceled with the transport's error as the cause.
+func TestRecvTransportError(t *testing.T) {
+	t.Parallel()
+
+	errRecv := errors.New("receive failed")
+	started := make(chan struct{})
+	causes := make(chan error, 1)
+	srv := newServer(func(ctx context.Context, call *server.Call) error {
+		select {
+		case <-started:
+			return nil
+		default:
+			close(started)
+		}
+		<-ctx.Done()
+		causes <- ctxutil.Cause(ctx)
+		return ctx.Err()
+	}, nil)
+	left, right := transport.NewPipe(1)
+	fc := &failCodec{Codec: left, fail: make(chan struct{}), err: errRecv}
+	p1, p2 := rpc.NewTransport(fc), rpc.NewTransport(right)
+	srvConn := rpc.NewConn(p1, &rpc.Options{BootstrapClient: srv})
+	defer srvConn.Close()
+	cliConn := rpc.NewConn(p2, nil)
+	defer cliConn.Close()
+
+	ctx := context.Background()
+	client := cliConn.Bootstrap(ctx)
+	defer client.Release()
+	ans, finish := client.SendCall(ctx, capnp.Send{
+		Method: capnp.Method{InterfaceID: interfaceID, MethodID: methodID},
+	})
+	defer finish()
+	<-started
+
+	// The next message the callee reads fails.
+	close(fc.fail)
+	_, finish2 := client.SendCall(ctx, capnp.Send{
+		Method: capnp.Method{InterfaceID: interfaceID, MethodID: methodID},
+	})
+	defer finish2()
+	select {
+	case cause := <-causes:
+		if exc.TypeOf(cause) != exc.Disconnected {
+			t.Errorf("call context cancel cause = %v; want disconnected", cause)
+		}
+		if !errors.Is(cause, errRecv) {
+			t.Errorf("call context cancel cause = %v; want it to wrap %v", cause, errRecv)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("call context not canceled after transport failed")
+	}
+	if _, err := ans.Struct(); err == nil {
+		t.Error("call succeeded after transport failed")
+	}
+}
+
+// failCodec is a transport.Codec whose Decode returns err once fail is
+// closed.
+type failCodec struct {
+	transport.Codec
+	fail chan struct{}
+	err  error
+}
+
+func (c *failCodec) Decode() (*capnp.Message, error) {
+	m, err := c.Codec.Decode()
+	select {
+	case <-c.fail:
+		return nil, c.err
+	default:
+		return m, err
+	}
+}
+
 // TestSendCancel makes a call, cancels the Context, then checks to
 // see whether a finish message was sent.  Level 0 requirement.
 func TestSendCancel(t *testing.T) {
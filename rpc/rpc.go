@@ -12,6 +12,7 @@ import (
 	"capnproto.org/go/capnp/v3"
 	"capnproto.org/go/capnp/v3/exc"
 	"capnproto.org/go/capnp/v3/exp/spsc"
+	"capnproto.org/go/capnp/v3/internal/ctxutil"
 	"capnproto.org/go/capnp/v3/internal/str"
 	"capnproto.org/go/capnp/v3/internal/syncutil"
 	"capnproto.org/go/capnp/v3/rpc/transport"
@@ -88,6 +89,13 @@ type Conn struct {
 	// being the *only* time it will be canceled.
 	bgctx context.Context
 
+	// callctx is the parent of the Contexts of received calls.  It is
+	// derived from bgctx, but cancelCalls gives it an exc.Disconnected
+	// cause first: ExcClosed when shutdown starts, or the error of the
+	// background task that stopped.
+	callctx     context.Context
+	cancelCalls ctxutil.CancelCauseFunc
+
 	// tasks block shutdown.
 	tasks  sync.WaitGroup
 	closed chan struct{} // closed when shutdown() returns
@@ -215,10 +223,13 @@ func NewConn(t Transport, opts *Options) *Conn {
 	// to each other.
 	g, ctx := errgroup.WithContext(ctx)
 
+	callctx, cancelCalls := ctxutil.WithCancelCause(ctx)
 	c := &Conn{
-		transport: t,
-		closed:    make(chan struct{}),
-		bgctx:     ctx,
+		transport:   t,
+		closed:      make(chan struct{}),
+		bgctx:       ctx,
+		callctx:     callctx,
+		cancelCalls: cancelCalls,
 	}
 	sender := spsc.New[asyncSend]()
 	c.sendRx = &sender.Rx
@@ -274,6 +285,14 @@ func (c *Conn) backgroundTask(f func() error) func() error {
 			err = context.Canceled
 		}
 
+		// Cancel received calls before the errgroup cancels bgctx, so
+		// that they see why the connection stopped.
+		if errors.Is(err, context.Canceled) {
+			c.cancelCalls(ExcClosed)
+		} else {
+			c.cancelCalls(rpcerr.Disconnected(err))
+		}
+
 		return err
 	}
 }
@@ -373,6 +392,7 @@ func (c *Conn) shutdown(abortErr error) (err error) {
 		alreadyClosing = c.lk.closing
 		if !alreadyClosing {
 			c.lk.closing = true
+			c.cancelCalls(ExcClosed)
 			c.lk.bgcancel()
 			c.cancelTasks()
 		}
@@ -413,7 +433,7 @@ func (c *Conn) shutdown(abortErr error) (err error) {
 func (c *Conn) cancelTasks() {
 	for _, a := range c.lk.answers {
 		if a != nil && a.cancel != nil {
-			a.cancel()
+			a.cancel(ExcClosed)
 		}
 	}
 }
@@ -845,7 +865,7 @@ func (c *Conn) handleCall(ctx context.Context, call rpccp.Call, releaseCall capn
 			}
 			c.tasks.Add(1) // will be finished by answer.Return
 			var callCtx context.Context
-			callCtx, ans.cancel = context.WithCancel(c.bgctx)
+			callCtx, ans.cancel = ctxutil.WithCancelCause(c.callctx)
 			rl.Add(func() {
 				pcall := ent.client.RecvCall(callCtx, recv)
 				// Place PipelineCaller into answer.  Since the receive goroutine is
@@ -907,7 +927,7 @@ func (c *Conn) handleCall(ctx context.Context, call rpccp.Call, releaseCall capn
 				}
 				c.tasks.Add(1) // will be finished by answer.Return
 				var callCtx context.Context
-				callCtx, ans.cancel = context.WithCancel(c.bgctx)
+				callCtx, ans.cancel = ctxutil.WithCancelCause(c.callctx)
 				rl.Add(func() {
 					pcall := tgt.RecvCall(callCtx, recv)
 					ans.setPipelineCaller(p.method, pcall)
@@ -916,7 +936,7 @@ func (c *Conn) handleCall(ctx context.Context, call rpccp.Call, releaseCall capn
 				// Results not ready, use pipeline caller.
 				tgtAns.pcalls.Add(1) // will be finished by answer.Return
 				var callCtx context.Context
-				callCtx, ans.cancel = context.WithCancel(c.bgctx)
+				callCtx, ans.cancel = ctxutil.WithCancelCause(c.callctx)
 				tgt := tgtAns.pcall
 				c.tasks.Add(1) // will be finished by answer.Return
 				rl.Add(func() {
@@ -1220,7 +1240,7 @@ func (c *Conn) handleFinish(ctx context.Context, id answerID, releaseResultCaps
 			ans.flags |= releaseResultCapsFlag
 		}
 		if ans.cancel != nil {
-			ans.cancel()
+			ans.cancel(ctxutil.ErrCallCanceled)
 		}
 		if !ans.flags.Contains(returnSent) {
 			return nil
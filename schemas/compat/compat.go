@@ -0,0 +1,432 @@
+// Package compat compares two versions of a schema and reports the
+// changes between them, distinguishing changes that break wire
+// compatibility from safe ones.
+//
+// Nodes are matched by ID, struct fields by ordinal, and enumerants
+// and methods by their position in ordinal order, so renaming a
+// declaration is safe but renumbering it is not.  The rules follow the
+// Cap'n Proto schema evolution guidelines at
+// https://capnproto.org/language.html#evolving-your-protocol.
+package compat
+
+import (
+	"fmt"
+	"strings"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/schemas"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// A Schema is a set of nodes to compare.
+type Schema struct {
+	nodes map[uint64]schema.Node
+	roots []uint64
+}
+
+// FromRequest returns the schema for the files requested in req.
+// Imported files are only consulted for the types they declare.
+func FromRequest(req schema.CodeGeneratorRequest) (*Schema, error) {
+	s := &Schema{nodes: make(map[uint64]schema.Node)}
+	if err := s.add(req); err != nil {
+		return nil, err
+	}
+	files, err := req.RequestedFiles()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < files.Len(); i++ {
+		s.roots = append(s.roots, files.At(i).Id())
+	}
+	return s, nil
+}
+
+// FromRegistry returns the schema for the nodes with the given IDs,
+// and the nodes nested inside them, as registered in reg.  Generated
+// code registers the nodes of each file, so passing the IDs of a
+// package's top-level types compares that package.
+func FromRegistry(reg *schemas.Registry, ids ...uint64) (*Schema, error) {
+	s := &Schema{nodes: make(map[uint64]schema.Node)}
+	for _, id := range ids {
+		if _, ok := s.nodes[id]; !ok {
+			data, err := reg.Find(id)
+			if err != nil {
+				return nil, err
+			}
+			msg, err := capnp.Unmarshal(data)
+			if err != nil {
+				return nil, err
+			}
+			req, err := schema.ReadRootCodeGeneratorRequest(msg)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.add(req); err != nil {
+				return nil, err
+			}
+		}
+		s.roots = append(s.roots, id)
+	}
+	return s, nil
+}
+
+func (s *Schema) add(req schema.CodeGeneratorRequest) error {
+	nodes, err := req.Nodes()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < nodes.Len(); i++ {
+		// Registered schemas pad their node lists with empty nodes.
+		if n := nodes.At(i); n.Id() != 0 {
+			s.nodes[n.Id()] = n
+		}
+	}
+	return nil
+}
+
+// A Change is a difference between two versions of a schema.
+type Change struct {
+	// Breaking is true if messages or calls written with one version
+	// may be misread by the other.
+	Breaking bool
+
+	// Node is the display name of the declaration that changed, such
+	// as "foo.capnp:Foo".
+	Node string
+
+	// Msg describes the change.
+	Msg string
+}
+
+func (c Change) String() string {
+	if c.Breaking {
+		return c.Node + ": breaking: " + c.Msg
+	}
+	return c.Node + ": " + c.Msg
+}
+
+// HasBreaking reports whether any of changes is breaking.
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Check compares the declarations in old with those in new.  Every
+// declaration nested in one of old's roots is compared with the node of
+// the same ID in new.
+func Check(old, new *Schema) ([]Change, error) {
+	c := &checker{old: old, new: new, seen: make(map[uint64]bool)}
+	for _, id := range old.roots {
+		if err := c.node(id); err != nil {
+			return nil, err
+		}
+	}
+	return c.changes, nil
+}
+
+type checker struct {
+	old, new *Schema
+	seen     map[uint64]bool
+	changes  []Change
+}
+
+func (c *checker) add(breaking bool, node, format string, args ...any) {
+	c.changes = append(c.changes, Change{
+		Breaking: breaking,
+		Node:     node,
+		Msg:      fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) node(id uint64) error {
+	if c.seen[id] {
+		return nil
+	}
+	c.seen[id] = true
+	o, ok := c.old.nodes[id]
+	if !ok {
+		return fmt.Errorf("node @%#x not found in old schema", id)
+	}
+	name, err := o.DisplayName()
+	if err != nil {
+		return err
+	}
+	n, ok := c.new.nodes[id]
+	if !ok {
+		c.add(true, name, "%s removed", kind(o))
+		return nil
+	}
+	// Only names within a file matter: the old and new versions of a
+	// file are often read from different places.
+	if newName, err := n.DisplayName(); err != nil {
+		return err
+	} else if o.Which() != schema.Node_Which_file && localName(newName) != localName(name) {
+		c.add(false, name, "renamed to %s", localName(newName))
+	}
+	if o.Which() != n.Which() {
+		c.add(true, name, "changed from %s to %s", kind(o), kind(n))
+		return nil
+	}
+
+	switch o.Which() {
+	case schema.Node_Which_structNode:
+		if !o.StructNode().IsGroup() {
+			if err := c.structs(name, o, n); err != nil {
+				return err
+			}
+		}
+	case schema.Node_Which_enum:
+		if err := c.enums(name, o, n); err != nil {
+			return err
+		}
+	case schema.Node_Which_interface:
+		if err := c.interfaces(name, o, n); err != nil {
+			return err
+		}
+	case schema.Node_Which_const:
+		if err := c.consts(name, o, n); err != nil {
+			return err
+		}
+	}
+	return c.nested(name, o, n)
+}
+
+func (c *checker) nested(name string, o, n schema.Node) error {
+	on, err := o.NestedNodes()
+	if err != nil {
+		return err
+	}
+	nn, err := n.NestedNodes()
+	if err != nil {
+		return err
+	}
+	inNew := make(map[uint64]bool, nn.Len())
+	for i := 0; i < nn.Len(); i++ {
+		inNew[nn.At(i).Id()] = true
+	}
+	inOld := make(map[uint64]bool, on.Len())
+	for i := 0; i < on.Len(); i++ {
+		id := on.At(i).Id()
+		inOld[id] = true
+		if !inNew[id] {
+			if _, moved := c.new.nodes[id]; !moved {
+				nestedName, _ := on.At(i).Name()
+				c.add(true, name, "nested declaration %s removed", nestedName)
+				c.seen[id] = true
+				continue
+			}
+		}
+		if err := c.node(id); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < nn.Len(); i++ {
+		if !inOld[nn.At(i).Id()] {
+			nestedName, _ := nn.At(i).Name()
+			c.add(false, name, "nested declaration %s added", nestedName)
+		}
+	}
+	return nil
+}
+
+// localName returns a display name without its file name.
+func localName(dn string) string {
+	if i := strings.IndexByte(dn, ':'); i >= 0 {
+		return dn[i+1:]
+	}
+	return dn
+}
+
+func kind(n schema.Node) string {
+	switch n.Which() {
+	case schema.Node_Which_structNode:
+		if n.StructNode().IsGroup() {
+			return "group"
+		}
+		return "struct"
+	case schema.Node_Which_annotation:
+		return "annotation"
+	default:
+		return n.Which().String()
+	}
+}
+
+func (c *checker) enums(name string, o, n schema.Node) error {
+	oe, err := o.Enum().Enumerants()
+	if err != nil {
+		return err
+	}
+	ne, err := n.Enum().Enumerants()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < oe.Len(); i++ {
+		oName, _ := oe.At(i).Name()
+		if i >= ne.Len() {
+			c.add(true, name, "enumerant %s (@%d) removed", oName, i)
+			continue
+		}
+		if nName, _ := ne.At(i).Name(); nName != oName {
+			c.add(false, name, "enumerant @%d renamed from %s to %s", i, oName, nName)
+		}
+	}
+	for i := oe.Len(); i < ne.Len(); i++ {
+		nName, _ := ne.At(i).Name()
+		c.add(false, name, "enumerant %s (@%d) added", nName, i)
+	}
+	return nil
+}
+
+func (c *checker) interfaces(name string, o, n schema.Node) error {
+	osup, err := o.Interface().Superclasses()
+	if err != nil {
+		return err
+	}
+	nsup, err := n.Interface().Superclasses()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < osup.Len(); i++ {
+		id := osup.At(i).Id()
+		found := false
+		for j := 0; j < nsup.Len(); j++ {
+			found = found || nsup.At(j).Id() == id
+		}
+		if !found {
+			c.add(true, name, "no longer extends %s", c.typeName(c.old, id))
+		}
+	}
+
+	om, err := o.Interface().Methods()
+	if err != nil {
+		return err
+	}
+	nm, err := n.Interface().Methods()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < om.Len(); i++ {
+		oName, _ := om.At(i).Name()
+		if i >= nm.Len() {
+			c.add(true, name, "method %s (@%d) removed", oName, i)
+			continue
+		}
+		if nName, _ := nm.At(i).Name(); nName != oName {
+			c.add(false, name, "method @%d renamed from %s to %s", i, oName, nName)
+		}
+		mname := name + "." + oName
+		if err := c.methodStruct(mname+" params", om.At(i).ParamStructType(), nm.At(i).ParamStructType()); err != nil {
+			return err
+		}
+		if err := c.methodStruct(mname+" results", om.At(i).ResultStructType(), nm.At(i).ResultStructType()); err != nil {
+			return err
+		}
+	}
+	for i := om.Len(); i < nm.Len(); i++ {
+		nName, _ := nm.At(i).Name()
+		c.add(false, name, "method %s (@%d) added", nName, i)
+	}
+	return nil
+}
+
+// methodStruct compares the parameter or result structs of a method,
+// which may be replaced by another struct with the same layout.
+func (c *checker) methodStruct(name string, oid, nid uint64) error {
+	o, ok := c.old.nodes[oid]
+	if !ok {
+		return fmt.Errorf("%s: struct @%#x not found in old schema", name, oid)
+	}
+	n, ok := c.new.nodes[nid]
+	if !ok {
+		return fmt.Errorf("%s: struct @%#x not found in new schema", name, nid)
+	}
+	if o.Which() != schema.Node_Which_structNode || n.Which() != schema.Node_Which_structNode {
+		return fmt.Errorf("%s: not a struct", name)
+	}
+	if oid == nid {
+		// Named structs are compared in their own right.
+		if o.ScopeId() != 0 {
+			return nil
+		}
+		c.seen[oid] = true
+	}
+	return c.structs(name, o, n)
+}
+
+func (c *checker) consts(name string, o, n schema.Node) error {
+	ot, err := o.Const().Type()
+	if err != nil {
+		return err
+	}
+	nt, err := n.Const().Type()
+	if err != nil {
+		return err
+	}
+	if os, ns := c.typeString(c.old, ot), c.typeString(c.new, nt); os != ns {
+		c.add(false, name, "type changed from %s to %s", os, ns)
+		return nil
+	}
+	ov, err := o.Const().Value()
+	if err != nil {
+		return err
+	}
+	nv, err := n.Const().Value()
+	if err != nil {
+		return err
+	}
+	if eq, err := capnp.Equal(ov.ToPtr(), nv.ToPtr()); err != nil {
+		return err
+	} else if !eq {
+		c.add(false, name, "value changed")
+	}
+	return nil
+}
+
+// typeName returns the display name of the node id, without its file.
+func (c *checker) typeName(s *Schema, id uint64) string {
+	n, ok := s.nodes[id]
+	if !ok {
+		return fmt.Sprintf("@%#x", id)
+	}
+	dn, _ := n.DisplayName()
+	return localName(dn)
+}
+
+func (c *checker) typeString(s *Schema, t schema.Type) string {
+	switch t.Which() {
+	case schema.Type_Which_list:
+		elem, _ := t.List().ElementType()
+		return "List(" + c.typeString(s, elem) + ")"
+	case schema.Type_Which_enum:
+		return c.typeName(s, t.Enum().TypeId())
+	case schema.Type_Which_structType:
+		return c.typeName(s, t.StructType().TypeId())
+	case schema.Type_Which_interface:
+		return c.typeName(s, t.Interface().TypeId())
+	case schema.Type_Which_anyPointer:
+		return "AnyPointer"
+	default:
+		return builtinNames[t.Which()]
+	}
+}
+
+var builtinNames = map[schema.Type_Which]string{
+	schema.Type_Which_void:    "Void",
+	schema.Type_Which_bool:    "Bool",
+	schema.Type_Which_int8:    "Int8",
+	schema.Type_Which_int16:   "Int16",
+	schema.Type_Which_int32:   "Int32",
+	schema.Type_Which_int64:   "Int64",
+	schema.Type_Which_uint8:   "UInt8",
+	schema.Type_Which_uint16:  "UInt16",
+	schema.Type_Which_uint32:  "UInt32",
+	schema.Type_Which_uint64:  "UInt64",
+	schema.Type_Which_float32: "Float32",
+	schema.Type_Which_float64: "Float64",
+	schema.Type_Which_text:    "Text",
+	schema.Type_Which_data:    "Data",
+}
@@ -0,0 +1,182 @@
+package compat
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"capnproto.org/go/capnp/v3/schemas"
+	"capnproto.org/go/capnp/v3/schemas/compiler"
+	schemaschema "capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+func compile(t *testing.T, src string) *Schema {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.capnp")
+	if err := os.WriteFile(path, []byte("@0xa1b2c3d4e5f60718;\n"+src), 0666); err != nil {
+		t.Fatal(err)
+	}
+	c := &compiler.Compiler{SourcePrefixes: []string{dir}}
+	req, err := c.Compile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := FromRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		changes  []string
+	}{
+		{
+			name:    "unchanged",
+			old:     "struct Foo { a @0 :Int32; }",
+			new:     "struct Foo { a @0 :Int32; }",
+			changes: nil,
+		},
+		{
+			name: "rename and add",
+			old:  "struct Foo { a @0 :Int32; }",
+			new:  "struct Foo { b @0 :Int32; c @1 :Text; }",
+			changes: []string{
+				"test.capnp:Foo: field @0 renamed from a to b",
+				"test.capnp:Foo: field c (@1) added",
+			},
+		},
+		{
+			name: "type change",
+			old:  "struct Foo { a @0 :Int32; b @1 :Text; }",
+			new:  "struct Foo { a @0 :UInt32; b @1 :Data; }",
+			changes: []string{
+				"test.capnp:Foo: breaking: field a (@0) changed type from Int32 to UInt32",
+				"test.capnp:Foo: field b (@1) changed type from Text to Data",
+			},
+		},
+		{
+			name: "any pointer",
+			old:  "struct Foo { a @0 :List(Bar); } struct Bar {}",
+			new:  "struct Foo { a @0 :AnyPointer; } struct Bar {}",
+			changes: []string{
+				"test.capnp:Foo: field a (@0) changed type from List(Bar) to AnyPointer",
+			},
+		},
+		{
+			name: "default",
+			old:  "struct Foo { a @0 :Int32 = 1; b @1 :Text = \"x\"; }",
+			new:  "struct Foo { a @0 :Int32 = 2; b @1 :Text = \"y\"; }",
+			changes: []string{
+				"test.capnp:Foo: breaking: field a (@0) changed default value",
+				"test.capnp:Foo: field b (@1) changed default value",
+			},
+		},
+		{
+			name: "new union",
+			old:  "struct Foo { a @0 :Int32; }",
+			new:  "struct Foo { union { a @0 :Int32; b @1 :Text; } }",
+			changes: []string{
+				"test.capnp:Foo: field a (@0) moved into a new union",
+				"test.capnp:Foo: field b (@1) added",
+			},
+		},
+		{
+			name: "existing field into union",
+			old:  "struct Foo { a @0 :Int32; b @1 :Text; }",
+			new:  "struct Foo { union { a @0 :Int32; b @1 :Text; } }",
+			changes: []string{
+				"test.capnp:Foo: breaking: field a (@0) moved into or out of a union",
+				"test.capnp:Foo: breaking: field b (@1) moved into or out of a union",
+			},
+		},
+		{
+			name: "group",
+			old:  "struct Foo { g :group { a @0 :Int32; } }",
+			new:  "struct Foo { h :group { a @0 :Int32; } }",
+			changes: []string{
+				"test.capnp:Foo: field @0 renamed from g.a to h.a",
+			},
+		},
+		{
+			name: "enum",
+			old:  "enum E { a @0; b @1; c @2; }",
+			new:  "enum E { a @0; bee @1; }",
+			changes: []string{
+				"test.capnp:E: enumerant @1 renamed from b to bee",
+				"test.capnp:E: breaking: enumerant c (@2) removed",
+			},
+		},
+		{
+			name: "method",
+			old:  "interface I { f @0 (x :Int32) -> (y :Text); g @1 (); }",
+			new:  "interface I { f @0 (x :Int64) -> (y :Text, z :Bool); }",
+			changes: []string{
+				"test.capnp:I.f params: breaking: field x (@0) changed type from Int32 to Int64",
+				"test.capnp:I.f results: field z (@1) added",
+				"test.capnp:I: breaking: method g (@1) removed",
+			},
+		},
+		{
+			name: "removed declaration",
+			old:  "struct Foo { struct Bar {} }",
+			new:  "struct Foo {}",
+			changes: []string{
+				"test.capnp:Foo: breaking: nested declaration Bar removed",
+			},
+		},
+		{
+			name: "kind change",
+			old:  "struct Foo @0xb1b2c3d4e5f60718 {}",
+			new:  "interface Foo @0xb1b2c3d4e5f60718 {}",
+			changes: []string{
+				"test.capnp:Foo: breaking: changed from struct to interface",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes, err := Check(compile(t, test.old), compile(t, test.new))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.String())
+			}
+			if !reflect.DeepEqual(got, test.changes) {
+				t.Errorf("changes =\n%q\nwant\n%q", got, test.changes)
+			}
+			breaking := false
+			for _, c := range changes {
+				breaking = breaking || c.Breaking
+			}
+			if HasBreaking(changes) != breaking {
+				t.Errorf("HasBreaking = %t; want %t", !breaking, breaking)
+			}
+		})
+	}
+}
+
+func TestFromRegistry(t *testing.T) {
+	s, err := FromRegistry(&schemas.DefaultRegistry, schemaschema.Node_TypeID, schemaschema.CodeGeneratorRequest_TypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes, err := Check(s, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) > 0 {
+		t.Errorf("comparing schema.capnp to itself: %v", changes)
+	}
+
+	if _, err := FromRegistry(new(schemas.Registry), schemaschema.Node_TypeID); !schemas.IsNotFound(err) {
+		t.Errorf("FromRegistry(empty registry) error = %v; want not found", err)
+	}
+}
@@ -0,0 +1,240 @@
+package compat
+
+import (
+	"fmt"
+	"sort"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+const noDiscriminant = 0xffff
+
+// A field is a slot in a struct or one of its groups.
+type field struct {
+	name    string // qualified by group names
+	ordinal uint16
+	typ     schema.Type
+	offset  uint32
+	def     schema.Value
+	unions  []unionMember
+}
+
+// A unionMember records that a field is only set when the union
+// discriminant at offset has the given value.
+type unionMember struct {
+	offset uint32
+	value  uint16
+}
+
+// fields returns the slots of the struct n and its groups by ordinal.
+func (s *Schema) fields(n schema.Node) (map[uint16]*field, error) {
+	m := make(map[uint16]*field)
+	err := s.collect(m, n, "", nil)
+	return m, err
+}
+
+func (s *Schema) collect(m map[uint16]*field, n schema.Node, prefix string, unions []unionMember) error {
+	st := n.StructNode()
+	list, err := st.Fields()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < list.Len(); i++ {
+		f := list.At(i)
+		name, err := f.Name()
+		if err != nil {
+			return err
+		}
+		u := unions
+		if dv := f.DiscriminantValue(); dv != noDiscriminant {
+			u = append(unions[:len(unions):len(unions)], unionMember{offset: st.DiscriminantOffset(), value: dv})
+		}
+		switch f.Which() {
+		case schema.Field_Which_slot:
+			if f.Ordinal().Which() != schema.Field_ordinal_Which_explicit {
+				continue
+			}
+			t, err := f.Slot().Type()
+			if err != nil {
+				return err
+			}
+			def, err := f.Slot().DefaultValue()
+			if err != nil {
+				return err
+			}
+			m[f.Ordinal().Explicit()] = &field{
+				name:    prefix + name,
+				ordinal: f.Ordinal().Explicit(),
+				typ:     t,
+				offset:  f.Slot().Offset(),
+				def:     def,
+				unions:  u,
+			}
+		case schema.Field_Which_group:
+			g, ok := s.nodes[f.Group().TypeId()]
+			if !ok {
+				return fmt.Errorf("group @%#x not found", f.Group().TypeId())
+			}
+			if err := s.collect(m, g, prefix+name+".", u); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *checker) structs(name string, o, n schema.Node) error {
+	of, err := c.old.fields(o)
+	if err != nil {
+		return err
+	}
+	nf, err := c.new.fields(n)
+	if err != nil {
+		return err
+	}
+	for _, ord := range sortedOrdinals(of) {
+		f := of[ord]
+		g := nf[ord]
+		if g == nil {
+			c.add(true, name, "field %s (@%d) removed", f.name, ord)
+			continue
+		}
+		if f.name != g.name {
+			c.add(false, name, "field @%d renamed from %s to %s", ord, f.name, g.name)
+		}
+		if err := c.field(name, f, g, of, nf); err != nil {
+			return err
+		}
+	}
+	for _, ord := range sortedOrdinals(nf) {
+		if of[ord] == nil {
+			c.add(false, name, "field %s (@%d) added", nf[ord].name, ord)
+		}
+	}
+	return nil
+}
+
+func (c *checker) field(name string, f, g *field, of, nf map[uint16]*field) error {
+	ot, nt := c.typeString(c.old, f.typ), c.typeString(c.new, g.typ)
+	switch ok, safe := c.sameType(f.typ, g.typ); {
+	case !ok:
+		c.add(true, name, "field %s (@%d) changed type from %s to %s", g.name, g.ordinal, ot, nt)
+		return nil
+	case safe:
+		c.add(false, name, "field %s (@%d) changed type from %s to %s", g.name, g.ordinal, ot, nt)
+	}
+	if f.offset != g.offset {
+		c.add(true, name, "field %s (@%d) moved", g.name, g.ordinal)
+		return nil
+	}
+	if eq, err := capnp.Equal(f.def.ToPtr(), g.def.ToPtr()); err != nil {
+		return err
+	} else if !eq && f.def.Which() == g.def.Which() {
+		// Data fields are stored XORed with their defaults.
+		c.add(!isPointer(g.typ), name, "field %s (@%d) changed default value", g.name, g.ordinal)
+	}
+	c.unions(name, f, g, of, nf)
+	return nil
+}
+
+// unions checks that f is in the same unions as before.  A field may
+// be moved into a new union as long as it is the union's first member
+// (by ordinal) and every other member is new.
+func (c *checker) unions(name string, f, g *field, of, nf map[uint16]*field) {
+	if sameUnions(f.unions, g.unions) {
+		return
+	}
+	if len(g.unions) == len(f.unions)+1 {
+		for i, u := range g.unions {
+			rest := append(g.unions[:i:i], g.unions[i+1:]...)
+			if u.value != 0 || !sameUnions(f.unions, rest) {
+				continue
+			}
+			if !c.unionIsNew(u, of, nf) {
+				break
+			}
+			c.add(false, name, "field %s (@%d) moved into a new union", g.name, g.ordinal)
+			return
+		}
+	}
+	c.add(true, name, "field %s (@%d) moved into or out of a union", g.name, g.ordinal)
+}
+
+// unionIsNew reports whether u's other members are all new fields.
+func (c *checker) unionIsNew(u unionMember, of, nf map[uint16]*field) bool {
+	for ord, h := range nf {
+		for _, hu := range h.unions {
+			if hu.offset == u.offset && hu.value != u.value && of[ord] != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func sameUnions(a, b []unionMember) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameType reports whether a field of type o can be read as type n.
+// safe is set when the types differ but are wire compatible.
+func (c *checker) sameType(o, n schema.Type) (ok, safe bool) {
+	if o.Which() != n.Which() {
+		switch {
+		case n.Which() == schema.Type_Which_anyPointer && isPointer(o):
+			// Any pointer field can become AnyPointer, as long as
+			// the new type is not constrained to another kind.
+			if n.AnyPointer().Which() != schema.Type_anyPointer_Which_unconstrained {
+				return true, true
+			}
+			return n.AnyPointer().Unconstrained().Which() == schema.Type_anyPointer_unconstrained_Which_anyKind, true
+		case o.Which() == schema.Type_Which_text && n.Which() == schema.Type_Which_data:
+			return true, true
+		}
+		return false, false
+	}
+	switch o.Which() {
+	case schema.Type_Which_list:
+		oe, err1 := o.List().ElementType()
+		ne, err2 := n.List().ElementType()
+		if err1 != nil || err2 != nil {
+			return false, false
+		}
+		return c.sameType(oe, ne)
+	case schema.Type_Which_enum:
+		return o.Enum().TypeId() == n.Enum().TypeId(), false
+	case schema.Type_Which_structType:
+		return o.StructType().TypeId() == n.StructType().TypeId(), false
+	case schema.Type_Which_interface:
+		return o.Interface().TypeId() == n.Interface().TypeId(), false
+	}
+	return true, false
+}
+
+func isPointer(t schema.Type) bool {
+	switch t.Which() {
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list,
+		schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		return true
+	}
+	return false
+}
+
+// sortedOrdinals returns the keys of m in increasing order.
+func sortedOrdinals(m map[uint16]*field) []uint16 {
+	keys := make([]uint16, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,131 @@
+package compiler
+
+type declKind int
+
+const (
+	declFile declKind = iota
+	declUsing
+	declConst
+	declEnum
+	declEnumerant
+	declStruct
+	declField
+	declUnion
+	declGroup
+	declInterface
+	declMethod
+	declAnnotation
+)
+
+func (k declKind) String() string {
+	switch k {
+	case declFile:
+		return "file"
+	case declUsing:
+		return "using"
+	case declConst:
+		return "const"
+	case declEnum:
+		return "enum"
+	case declEnumerant:
+		return "enumerant"
+	case declStruct:
+		return "struct"
+	case declField:
+		return "field"
+	case declUnion:
+		return "union"
+	case declGroup:
+		return "group"
+	case declInterface:
+		return "interface"
+	case declMethod:
+		return "method"
+	case declAnnotation:
+		return "annotation"
+	default:
+		return "declaration"
+	}
+}
+
+// A decl is a declaration parsed from a schema file.
+type decl struct {
+	kind declKind
+	pos  int
+	name string
+	doc  string
+
+	id         uint64 // explicit @0x... ID, or zero
+	ordinal    int    // @N, or -1
+	ordinalPos int
+
+	params      []string // generic parameters, or a method's implicit parameters
+	typ         *expr    // field, const, annotation, or using target
+	value       *expr    // default or constant value
+	annotations []*annotationUse
+	members     []*decl // nested declarations and members, in code order
+
+	// Interfaces.
+	extends []*expr
+
+	// Methods.  A nil paramType means the parameters are given as a
+	// list in params; likewise for results.  A method without "->"
+	// has noResults set.
+	paramList  []*decl
+	paramType  *expr
+	resultList []*decl
+	resultType *expr
+	noResults  bool
+	listPos    int
+
+	// Annotations.
+	targets []string
+}
+
+// An annotationUse is an annotation applied to a declaration: $name(value).
+type annotationUse struct {
+	pos   int
+	name  *expr
+	value *expr // nil if no value was given
+}
+
+type exprKind int
+
+const (
+	exprIdent exprKind = iota
+	exprAbsolute
+	exprImport
+	exprEmbed
+	exprMember
+	exprApply
+	exprInt
+	exprFloat
+	exprString
+	exprBinary
+	exprTuple
+	exprList
+)
+
+// An expr is a type or value expression.
+type expr struct {
+	kind exprKind
+	pos  int
+
+	name  string  // identifier or member name
+	str   string  // string contents or import path
+	x     *expr   // base of a member or application
+	args  []*arg  // application arguments or tuple fields
+	elems []*expr // list elements
+
+	ival uint64
+	fval float64
+	neg  bool // negated numeric literal
+}
+
+// An arg is an element of a parenthesized expression: either a
+// positional value or name = value.
+type arg struct {
+	pos   int
+	name  string
+	value *expr
+}
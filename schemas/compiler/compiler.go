@@ -0,0 +1,549 @@
+// Package compiler parses Cap'n Proto schema language files and compiles
+// them into CodeGeneratorRequests, the same input that the reference
+// "capnp compile" tool gives to code generator plugins.  It needs no
+// tools outside of Go.
+//
+// Node IDs and struct layouts are computed with the same algorithms as
+// the reference compiler, so generated code is compatible with code
+// generated from the output of "capnp compile".
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// A Compiler compiles schema files.  The zero value compiles files that
+// only import files relative to themselves.
+type Compiler struct {
+	// ImportPath lists the directories searched for imports that begin
+	// with a slash, such as "/capnp/c++.capnp".  It corresponds to the
+	// -I flag of the capnp tool.
+	ImportPath []string
+
+	// SourcePrefixes are removed from the start of the names of
+	// requested files to form the names recorded in the request.  It
+	// corresponds to the --src-prefix flag of the capnp tool.
+	SourcePrefixes []string
+}
+
+// An Error is a problem with a schema file.
+type Error struct {
+	Filename string
+	Line     int // 1-based
+	Column   int // 1-based, in bytes
+	Msg      string
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return e.Filename + ": " + e.Msg
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.Filename, e.Line, e.Column, e.Msg)
+}
+
+// Compile parses the named files and the files they import, and
+// returns a CodeGeneratorRequest in a new message that requests code
+// for the named files.  The request includes nodes for every
+// declaration in the named and imported files.
+func (c *Compiler) Compile(files ...string) (schema.CodeGeneratorRequest, error) {
+	s := &state{
+		c:         c,
+		files:     make(map[string]*file),
+		nodes:     make(map[uint64]*node),
+		declNodes: make(map[*decl]*node),
+	}
+	var requested []*file
+	for _, name := range files {
+		f, err := s.loadFile(name, s.displayName(name))
+		if err != nil {
+			return schema.CodeGeneratorRequest{}, err
+		}
+		requested = append(requested, f)
+	}
+	if err := s.compile(); err != nil {
+		return schema.CodeGeneratorRequest{}, err
+	}
+	return s.write(requested)
+}
+
+// state holds the files and nodes of a single compilation.
+type state struct {
+	c         *Compiler
+	files     map[string]*file // by path on disk
+	fileOrder []*file
+	nodes     map[uint64]*node
+	order     []*node // in creation order
+	declNodes map[*decl]*node
+	usings    map[*decl]bool // usings being resolved, for cycle detection
+	seg       *capnp.Segment
+}
+
+// A file is a parsed schema file.
+type file struct {
+	path    string // path on disk
+	name    string // display name
+	src     []byte
+	root    *decl
+	node    *node
+	imports map[string]*file
+	order   []string // import names in order of appearance
+}
+
+// A node is a declaration that becomes a schema.Node.
+type node struct {
+	id          uint64
+	kind        declKind
+	decl        *decl
+	file        *file
+	parent      *node // enclosing scope; nil for files
+	name        string
+	displayName string
+	prefixLen   int
+	scopeID     uint64
+	params      []string
+	generic     bool
+	nested      []*node
+	scope       map[string]*decl // names declared within the node
+
+	// Method parameter and result structs.
+	method    *decl
+	isResults bool
+
+	// Structs and groups.
+	st *structInfo
+}
+
+// scopeNode returns the node that names within n are resolved against.
+// Groups and method parameter structs do not introduce scopes.
+func (n *node) scopeNode() *node {
+	for n.kind == declGroup || n.kind == declUnion || n.method != nil {
+		n = n.parent
+	}
+	return n
+}
+
+// isWithin reports whether n is the same as or nested inside of anc.
+func (n *node) isWithin(anc *node) bool {
+	for ; n != nil; n = n.parent {
+		if n == anc {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *state) displayName(name string) string {
+	name = filepath.ToSlash(filepath.Clean(name))
+	best := ""
+	for _, p := range s.c.SourcePrefixes {
+		p = filepath.ToSlash(filepath.Clean(p))
+		if !strings.HasSuffix(p, "/") {
+			p += "/"
+		}
+		if strings.HasPrefix(name, p) && len(p) > len(best) {
+			best = p
+		}
+	}
+	return strings.TrimPrefix(name, best)
+}
+
+func (s *state) loadFile(diskPath, name string) (*file, error) {
+	key := filepath.Clean(diskPath)
+	if f := s.files[key]; f != nil {
+		return f, nil
+	}
+	src, err := os.ReadFile(diskPath)
+	if err != nil {
+		return nil, &Error{Filename: name, Msg: err.Error()}
+	}
+	f := &file{
+		path:    key,
+		name:    name,
+		src:     src,
+		imports: make(map[string]*file),
+	}
+	f.root, err = parseFile(src)
+	if err != nil {
+		return nil, f.wrap(err)
+	}
+	if f.root.id == 0 {
+		return nil, f.errorf(0, "file has no ID; add a line like \"@%#x;\"", randomID())
+	}
+	s.files[key] = f
+	s.fileOrder = append(s.fileOrder, f)
+
+	var imports []*expr
+	walkDecl(f.root, func(e *expr) {
+		if e.kind == exprImport {
+			imports = append(imports, e)
+		}
+	})
+	for _, e := range imports {
+		if _, ok := f.imports[e.str]; ok {
+			continue
+		}
+		imp, err := s.importFile(f, e)
+		if err != nil {
+			return nil, err
+		}
+		f.imports[e.str] = imp
+		f.order = append(f.order, e.str)
+	}
+	return f, nil
+}
+
+func (s *state) importFile(from *file, e *expr) (*file, error) {
+	if strings.HasPrefix(e.str, "/") {
+		for _, dir := range s.c.ImportPath {
+			p := filepath.Join(dir, filepath.FromSlash(e.str))
+			if _, err := os.Stat(p); err == nil {
+				return s.loadFile(p, strings.TrimPrefix(e.str, "/"))
+			}
+		}
+		return nil, from.errorf(e.pos, "import %q not found in import path", e.str)
+	}
+	p := filepath.Join(filepath.Dir(from.path), filepath.FromSlash(e.str))
+	if _, err := os.Stat(p); err != nil {
+		return nil, from.errorf(e.pos, "import %q: %v", e.str, err)
+	}
+	return s.loadFile(p, path.Join(path.Dir(from.name), e.str))
+}
+
+// wrap converts a parse error into an *Error.
+func (f *file) wrap(err error) error {
+	if pe, ok := err.(*posError); ok {
+		return f.errorf(pe.pos, "%s", pe.msg)
+	}
+	return err
+}
+
+func (f *file) errorf(pos int, format string, args ...interface{}) error {
+	e := &Error{Filename: f.name, Msg: fmt.Sprintf(format, args...)}
+	if pos >= 0 && pos <= len(f.src) {
+		e.Line = 1 + bytes.Count(f.src[:pos], []byte("\n"))
+		e.Column = 1 + pos - (bytes.LastIndexByte(f.src[:pos], '\n') + 1)
+	}
+	return e
+}
+
+// walkDecl calls fn for every expression in d and its members.
+func walkDecl(d *decl, fn func(*expr)) {
+	walkExpr(d.typ, fn)
+	walkExpr(d.value, fn)
+	walkExpr(d.paramType, fn)
+	walkExpr(d.resultType, fn)
+	for _, e := range d.extends {
+		walkExpr(e, fn)
+	}
+	for _, a := range d.annotations {
+		walkExpr(a.name, fn)
+		walkExpr(a.value, fn)
+	}
+	for _, lists := range [][]*decl{d.members, d.paramList, d.resultList} {
+		for _, m := range lists {
+			walkDecl(m, fn)
+		}
+	}
+}
+
+func walkExpr(e *expr, fn func(*expr)) {
+	if e == nil {
+		return
+	}
+	fn(e)
+	walkExpr(e.x, fn)
+	for _, a := range e.args {
+		walkExpr(a.value, fn)
+	}
+	for _, el := range e.elems {
+		walkExpr(el, fn)
+	}
+}
+
+// compile builds the nodes of every loaded file and lays out structs.
+func (s *state) compile() error {
+	for _, f := range s.fileOrder {
+		n := &node{
+			id:          f.root.id,
+			kind:        declFile,
+			decl:        f.root,
+			file:        f,
+			name:        f.name,
+			displayName: f.name,
+		}
+		n.prefixLen = len(f.name)
+		if i := strings.LastIndexByte(f.name, '.'); i >= 0 {
+			n.prefixLen = i + 1
+		}
+		f.node = n
+		if err := s.addNode(n); err != nil {
+			return err
+		}
+		if err := s.buildScope(n, f.root.members); err != nil {
+			return err
+		}
+	}
+	for _, n := range append([]*node(nil), s.order...) {
+		if n.kind == declStruct {
+			if err := s.layoutStruct(n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *state) addNode(n *node) error {
+	if prev := s.nodes[n.id]; prev != nil {
+		pos := 0
+		if n.decl != nil {
+			pos = n.decl.pos
+		}
+		return n.file.errorf(pos, "%s has the same ID (%#x) as %s", n.displayName, n.id, prev.displayName)
+	}
+	s.nodes[n.id] = n
+	s.order = append(s.order, n)
+	if n.decl != nil && n.method == nil {
+		s.declNodes[n.decl] = n
+	}
+	return nil
+}
+
+// buildScope creates nodes for the declarations nested in parent.
+func (s *state) buildScope(parent *node, members []*decl) error {
+	parent.scope = make(map[string]*decl)
+	for _, d := range members {
+		switch d.kind {
+		case declUsing, declConst, declEnum, declStruct, declInterface, declAnnotation:
+		default:
+			continue
+		}
+		if prev := parent.scope[d.name]; prev != nil {
+			return parent.file.errorf(d.pos, "%s is already declared in %s", d.name, parent.displayName)
+		}
+		parent.scope[d.name] = d
+		if d.kind == declUsing {
+			continue
+		}
+		n, err := s.newChild(parent, d, d.name)
+		if err != nil {
+			return err
+		}
+		parent.nested = append(parent.nested, n)
+		switch d.kind {
+		case declStruct, declEnum:
+			if err := s.buildScope(n, d.members); err != nil {
+				return err
+			}
+		case declInterface:
+			if err := s.buildScope(n, d.members); err != nil {
+				return err
+			}
+			if err := s.buildMethods(n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newChild creates the node for d nested in parent.
+func (s *state) newChild(parent *node, d *decl, name string) (*node, error) {
+	sep := "."
+	if parent.kind == declFile {
+		sep = ":"
+	}
+	n := &node{
+		id:          d.id,
+		kind:        d.kind,
+		decl:        d,
+		file:        parent.file,
+		parent:      parent,
+		name:        name,
+		displayName: parent.displayName + sep + name,
+		prefixLen:   len(parent.displayName) + len(sep),
+		scopeID:     parent.id,
+		params:      d.params,
+		generic:     len(d.params) > 0 || parent.generic,
+	}
+	if n.id == 0 {
+		n.id = childID(parent.id, name)
+	}
+	return n, s.addNode(n)
+}
+
+// buildMethods creates the implicit parameter and result structs of
+// the methods of the interface n.
+func (s *state) buildMethods(n *node) error {
+	for _, m := range n.decl.members {
+		if m.kind != declMethod {
+			continue
+		}
+		for _, results := range []bool{false, true} {
+			if results && m.resultType != nil || !results && m.paramType != nil {
+				continue
+			}
+			suffix := "$Params"
+			if results {
+				suffix = "$Results"
+			}
+			p := &node{
+				id:          methodParamsID(n.id, uint16(m.ordinal), results),
+				kind:        declStruct,
+				decl:        m,
+				file:        n.file,
+				parent:      n,
+				name:        m.name + suffix,
+				displayName: n.displayName + "." + m.name + suffix,
+				prefixLen:   len(n.displayName) + 1,
+				generic:     n.generic || len(m.params) > 0,
+				method:      m,
+				isResults:   results,
+			}
+			if err := s.addNode(p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// write builds the CodeGeneratorRequest.
+func (s *state) write(requested []*file) (schema.CodeGeneratorRequest, error) {
+	_, seg, err := capnp.NewMessage(capnp.MultiSegment(nil))
+	if err != nil {
+		return schema.CodeGeneratorRequest{}, err
+	}
+	s.seg = seg
+	req, err := schema.NewRootCodeGeneratorRequest(seg)
+	if err != nil {
+		return schema.CodeGeneratorRequest{}, err
+	}
+	nodes, err := req.NewNodes(int32(len(s.order)))
+	if err != nil {
+		return schema.CodeGeneratorRequest{}, err
+	}
+	for i, n := range s.order {
+		if err := s.writeNode(nodes.At(i), n); err != nil {
+			return schema.CodeGeneratorRequest{}, err
+		}
+	}
+
+	var infos []*node
+	for _, n := range s.order {
+		if n.method == nil {
+			infos = append(infos, n)
+		}
+	}
+	sil, err := req.NewSourceInfo(int32(len(infos)))
+	if err != nil {
+		return schema.CodeGeneratorRequest{}, err
+	}
+	for i, n := range infos {
+		if err := writeSourceInfo(sil.At(i), n); err != nil {
+			return schema.CodeGeneratorRequest{}, err
+		}
+	}
+
+	rfl, err := req.NewRequestedFiles(int32(len(requested)))
+	if err != nil {
+		return schema.CodeGeneratorRequest{}, err
+	}
+	for i, f := range requested {
+		rf := rfl.At(i)
+		rf.SetId(f.node.id)
+		if err := rf.SetFilename(f.name); err != nil {
+			return schema.CodeGeneratorRequest{}, err
+		}
+		imps, err := rf.NewImports(int32(len(f.order)))
+		if err != nil {
+			return schema.CodeGeneratorRequest{}, err
+		}
+		for j, name := range f.order {
+			imps.At(j).SetId(f.imports[name].node.id)
+			if err := imps.At(j).SetName(name); err != nil {
+				return schema.CodeGeneratorRequest{}, err
+			}
+		}
+	}
+	return req, nil
+}
+
+func writeSourceInfo(si schema.Node_SourceInfo, n *node) error {
+	si.SetId(n.id)
+	if err := si.SetDocComment(n.decl.doc); err != nil {
+		return err
+	}
+	var members []*decl
+	switch {
+	case n.st != nil:
+		for _, f := range n.st.fields {
+			members = append(members, f.decl)
+		}
+	case n.kind == declEnum:
+		members = sortedMembers(n.decl, declEnumerant)
+	case n.kind == declInterface:
+		members = sortedMembers(n.decl, declMethod)
+	}
+	ml, err := si.NewMembers(int32(len(members)))
+	if err != nil {
+		return err
+	}
+	for i, m := range members {
+		if err := ml.At(i).SetDocComment(m.doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedMembers returns the members of d of the given kind, sorted by
+// ordinal.
+func sortedMembers(d *decl, kind declKind) []*decl {
+	var list []*decl
+	for _, m := range d.members {
+		if m.kind == kind {
+			list = append(list, m)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].ordinal < list[j].ordinal })
+	return list
+}
+
+// codeOrder returns the position of m among the members of d of the
+// same kind.
+func codeOrder(d *decl, m *decl) int {
+	i := 0
+	for _, x := range d.members {
+		if x == m {
+			return i
+		}
+		if x.kind == m.kind {
+			i++
+		}
+	}
+	return -1
+}
+
+// checkOrdinals reports an error if the ordinals of list, which is
+// sorted by ordinal, are not sequential from zero.
+func checkOrdinals(f *file, list []*decl) error {
+	for i, m := range list {
+		switch {
+		case m.ordinal < i:
+			return f.errorf(m.ordinalPos, "duplicate ordinal @%d", m.ordinal)
+		case m.ordinal > i:
+			return f.errorf(m.ordinalPos, "skipped ordinal @%d; ordinals must be sequential with no holes", i)
+		}
+	}
+	return nil
+}
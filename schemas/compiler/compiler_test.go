@@ -0,0 +1,161 @@
+package compiler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+func TestIDs(t *testing.T) {
+	const goFile = 0xd12a1c51fedd6c88
+	if got, want := childID(goFile, "package"), uint64(0xbea97f1023792be0); got != want {
+		t.Errorf("childID(go.capnp, \"package\") = %#x; want %#x", got, want)
+	}
+	const node = 0xe682ab4cf923a417
+	if got, want := groupID(node, 7), uint64(0x9ea0b19b37fb4435); got != want {
+		t.Errorf("groupID(Node, 7) = %#x; want %#x", got, want)
+	}
+	if id := randomID(); id&(1<<63) == 0 {
+		t.Errorf("randomID() = %#x; high bit not set", id)
+	}
+}
+
+// compileSource writes src to a file named test.capnp and compiles it.
+func compileSource(t *testing.T, src string) (schema.CodeGeneratorRequest, error) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.capnp")
+	if err := os.WriteFile(path, []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+	c := &Compiler{SourcePrefixes: []string{dir}}
+	return c.Compile(path)
+}
+
+func findNode(t *testing.T, req schema.CodeGeneratorRequest, name string) schema.Node {
+	t.Helper()
+	nodes, err := req.Nodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < nodes.Len(); i++ {
+		if dn, _ := nodes.At(i).DisplayName(); dn == name {
+			return nodes.At(i)
+		}
+	}
+	t.Fatalf("no node named %q", name)
+	return schema.Node{}
+}
+
+func TestStructLayout(t *testing.T) {
+	req, err := compileSource(t, `@0xa1b2c3d4e5f60718;
+struct Foo {
+  a @0 :UInt8;
+  b @3 :Bool;
+  union {
+    c @1 :Text;
+    d @2 :UInt64;
+    e :group {
+      f @4 :Int16 = 7;
+    }
+  }
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := findNode(t, req, "test.capnp:Foo").StructNode()
+	if st.DataWordCount() != 2 || st.PointerCount() != 1 {
+		t.Errorf("size = %d words, %d pointers; want 2 words, 1 pointer", st.DataWordCount(), st.PointerCount())
+	}
+	if st.DiscriminantCount() != 3 || st.DiscriminantOffset() != 1 {
+		t.Errorf("discriminant count, offset = %d, %d; want 3, 1", st.DiscriminantCount(), st.DiscriminantOffset())
+	}
+
+	// Fields are listed, and union members numbered, in ordinal order.
+	fields, _ := st.Fields()
+	tests := []struct {
+		name    string
+		discrim uint16
+		offset  uint32
+	}{
+		{"a", noDiscriminant, 0},
+		{"c", 0, 0},
+		{"d", 1, 1},
+		{"b", noDiscriminant, 8},
+		{"e", 2, 0},
+	}
+	if fields.Len() != len(tests) {
+		t.Fatalf("len(fields) = %d; want %d", fields.Len(), len(tests))
+	}
+	for i, test := range tests {
+		f := fields.At(i)
+		name, _ := f.Name()
+		if name != test.name || f.DiscriminantValue() != test.discrim {
+			t.Errorf("fields[%d] = %s (discriminant %d); want %s (discriminant %d)", i, name, f.DiscriminantValue(), test.name, test.discrim)
+		}
+		if f.Which() == schema.Field_Which_slot && f.Slot().Offset() != test.offset {
+			t.Errorf("%s offset = %d; want %d", name, f.Slot().Offset(), test.offset)
+		}
+	}
+
+	e := findNode(t, req, "test.capnp:Foo.e")
+	if e.ScopeId() != findNode(t, req, "test.capnp:Foo").Id() || !e.StructNode().IsGroup() {
+		t.Error("Foo.e is not a group scoped to Foo")
+	}
+	ef, _ := e.StructNode().Fields()
+	dv, _ := ef.At(0).Slot().DefaultValue()
+	if dv.Int16() != 7 {
+		t.Errorf("Foo.e.f default = %d; want 7", dv.Int16())
+	}
+}
+
+func TestConst(t *testing.T) {
+	req, err := compileSource(t, `@0xa1b2c3d4e5f60718;
+const answer :Int32 = 42;
+const alias :Int32 = .answer;
+const greeting :Text = "hello";
+const list :List(UInt16) = [1, 2, 3];
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"answer", "alias"} {
+		v, _ := findNode(t, req, "test.capnp:"+name).Const().Value()
+		if v.Int32() != 42 {
+			t.Errorf("%s = %d; want 42", name, v.Int32())
+		}
+	}
+	v, _ := findNode(t, req, "test.capnp:greeting").Const().Value()
+	if s, _ := v.Text(); s != "hello" {
+		t.Errorf("greeting = %q; want \"hello\"", s)
+	}
+	v, _ = findNode(t, req, "test.capnp:list").Const().Value()
+	p, _ := v.List()
+	if l := p.List(); l.Len() != 3 {
+		t.Errorf("len(list) = %d; want 3", l.Len())
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{"struct Foo {}\n", "test.capnp:1:1: file has no ID"},
+		{"@0xa1b2c3d4e5f60718;\nstruct Foo { a @0 :Bar; }\n", "test.capnp:2:20: Bar is not declared"},
+		{"@0xa1b2c3d4e5f60718;\nstruct Foo { a @0 :Int8; b @0 :Int8; }\n", "test.capnp:2:28: duplicate ordinal @0"},
+		{"@0xa1b2c3d4e5f60718;\nstruct Foo { a @1 :Int8; }\n", "test.capnp:2:16: skipped ordinal @0"},
+		{"@0xa1b2c3d4e5f60718;\nconst x :UInt8 = 256;\n", "test.capnp:2:"},
+		{"@0xa1b2c3d4e5f60718;\nstruct Foo { a @0 :Int8 }\n", "test.capnp:2:"},
+	}
+	for _, test := range tests {
+		_, err := compileSource(t, test.src)
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("compiling %q: error = %v; want prefix %q", test.src, err, test.err)
+		}
+	}
+}
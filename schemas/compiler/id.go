@@ -0,0 +1,57 @@
+package compiler
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// These functions compute the IDs that the reference compiler assigns to
+// nodes without an explicit ID, so that compiling a schema with either
+// compiler yields the same IDs.
+
+// childID returns the ID of the declaration named name within parent.
+func childID(parent uint64, name string) uint64 {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], parent)
+	h := md5.New()
+	h.Write(buf[:])
+	h.Write([]byte(name))
+	return hashID(h.Sum(nil))
+}
+
+// groupID returns the ID of the index'th member group of parent.
+func groupID(parent uint64, index uint16) uint64 {
+	var buf [10]byte
+	binary.LittleEndian.PutUint64(buf[:8], parent)
+	binary.LittleEndian.PutUint16(buf[8:], index)
+	sum := md5.Sum(buf[:])
+	return hashID(sum[:])
+}
+
+// methodParamsID returns the ID of the implicit parameter or result
+// struct of a method.
+func methodParamsID(parent uint64, ordinal uint16, isResults bool) uint64 {
+	var buf [11]byte
+	binary.LittleEndian.PutUint64(buf[:8], parent)
+	binary.LittleEndian.PutUint16(buf[8:10], ordinal)
+	if isResults {
+		buf[10] = 1
+	}
+	sum := md5.Sum(buf[:])
+	return hashID(sum[:])
+}
+
+func hashID(sum []byte) uint64 {
+	return binary.BigEndian.Uint64(sum[:8]) | 1<<63
+}
+
+// randomID returns a new file ID, as suggested by the reference compiler
+// for files that lack one.
+func randomID() uint64 {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic(err)
+	}
+	return binary.LittleEndian.Uint64(buf[:]) | 1<<63
+}
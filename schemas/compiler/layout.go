@@ -0,0 +1,327 @@
+package compiler
+
+// This file assigns field offsets with the same algorithm as the
+// reference compiler, so that both compilers produce the same struct
+// layouts.  Data fields are packed into holes left by earlier fields
+// where possible; union members share space with each other.
+
+const numHoles = 6 // one hole of each size from 1 bit to 32 bits
+
+// holeSet tracks the unused space at the end of a section: at most one
+// hole of each power-of-two size.  holes[lg] is the offset of the hole
+// of size 2^lg bits in units of its size, or zero for no hole.  No hole
+// can be at offset zero, since the first field of a section is always
+// placed at its beginning.
+type holeSet [numHoles]uint
+
+func (h *holeSet) tryAllocate(lgSize uint) (uint, bool) {
+	if lgSize >= numHoles {
+		return 0, false
+	}
+	if h[lgSize] != 0 {
+		off := h[lgSize]
+		h[lgSize] = 0
+		return off, true
+	}
+	next, ok := h.tryAllocate(lgSize + 1)
+	if !ok {
+		return 0, false
+	}
+	off := next * 2
+	h[lgSize] = off + 1
+	return off, true
+}
+
+// addHolesAtEnd records holes of sizes [lgSize, limit) left after
+// allocating a field of size 2^lgSize at offset-1 from a space of size
+// 2^limit.
+func (h *holeSet) addHolesAtEnd(lgSize, offset, limit uint) {
+	for lgSize < limit {
+		h[lgSize] = offset
+		lgSize++
+		offset = (offset + 1) / 2
+	}
+}
+
+// tryExpand tries to grow the value at the given location by combining
+// it with the holes after it, to 2^expansion times its size.
+func (h *holeSet) tryExpand(oldLgSize, oldOffset, expansion uint) bool {
+	if expansion == 0 {
+		return true
+	}
+	if oldLgSize >= numHoles {
+		return false
+	}
+	if h[oldLgSize] != oldOffset+1 {
+		return false
+	}
+	if !h.tryExpand(oldLgSize+1, oldOffset>>1, expansion-1) {
+		return false
+	}
+	h[oldLgSize] = 0
+	return true
+}
+
+func (h *holeSet) smallestAtLeast(lgSize uint) (uint, bool) {
+	for i := lgSize; i < numHoles; i++ {
+		if h[i] != 0 {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// A layoutScope is a place where fields can be allocated: either a
+// whole struct or a member of a union.
+type layoutScope interface {
+	addVoid()
+	addData(lgSize uint) uint
+	addPointer() uint
+	tryExpandData(oldLgSize, oldOffset, expansion uint) bool
+}
+
+// topLayout is the layout of a struct's top-level scope.
+type topLayout struct {
+	dataWords uint
+	pointers  uint
+	holes     holeSet
+}
+
+func (t *topLayout) addVoid() {}
+
+func (t *topLayout) addData(lgSize uint) uint {
+	if off, ok := t.holes.tryAllocate(lgSize); ok {
+		return off
+	}
+	off := t.dataWords << (6 - lgSize)
+	t.dataWords++
+	t.holes.addHolesAtEnd(lgSize, off+1, numHoles)
+	return off
+}
+
+func (t *topLayout) addPointer() uint {
+	t.pointers++
+	return t.pointers - 1
+}
+
+func (t *topLayout) tryExpandData(oldLgSize, oldOffset, expansion uint) bool {
+	return t.holes.tryExpand(oldLgSize, oldOffset, expansion)
+}
+
+// A dataLocation is a slot of the parent scope shared by members of a
+// union.
+type dataLocation struct {
+	lgSize uint
+	offset uint
+}
+
+func (loc *dataLocation) tryExpandTo(u *unionLayout, newLgSize uint) bool {
+	if newLgSize <= loc.lgSize {
+		return true
+	}
+	if !u.parent.tryExpandData(loc.lgSize, loc.offset, newLgSize-loc.lgSize) {
+		return false
+	}
+	loc.offset >>= newLgSize - loc.lgSize
+	loc.lgSize = newLgSize
+	return true
+}
+
+// unionLayout is the layout of a union: the space its members share
+// and its discriminant.
+type unionLayout struct {
+	parent        layoutScope
+	groupCount    int
+	discriminant  uint
+	hasDiscrim    bool
+	dataLocations []*dataLocation
+	pointers      []uint
+}
+
+func (u *unionLayout) addNewDataLocation(lgSize uint) uint {
+	off := u.parent.addData(lgSize)
+	u.dataLocations = append(u.dataLocations, &dataLocation{lgSize: lgSize, offset: off})
+	return off
+}
+
+func (u *unionLayout) addNewPointerLocation() uint {
+	p := u.parent.addPointer()
+	u.pointers = append(u.pointers, p)
+	return p
+}
+
+func (u *unionLayout) newGroupAddingFirstMember() {
+	u.groupCount++
+	if u.groupCount == 2 {
+		u.addDiscriminant()
+	}
+}
+
+func (u *unionLayout) addDiscriminant() {
+	if !u.hasDiscrim {
+		u.discriminant = u.parent.addData(4)
+		u.hasDiscrim = true
+	}
+}
+
+// locationUsage records how much of one of a union's data locations a
+// particular member uses.
+type locationUsage struct {
+	used       bool
+	lgSizeUsed uint
+	holes      holeSet
+}
+
+func (lu *locationUsage) smallestHoleAtLeast(loc *dataLocation, lgSize uint) (uint, bool) {
+	switch {
+	case !lu.used:
+		if lgSize <= loc.lgSize {
+			return loc.lgSize, true
+		}
+		return 0, false
+	case lgSize >= lu.lgSizeUsed:
+		if lgSize < loc.lgSize {
+			return lgSize, true
+		}
+		return 0, false
+	}
+	if hole, ok := lu.holes.smallestAtLeast(lgSize); ok {
+		return hole, true
+	}
+	if lu.lgSizeUsed < loc.lgSize {
+		return lu.lgSizeUsed, true
+	}
+	return 0, false
+}
+
+func (lu *locationUsage) allocateFromHole(loc *dataLocation, lgSize uint) uint {
+	base := loc.offset << (loc.lgSize - lgSize)
+	switch {
+	case !lu.used:
+		lu.used = true
+		lu.lgSizeUsed = lgSize
+		return base
+	case lgSize >= lu.lgSizeUsed:
+		lu.holes.addHolesAtEnd(lu.lgSizeUsed, 1, lgSize)
+		lu.lgSizeUsed = lgSize + 1
+		return base + 1
+	}
+	if off, ok := lu.holes.tryAllocate(lgSize); ok {
+		return base + off
+	}
+	// Double the space used and allocate from the new half.
+	off := uint(1) << (lu.lgSizeUsed - lgSize)
+	lu.holes.addHolesAtEnd(lgSize, off+1, lu.lgSizeUsed)
+	lu.lgSizeUsed++
+	return base + off
+}
+
+func (lu *locationUsage) tryAllocateByExpanding(u *unionLayout, loc *dataLocation, lgSize uint) (uint, bool) {
+	if !lu.used {
+		if !loc.tryExpandTo(u, lgSize) {
+			return 0, false
+		}
+		lu.used = true
+		lu.lgSizeUsed = lgSize
+		return loc.offset << (loc.lgSize - lgSize), true
+	}
+	newSize := lu.lgSizeUsed
+	if lgSize > newSize {
+		newSize = lgSize
+	}
+	newSize++
+	if !lu.tryExpandUsage(u, loc, newSize, true) {
+		return 0, false
+	}
+	off, _ := lu.holes.tryAllocate(lgSize)
+	return loc.offset<<(loc.lgSize-lgSize) + off, true
+}
+
+func (lu *locationUsage) tryExpand(u *unionLayout, loc *dataLocation, oldLgSize, oldOffset, expansion uint) bool {
+	if oldOffset == 0 && lu.lgSizeUsed == oldLgSize {
+		return lu.tryExpandUsage(u, loc, oldLgSize+expansion, false)
+	}
+	return lu.holes.tryExpand(oldLgSize, oldOffset, expansion)
+}
+
+func (lu *locationUsage) tryExpandUsage(u *unionLayout, loc *dataLocation, desired uint, newHoles bool) bool {
+	if desired > loc.lgSize && !loc.tryExpandTo(u, desired) {
+		return false
+	}
+	if newHoles {
+		lu.holes.addHolesAtEnd(lu.lgSizeUsed, 1, desired)
+	}
+	lu.lgSizeUsed = desired
+	return true
+}
+
+// groupLayout is the layout of one member of a union: either a single
+// field or a group.
+type groupLayout struct {
+	parent       *unionLayout
+	usage        []*locationUsage
+	pointersUsed int
+	hasMembers   bool
+}
+
+func (g *groupLayout) addMember() {
+	if !g.hasMembers {
+		g.hasMembers = true
+		g.parent.newGroupAddingFirstMember()
+	}
+}
+
+func (g *groupLayout) addVoid() {
+	g.addMember()
+	// Let an enclosing union know that a member was added, so that it
+	// can allocate its discriminant.
+	g.parent.parent.addVoid()
+}
+
+func (g *groupLayout) addData(lgSize uint) uint {
+	g.addMember()
+
+	best := -1
+	bestSize := uint(1 << 31)
+	for i, loc := range g.parent.dataLocations {
+		if i == len(g.usage) {
+			g.usage = append(g.usage, new(locationUsage))
+		}
+		if hole, ok := g.usage[i].smallestHoleAtLeast(loc, lgSize); ok && hole < bestSize {
+			best, bestSize = i, hole
+		}
+	}
+	if best >= 0 {
+		return g.usage[best].allocateFromHole(g.parent.dataLocations[best], lgSize)
+	}
+
+	for i, lu := range g.usage {
+		if off, ok := lu.tryAllocateByExpanding(g.parent, g.parent.dataLocations[i], lgSize); ok {
+			return off
+		}
+	}
+
+	g.usage = append(g.usage, &locationUsage{used: true, lgSizeUsed: lgSize})
+	return g.parent.addNewDataLocation(lgSize)
+}
+
+func (g *groupLayout) addPointer() uint {
+	g.addMember()
+	if g.pointersUsed < len(g.parent.pointers) {
+		g.pointersUsed++
+		return g.parent.pointers[g.pointersUsed-1]
+	}
+	g.pointersUsed++
+	return g.parent.addNewPointerLocation()
+}
+
+func (g *groupLayout) tryExpandData(oldLgSize, oldOffset, expansion uint) bool {
+	for i, lu := range g.usage {
+		loc := g.parent.dataLocations[i]
+		if loc.lgSize >= oldLgSize && oldOffset>>(loc.lgSize-oldLgSize) == loc.offset {
+			local := oldOffset - loc.offset<<(loc.lgSize-oldLgSize)
+			return lu.tryExpand(g.parent, loc, oldLgSize, local, expansion)
+		}
+	}
+	return false
+}
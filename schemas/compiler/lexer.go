@@ -0,0 +1,348 @@
+package compiler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokInt
+	tokFloat
+	tokString
+	tokBinary
+	tokPunct
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of file"
+	case tokIdent:
+		return "identifier"
+	case tokInt:
+		return "integer"
+	case tokFloat:
+		return "float"
+	case tokString:
+		return "string"
+	case tokBinary:
+		return "binary literal"
+	default:
+		return "punctuation"
+	}
+}
+
+// A token is a lexical element of a schema file.
+type token struct {
+	kind tokenKind
+	pos  int    // byte offset in source
+	text string // identifier, punctuation, or decoded string
+	ival uint64
+	fval float64
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of file"
+	case tokString:
+		return strconv.Quote(t.text)
+	case tokBinary:
+		return fmt.Sprintf("0x%q", t.text)
+	case tokInt:
+		return strconv.FormatUint(t.ival, 10)
+	case tokFloat:
+		return strconv.FormatFloat(t.fval, 'g', -1, 64)
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+// lex splits src into tokens.  Comments and whitespace are discarded;
+// doc comments are recovered from the source by docComment.
+func lex(src []byte) ([]token, error) {
+	var toks []token
+	i := 0
+	for {
+		i = skipSpace(src, i)
+		if i >= len(src) {
+			toks = append(toks, token{kind: tokEOF, pos: len(src)})
+			return toks, nil
+		}
+		start := i
+		c := src[i]
+		switch {
+		case isIdentStart(c):
+			for i < len(src) && isIdentChar(src[i]) {
+				i++
+			}
+			toks = append(toks, token{kind: tokIdent, pos: start, text: string(src[start:i])})
+		case c == '0' && i+2 < len(src) && src[i+1] == 'x' && src[i+2] == '"':
+			s, n, err := lexString(src, i+2)
+			if err != nil {
+				return nil, &posError{pos: i, msg: err.Error()}
+			}
+			b, err := decodeHex(s)
+			if err != nil {
+				return nil, &posError{pos: i, msg: err.Error()}
+			}
+			i = n
+			toks = append(toks, token{kind: tokBinary, pos: start, text: b})
+		case c >= '0' && c <= '9':
+			t, n, err := lexNumber(src, i)
+			if err != nil {
+				return nil, &posError{pos: i, msg: err.Error()}
+			}
+			i = n
+			toks = append(toks, t)
+		case c == '"':
+			s, n, err := lexString(src, i)
+			if err != nil {
+				return nil, &posError{pos: i, msg: err.Error()}
+			}
+			i = n
+			toks = append(toks, token{kind: tokString, pos: start, text: s})
+		case c == '-' && i+1 < len(src) && src[i+1] == '>':
+			i += 2
+			toks = append(toks, token{kind: tokPunct, pos: start, text: "->"})
+		case strings.IndexByte("@:;=()[]{},.$-+*", c) >= 0:
+			i++
+			toks = append(toks, token{kind: tokPunct, pos: start, text: string(c)})
+		default:
+			r, _ := utf8.DecodeRune(src[i:])
+			return nil, &posError{pos: i, msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+}
+
+func skipSpace(src []byte, i int) int {
+	for i < len(src) {
+		switch src[i] {
+		case ' ', '\t', '\r', '\n':
+			i++
+		case '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || '0' <= c && c <= '9'
+}
+
+func lexNumber(src []byte, i int) (token, int, error) {
+	start := i
+	if src[i] == '0' && i+1 < len(src) && (src[i+1] == 'x' || src[i+1] == 'X') {
+		i += 2
+		for i < len(src) && isHexDigit(src[i]) {
+			i++
+		}
+		v, err := strconv.ParseUint(string(src[start+2:i]), 16, 64)
+		if err != nil {
+			return token{}, 0, fmt.Errorf("invalid integer %s", src[start:i])
+		}
+		return token{kind: tokInt, pos: start, ival: v}, i, nil
+	}
+	for i < len(src) && '0' <= src[i] && src[i] <= '9' {
+		i++
+	}
+	isFloat := false
+	if i+1 < len(src) && src[i] == '.' && '0' <= src[i+1] && src[i+1] <= '9' {
+		isFloat = true
+		i++
+		for i < len(src) && '0' <= src[i] && src[i] <= '9' {
+			i++
+		}
+	}
+	if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+		j := i + 1
+		if j < len(src) && (src[j] == '+' || src[j] == '-') {
+			j++
+		}
+		if j < len(src) && '0' <= src[j] && src[j] <= '9' {
+			isFloat = true
+			i = j
+			for i < len(src) && '0' <= src[i] && src[i] <= '9' {
+				i++
+			}
+		}
+	}
+	text := string(src[start:i])
+	if isFloat {
+		v, err := strconv.ParseFloat(text, 64)
+		if err != nil && !isRangeError(err) {
+			return token{}, 0, fmt.Errorf("invalid number %s", text)
+		}
+		return token{kind: tokFloat, pos: start, fval: v}, i, nil
+	}
+	base := 10
+	if len(text) > 1 && text[0] == '0' {
+		base = 8
+	}
+	v, err := strconv.ParseUint(text, base, 64)
+	if err != nil {
+		return token{}, 0, fmt.Errorf("invalid integer %s", text)
+	}
+	return token{kind: tokInt, pos: start, ival: v}, i, nil
+}
+
+func isRangeError(err error) bool {
+	ne, ok := err.(*strconv.NumError)
+	return ok && ne.Err == strconv.ErrRange
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// lexString decodes the string literal starting at src[i], which must
+// be a double quote.  It returns the decoded string and the offset
+// just past the closing quote.
+func lexString(src []byte, i int) (string, int, error) {
+	var sb strings.Builder
+	i++
+	for {
+		if i >= len(src) || src[i] == '\n' {
+			return "", 0, fmt.Errorf("unterminated string")
+		}
+		c := src[i]
+		if c == '"' {
+			return sb.String(), i + 1, nil
+		}
+		if c != '\\' {
+			sb.WriteByte(c)
+			i++
+			continue
+		}
+		i++
+		if i >= len(src) {
+			return "", 0, fmt.Errorf("unterminated string")
+		}
+		c = src[i]
+		i++
+		switch c {
+		case 'a':
+			sb.WriteByte('\a')
+		case 'b':
+			sb.WriteByte('\b')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 'v':
+			sb.WriteByte('\v')
+		case '\'', '"', '\\', '?':
+			sb.WriteByte(c)
+		case 'x':
+			n := 0
+			var v byte
+			for n < 2 && i < len(src) && isHexDigit(src[i]) {
+				v = v<<4 | unhex(src[i])
+				i++
+				n++
+			}
+			if n == 0 {
+				return "", 0, fmt.Errorf("invalid escape \\x")
+			}
+			sb.WriteByte(v)
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			v := uint(c - '0')
+			for n := 1; n < 3 && i < len(src) && '0' <= src[i] && src[i] <= '7'; n++ {
+				v = v<<3 | uint(src[i]-'0')
+				i++
+			}
+			if v > math.MaxUint8 {
+				return "", 0, fmt.Errorf("octal escape out of range")
+			}
+			sb.WriteByte(byte(v))
+		default:
+			return "", 0, fmt.Errorf("invalid escape \\%c", c)
+		}
+	}
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+// decodeHex decodes the contents of a 0x"..." literal, which may
+// contain whitespace between bytes.
+func decodeHex(s string) (string, error) {
+	var b []byte
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case i+1 < len(s) && isHexDigit(c) && isHexDigit(s[i+1]):
+			b = append(b, unhex(c)<<4|unhex(s[i+1]))
+			i += 2
+		default:
+			return "", fmt.Errorf("invalid hex in binary literal")
+		}
+	}
+	return string(b), nil
+}
+
+// docComment returns the doc comment that follows the token ending at
+// src[i]: comment lines on the same line or the lines immediately
+// after it, stopping at the first line that is not a comment.  Each
+// line has its leading '#' and one following space removed.
+func docComment(src []byte, i int) string {
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t' || src[i] == '\r') {
+		i++
+	}
+	if i < len(src) && src[i] == '\n' {
+		i++
+	}
+	var sb strings.Builder
+	for {
+		j := i
+		for j < len(src) && (src[j] == ' ' || src[j] == '\t') {
+			j++
+		}
+		if j >= len(src) || src[j] != '#' {
+			return sb.String()
+		}
+		j++
+		if j < len(src) && src[j] == ' ' {
+			j++
+		}
+		end := j
+		for end < len(src) && src[end] != '\n' {
+			end++
+		}
+		sb.Write([]byte(strings.TrimSuffix(string(src[j:end]), "\r")))
+		sb.WriteByte('\n')
+		if end >= len(src) {
+			return sb.String()
+		}
+		i = end + 1
+	}
+}
@@ -0,0 +1,318 @@
+package compiler
+
+import (
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+func (s *state) writeNode(out schema.Node, n *node) error {
+	out.SetId(n.id)
+	if err := out.SetDisplayName(n.displayName); err != nil {
+		return err
+	}
+	out.SetDisplayNamePrefixLength(uint32(n.prefixLen))
+	out.SetScopeId(n.scopeID)
+	out.SetIsGeneric(n.generic)
+	pl, err := out.NewParameters(int32(len(n.params)))
+	if err != nil {
+		return err
+	}
+	for i, p := range n.params {
+		if err := pl.At(i).SetName(p); err != nil {
+			return err
+		}
+	}
+	nl, err := out.NewNestedNodes(int32(len(n.nested)))
+	if err != nil {
+		return err
+	}
+	for i, nn := range n.nested {
+		if err := nl.At(i).SetName(nn.name); err != nil {
+			return err
+		}
+		nl.At(i).SetId(nn.id)
+	}
+
+	// Annotations on a declaration are resolved in the scope it
+	// appears in.
+	en := env{scope: n}
+	if n.parent != nil {
+		en.scope = n.parent.scopeNode()
+	}
+	// Method parameter structs have no annotations of their own, and
+	// those of groups and unions belong to their fields.
+	var annotations []*annotationUse
+	if n.method == nil && (n.st == nil || !n.st.isGroup) {
+		annotations = n.decl.annotations
+	}
+	target := n.kind.String()
+	if err := s.writeAnnotations(out.NewAnnotations, en, annotations, target); err != nil {
+		return err
+	}
+
+	switch {
+	case n.kind == declFile:
+		out.SetFile()
+	case n.st != nil:
+		return s.writeStruct(out, n)
+	case n.kind == declEnum:
+		return s.writeEnum(out, n)
+	case n.kind == declInterface:
+		return s.writeInterface(out, n)
+	case n.kind == declConst:
+		return s.writeConst(out, n, en)
+	case n.kind == declAnnotation:
+		return s.writeAnnotationDecl(out, n, en)
+	}
+	return nil
+}
+
+func (s *state) writeEnum(out schema.Node, n *node) error {
+	out.SetEnum()
+	list := sortedMembers(n.decl, declEnumerant)
+	if err := checkOrdinals(n.file, list); err != nil {
+		return err
+	}
+	el, err := out.Enum().NewEnumerants(int32(len(list)))
+	if err != nil {
+		return err
+	}
+	en := env{scope: n}
+	for i, d := range list {
+		o := el.At(i)
+		if err := o.SetName(d.name); err != nil {
+			return err
+		}
+		o.SetCodeOrder(uint16(codeOrder(n.decl, d)))
+		if err := s.writeAnnotations(o.NewAnnotations, en, d.annotations, "enumerant"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) writeInterface(out schema.Node, n *node) error {
+	out.SetInterface()
+	iface := out.Interface()
+	en := env{scope: n}
+
+	sl, err := iface.NewSuperclasses(int32(len(n.decl.extends)))
+	if err != nil {
+		return err
+	}
+	for i, e := range n.decl.extends {
+		t, err := s.resolveType(env{scope: n.parent.scopeNode()}, e)
+		if err != nil {
+			return err
+		}
+		if t.which != schema.Type_Which_interface {
+			return n.file.errorf(e.pos, "%v is not an interface", t)
+		}
+		sl.At(i).SetId(t.node.id)
+		b, err := sl.At(i).NewBrand()
+		if err != nil {
+			return err
+		}
+		if err := s.writeBrand(b, t.brand); err != nil {
+			return err
+		}
+	}
+
+	list := sortedMembers(n.decl, declMethod)
+	if err := checkOrdinals(n.file, list); err != nil {
+		return err
+	}
+	ml, err := iface.NewMethods(int32(len(list)))
+	if err != nil {
+		return err
+	}
+	for i, d := range list {
+		o := ml.At(i)
+		if err := o.SetName(d.name); err != nil {
+			return err
+		}
+		o.SetCodeOrder(uint16(codeOrder(n.decl, d)))
+		ip, err := o.NewImplicitParameters(int32(len(d.params)))
+		if err != nil {
+			return err
+		}
+		for j, p := range d.params {
+			if err := ip.At(j).SetName(p); err != nil {
+				return err
+			}
+		}
+		men := en
+		men.implicit = d.params
+		for _, results := range []bool{false, true} {
+			id, b, err := s.methodStruct(n, d, men, results)
+			if err != nil {
+				return err
+			}
+			var bb schema.Brand
+			if results {
+				o.SetResultStructType(id)
+				bb, err = o.NewResultBrand()
+			} else {
+				o.SetParamStructType(id)
+				bb, err = o.NewParamBrand()
+			}
+			if err != nil {
+				return err
+			}
+			if err := s.writeBrand(bb, b); err != nil {
+				return err
+			}
+		}
+		if err := s.writeAnnotations(o.NewAnnotations, en, d.annotations, "method"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// methodStruct returns the ID and brand of a method's parameter or
+// result struct.
+func (s *state) methodStruct(n *node, d *decl, en env, results bool) (uint64, *brand, error) {
+	e := d.paramType
+	if results {
+		e = d.resultType
+	}
+	if e == nil {
+		return methodParamsID(n.id, uint16(d.ordinal), results), inheritBrand(n), nil
+	}
+	t, err := s.resolveType(en, e)
+	if err != nil {
+		return 0, nil, err
+	}
+	if t.which != schema.Type_Which_structType {
+		return 0, nil, n.file.errorf(e.pos, "method parameters must be a struct type, not %v", t)
+	}
+	return t.node.id, t.brand, nil
+}
+
+func (s *state) writeConst(out schema.Node, n *node, en env) error {
+	out.SetConst()
+	t, err := s.resolveType(en, n.decl.typ)
+	if err != nil {
+		return err
+	}
+	ot, err := out.Const().NewType()
+	if err != nil {
+		return err
+	}
+	if err := s.writeType(ot, t); err != nil {
+		return err
+	}
+	v, err := out.Const().NewValue()
+	if err != nil {
+		return err
+	}
+	return s.writeValue(v, t, n.decl.value, en)
+}
+
+// annotationTargets lists the declaration kinds that annotations can
+// be applied to, with the setters for their flags.
+var annotationTargets = []struct {
+	name string
+	set  func(schema.Node_annotation, bool)
+}{
+	{"file", schema.Node_annotation.SetTargetsFile},
+	{"const", schema.Node_annotation.SetTargetsConst},
+	{"enum", schema.Node_annotation.SetTargetsEnum},
+	{"enumerant", schema.Node_annotation.SetTargetsEnumerant},
+	{"struct", schema.Node_annotation.SetTargetsStruct},
+	{"field", schema.Node_annotation.SetTargetsField},
+	{"union", schema.Node_annotation.SetTargetsUnion},
+	{"group", schema.Node_annotation.SetTargetsGroup},
+	{"interface", schema.Node_annotation.SetTargetsInterface},
+	{"method", schema.Node_annotation.SetTargetsMethod},
+	{"param", schema.Node_annotation.SetTargetsParam},
+	{"annotation", schema.Node_annotation.SetTargetsAnnotation},
+}
+
+func (s *state) writeAnnotationDecl(out schema.Node, n *node, en env) error {
+	out.SetAnnotation()
+	a := out.Annotation()
+	t, err := s.resolveType(en, n.decl.typ)
+	if err != nil {
+		return err
+	}
+	ot, err := a.NewType()
+	if err != nil {
+		return err
+	}
+	if err := s.writeType(ot, t); err != nil {
+		return err
+	}
+	for _, name := range n.decl.targets {
+		found := false
+		for _, tg := range annotationTargets {
+			if name == "*" || name == tg.name {
+				tg.set(a, true)
+				found = true
+			}
+		}
+		if !found {
+			return n.file.errorf(n.decl.pos, "unknown annotation target %q", name)
+		}
+	}
+	return nil
+}
+
+func targets(d *decl, target string) bool {
+	for _, t := range d.targets {
+		if t == "*" || t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// writeAnnotations writes the annotations applied to a declaration of
+// the given kind, using newList to allocate the list.
+func (s *state) writeAnnotations(newList func(int32) (schema.Annotation_List, error), en env, uses []*annotationUse, target string) error {
+	al, err := newList(int32(len(uses)))
+	if err != nil {
+		return err
+	}
+	for i, u := range uses {
+		ent, err := s.resolve(en, u.name)
+		if err != nil {
+			return err
+		}
+		if ent.node == nil || ent.node.kind != declAnnotation {
+			return en.file().errorf(u.pos, "not an annotation")
+		}
+		an := ent.node
+		if !targets(an.decl, target) {
+			return en.file().errorf(u.pos, "%s cannot be applied to a %s", an.displayName, target)
+		}
+		t, err := s.resolveType(env{scope: an.parent.scopeNode()}, an.decl.typ)
+		if err != nil {
+			return err
+		}
+		o := al.At(i)
+		o.SetId(an.id)
+		b, err := o.NewBrand()
+		if err != nil {
+			return err
+		}
+		if err := s.writeBrand(b, nil); err != nil {
+			return err
+		}
+		v, err := o.NewValue()
+		if err != nil {
+			return err
+		}
+		val := u.value
+		if val == nil {
+			if t.which != schema.Type_Which_void {
+				return en.file().errorf(u.pos, "%s needs a value of type %v", an.displayName, t)
+			}
+			val = &expr{kind: exprIdent, pos: u.pos, name: "void"}
+		}
+		if err := s.writeValue(v, t, val, en); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,829 @@
+package compiler
+
+import (
+	"fmt"
+	"math"
+)
+
+// A posError is an error at a byte offset in the file being parsed.
+type posError struct {
+	pos int
+	msg string
+}
+
+func (e *posError) Error() string { return e.msg }
+
+type parser struct {
+	src  []byte
+	toks []token
+	i    int
+}
+
+// parseFile parses a schema file into a declaration of kind declFile.
+func parseFile(src []byte) (*decl, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{src: src, toks: toks}
+	f := &decl{kind: declFile, ordinal: -1}
+	for p.peek().kind != tokEOF {
+		if p.isPunct("@") {
+			pos := p.next().pos
+			t, err := p.expect(tokInt)
+			if err != nil {
+				return nil, err
+			}
+			if f.id != 0 {
+				return nil, &posError{pos: pos, msg: "file ID already declared"}
+			}
+			f.id = t.ival
+			if _, err := p.expectPunct(";"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if p.isPunct("$") {
+			a, err := p.parseAnnotationUse()
+			if err != nil {
+				return nil, err
+			}
+			f.annotations = append(f.annotations, a)
+			if _, err := p.expectPunct(";"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		d, err := p.parseNestedDecl()
+		if err != nil {
+			return nil, err
+		}
+		f.members = append(f.members, d)
+	}
+	return f, nil
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.i+n >= len(p.toks) {
+		return p.toks[len(p.toks)-1]
+	}
+	return p.toks[p.i+n]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) isPunct(s string) bool {
+	t := p.peek()
+	return t.kind == tokPunct && t.text == s
+}
+
+func (p *parser) isKeyword(s string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && t.text == s
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return &posError{pos: t.pos, msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) expect(k tokenKind) (token, error) {
+	t := p.next()
+	if t.kind != k {
+		return t, p.errorf(t, "expected %v, found %v", k, t)
+	}
+	return t, nil
+}
+
+func (p *parser) expectPunct(s string) (token, error) {
+	t := p.next()
+	if t.kind != tokPunct || t.text != s {
+		return t, p.errorf(t, "expected '%s', found %v", s, t)
+	}
+	return t, nil
+}
+
+// endStatement consumes the ';' that ends a statement and returns the
+// doc comment that follows it.
+func (p *parser) endStatement() (string, error) {
+	t, err := p.expectPunct(";")
+	if err != nil {
+		return "", err
+	}
+	return docComment(p.src, t.pos+1), nil
+}
+
+// beginBlock consumes the '{' that begins a block and returns the doc
+// comment that follows it.
+func (p *parser) beginBlock() (string, error) {
+	t, err := p.expectPunct("{")
+	if err != nil {
+		return "", err
+	}
+	return docComment(p.src, t.pos+1), nil
+}
+
+// isNestedDecl reports whether the next tokens start a nested
+// declaration rather than a member named by a keyword, as in
+// "struct @0 :Text".
+func (p *parser) isNestedDecl() bool {
+	t := p.peek()
+	if t.kind != tokIdent {
+		return false
+	}
+	switch t.text {
+	case "using", "const", "enum", "struct", "interface", "annotation":
+		n := p.peekAt(1)
+		return !(n.kind == tokPunct && (n.text == "@" || n.text == ":"))
+	}
+	return false
+}
+
+func (p *parser) parseNestedDecl() (*decl, error) {
+	if !p.isNestedDecl() {
+		return nil, p.errorf(p.peek(), "expected declaration, found %v", p.peek())
+	}
+	switch p.peek().text {
+	case "using":
+		return p.parseUsing()
+	case "const":
+		return p.parseConst()
+	case "enum":
+		return p.parseEnum()
+	case "struct":
+		return p.parseStruct()
+	case "interface":
+		return p.parseInterface()
+	default:
+		return p.parseAnnotationDecl()
+	}
+}
+
+func (p *parser) parseName(d *decl) error {
+	t, err := p.expect(tokIdent)
+	if err != nil {
+		return err
+	}
+	d.pos = t.pos
+	d.name = t.text
+	return nil
+}
+
+// parseID parses an optional @0x... ID.
+func (p *parser) parseID(d *decl) error {
+	if !p.isPunct("@") {
+		return nil
+	}
+	p.next()
+	t, err := p.expect(tokInt)
+	if err != nil {
+		return err
+	}
+	if t.ival&(1<<63) == 0 {
+		return p.errorf(t, "invalid ID %#x: IDs must have the high bit set", t.ival)
+	}
+	d.id = t.ival
+	return nil
+}
+
+// parseOrdinal parses an @N ordinal.
+func (p *parser) parseOrdinal(d *decl) error {
+	at, err := p.expectPunct("@")
+	if err != nil {
+		return err
+	}
+	t, err := p.expect(tokInt)
+	if err != nil {
+		return err
+	}
+	if t.ival > math.MaxUint16 {
+		return p.errorf(t, "ordinal @%d is too large", t.ival)
+	}
+	d.ordinal = int(t.ival)
+	d.ordinalPos = at.pos
+	return nil
+}
+
+func (p *parser) parseAnnotations() ([]*annotationUse, error) {
+	var list []*annotationUse
+	for p.isPunct("$") {
+		a, err := p.parseAnnotationUse()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	return list, nil
+}
+
+func (p *parser) parseAnnotationUse() (*annotationUse, error) {
+	dollar, err := p.expectPunct("$")
+	if err != nil {
+		return nil, err
+	}
+	a := &annotationUse{pos: dollar.pos}
+	a.name, err = p.parseAnnotationName()
+	if err != nil {
+		return nil, err
+	}
+	if p.isPunct("(") {
+		t := p.peek()
+		args, err := p.parseArgs("(", ")")
+		if err != nil {
+			return nil, err
+		}
+		if len(args) == 1 && args[0].name == "" {
+			a.value = args[0].value
+		} else {
+			a.value = &expr{kind: exprTuple, pos: t.pos, args: args}
+		}
+	}
+	return a, nil
+}
+
+// parseAnnotationName parses the name of an annotation: a possibly
+// qualified name, or a member of an import, without applications.
+func (p *parser) parseAnnotationName() (*expr, error) {
+	var e *expr
+	if p.isPunct(".") {
+		dot := p.next()
+		t, err := p.expect(tokIdent)
+		if err != nil {
+			return nil, err
+		}
+		e = &expr{kind: exprAbsolute, pos: dot.pos, name: t.text}
+	} else if p.peek().text == "import" && p.peekAt(1).kind == tokString {
+		t := p.next()
+		e = &expr{kind: exprImport, pos: t.pos, str: p.next().text}
+	} else {
+		t, err := p.expect(tokIdent)
+		if err != nil {
+			return nil, err
+		}
+		e = &expr{kind: exprIdent, pos: t.pos, name: t.text}
+	}
+	for p.isPunct(".") {
+		p.next()
+		t, err := p.expect(tokIdent)
+		if err != nil {
+			return nil, err
+		}
+		e = &expr{kind: exprMember, pos: t.pos, name: t.text, x: e}
+	}
+	return e, nil
+}
+
+func (p *parser) parseUsing() (*decl, error) {
+	kw := p.next()
+	d := &decl{kind: declUsing, pos: kw.pos, ordinal: -1}
+	if p.peek().kind == tokIdent && p.peekAt(1).kind == tokPunct && p.peekAt(1).text == "=" {
+		d.name = p.next().text
+		p.next()
+	}
+	var err error
+	if d.typ, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if d.name == "" {
+		switch d.typ.kind {
+		case exprIdent, exprMember:
+			d.name = d.typ.name
+		default:
+			return nil, p.errorf(kw, "using declaration needs a name")
+		}
+	}
+	d.doc, err = p.endStatement()
+	return d, err
+}
+
+func (p *parser) parseConst() (*decl, error) {
+	p.next()
+	d := &decl{kind: declConst, ordinal: -1}
+	if err := p.parseName(d); err != nil {
+		return nil, err
+	}
+	if err := p.parseID(d); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectPunct(":"); err != nil {
+		return nil, err
+	}
+	var err error
+	if d.typ, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectPunct("="); err != nil {
+		return nil, err
+	}
+	if d.value, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if d.annotations, err = p.parseAnnotations(); err != nil {
+		return nil, err
+	}
+	d.doc, err = p.endStatement()
+	return d, err
+}
+
+func (p *parser) parseAnnotationDecl() (*decl, error) {
+	p.next()
+	d := &decl{kind: declAnnotation, ordinal: -1}
+	if err := p.parseName(d); err != nil {
+		return nil, err
+	}
+	if err := p.parseID(d); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectPunct("("); err != nil {
+		return nil, err
+	}
+	for {
+		t := p.next()
+		switch {
+		case t.kind == tokIdent:
+			d.targets = append(d.targets, t.text)
+		case t.kind == tokPunct && t.text == "*":
+			d.targets = append(d.targets, "*")
+		default:
+			return nil, p.errorf(t, "expected annotation target, found %v", t)
+		}
+		if p.isPunct(")") {
+			p.next()
+			break
+		}
+		if _, err := p.expectPunct(","); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.expectPunct(":"); err != nil {
+		return nil, err
+	}
+	var err error
+	if d.typ, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if d.annotations, err = p.parseAnnotations(); err != nil {
+		return nil, err
+	}
+	d.doc, err = p.endStatement()
+	return d, err
+}
+
+// parseHeader parses the ID and generic parameters that may follow a
+// struct or interface name, in either order.
+func (p *parser) parseHeader(d *decl) error {
+	for i := 0; i < 2; i++ {
+		switch {
+		case p.isPunct("@") && d.id == 0:
+			if err := p.parseID(d); err != nil {
+				return err
+			}
+		case p.isPunct("(") && d.params == nil:
+			p.next()
+			for {
+				t, err := p.expect(tokIdent)
+				if err != nil {
+					return err
+				}
+				d.params = append(d.params, t.text)
+				if p.isPunct(")") {
+					p.next()
+					break
+				}
+				if _, err := p.expectPunct(","); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parser) parseEnum() (*decl, error) {
+	p.next()
+	d := &decl{kind: declEnum, ordinal: -1}
+	if err := p.parseName(d); err != nil {
+		return nil, err
+	}
+	if err := p.parseID(d); err != nil {
+		return nil, err
+	}
+	var err error
+	if d.annotations, err = p.parseAnnotations(); err != nil {
+		return nil, err
+	}
+	if d.doc, err = p.beginBlock(); err != nil {
+		return nil, err
+	}
+	for !p.isPunct("}") {
+		if p.isNestedDecl() {
+			m, err := p.parseNestedDecl()
+			if err != nil {
+				return nil, err
+			}
+			d.members = append(d.members, m)
+			continue
+		}
+		e := &decl{kind: declEnumerant}
+		if err := p.parseName(e); err != nil {
+			return nil, err
+		}
+		if err := p.parseOrdinal(e); err != nil {
+			return nil, err
+		}
+		if e.annotations, err = p.parseAnnotations(); err != nil {
+			return nil, err
+		}
+		if e.doc, err = p.endStatement(); err != nil {
+			return nil, err
+		}
+		d.members = append(d.members, e)
+	}
+	p.next()
+	return d, nil
+}
+
+func (p *parser) parseStruct() (*decl, error) {
+	p.next()
+	d := &decl{kind: declStruct, ordinal: -1}
+	if err := p.parseName(d); err != nil {
+		return nil, err
+	}
+	if err := p.parseHeader(d); err != nil {
+		return nil, err
+	}
+	var err error
+	if d.annotations, err = p.parseAnnotations(); err != nil {
+		return nil, err
+	}
+	if d.doc, d.members, err = p.parseStructBody(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// parseStructBody parses the members of a struct, group, or union,
+// including the enclosing braces.
+func (p *parser) parseStructBody() (string, []*decl, error) {
+	doc, err := p.beginBlock()
+	if err != nil {
+		return "", nil, err
+	}
+	var members []*decl
+	for !p.isPunct("}") {
+		m, err := p.parseStructMember()
+		if err != nil {
+			return "", nil, err
+		}
+		members = append(members, m)
+	}
+	p.next()
+	return doc, members, nil
+}
+
+func (p *parser) parseStructMember() (*decl, error) {
+	if p.isNestedDecl() {
+		return p.parseNestedDecl()
+	}
+	if p.isKeyword("union") && p.peekAt(1).kind == tokPunct && p.peekAt(1).text == "{" {
+		t := p.next()
+		d := &decl{kind: declUnion, pos: t.pos, ordinal: -1}
+		var err error
+		if d.doc, d.members, err = p.parseStructBody(); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+
+	d := &decl{ordinal: -1}
+	if err := p.parseName(d); err != nil {
+		return nil, err
+	}
+	if p.isPunct("@") {
+		if err := p.parseOrdinal(d); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := p.expectPunct(":"); err != nil {
+		return nil, err
+	}
+	if (p.isKeyword("group") || p.isKeyword("union")) && !(p.peekAt(1).kind == tokPunct && p.peekAt(1).text == ".") {
+		d.kind = declGroup
+		if p.next().text == "union" {
+			d.kind = declUnion
+		}
+		if d.kind == declGroup && d.ordinal >= 0 {
+			return nil, &posError{pos: d.ordinalPos, msg: "groups cannot have ordinals"}
+		}
+		var err error
+		if d.annotations, err = p.parseAnnotations(); err != nil {
+			return nil, err
+		}
+		if d.doc, d.members, err = p.parseStructBody(); err != nil {
+			return nil, err
+		}
+		return d, nil
+	}
+
+	d.kind = declField
+	if d.ordinal < 0 {
+		return nil, p.errorf(p.toks[p.i-1], "field %s needs an ordinal", d.name)
+	}
+	var err error
+	if d.typ, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("=") {
+		p.next()
+		if d.value, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if d.annotations, err = p.parseAnnotations(); err != nil {
+		return nil, err
+	}
+	d.doc, err = p.endStatement()
+	return d, err
+}
+
+func (p *parser) parseInterface() (*decl, error) {
+	p.next()
+	d := &decl{kind: declInterface, ordinal: -1}
+	if err := p.parseName(d); err != nil {
+		return nil, err
+	}
+	if err := p.parseHeader(d); err != nil {
+		return nil, err
+	}
+	if p.isKeyword("extends") {
+		p.next()
+		args, err := p.parseArgs("(", ")")
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range args {
+			if a.name != "" {
+				return nil, &posError{pos: a.pos, msg: "expected superclass type"}
+			}
+			d.extends = append(d.extends, a.value)
+		}
+	}
+	var err error
+	if d.annotations, err = p.parseAnnotations(); err != nil {
+		return nil, err
+	}
+	if d.doc, err = p.beginBlock(); err != nil {
+		return nil, err
+	}
+	for !p.isPunct("}") {
+		if p.isNestedDecl() {
+			m, err := p.parseNestedDecl()
+			if err != nil {
+				return nil, err
+			}
+			d.members = append(d.members, m)
+			continue
+		}
+		m, err := p.parseMethod()
+		if err != nil {
+			return nil, err
+		}
+		d.members = append(d.members, m)
+	}
+	p.next()
+	return d, nil
+}
+
+func (p *parser) parseMethod() (*decl, error) {
+	d := &decl{kind: declMethod}
+	if err := p.parseName(d); err != nil {
+		return nil, err
+	}
+	if err := p.parseOrdinal(d); err != nil {
+		return nil, err
+	}
+	if p.isPunct("[") {
+		p.next()
+		for {
+			t, err := p.expect(tokIdent)
+			if err != nil {
+				return nil, err
+			}
+			d.params = append(d.params, t.text)
+			if p.isPunct("]") {
+				p.next()
+				break
+			}
+			if _, err := p.expectPunct(","); err != nil {
+				return nil, err
+			}
+		}
+	}
+	var err error
+	d.listPos = p.peek().pos
+	if d.paramList, d.paramType, err = p.parseParamSpec(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("->") {
+		p.next()
+		if t := p.peek(); t.text == "stream" && t.kind == tokIdent && (p.peekAt(1).text == ";" || p.peekAt(1).text == "$") {
+			// Streaming methods return the standard StreamResult,
+			// as with the reference compiler.
+			p.next()
+			imp := &expr{kind: exprImport, pos: t.pos, str: "/capnp/stream.capnp"}
+			d.resultType = &expr{kind: exprMember, pos: t.pos, name: "StreamResult", x: imp}
+		} else if d.resultList, d.resultType, err = p.parseParamSpec(); err != nil {
+			return nil, err
+		}
+	} else {
+		d.noResults = true
+	}
+	if d.annotations, err = p.parseAnnotations(); err != nil {
+		return nil, err
+	}
+	d.doc, err = p.endStatement()
+	return d, err
+}
+
+// parseParamSpec parses either a parenthesized parameter list or the
+// name of a struct type.
+func (p *parser) parseParamSpec() ([]*decl, *expr, error) {
+	if !p.isPunct("(") {
+		e, err := p.parseExpr()
+		return nil, e, err
+	}
+	p.next()
+	params := []*decl{}
+	for !p.isPunct(")") {
+		d := &decl{kind: declField, ordinal: len(params)}
+		if err := p.parseName(d); err != nil {
+			return nil, nil, err
+		}
+		if _, err := p.expectPunct(":"); err != nil {
+			return nil, nil, err
+		}
+		var err error
+		if d.typ, err = p.parseExpr(); err != nil {
+			return nil, nil, err
+		}
+		if p.isPunct("=") {
+			p.next()
+			if d.value, err = p.parseExpr(); err != nil {
+				return nil, nil, err
+			}
+		}
+		if d.annotations, err = p.parseAnnotations(); err != nil {
+			return nil, nil, err
+		}
+		params = append(params, d)
+		if !p.isPunct(")") {
+			if _, err := p.expectPunct(","); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	p.next()
+	return params, nil, nil
+}
+
+// parseArgs parses a delimited, comma-separated list of values, each
+// optionally preceded by "name =".
+func (p *parser) parseArgs(open, close string) ([]*arg, error) {
+	if _, err := p.expectPunct(open); err != nil {
+		return nil, err
+	}
+	args := []*arg{}
+	for !p.isPunct(close) {
+		a := &arg{pos: p.peek().pos}
+		if p.peek().kind == tokIdent && p.peekAt(1).kind == tokPunct && p.peekAt(1).text == "=" {
+			a.name = p.next().text
+			p.next()
+		}
+		var err error
+		if a.value, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		args = append(args, a)
+		if !p.isPunct(close) {
+			if _, err := p.expectPunct(","); err != nil {
+				return nil, err
+			}
+		}
+	}
+	p.next()
+	return args, nil
+}
+
+func (p *parser) parseExpr() (*expr, error) {
+	e, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isPunct("."):
+			p.next()
+			t, err := p.expect(tokIdent)
+			if err != nil {
+				return nil, err
+			}
+			e = &expr{kind: exprMember, pos: t.pos, name: t.text, x: e}
+		case p.isPunct("(") && e.kind != exprTuple && e.kind != exprList:
+			pos := p.peek().pos
+			args, err := p.parseArgs("(", ")")
+			if err != nil {
+				return nil, err
+			}
+			e = &expr{kind: exprApply, pos: pos, x: e, args: args}
+		default:
+			return e, nil
+		}
+	}
+}
+
+func (p *parser) parseTerm() (*expr, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokInt:
+		p.next()
+		return &expr{kind: exprInt, pos: t.pos, ival: t.ival}, nil
+	case tokFloat:
+		p.next()
+		return &expr{kind: exprFloat, pos: t.pos, fval: t.fval}, nil
+	case tokString, tokBinary:
+		e := &expr{kind: exprString, pos: t.pos}
+		if t.kind == tokBinary {
+			e.kind = exprBinary
+		}
+		for p.peek().kind == t.kind {
+			e.str += p.next().text
+		}
+		return e, nil
+	case tokIdent:
+		p.next()
+		if (t.text == "import" || t.text == "embed") && p.peek().kind == tokString {
+			s := p.next()
+			k := exprImport
+			if t.text == "embed" {
+				k = exprEmbed
+			}
+			return &expr{kind: k, pos: t.pos, str: s.text}, nil
+		}
+		return &expr{kind: exprIdent, pos: t.pos, name: t.text}, nil
+	case tokPunct:
+		switch t.text {
+		case "-", "+":
+			p.next()
+			n := p.next()
+			var e *expr
+			switch {
+			case n.kind == tokInt:
+				e = &expr{kind: exprInt, pos: t.pos, ival: n.ival}
+			case n.kind == tokFloat:
+				e = &expr{kind: exprFloat, pos: t.pos, fval: n.fval}
+			case n.kind == tokIdent && n.text == "inf":
+				e = &expr{kind: exprFloat, pos: t.pos, fval: math.Inf(1)}
+			default:
+				return nil, p.errorf(n, "expected number after '%s', found %v", t.text, n)
+			}
+			e.neg = t.text == "-"
+			return e, nil
+		case ".":
+			p.next()
+			n, err := p.expect(tokIdent)
+			if err != nil {
+				return nil, err
+			}
+			return &expr{kind: exprAbsolute, pos: t.pos, name: n.text}, nil
+		case "(":
+			args, err := p.parseArgs("(", ")")
+			if err != nil {
+				return nil, err
+			}
+			return &expr{kind: exprTuple, pos: t.pos, args: args}, nil
+		case "[":
+			args, err := p.parseArgs("[", "]")
+			if err != nil {
+				return nil, err
+			}
+			e := &expr{kind: exprList, pos: t.pos, elems: []*expr{}}
+			for _, a := range args {
+				if a.name != "" {
+					return nil, &posError{pos: a.pos, msg: "unexpected field assignment in list"}
+				}
+				e.elems = append(e.elems, a.value)
+			}
+			return e, nil
+		}
+	}
+	return nil, p.errorf(t, "expected expression, found %v", t)
+}
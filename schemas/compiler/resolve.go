@@ -0,0 +1,457 @@
+package compiler
+
+import (
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// An env is the context in which an expression is resolved.
+type env struct {
+	scope    *node    // innermost enclosing scope
+	implicit []string // implicit parameters of the enclosing method
+}
+
+func (e env) file() *file { return e.scope.file }
+
+// A typ is a resolved type.
+type typ struct {
+	which schema.Type_Which
+	elem  *typ  // lists
+	node  *node // structs, enums, and interfaces
+	brand *brand
+
+	// AnyPointer
+	anyKind    schema.Type_anyPointer_Which
+	constraint schema.Type_anyPointer_unconstrained_Which
+	paramScope uint64
+	paramIndex int
+}
+
+// A brand records the bindings of generic parameters of a type.
+type brand struct {
+	scopes []brandScope
+}
+
+type brandScope struct {
+	id      uint64
+	inherit bool
+	bind    []*typ // nil elements are unbound
+}
+
+func (b *brand) with(sc brandScope) *brand {
+	nb := &brand{}
+	if b != nil {
+		for _, x := range b.scopes {
+			if x.id != sc.id {
+				nb.scopes = append(nb.scopes, x)
+			}
+		}
+	}
+	nb.scopes = append([]brandScope{sc}, nb.scopes...)
+	return nb
+}
+
+func (b *brand) has(id uint64) bool {
+	if b == nil {
+		return false
+	}
+	for _, sc := range b.scopes {
+		if sc.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// An entity is the result of resolving a name.
+type entity struct {
+	node  *node // a declaration, with brand
+	brand *brand
+	file  *file // an imported file
+	typ   *typ  // a built-in type or generic parameter
+}
+
+var builtinTypes = map[string]schema.Type_Which{
+	"Void":    schema.Type_Which_void,
+	"Bool":    schema.Type_Which_bool,
+	"Int8":    schema.Type_Which_int8,
+	"Int16":   schema.Type_Which_int16,
+	"Int32":   schema.Type_Which_int32,
+	"Int64":   schema.Type_Which_int64,
+	"UInt8":   schema.Type_Which_uint8,
+	"UInt16":  schema.Type_Which_uint16,
+	"UInt32":  schema.Type_Which_uint32,
+	"UInt64":  schema.Type_Which_uint64,
+	"Float32": schema.Type_Which_float32,
+	"Float64": schema.Type_Which_float64,
+	"Text":    schema.Type_Which_text,
+	"Data":    schema.Type_Which_data,
+}
+
+var builtinPointers = map[string]schema.Type_anyPointer_unconstrained_Which{
+	"AnyPointer": schema.Type_anyPointer_unconstrained_Which_anyKind,
+	"AnyStruct":  schema.Type_anyPointer_unconstrained_Which_struct,
+	"AnyList":    schema.Type_anyPointer_unconstrained_Which_list,
+	"Capability": schema.Type_anyPointer_unconstrained_Which_capability,
+}
+
+// resolve resolves a name expression.
+func (s *state) resolve(en env, e *expr) (*entity, error) {
+	switch e.kind {
+	case exprIdent:
+		return s.lookup(en, e)
+	case exprAbsolute:
+		root := en.file().node
+		d := root.scope[e.name]
+		if d == nil {
+			return nil, en.file().errorf(e.pos, "%s is not declared in %s", e.name, root.displayName)
+		}
+		return s.declEntity(root, d, nil, en)
+	case exprImport:
+		return &entity{file: en.file().imports[e.str]}, nil
+	case exprMember:
+		base, err := s.resolve(en, e.x)
+		if err != nil {
+			return nil, err
+		}
+		var scope *node
+		switch {
+		case base.file != nil:
+			scope = base.file.node
+		case base.node != nil:
+			scope = base.node
+		default:
+			return nil, en.file().errorf(e.pos, "built-in type has no member %s", e.name)
+		}
+		d := scope.scope[e.name]
+		if d == nil {
+			return nil, en.file().errorf(e.pos, "%s has no member %s", scope.displayName, e.name)
+		}
+		return s.declEntity(scope, d, base.brand, en)
+	case exprApply:
+		return s.apply(en, e)
+	}
+	return nil, en.file().errorf(e.pos, "expected a name")
+}
+
+func (s *state) lookup(en env, e *expr) (*entity, error) {
+	for i, p := range en.implicit {
+		if p == e.name {
+			return &entity{typ: &typ{
+				which:      schema.Type_Which_anyPointer,
+				anyKind:    schema.Type_anyPointer_Which_implicitMethodParameter,
+				paramIndex: i,
+			}}, nil
+		}
+	}
+	for sc := en.scope; sc != nil; sc = sc.parent {
+		for i, p := range sc.params {
+			if p == e.name {
+				return &entity{typ: &typ{
+					which:      schema.Type_Which_anyPointer,
+					anyKind:    schema.Type_anyPointer_Which_parameter,
+					paramScope: sc.id,
+					paramIndex: i,
+				}}, nil
+			}
+		}
+		if d := sc.scope[e.name]; d != nil {
+			return s.declEntity(sc, d, inheritBrand(sc), en)
+		}
+	}
+	if w, ok := builtinTypes[e.name]; ok {
+		return &entity{typ: &typ{which: w}}, nil
+	}
+	if c, ok := builtinPointers[e.name]; ok {
+		return &entity{typ: &typ{which: schema.Type_Which_anyPointer, constraint: c}}, nil
+	}
+	if e.name == "List" {
+		return &entity{typ: &typ{which: schema.Type_Which_list}}, nil
+	}
+	return nil, en.file().errorf(e.pos, "%s is not declared", e.name)
+}
+
+// inheritBrand returns the brand of a declaration referred to from
+// within sc: the parameters of sc and its enclosing scopes are the ones
+// currently in scope.
+func inheritBrand(sc *node) *brand {
+	b := &brand{}
+	for ; sc != nil; sc = sc.parent {
+		if len(sc.params) > 0 {
+			b.scopes = append(b.scopes, brandScope{id: sc.id, inherit: true})
+		}
+	}
+	return b
+}
+
+// declEntity returns the entity for d, declared in scope, as referred to
+// from en.  b is the brand of scope.
+func (s *state) declEntity(scope *node, d *decl, b *brand, en env) (*entity, error) {
+	if d.kind == declUsing {
+		if s.usings == nil {
+			s.usings = make(map[*decl]bool)
+		}
+		if s.usings[d] {
+			return nil, scope.file.errorf(d.pos, "using declaration %s refers to itself", d.name)
+		}
+		s.usings[d] = true
+		defer delete(s.usings, d)
+		return s.resolve(env{scope: scope}, d.typ)
+	}
+	n := s.declNodes[d]
+	if b == nil {
+		b = &brand{}
+	}
+	if len(n.params) > 0 && en.scope.isWithin(n) && !b.has(n.id) {
+		// A generic declaration named from within itself refers to
+		// its own parameters.
+		b = b.with(brandScope{id: n.id, inherit: true})
+	}
+	return &entity{node: n, brand: b}, nil
+}
+
+func (s *state) apply(en env, e *expr) (*entity, error) {
+	base, err := s.resolve(en, e.x)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]*typ, len(e.args))
+	for i, a := range e.args {
+		if a.name != "" {
+			return nil, en.file().errorf(a.pos, "expected a type parameter")
+		}
+		if args[i], err = s.resolveType(en, a.value); err != nil {
+			return nil, err
+		}
+	}
+	if base.typ != nil && base.typ.which == schema.Type_Which_list && base.typ.elem == nil {
+		if len(args) != 1 {
+			return nil, en.file().errorf(e.pos, "List takes exactly one parameter")
+		}
+		return &entity{typ: &typ{which: schema.Type_Which_list, elem: args[0]}}, nil
+	}
+	if base.node == nil || len(base.node.params) == 0 {
+		return nil, en.file().errorf(e.pos, "not a generic type")
+	}
+	n := base.node
+	if len(args) != len(n.params) {
+		return nil, en.file().errorf(e.pos, "%s takes %d parameters, got %d", n.displayName, len(n.params), len(args))
+	}
+	for i, t := range args {
+		if !t.isPointer() {
+			return nil, en.file().errorf(e.args[i].pos, "generic parameters must be pointer types")
+		}
+	}
+	b := base.brand
+	if b == nil {
+		b = &brand{}
+	}
+	return &entity{node: n, brand: b.with(brandScope{id: n.id, bind: args})}, nil
+}
+
+// resolveType resolves e as a type.
+func (s *state) resolveType(en env, e *expr) (*typ, error) {
+	ent, err := s.resolve(en, e)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case ent.typ != nil:
+		if ent.typ.which == schema.Type_Which_list && ent.typ.elem == nil {
+			return nil, en.file().errorf(e.pos, "List needs a parameter")
+		}
+		return ent.typ, nil
+	case ent.node != nil:
+		t := &typ{node: ent.node, brand: ent.brand}
+		switch ent.node.kind {
+		case declStruct:
+			t.which = schema.Type_Which_structType
+		case declEnum:
+			t.which = schema.Type_Which_enum
+		case declInterface:
+			t.which = schema.Type_Which_interface
+		default:
+			return nil, en.file().errorf(e.pos, "%s is a %v, not a type", ent.node.displayName, ent.node.kind)
+		}
+		return t, nil
+	}
+	return nil, en.file().errorf(e.pos, "%s is a file, not a type", ent.file.name)
+}
+
+func (t *typ) isPointer() bool {
+	switch t.which {
+	case schema.Type_Which_text, schema.Type_Which_data, schema.Type_Which_list,
+		schema.Type_Which_structType, schema.Type_Which_interface, schema.Type_Which_anyPointer:
+		return true
+	}
+	return false
+}
+
+// dataSize returns the lg of the size in bits of a data type, and false
+// for void and pointer types.
+func (t *typ) dataSize() (uint, bool) {
+	switch t.which {
+	case schema.Type_Which_bool:
+		return 0, true
+	case schema.Type_Which_int8, schema.Type_Which_uint8:
+		return 3, true
+	case schema.Type_Which_int16, schema.Type_Which_uint16, schema.Type_Which_enum:
+		return 4, true
+	case schema.Type_Which_int32, schema.Type_Which_uint32, schema.Type_Which_float32:
+		return 5, true
+	case schema.Type_Which_int64, schema.Type_Which_uint64, schema.Type_Which_float64:
+		return 6, true
+	}
+	return 0, false
+}
+
+func (t *typ) String() string {
+	switch t.which {
+	case schema.Type_Which_list:
+		return "List(" + t.elem.String() + ")"
+	case schema.Type_Which_structType, schema.Type_Which_enum, schema.Type_Which_interface:
+		return t.node.displayName
+	case schema.Type_Which_anyPointer:
+		return "AnyPointer"
+	}
+	return t.which.String()
+}
+
+// sameType reports whether values of a and b are interchangeable.
+func sameType(a, b *typ) bool {
+	if a.which != b.which {
+		return false
+	}
+	switch a.which {
+	case schema.Type_Which_list:
+		return sameType(a.elem, b.elem)
+	case schema.Type_Which_structType, schema.Type_Which_enum, schema.Type_Which_interface:
+		return a.node == b.node
+	}
+	return true
+}
+
+func (s *state) writeType(out schema.Type, t *typ) error {
+	switch t.which {
+	case schema.Type_Which_void:
+		out.SetVoid()
+	case schema.Type_Which_bool:
+		out.SetBool()
+	case schema.Type_Which_int8:
+		out.SetInt8()
+	case schema.Type_Which_int16:
+		out.SetInt16()
+	case schema.Type_Which_int32:
+		out.SetInt32()
+	case schema.Type_Which_int64:
+		out.SetInt64()
+	case schema.Type_Which_uint8:
+		out.SetUint8()
+	case schema.Type_Which_uint16:
+		out.SetUint16()
+	case schema.Type_Which_uint32:
+		out.SetUint32()
+	case schema.Type_Which_uint64:
+		out.SetUint64()
+	case schema.Type_Which_float32:
+		out.SetFloat32()
+	case schema.Type_Which_float64:
+		out.SetFloat64()
+	case schema.Type_Which_text:
+		out.SetText()
+	case schema.Type_Which_data:
+		out.SetData()
+	case schema.Type_Which_list:
+		out.SetList()
+		elem, err := out.List().NewElementType()
+		if err != nil {
+			return err
+		}
+		return s.writeType(elem, t.elem)
+	case schema.Type_Which_enum:
+		out.SetEnum()
+		out.Enum().SetTypeId(t.node.id)
+		b, err := out.Enum().NewBrand()
+		if err != nil {
+			return err
+		}
+		return s.writeBrand(b, t.brand)
+	case schema.Type_Which_structType:
+		out.SetStructType()
+		out.StructType().SetTypeId(t.node.id)
+		b, err := out.StructType().NewBrand()
+		if err != nil {
+			return err
+		}
+		return s.writeBrand(b, t.brand)
+	case schema.Type_Which_interface:
+		out.SetInterface()
+		out.Interface().SetTypeId(t.node.id)
+		b, err := out.Interface().NewBrand()
+		if err != nil {
+			return err
+		}
+		return s.writeBrand(b, t.brand)
+	case schema.Type_Which_anyPointer:
+		out.SetAnyPointer()
+		ap := out.AnyPointer()
+		switch t.anyKind {
+		case schema.Type_anyPointer_Which_parameter:
+			ap.SetParameter()
+			ap.Parameter().SetScopeId(t.paramScope)
+			ap.Parameter().SetParameterIndex(uint16(t.paramIndex))
+		case schema.Type_anyPointer_Which_implicitMethodParameter:
+			ap.SetImplicitMethodParameter()
+			ap.ImplicitMethodParameter().SetParameterIndex(uint16(t.paramIndex))
+		default:
+			ap.SetUnconstrained()
+			switch t.constraint {
+			case schema.Type_anyPointer_unconstrained_Which_struct:
+				ap.Unconstrained().SetStruct()
+			case schema.Type_anyPointer_unconstrained_Which_list:
+				ap.Unconstrained().SetList()
+			case schema.Type_anyPointer_unconstrained_Which_capability:
+				ap.Unconstrained().SetCapability()
+			default:
+				ap.Unconstrained().SetAnyKind()
+			}
+		}
+	}
+	return nil
+}
+
+func (s *state) writeBrand(out schema.Brand, b *brand) error {
+	var scopes []brandScope
+	if b != nil {
+		scopes = b.scopes
+	}
+	sl, err := out.NewScopes(int32(len(scopes)))
+	if err != nil {
+		return err
+	}
+	for i, sc := range scopes {
+		o := sl.At(i)
+		o.SetScopeId(sc.id)
+		if sc.inherit {
+			o.SetInherit()
+			continue
+		}
+		bl, err := o.NewBind(int32(len(sc.bind)))
+		if err != nil {
+			return err
+		}
+		for j, t := range sc.bind {
+			if t == nil {
+				bl.At(j).SetUnbound()
+				continue
+			}
+			bt, err := bl.At(j).NewType()
+			if err != nil {
+				return err
+			}
+			if err := s.writeType(bt, t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,360 @@
+package compiler
+
+import (
+	"sort"
+
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+const noDiscriminant = 0xffff
+
+// structInfo is the compiled form of a struct or group.
+type structInfo struct {
+	top          *topLayout // shared by a struct and its groups
+	fields       []*fieldInfo
+	discrimCount int
+	discrimOff   uint
+	isGroup      bool
+}
+
+// fieldInfo is a compiled member of a struct or group.
+type fieldInfo struct {
+	decl      *decl
+	codeOrder int
+	discrim   int
+	group     *node // group or union; nil for slots
+	typ       *typ
+	offset    uint
+	env       env
+	isParam   bool
+}
+
+// member tracks a field, group, or union while its struct is laid out,
+// following the reference compiler so that fields and group IDs come
+// out the same.
+type member struct {
+	parent     *member
+	decl       *decl
+	codeOrder  int
+	nextCode   int // code order of the next member in this scope
+	unionCount int // discriminant values handed out
+	inUnion    bool
+	discrim    int
+	node       *node // struct or group node for scopes
+	scope      layoutScope
+	union      *unionLayout // this scope's union, if any
+	field      *fieldInfo
+	fieldsInit bool
+	isParam    bool
+}
+
+func (s *state) newMember(parent *member, d *decl, scope layoutScope, inUnion bool) *member {
+	m := &member{
+		parent:    parent,
+		decl:      d,
+		codeOrder: parent.nextCode,
+		discrim:   noDiscriminant,
+		scope:     scope,
+		isParam:   parent.isParam,
+	}
+	parent.nextCode++
+	m.inUnion = inUnion
+	return m
+}
+
+// schema returns m's entry in its parent's field list, creating it if
+// needed.  Entries are created in the order that members are first
+// needed, which places groups where their first member (by ordinal)
+// would go.  Discriminant values and group IDs are handed out as
+// entries are created, so they follow ordinal order as well.
+func (m *member) schema() *fieldInfo {
+	if m.field == nil {
+		p := m.parent
+		if !p.fieldsInit {
+			p.fieldsInit = true
+			if p.parent != nil {
+				p.schema()
+			}
+		}
+		if m.inUnion {
+			m.discrim = p.unionCount
+			p.unionCount++
+		}
+		if m.node != nil {
+			m.node.id = groupID(p.node.id, uint16(len(p.node.st.fields)))
+			m.node.scopeID = p.node.id
+		}
+		m.field = &fieldInfo{
+			decl:      m.decl,
+			codeOrder: m.codeOrder,
+			discrim:   m.discrim,
+			group:     m.node,
+			isParam:   m.isParam,
+		}
+		p.node.st.fields = append(p.node.st.fields, m.field)
+	}
+	return m.field
+}
+
+// structBuilder holds the members of a struct while it is laid out.
+type structBuilder struct {
+	s         *state
+	root      *node
+	all       []*member
+	byOrdinal []*member
+}
+
+// layoutStruct lays out the struct n and creates nodes for its groups.
+func (s *state) layoutStruct(n *node) error {
+	top := new(topLayout)
+	n.st = &structInfo{top: top}
+	b := &structBuilder{s: s, root: n}
+	root := &member{node: n, discrim: noDiscriminant, fieldsInit: true}
+
+	if n.method != nil {
+		root.isParam = true
+		params := n.method.paramList
+		if n.isResults {
+			params = n.method.resultList
+		}
+		for _, p := range params {
+			m := s.newMember(root, p, top, false)
+			b.all = append(b.all, m)
+			b.byOrdinal = append(b.byOrdinal, m)
+		}
+	} else if err := b.traverseTopOrGroup(n.decl.members, root, top); err != nil {
+		return err
+	}
+
+	sort.SliceStable(b.byOrdinal, func(i, j int) bool {
+		return b.byOrdinal[i].decl.ordinal < b.byOrdinal[j].decl.ordinal
+	})
+	var decls []*decl
+	for _, m := range b.byOrdinal {
+		decls = append(decls, m.decl)
+	}
+	if n.method == nil {
+		if err := checkOrdinals(n.file, decls); err != nil {
+			return err
+		}
+	}
+
+	en := env{scope: n.scopeNode()}
+	if n.method != nil {
+		en.implicit = n.method.params
+	}
+	for _, m := range b.byOrdinal {
+		f := m.schema()
+		f.env = en
+		t, err := s.resolveType(en, m.decl.typ)
+		if err != nil {
+			return err
+		}
+		f.typ = t
+		if lg, ok := t.dataSize(); ok {
+			f.offset = m.scope.addData(lg)
+		} else if t.isPointer() {
+			f.offset = m.scope.addPointer()
+		} else {
+			m.scope.addVoid()
+		}
+	}
+
+	finishGroup(root)
+	for _, m := range b.all {
+		if m.decl.kind == declGroup || m.decl.kind == declUnion {
+			finishGroup(m)
+		}
+	}
+	for _, m := range b.all {
+		if m.node != nil {
+			if err := s.addNode(m.node); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func finishGroup(m *member) {
+	st := m.node.st
+	if m.union != nil {
+		m.union.addDiscriminant()
+		st.discrimCount = m.unionCount
+		st.discrimOff = m.union.discriminant
+	}
+	if m.parent != nil {
+		m.schema()
+	}
+}
+
+func (b *structBuilder) traverseTopOrGroup(members []*decl, parent *member, layout layoutScope) error {
+	for _, d := range members {
+		switch d.kind {
+		case declField:
+			m := b.s.newMember(parent, d, layout, false)
+			b.all = append(b.all, m)
+			b.byOrdinal = append(b.byOrdinal, m)
+		case declUnion:
+			u := &unionLayout{parent: layout}
+			if d.name == "" {
+				if parent.union != nil {
+					return parent.node.file.errorf(d.pos, "a struct or group can have only one unnamed union")
+				}
+				parent.union = u
+				if err := b.traverseUnion(d, parent, u); err != nil {
+					return err
+				}
+				continue
+			}
+			m := b.newGroup(parent, d, layout, false)
+			m.union = u
+			if err := b.traverseUnion(d, m, u); err != nil {
+				return err
+			}
+		case declGroup:
+			m := b.newGroup(parent, d, layout, false)
+			if err := b.traverseGroup(d, m, layout); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *structBuilder) traverseUnion(d *decl, parent *member, layout *unionLayout) error {
+	count := 0
+	for _, m := range d.members {
+		if m.kind == declField || m.kind == declGroup || m.kind == declUnion {
+			count++
+		}
+	}
+	if count < 2 {
+		return b.root.file.errorf(d.pos, "a union must have at least two members")
+	}
+	if d.ordinal >= 0 {
+		return b.root.file.errorf(d.ordinalPos, "unions with ordinals are not supported")
+	}
+	for _, md := range d.members {
+		switch md.kind {
+		case declField:
+			m := b.s.newMember(parent, md, &groupLayout{parent: layout}, true)
+			b.all = append(b.all, m)
+			b.byOrdinal = append(b.byOrdinal, m)
+		case declGroup:
+			g := &groupLayout{parent: layout}
+			m := b.newGroup(parent, md, g, true)
+			if err := b.traverseGroup(md, m, g); err != nil {
+				return err
+			}
+		case declUnion:
+			if md.name == "" {
+				return b.root.file.errorf(md.pos, "a union cannot directly contain an unnamed union")
+			}
+			g := &groupLayout{parent: layout}
+			m := b.newGroup(parent, md, g, true)
+			u := &unionLayout{parent: g}
+			m.union = u
+			if err := b.traverseUnion(md, m, u); err != nil {
+				return err
+			}
+		default:
+			return b.root.file.errorf(md.pos, "unions can only contain fields and groups")
+		}
+	}
+	return nil
+}
+
+func (b *structBuilder) traverseGroup(d *decl, m *member, layout layoutScope) error {
+	if len(d.members) == 0 {
+		return b.root.file.errorf(d.pos, "a group must have at least one member")
+	}
+	return b.traverseTopOrGroup(d.members, m, layout)
+}
+
+// newGroup creates the member and node for a group or named union.
+// Its ID is assigned once its place in the parent's field list is known.
+func (b *structBuilder) newGroup(parent *member, d *decl, layout layoutScope, inUnion bool) *member {
+	m := b.s.newMember(parent, d, layout, inUnion)
+	pn := parent.node
+	n := &node{
+		kind:        d.kind,
+		decl:        d,
+		file:        pn.file,
+		parent:      pn,
+		name:        d.name,
+		displayName: pn.displayName + "." + d.name,
+		prefixLen:   len(pn.displayName) + 1,
+		generic:     pn.generic,
+		st:          &structInfo{top: b.root.st.top, isGroup: true},
+	}
+	m.node = n
+	b.all = append(b.all, m)
+	return m
+}
+
+func (s *state) writeStruct(out schema.Node, n *node) error {
+	out.SetStructNode()
+	sn := out.StructNode()
+	st := n.st
+	sn.SetDataWordCount(uint16(st.top.dataWords))
+	sn.SetPointerCount(uint16(st.top.pointers))
+	sn.SetPreferredListEncoding(schema.ElementSize_inlineComposite)
+	sn.SetIsGroup(st.isGroup)
+	sn.SetDiscriminantCount(uint16(st.discrimCount))
+	sn.SetDiscriminantOffset(uint32(st.discrimOff))
+	fl, err := sn.NewFields(int32(len(st.fields)))
+	if err != nil {
+		return err
+	}
+	for i, f := range st.fields {
+		o := fl.At(i)
+		if err := o.SetName(f.decl.name); err != nil {
+			return err
+		}
+		o.SetCodeOrder(uint16(f.codeOrder))
+		o.SetDiscriminantValue(uint16(f.discrim))
+		target := "field"
+		switch {
+		case f.isParam:
+			target = "param"
+		case f.decl.kind == declGroup:
+			target = "group"
+		case f.decl.kind == declUnion:
+			target = "union"
+		}
+		en := env{scope: n.scopeNode()}
+		if f.env.scope != nil {
+			en = f.env
+		}
+		if err := s.writeAnnotations(o.NewAnnotations, en, f.decl.annotations, target); err != nil {
+			return err
+		}
+		if f.group != nil {
+			o.SetGroup()
+			o.Group().SetTypeId(f.group.id)
+			o.Ordinal().SetImplicit()
+			continue
+		}
+		o.SetSlot()
+		slot := o.Slot()
+		slot.SetOffset(uint32(f.offset))
+		t, err := slot.NewType()
+		if err != nil {
+			return err
+		}
+		if err := s.writeType(t, f.typ); err != nil {
+			return err
+		}
+		dv, err := slot.NewDefaultValue()
+		if err != nil {
+			return err
+		}
+		if err := s.writeValue(dv, f.typ, f.decl.value, f.env); err != nil {
+			return err
+		}
+		slot.SetHadExplicitDefault(f.decl.value != nil)
+		o.Ordinal().SetExplicit(uint16(f.decl.ordinal))
+	}
+	return nil
+}
@@ -0,0 +1,462 @@
+package compiler
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// writeValue writes the value of e, of type t, into out.  A nil e
+// writes the default value of t.
+func (s *state) writeValue(out schema.Value, t *typ, e *expr, en env) error {
+	if _, ok := t.dataSize(); ok {
+		var bits uint64
+		if e != nil {
+			var err error
+			if bits, err = s.evalScalar(t, e, en); err != nil {
+				return err
+			}
+		}
+		switch t.which {
+		case schema.Type_Which_bool:
+			out.SetBool(bits != 0)
+		case schema.Type_Which_int8:
+			out.SetInt8(int8(bits))
+		case schema.Type_Which_int16:
+			out.SetInt16(int16(bits))
+		case schema.Type_Which_int32:
+			out.SetInt32(int32(bits))
+		case schema.Type_Which_int64:
+			out.SetInt64(int64(bits))
+		case schema.Type_Which_uint8:
+			out.SetUint8(uint8(bits))
+		case schema.Type_Which_uint16:
+			out.SetUint16(uint16(bits))
+		case schema.Type_Which_uint32:
+			out.SetUint32(uint32(bits))
+		case schema.Type_Which_uint64:
+			out.SetUint64(bits)
+		case schema.Type_Which_float32:
+			out.SetFloat32(math.Float32frombits(uint32(bits)))
+		case schema.Type_Which_float64:
+			out.SetFloat64(math.Float64frombits(bits))
+		case schema.Type_Which_enum:
+			out.SetEnum(uint16(bits))
+		}
+		return nil
+	}
+
+	if t.which == schema.Type_Which_void {
+		if e != nil {
+			if _, err := s.evalScalar(t, e, en); err != nil {
+				return err
+			}
+		}
+		out.SetVoid()
+		return nil
+	}
+	var p capnp.Ptr
+	if e != nil {
+		var err error
+		if p, err = s.buildPtr(out.Segment(), t, e, en); err != nil {
+			return err
+		}
+	}
+	switch t.which {
+	case schema.Type_Which_text:
+		return out.SetText(string(p.TextBytes()))
+	case schema.Type_Which_data:
+		return out.SetData(p.Data())
+	case schema.Type_Which_list:
+		return out.SetList(p)
+	case schema.Type_Which_structType:
+		return out.SetStructValue(p)
+	case schema.Type_Which_interface:
+		out.SetInterface()
+		return nil
+	default:
+		return out.SetAnyPointer(p)
+	}
+}
+
+// constRef returns the constant named by e, or nil if e does not name
+// a constant.
+func (s *state) constRef(en env, e *expr) (*node, error) {
+	switch e.kind {
+	case exprIdent, exprAbsolute, exprMember:
+	default:
+		return nil, nil
+	}
+	ent, err := s.resolve(en, e)
+	if err != nil {
+		return nil, err
+	}
+	if ent.node == nil || ent.node.kind != declConst {
+		return nil, en.file().errorf(e.pos, "expected a value")
+	}
+	return ent.node, nil
+}
+
+// followConst returns the value expression of the constant named by e,
+// checking that its type is t.  It returns e itself if e does not name
+// a constant.
+func (s *state) followConst(t *typ, en env, e *expr) (*expr, env, error) {
+	for depth := 0; ; depth++ {
+		c, err := s.constRef(en, e)
+		if err != nil || c == nil {
+			return e, en, err
+		}
+		if depth > 100 {
+			return nil, en, en.file().errorf(e.pos, "constant refers to itself")
+		}
+		cen := env{scope: c.parent.scopeNode()}
+		ct, err := s.resolveType(cen, c.decl.typ)
+		if err != nil {
+			return nil, en, err
+		}
+		if !sameType(t, ct) {
+			return nil, en, en.file().errorf(e.pos, "%s has type %v, not %v", c.displayName, ct, t)
+		}
+		e, en = c.decl.value, cen
+	}
+}
+
+// evalScalar evaluates e as a value of the data type t and returns its
+// bits.
+func (s *state) evalScalar(t *typ, e *expr, en env) (uint64, error) {
+	if v, ok := keywordValue(t, e); ok {
+		return v, nil
+	}
+	e, en, err := s.followConst(t, en, e)
+	if err != nil {
+		return 0, err
+	}
+	if v, ok := keywordValue(t, e); ok {
+		return v, nil
+	}
+	switch t.which {
+	case schema.Type_Which_float32, schema.Type_Which_float64:
+		var f float64
+		switch e.kind {
+		case exprFloat:
+			f = e.fval
+		case exprInt:
+			f = float64(e.ival)
+		default:
+			return 0, en.file().errorf(e.pos, "expected a number")
+		}
+		if e.neg {
+			f = -f
+		}
+		return floatBits(t, f), nil
+	case schema.Type_Which_int8, schema.Type_Which_int16, schema.Type_Which_int32, schema.Type_Which_int64,
+		schema.Type_Which_uint8, schema.Type_Which_uint16, schema.Type_Which_uint32, schema.Type_Which_uint64:
+		if e.kind != exprInt {
+			return 0, en.file().errorf(e.pos, "expected an integer")
+		}
+		return intBits(t, e, en)
+	}
+	return 0, en.file().errorf(e.pos, "expected a value of type %v", t)
+}
+
+// keywordValue evaluates the identifiers that stand for values of data
+// types: void, true, false, inf, nan, and enumerant names.
+func keywordValue(t *typ, e *expr) (uint64, bool) {
+	if e.kind != exprIdent {
+		return 0, false
+	}
+	switch t.which {
+	case schema.Type_Which_void:
+		return 0, e.name == "void"
+	case schema.Type_Which_bool:
+		switch e.name {
+		case "true":
+			return 1, true
+		case "false":
+			return 0, true
+		}
+	case schema.Type_Which_float32, schema.Type_Which_float64:
+		switch e.name {
+		case "inf":
+			return floatBits(t, math.Inf(1)), true
+		case "nan":
+			return floatBits(t, math.NaN()), true
+		}
+	case schema.Type_Which_enum:
+		if v, ok := enumerant(t.node, e.name); ok {
+			return uint64(v), true
+		}
+	}
+	return 0, false
+}
+
+func floatBits(t *typ, f float64) uint64 {
+	if t.which == schema.Type_Which_float32 {
+		return uint64(math.Float32bits(float32(f)))
+	}
+	return math.Float64bits(f)
+}
+
+func intBits(t *typ, e *expr, en env) (uint64, error) {
+	lg, _ := t.dataSize()
+	bits := uint(1) << lg
+	signed := t.which == schema.Type_Which_int8 || t.which == schema.Type_Which_int16 ||
+		t.which == schema.Type_Which_int32 || t.which == schema.Type_Which_int64
+	v := e.ival
+	var ok bool
+	switch {
+	case !signed:
+		ok = !e.neg || v == 0
+		ok = ok && (bits == 64 || v < 1<<bits)
+	case e.neg:
+		ok = v <= 1<<(bits-1)
+		v = -v
+	default:
+		ok = v < 1<<(bits-1)
+	}
+	if !ok {
+		return 0, en.file().errorf(e.pos, "integer value out of range for %v", t)
+	}
+	if bits < 64 {
+		v &= 1<<bits - 1
+	}
+	return v, nil
+}
+
+func enumerant(n *node, name string) (uint16, bool) {
+	for _, m := range n.decl.members {
+		if m.kind == declEnumerant && m.name == name {
+			return uint16(m.ordinal), true
+		}
+	}
+	return 0, false
+}
+
+// buildPtr builds the pointer value e of type t in seg.
+func (s *state) buildPtr(seg *capnp.Segment, t *typ, e *expr, en env) (capnp.Ptr, error) {
+	e, en, err := s.followConst(t, en, e)
+	if err != nil {
+		return capnp.Ptr{}, err
+	}
+	switch t.which {
+	case schema.Type_Which_text, schema.Type_Which_data:
+		var b []byte
+		switch e.kind {
+		case exprString, exprBinary:
+			b = []byte(e.str)
+		case exprEmbed:
+			if b, err = os.ReadFile(filepath.Join(filepath.Dir(en.file().path), filepath.FromSlash(e.str))); err != nil {
+				return capnp.Ptr{}, en.file().errorf(e.pos, "embed: %v", err)
+			}
+		default:
+			return capnp.Ptr{}, en.file().errorf(e.pos, "expected a string")
+		}
+		if t.which == schema.Type_Which_text {
+			l, err := capnp.NewTextFromBytes(seg, b)
+			return l.ToPtr(), err
+		}
+		l, err := capnp.NewData(seg, b)
+		return l.ToPtr(), err
+	case schema.Type_Which_structType:
+		st := t.node.st
+		str, err := capnp.NewStruct(seg, capnp.ObjectSize{
+			DataSize:     capnp.Size(st.top.dataWords * 8),
+			PointerCount: uint16(st.top.pointers),
+		})
+		if err != nil {
+			return capnp.Ptr{}, err
+		}
+		if err := s.fillStruct(str, t.node, e, en); err != nil {
+			return capnp.Ptr{}, err
+		}
+		return str.ToPtr(), nil
+	case schema.Type_Which_list:
+		l, err := s.buildList(seg, t.elem, e, en)
+		return l.ToPtr(), err
+	}
+	return capnp.Ptr{}, en.file().errorf(e.pos, "cannot write a value of type %v", t)
+}
+
+// fillStruct sets the fields of str, a struct of type n (or one of its
+// groups), from the struct literal e.
+func (s *state) fillStruct(str capnp.Struct, n *node, e *expr, en env) error {
+	if e.kind != exprTuple {
+		return en.file().errorf(e.pos, "expected a struct value like (field = value)")
+	}
+	unionSet := false
+	for _, a := range e.args {
+		if a.name == "" {
+			return en.file().errorf(a.pos, "expected field = value")
+		}
+		var f *fieldInfo
+		for _, x := range n.st.fields {
+			if x.decl.name == a.name {
+				f = x
+				break
+			}
+		}
+		if f == nil {
+			return en.file().errorf(a.pos, "%s has no field %s", n.displayName, a.name)
+		}
+		if f.discrim != noDiscriminant {
+			if unionSet {
+				return en.file().errorf(a.pos, "more than one member of a union is set")
+			}
+			unionSet = true
+			str.SetUint16(capnp.DataOffset(n.st.discrimOff*2), uint16(f.discrim))
+		}
+		if f.group != nil {
+			if err := s.fillStruct(str, f.group, a.value, en); err != nil {
+				return err
+			}
+			continue
+		}
+		if lg, ok := f.typ.dataSize(); ok {
+			bits, err := s.evalScalar(f.typ, a.value, en)
+			if err != nil {
+				return err
+			}
+			if f.decl.value != nil {
+				def, err := s.evalScalar(f.typ, f.decl.value, f.env)
+				if err != nil {
+					return err
+				}
+				bits ^= def
+			}
+			storeBits(str, lg, f.offset, bits)
+			continue
+		}
+		if !f.typ.isPointer() {
+			if _, err := s.evalScalar(f.typ, a.value, en); err != nil {
+				return err
+			}
+			continue
+		}
+		p, err := s.buildPtr(str.Segment(), f.typ, a.value, en)
+		if err != nil {
+			return err
+		}
+		if err := str.SetPtr(uint16(f.offset), p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func storeBits(str capnp.Struct, lg, off uint, bits uint64) {
+	switch lg {
+	case 0:
+		str.SetBit(capnp.BitOffset(off), bits != 0)
+	case 3:
+		str.SetUint8(capnp.DataOffset(off), uint8(bits))
+	case 4:
+		str.SetUint16(capnp.DataOffset(off*2), uint16(bits))
+	case 5:
+		str.SetUint32(capnp.DataOffset(off*4), uint32(bits))
+	case 6:
+		str.SetUint64(capnp.DataOffset(off*8), bits)
+	}
+}
+
+// buildList builds a list of elem from the list literal e.
+func (s *state) buildList(seg *capnp.Segment, elem *typ, e *expr, en env) (capnp.List, error) {
+	if e.kind != exprList {
+		return capnp.List{}, en.file().errorf(e.pos, "expected a list value like [a, b]")
+	}
+	n := int32(len(e.elems))
+	if lg, ok := elem.dataSize(); ok {
+		var l capnp.List
+		var err error
+		switch lg {
+		case 0:
+			var bl capnp.BitList
+			bl, err = capnp.NewBitList(seg, n)
+			l = capnp.List(bl)
+		case 3:
+			var ul capnp.UInt8List
+			ul, err = capnp.NewUInt8List(seg, n)
+			l = capnp.List(ul)
+		case 4:
+			var ul capnp.UInt16List
+			ul, err = capnp.NewUInt16List(seg, n)
+			l = capnp.List(ul)
+		case 5:
+			var ul capnp.UInt32List
+			ul, err = capnp.NewUInt32List(seg, n)
+			l = capnp.List(ul)
+		default:
+			var ul capnp.UInt64List
+			ul, err = capnp.NewUInt64List(seg, n)
+			l = capnp.List(ul)
+		}
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, x := range e.elems {
+			bits, err := s.evalScalar(elem, x, en)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			switch lg {
+			case 0:
+				capnp.BitList(l).Set(i, bits != 0)
+			case 3:
+				capnp.UInt8List(l).Set(i, uint8(bits))
+			case 4:
+				capnp.UInt16List(l).Set(i, uint16(bits))
+			case 5:
+				capnp.UInt32List(l).Set(i, uint32(bits))
+			default:
+				capnp.UInt64List(l).Set(i, bits)
+			}
+		}
+		return l, nil
+	}
+
+	switch elem.which {
+	case schema.Type_Which_void:
+		for _, x := range e.elems {
+			if _, err := s.evalScalar(elem, x, en); err != nil {
+				return capnp.List{}, err
+			}
+		}
+		return capnp.List(capnp.NewVoidList(seg, n)), nil
+	case schema.Type_Which_structType:
+		st := elem.node.st
+		l, err := capnp.NewCompositeList(seg, capnp.ObjectSize{
+			DataSize:     capnp.Size(st.top.dataWords * 8),
+			PointerCount: uint16(st.top.pointers),
+		}, n)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		for i, x := range e.elems {
+			x, xen, err := s.followConst(elem, en, x)
+			if err != nil {
+				return capnp.List{}, err
+			}
+			if err := s.fillStruct(l.Struct(i), elem.node, x, xen); err != nil {
+				return capnp.List{}, err
+			}
+		}
+		return l, nil
+	}
+
+	pl, err := capnp.NewPointerList(seg, n)
+	if err != nil {
+		return capnp.List{}, err
+	}
+	for i, x := range e.elems {
+		p, err := s.buildPtr(seg, elem, x, en)
+		if err != nil {
+			return capnp.List{}, err
+		}
+		if err := pl.Set(i, p); err != nil {
+			return capnp.List{}, err
+		}
+	}
+	return capnp.List(pl), nil
+}
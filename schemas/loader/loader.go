@@ -0,0 +1,449 @@
+// Package loader populates a schema registry from CodeGeneratorRequests
+// obtained at runtime, such as the output of "capnp compile -o-", and
+// looks up the loaded nodes by ID or by name.
+package loader
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/internal/nodemap"
+	"capnproto.org/go/capnp/v3/schemas"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+// A Loader adds schemas to a registry and indexes their nodes.  It is
+// safe to use from multiple goroutines, but the registry must not be
+// read while Load is running.
+type Loader struct {
+	reg *schemas.Registry
+
+	mu      sync.RWMutex
+	nodes   map[uint64]schema.Node
+	names   map[string]uint64 // fully qualified name -> ID
+	files   map[uint64]string // file ID -> name used in display names
+	imports map[string]uint64 // import path -> file ID
+	others  nodemap.Map       // nodes registered by other means
+}
+
+// New returns a loader that adds schemas to reg.  If reg is nil, the
+// loader uses schemas.DefaultRegistry.
+func New(reg *schemas.Registry) *Loader {
+	if reg == nil {
+		reg = &schemas.DefaultRegistry
+	}
+	l := &Loader{
+		reg:     reg,
+		nodes:   make(map[uint64]schema.Node),
+		names:   make(map[string]uint64),
+		files:   make(map[uint64]string),
+		imports: make(map[string]uint64),
+	}
+	l.others.UseRegistry(reg)
+	return l
+}
+
+// Registry returns the registry that the loader populates.
+func (l *Loader) Registry() *schemas.Registry {
+	return l.reg
+}
+
+// Load reads a CodeGeneratorRequest message in the standard stream
+// framing and adds its nodes to the registry.  Nodes whose IDs are
+// already in the registry are indexed but not registered again, so it
+// is fine to load several requests that import the same files.
+//
+// Every file imported by a requested file must be described by the
+// request or by a previous call to Load.  The loader keeps a reference
+// to data, so the caller must not modify it afterward.  If the request
+// is malformed, Load returns an error and leaves the loader and the
+// registry unchanged.
+func (l *Loader) Load(data []byte) error {
+	msg, err := capnp.Unmarshal(data)
+	if err != nil {
+		return fmt.Errorf("loader: %w", err)
+	}
+	req, err := schema.ReadRootCodeGeneratorRequest(msg)
+	if err != nil {
+		return fmt.Errorf("loader: read request: %w", err)
+	}
+	nodes, err := req.Nodes()
+	if err != nil {
+		return fmt.Errorf("loader: read nodes: %w", err)
+	}
+	files, err := req.RequestedFiles()
+	if err != nil {
+		return fmt.Errorf("loader: read requested files: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Check imports first.  Generated code strips
+	// file nodes, so a file also counts as present if any of its
+	// top-level declarations are.
+	present := make(map[uint64]bool)
+	for i := 0; i < nodes.Len(); i++ {
+		n := nodes.At(i)
+		present[n.Id()] = true
+		present[n.ScopeId()] = true
+	}
+	for i := 0; i < files.Len(); i++ {
+		present[files.At(i).Id()] = true
+	}
+	for i := 0; i < files.Len(); i++ {
+		f := files.At(i)
+		imps, err := f.Imports()
+		if err != nil {
+			return fmt.Errorf("loader: read imports: %w", err)
+		}
+		for j := 0; j < imps.Len(); j++ {
+			imp := imps.At(j)
+			if _, known := l.files[imp.Id()]; present[imp.Id()] || known {
+				continue
+			}
+			fn, _ := f.Filename()
+			name, _ := imp.Name()
+			return fmt.Errorf("loader: %s imports %s (@%#x), which is not loaded", fn, name, imp.Id())
+		}
+	}
+
+	// Read everything into pending, so that an error leaves the
+	// loader as it was.
+	pending := newIndex()
+	var ids []uint64
+	for i := 0; i < nodes.Len(); i++ {
+		n := nodes.At(i)
+		if n.Id() == 0 {
+			// Placeholder left by generated code.
+			continue
+		}
+		if err := l.index(pending, n); err != nil {
+			return err
+		}
+		if _, err := l.reg.Find(n.Id()); schemas.IsNotFound(err) {
+			ids = append(ids, n.Id())
+		}
+	}
+	for i := 0; i < files.Len(); i++ {
+		f := files.At(i)
+		if !l.hasFile(pending, f.Id()) {
+			fn, _ := f.Filename()
+			pending.files[f.Id()] = fn
+			pending.names[fn] = f.Id()
+		}
+		imps, _ := f.Imports()
+		for j := 0; j < imps.Len(); j++ {
+			name, _ := imps.At(j).Name()
+			pending.imports[name] = imps.At(j).Id()
+		}
+	}
+	if len(ids) > 0 {
+		if err := l.reg.Register(&schemas.Schema{Bytes: data, Nodes: ids}); err != nil {
+			return err
+		}
+	}
+	l.commit(pending)
+	return nil
+}
+
+// An index holds the entries that Load adds to a Loader's maps.
+type index struct {
+	nodes   map[uint64]schema.Node
+	names   map[string]uint64
+	files   map[uint64]string
+	imports map[string]uint64
+}
+
+func newIndex() *index {
+	return &index{
+		nodes:   make(map[uint64]schema.Node),
+		names:   make(map[string]uint64),
+		files:   make(map[uint64]string),
+		imports: make(map[string]uint64),
+	}
+}
+
+// hasFile reports whether the file with the given ID has a name in the
+// loader or in pending.
+func (l *Loader) hasFile(pending *index, id uint64) bool {
+	if _, ok := pending.files[id]; ok {
+		return true
+	}
+	_, ok := l.files[id]
+	return ok
+}
+
+// commit adds the entries in pending to the loader.
+func (l *Loader) commit(pending *index) {
+	for id, n := range pending.nodes {
+		l.nodes[id] = n
+	}
+	for name, id := range pending.names {
+		l.names[name] = id
+	}
+	for id, name := range pending.files {
+		l.files[id] = name
+	}
+	for name, id := range pending.imports {
+		l.imports[name] = id
+	}
+}
+
+// index adds n and its nested declarations to pending.
+func (l *Loader) index(pending *index, n schema.Node) error {
+	dn, err := n.DisplayName()
+	if err != nil {
+		return fmt.Errorf("loader: read name of @%#x: %w", n.Id(), err)
+	}
+	if int(n.DisplayNamePrefixLength()) > len(dn) {
+		return fmt.Errorf("loader: display name prefix of @%#x is longer than its name %q", n.Id(), dn)
+	}
+	pending.nodes[n.Id()] = n
+	pending.names[dn] = n.Id()
+	if n.Which() == schema.Node_Which_file {
+		pending.files[n.Id()] = dn
+	} else if prefix := dn[:n.DisplayNamePrefixLength()]; strings.HasSuffix(prefix, ":") {
+		if !l.hasFile(pending, n.ScopeId()) {
+			pending.files[n.ScopeId()] = strings.TrimSuffix(prefix, ":")
+		}
+	}
+
+	// Nested declarations might not be in the request, but they can
+	// still be found in the registry by ID.
+	sep := "."
+	if n.Which() == schema.Node_Which_file {
+		sep = ":"
+	}
+	nested, err := n.NestedNodes()
+	if err != nil {
+		return fmt.Errorf("loader: read nested nodes of %s: %w", dn, err)
+	}
+	for i := 0; i < nested.Len(); i++ {
+		name, _ := nested.At(i).Name()
+		pending.names[dn+sep+name] = nested.At(i).Id()
+	}
+	return nil
+}
+
+// Node returns the node with the given ID.  It finds nodes loaded by
+// Load as well as nodes registered in the loader's registry by other
+// means, such as generated code.
+func (l *Loader) Node(id uint64) (schema.Node, error) {
+	l.mu.RLock()
+	n, ok := l.nodes[id]
+	l.mu.RUnlock()
+	if ok {
+		return n, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	in, err := l.others.Find(id)
+	if err != nil {
+		return schema.Node{}, fmt.Errorf("loader: %w", err)
+	}
+	if !in.IsValid() {
+		return schema.Node{}, fmt.Errorf("loader: cannot find node @%#x", id)
+	}
+	return schema.Node(in), nil
+}
+
+// Lookup returns the node with the given fully qualified name: a file
+// name optionally followed by a colon and a dotted path of nested
+// declarations, as in "foo.capnp:Outer.Inner".  These are the display
+// names that the schema compiler assigns.  The file may also be named
+// by the path that a loaded file imports it with, as in
+// "/capnp/c++.capnp:namespace".
+func (l *Loader) Lookup(name string) (schema.Node, error) {
+	l.mu.RLock()
+	id, ok := l.names[name]
+	if !ok {
+		file, decl := name, ""
+		if i := strings.IndexByte(name, ':'); i >= 0 {
+			file, decl = name[:i], name[i:]
+		}
+		if fid, imported := l.imports[file]; imported {
+			if fn, known := l.files[fid]; known && decl != "" {
+				id, ok = l.names[fn+decl]
+			} else if decl == "" {
+				id, ok = fid, true
+			}
+		}
+	}
+	l.mu.RUnlock()
+	if !ok {
+		return schema.Node{}, fmt.Errorf("loader: cannot find %q", name)
+	}
+	return l.Node(id)
+}
+
+// ResolveType returns a copy of t with the generic parameters that
+// brand binds replaced by their bindings.  Parameters of scopes that
+// brand does not mention become AnyPointer, and parameters of scopes
+// that brand inherits are left as is.  Brands nested inside t, such as
+// the brand of a struct type, are resolved in the same way.
+//
+// A typical use is resolving the type of a field of a generic struct,
+// where brand is the brand of the struct type that refers to it.
+func (l *Loader) ResolveType(t schema.Type, brand schema.Brand) (schema.Type, error) {
+	_, seg, err := capnp.NewMessage(capnp.SingleSegment(nil))
+	if err != nil {
+		return schema.Type{}, err
+	}
+	out, err := schema.NewRootType(seg)
+	if err != nil {
+		return schema.Type{}, err
+	}
+	if err := l.resolveInto(out, t, brand); err != nil {
+		return schema.Type{}, fmt.Errorf("loader: resolve type: %w", err)
+	}
+	return out, nil
+}
+
+func (l *Loader) resolveInto(dst, src schema.Type, brand schema.Brand) error {
+	switch src.Which() {
+	case schema.Type_Which_anyPointer:
+		if src.AnyPointer().Which() != schema.Type_anyPointer_Which_parameter {
+			break
+		}
+		p := src.AnyPointer().Parameter()
+		b, bound, err := lookupBinding(brand, p.ScopeId(), p.ParameterIndex())
+		if err != nil {
+			return err
+		}
+		if !bound {
+			break
+		}
+		if b.Which() == schema.Brand_Binding_Which_type {
+			bt, err := b.Type()
+			if err != nil {
+				return err
+			}
+			return capnp.Struct(dst).CopyFrom(capnp.Struct(bt))
+		}
+		dst.SetAnyPointer()
+		dst.AnyPointer().SetUnconstrained()
+		dst.AnyPointer().Unconstrained().SetAnyKind()
+		return nil
+	case schema.Type_Which_list:
+		elem, err := src.List().ElementType()
+		if err != nil {
+			return err
+		}
+		dst.SetList()
+		de, err := dst.List().NewElementType()
+		if err != nil {
+			return err
+		}
+		return l.resolveInto(de, elem, brand)
+	case schema.Type_Which_structType, schema.Type_Which_enum, schema.Type_Which_interface:
+		if err := capnp.Struct(dst).CopyFrom(capnp.Struct(src)); err != nil {
+			return err
+		}
+		var (
+			inner schema.Brand
+			err   error
+		)
+		switch dst.Which() {
+		case schema.Type_Which_structType:
+			inner, err = dst.StructType().Brand()
+		case schema.Type_Which_enum:
+			inner, err = dst.Enum().Brand()
+		case schema.Type_Which_interface:
+			inner, err = dst.Interface().Brand()
+		}
+		if err != nil {
+			return err
+		}
+		return l.resolveBrand(inner, brand)
+	}
+	return capnp.Struct(dst).CopyFrom(capnp.Struct(src))
+}
+
+// resolveBrand resolves the bindings of inner, which has already been
+// copied into a new message, against outer.
+func (l *Loader) resolveBrand(inner, outer schema.Brand) error {
+	scopes, err := inner.Scopes()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < scopes.Len(); i++ {
+		sc := scopes.At(i)
+		if sc.Which() == schema.Brand_Scope_Which_inherit {
+			if os, ok, err := findScope(outer, sc.ScopeId()); err != nil {
+				return err
+			} else if ok {
+				if err := capnp.Struct(sc).CopyFrom(capnp.Struct(os)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		binds, err := sc.Bind()
+		if err != nil {
+			return err
+		}
+		for j := 0; j < binds.Len(); j++ {
+			b := binds.At(j)
+			if b.Which() != schema.Brand_Binding_Which_type {
+				continue
+			}
+			bt, err := b.Type()
+			if err != nil {
+				return err
+			}
+			resolved, err := l.ResolveType(bt, outer)
+			if err != nil {
+				return err
+			}
+			if err := capnp.Struct(bt).CopyFrom(capnp.Struct(resolved)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func findScope(brand schema.Brand, scopeID uint64) (schema.Brand_Scope, bool, error) {
+	if !brand.IsValid() {
+		return schema.Brand_Scope{}, false, nil
+	}
+	scopes, err := brand.Scopes()
+	if err != nil {
+		return schema.Brand_Scope{}, false, err
+	}
+	for i := 0; i < scopes.Len(); i++ {
+		if sc := scopes.At(i); sc.ScopeId() == scopeID {
+			return sc, true, nil
+		}
+	}
+	return schema.Brand_Scope{}, false, nil
+}
+
+// lookupBinding returns brand's binding for a parameter.  It returns
+// false if the parameter's scope is inherited, meaning that the
+// parameter should be left as is.  A parameter of a scope that brand
+// does not mention is reported as an unbound binding.
+func lookupBinding(brand schema.Brand, scopeID uint64, index uint16) (schema.Brand_Binding, bool, error) {
+	sc, ok, err := findScope(brand, scopeID)
+	if err != nil {
+		return schema.Brand_Binding{}, false, err
+	}
+	if !ok {
+		return schema.Brand_Binding{}, true, nil
+	}
+	if sc.Which() == schema.Brand_Scope_Which_inherit {
+		return schema.Brand_Binding{}, false, nil
+	}
+	binds, err := sc.Bind()
+	if err != nil {
+		return schema.Brand_Binding{}, false, err
+	}
+	if int(index) >= binds.Len() {
+		return schema.Brand_Binding{}, true, nil
+	}
+	return binds.At(int(index)), true, nil
+}
@@ -0,0 +1,214 @@
+package loader
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/schemas"
+	"capnproto.org/go/capnp/v3/std/capnp/persistent"
+	"capnproto.org/go/capnp/v3/std/capnp/schema"
+)
+
+func TestLoad(t *testing.T) {
+	// The request for json.capnp is shared with the encoding/json tests.
+	data, err := os.ReadFile("../../encoding/json/testdata/json.capnp.out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := new(schemas.Registry)
+	l := New(reg)
+	if err := l.Load(data); err != nil {
+		t.Fatal("Load:", err)
+	}
+
+	tests := []struct {
+		name string
+		id   uint64
+	}{
+		{"json.capnp:TestAllTypes", 0xa6dd493786c78852},
+		{"/capnp/compat/json.capnp:Value", 0xa3fa7845f919dd83},
+		{"/capnp/compat/json.capnp:Value.Field", 0xe31026e735d69ddf},
+	}
+	for _, test := range tests {
+		n, err := l.Lookup(test.name)
+		if err != nil {
+			t.Errorf("Lookup(%q): %v", test.name, err)
+			continue
+		}
+		if n.Id() != test.id {
+			t.Errorf("Lookup(%q).Id() = %#x; want %#x", test.name, n.Id(), test.id)
+		}
+		if _, err := reg.Find(test.id); err != nil {
+			t.Errorf("registry Find(%#x): %v", test.id, err)
+		}
+	}
+
+	n, err := l.Lookup("json.capnp:TestJsonAnnotations.aGroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Which() != schema.Node_Which_structNode || !n.StructNode().IsGroup() {
+		t.Errorf("%v is not a group", n)
+	}
+	if m, err := l.Node(n.Id()); err != nil || m.Id() != n.Id() {
+		t.Errorf("Node(%#x) = %#x, %v", n.Id(), m.Id(), err)
+	}
+
+	if _, err := l.Lookup("json.capnp:Nope"); err == nil {
+		t.Error("Lookup of missing name did not return an error")
+	}
+
+	// Loading the same request again must not register duplicates.
+	if err := l.Load(data); err != nil {
+		t.Error("second Load:", err)
+	}
+}
+
+func TestLoadGenerated(t *testing.T) {
+	data := schemas.Find(persistent.Persistent_TypeID)
+	if data == nil {
+		t.Fatal("persistent.capnp is not registered")
+	}
+	l := New(new(schemas.Registry))
+	if err := l.Load(data); err != nil {
+		t.Fatal("Load:", err)
+	}
+	n, err := l.Lookup("persistent.capnp:Persistent.SaveParams")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n.Id() != persistent.Persistent_SaveParams_TypeID {
+		t.Errorf("Id() = %#x; want %#x", n.Id(), uint64(persistent.Persistent_SaveParams_TypeID))
+	}
+}
+
+func TestLoadMissingImport(t *testing.T) {
+	msg, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	req, _ := schema.NewRootCodeGeneratorRequest(seg)
+	req.NewNodes(0)
+	files, _ := req.NewRequestedFiles(1)
+	files.At(0).SetId(0xdeadbeef)
+	files.At(0).SetFilename("a.capnp")
+	imps, _ := files.At(0).NewImports(1)
+	imps.At(0).SetId(0xfeedface)
+	imps.At(0).SetName("b.capnp")
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = New(new(schemas.Registry)).Load(data)
+	if err == nil || !strings.Contains(err.Error(), "b.capnp") {
+		t.Errorf("Load = %v; want error about b.capnp", err)
+	}
+}
+
+func TestLoadMalformed(t *testing.T) {
+	// The first node is fine; the second has a display name prefix
+	// longer than its display name.
+	msg, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	req, _ := schema.NewRootCodeGeneratorRequest(seg)
+	nodes, _ := req.NewNodes(2)
+	nodes.At(0).SetId(0xc0ffee00c0ffee00)
+	nodes.At(0).SetDisplayName("a.capnp:Good")
+	nodes.At(0).SetDisplayNamePrefixLength(8)
+	nodes.At(0).SetScopeId(0xdeadbeefdeadbeef)
+	nodes.At(0).SetStructNode()
+	nodes.At(1).SetId(0xc0ffee00c0ffee01)
+	nodes.At(1).SetDisplayName("ab")
+	nodes.At(1).SetDisplayNamePrefixLength(50)
+	nodes.At(1).SetScopeId(0xdeadbeefdeadbeef)
+	nodes.At(1).SetStructNode()
+	data, err := msg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reg := new(schemas.Registry)
+	l := New(reg)
+	if err := l.Load(data); err == nil {
+		t.Fatal("Load of malformed request succeeded")
+	}
+	if _, err := l.Lookup("a.capnp:Good"); err == nil {
+		t.Error("node from failed Load was indexed")
+	}
+	if _, err := reg.Find(0xc0ffee00c0ffee00); err == nil {
+		t.Error("node from failed Load was registered")
+	}
+}
+
+func TestResolveType(t *testing.T) {
+	l := New(nil)
+	results, err := l.Node(persistent.Persistent_SaveResults_TypeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, _ := results.StructNode().Fields()
+	param, err := fields.At(0).Slot().Type()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Persistent(Text, <unbound>)
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	brand, _ := schema.NewRootBrand(seg)
+	scopes, _ := brand.NewScopes(1)
+	scopes.At(0).SetScopeId(persistent.Persistent_TypeID)
+	binds, _ := scopes.At(0).NewBind(2)
+	bt, _ := binds.At(0).NewType()
+	bt.SetText()
+	binds.At(1).SetUnbound()
+
+	got, err := l.ResolveType(param, brand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Which() != schema.Type_Which_text {
+		t.Errorf("resolved SturdyRef = %v; want text", got.Which())
+	}
+
+	got, err = l.ResolveType(param, schema.Brand{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Which() != schema.Type_Which_anyPointer || got.AnyPointer().Which() != schema.Type_anyPointer_Which_unconstrained {
+		t.Errorf("SturdyRef with empty brand = %v; want unconstrained AnyPointer", got)
+	}
+
+	// List(SturdyRef)
+	lt, _ := schema.NewType(seg)
+	lt.SetList()
+	elem, _ := lt.List().NewElementType()
+	capnp.Struct(elem).CopyFrom(capnp.Struct(param))
+	got, err = l.ResolveType(lt, brand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, _ := got.List().ElementType(); got.Which() != schema.Type_Which_list || e.Which() != schema.Type_Which_text {
+		t.Errorf("resolved List(SturdyRef) = %v; want List(Text)", got)
+	}
+
+	// SaveParams with an inherited brand picks up the outer bindings.
+	st, _ := schema.NewType(seg)
+	st.SetStructType()
+	st.StructType().SetTypeId(persistent.Persistent_SaveParams_TypeID)
+	sb, _ := st.StructType().NewBrand()
+	ss, _ := sb.NewScopes(1)
+	ss.At(0).SetScopeId(persistent.Persistent_TypeID)
+	ss.At(0).SetInherit()
+	got, err = l.ResolveType(st, brand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gb, _ := got.StructType().Brand()
+	gs, _ := gb.Scopes()
+	if gs.Len() != 1 || gs.At(0).Which() != schema.Brand_Scope_Which_bind {
+		t.Fatalf("resolved SaveParams brand = %v; want bound scope", gb)
+	}
+	gbinds, _ := gs.At(0).Bind()
+	if b0, _ := gbinds.At(0).Type(); b0.Which() != schema.Type_Which_text {
+		t.Errorf("SaveParams binding 0 = %v; want text", b0)
+	}
+}
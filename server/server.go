@@ -3,20 +3,48 @@
 package server // import "capnproto.org/go/capnp/v3/server"
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"runtime/debug"
 	"sort"
 	"sync"
 
 	"capnproto.org/go/capnp/v3"
 	"capnproto.org/go/capnp/v3/exc"
 	"capnproto.org/go/capnp/v3/exp/mpsc"
+	"capnproto.org/go/capnp/v3/internal/ctxutil"
+	"capnproto.org/go/capnp/v3/internal/syncutil"
 )
 
 // A Method describes a single capability method on a server object.
 type Method struct {
 	capnp.Method
+
+	// Impl implements the method.  Its Context is canceled if the
+	// caller cancels the call, the caller's connection shuts down or
+	// the server shuts down before Impl returns; long-running methods
+	// should watch it and stop early.  On Go 1.20 and later,
+	// context.Cause reports which happened: an error wrapping
+	// context.Canceled (for a local caller, the caller's own Context
+	// error) if the call was canceled, or an exception of type
+	// exc.Disconnected if the caller or server went away.
 	Impl func(context.Context, *Call) error
+
+	// Validate, if not nil, is called with the call's arguments before
+	// the call is queued.  If it returns an error, the call fails with a
+	// failed exception and Impl is not called.  capnpc-go sets Validate
+	// for methods whose params have constraint annotations.
+	Validate func(capnp.Struct) error
+
+	// Concurrency, if not zero, limits how many calls to this method
+	// run at once, independently of the server's concurrency.  Calls
+	// to a method with Bounded(1) run one at a time, in the order
+	// they were made, while calls to other methods may run alongside
+	// them.  On a Serial server, the method's calls run alongside the
+	// server's serial calls, limited only by Concurrency; on other
+	// servers, they are also subject to the server's limit.
+	Concurrency Concurrency
 }
 
 // Call holds the state of an ongoing capability method call.
@@ -32,6 +60,17 @@ type Call struct {
 	results capnp.Struct
 
 	acked bool
+
+	// release gives back the call's slots under its method's and
+	// the server's concurrency limits.  It is nil for a serial call
+	// and once the slots have been given back.
+	release func()
+}
+
+// Method returns the method being called, including its interface
+// and method names if the server knows them.
+func (c *Call) Method() capnp.Method {
+	return c.method.Method
 }
 
 // Args returns the call's arguments.  Args is not safe to
@@ -64,14 +103,207 @@ func (c *Call) AllocResults(sz capnp.ObjectSize) (capnp.Struct, error) {
 //
 // Go need not be the first call in a function nor is it required.
 // short functions can return without calling Go.
+//
+// For a call that runs concurrently, Go gives back the call's slot
+// under its method's and the server's limits, so that the next call
+// waiting for one can start.  A method that calls back into its own
+// server should call Go first, or the callback may wait for a slot
+// that the method holds.
 func (c *Call) Go() {
 	if c.acked {
+		c.releaseSlots()
 		return
 	}
 	c.acked = true
 	go c.srv.handleCalls(c.srv.handleCallsCtx)
 }
 
+// releaseSlots gives back the call's slots, if it still holds any.
+func (c *Call) releaseSlots() {
+	if c.release != nil {
+		c.release()
+		c.release = nil
+	}
+}
+
+// An Interceptor is called in place of a server method, so that it can
+// act on every call to a Server: logging, checking authorization,
+// recording metrics and the like.  It calls next to continue the call,
+// and may do work before and after.  To reject the call, it returns an
+// error without calling next; the caller sees the error as an
+// exception, so use the exc package to choose the exception type.
+//
+// The *Call is the one passed to the method, and is subject to the
+// same rules.  Interceptors only see calls that reach a method
+// implementation: calls to unknown methods and calls whose arguments
+// fail validation are rejected before any interceptor runs.
+type Interceptor func(ctx context.Context, c *Call, next func(context.Context, *Call) error) error
+
+// An Option configures a Server created by New.
+type Option func(*Server)
+
+// WithInterceptors adds interceptors to a Server.  Each call passes
+// through the interceptors in order, so the first interceptor is the
+// outermost.  WithInterceptors may be given more than once, in which
+// case the interceptors are appended.
+func WithInterceptors(in ...Interceptor) Option {
+	return func(srv *Server) {
+		srv.interceptors = append(srv.interceptors, in...)
+	}
+}
+
+// Concurrency says how many calls a Server runs at once.
+//
+// Whatever the policy, calls start in the order they were made: a call
+// that has to wait for a slot holds up the calls made after it.  This
+// keeps the delivery order that callers rely on (E-order).  The one
+// exception is a call waiting for a slot under its method's own limit,
+// which holds up later calls to that method only.  Calling Call.Go in a
+// call that runs concurrently gives back its slots early.
+type Concurrency int
+
+const (
+	// Serial runs one call at a time, unless the method calls Call.Go
+	// to let the next call start or has its own Concurrency.  This is
+	// the default.
+	Serial Concurrency = 0
+
+	// Unlimited starts every call in its own goroutine as soon as it
+	// arrives.
+	Unlimited Concurrency = -1
+)
+
+// Bounded returns a Concurrency that runs up to n calls at once, each
+// in its own goroutine.  n must be positive.
+func Bounded(n int) Concurrency {
+	if n <= 0 {
+		panic("server.Bounded: n must be positive")
+	}
+	return Concurrency(n)
+}
+
+// WithConcurrency sets how many calls a Server runs at once.  Methods
+// with their own Concurrency are also subject to the server's limit,
+// except on a Serial server, where only their own limit applies.
+func WithConcurrency(c Concurrency) Option {
+	return func(srv *Server) {
+		srv.concurrency = c
+	}
+}
+
+// A semaphore limits how many calls run at once.  Calls get a slot in
+// the order they ask for one.  A nil *semaphore has no limit.
+type semaphore struct {
+	mu      sync.Mutex
+	free    int
+	waiters []chan struct{}
+}
+
+// newSem returns a semaphore for c, or nil if c doesn't limit calls.
+func newSem(c Concurrency) *semaphore {
+	if c <= 0 {
+		return nil
+	}
+	return &semaphore{free: int(c)}
+}
+
+// granted is a closed channel, returned by wait when a slot is free.
+var granted = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// wait takes a place in line for a slot without blocking, and returns
+// a channel that is closed once the slot is the caller's.
+func (sem *semaphore) wait() <-chan struct{} {
+	if sem == nil {
+		return granted
+	}
+	sem.mu.Lock()
+	defer sem.mu.Unlock()
+	if sem.free > 0 && len(sem.waiters) == 0 {
+		sem.free--
+		return granted
+	}
+	ch := make(chan struct{})
+	sem.waiters = append(sem.waiters, ch)
+	return ch
+}
+
+// release gives back a slot, handing it to the first caller in line.
+func (sem *semaphore) release() {
+	if sem == nil {
+		return
+	}
+	sem.mu.Lock()
+	defer sem.mu.Unlock()
+	if len(sem.waiters) == 0 {
+		sem.free++
+		return
+	}
+	close(sem.waiters[0])
+	sem.waiters[0] = nil
+	sem.waiters = sem.waiters[1:]
+}
+
+// A PanicPolicy says what a Server does when a method panics.
+type PanicPolicy int
+
+const (
+	// RecoverPanics recovers the panic and fails the call with a
+	// failed exception whose cause is a *PanicError.  The exception's
+	// message ends with the stack trace of the panic.  This is the
+	// default.
+	RecoverPanics PanicPolicy = iota
+
+	// RethrowPanics lets the panic continue, which usually crashes
+	// the process.
+	RethrowPanics
+
+	// ShutdownOnPanic recovers the panic like RecoverPanics, then
+	// shuts down the server: calls that are queued or made afterward
+	// fail with a disconnected exception, the Contexts of ongoing
+	// calls are canceled, and once they return, the server calls
+	// Shutdown on its Shutdowner.
+	ShutdownOnPanic
+)
+
+// WithPanicPolicy sets what a Server does when a method panics.
+func WithPanicPolicy(p PanicPolicy) Option {
+	return func(srv *Server) {
+		srv.panicPolicy = p
+	}
+}
+
+// ErrorReporter can receive errors from a Server.  It has the same
+// method as rpc.ErrorReporter, so the same reporter can be used for
+// both.  ReportError should be quick to return and must not make calls
+// on the Server.
+type ErrorReporter interface {
+	ReportError(error)
+}
+
+// WithErrorReporter sets a reporter that is given each panic a Server
+// recovers from a method.  The reported error is the one that fails
+// the call.
+func WithErrorReporter(r ErrorReporter) Option {
+	return func(srv *Server) {
+		srv.reporter = r
+	}
+}
+
+// A PanicError describes a panic recovered from a server method.
+type PanicError struct {
+	Method capnp.Method
+	Value  any    // the value passed to panic
+	Stack  []byte // stack trace of the panicking goroutine, from the panic
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic in %v: %v", e.Method, e.Value)
+}
+
 // Shutdowner is the interface that wraps the Shutdown method.
 type Shutdowner interface {
 	Shutdown()
@@ -84,6 +316,27 @@ type Server struct {
 	brand    any
 	shutdown Shutdowner
 
+	// interceptors wrap every method call.  dispatch calls the
+	// method through them.
+	interceptors []Interceptor
+	dispatch     func(context.Context, *Call) error
+
+	panicPolicy PanicPolicy
+	reporter    ErrorReporter
+
+	// concurrency is the server's policy, and sem limits the calls
+	// that run at once under it (nil if unlimited or serial).
+	concurrency Concurrency
+	sem         *semaphore
+
+	// shutdownOnce calls shutdown, once the server stops either
+	// after a panic or because it was released.
+	shutdownOnce sync.Once
+
+	mu         sync.Mutex
+	stopErr    error                   // set once the server has shut down after a panic
+	methodSems map[methodID]*semaphore // for methods with their own concurrency
+
 	// Cancels handleCallsCtx
 	cancelHandleCalls context.CancelFunc
 
@@ -109,7 +362,7 @@ type Server struct {
 // If shutdown is nil then the server's shutdown is a no-op.  The server
 // guarantees message delivery order by blocking each call on the
 // return of the previous call or a call to Call.Go.
-func New(methods []Method, brand any, shutdown Shutdowner) *Server {
+func New(methods []Method, brand any, shutdown Shutdowner, opts ...Option) *Server {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	srv := &Server{
@@ -122,6 +375,11 @@ func New(methods []Method, brand any, shutdown Shutdowner) *Server {
 	}
 	copy(srv.methods, methods)
 	sort.Sort(srv.methods)
+	for _, opt := range opts {
+		opt(srv)
+	}
+	srv.dispatch = chainInterceptors(srv.interceptors)
+	srv.sem = newSem(srv.concurrency)
 	go srv.handleCalls(ctx)
 	return srv
 }
@@ -135,10 +393,19 @@ func (srv *Server) Send(ctx context.Context, s capnp.Send) (*capnp.Answer, capnp
 	if mm == nil {
 		return capnp.ErrorAnswer(s.Method, capnp.Unimplemented("unimplemented")), func() {}
 	}
+	if err := srv.stopped(); err != nil {
+		return capnp.ErrorAnswer(mm.Method, err), func() {}
+	}
 	args, err := sendArgsToStruct(s)
 	if err != nil {
 		return capnp.ErrorAnswer(mm.Method, err), func() {}
 	}
+	if err := mm.validate(args); err != nil {
+		if msg := args.Message(); msg != nil {
+			msg.Reset(nil)
+		}
+		return capnp.ErrorAnswer(mm.Method, err), func() {}
+	}
 	ret := new(structReturner)
 	pcaller := srv.start(ctx, mm, capnp.Recv{
 		Method: mm.Method, // pick up names from server method
@@ -164,6 +431,14 @@ func (srv *Server) Recv(ctx context.Context, r capnp.Recv) capnp.PipelineCaller
 		r.Reject(capnp.Unimplemented("unimplemented"))
 		return nil
 	}
+	if err := srv.stopped(); err != nil {
+		r.Reject(err)
+		return nil
+	}
+	if err := mm.validate(r.Args); err != nil {
+		r.Reject(err)
+		return nil
+	}
 	return srv.start(ctx, mm, r)
 }
 
@@ -186,16 +461,44 @@ func (srv *Server) handleCalls(ctx context.Context) {
 		// (ctx); we need to monitor both and pass the call a
 		// context that will be canceled if *either* context is
 		// cancelled.
-		callCtx, cancelCall := context.WithCancel(call.ctx)
+		callCtx, cancelCall := ctxutil.WithCancelCause(call.ctx)
 		go func() {
-			defer cancelCall()
 			select {
 			case <-callCtx.Done():
 			case <-ctx.Done():
+				cancelCall(errShutdown)
 			}
 		}()
+		if msem, ssem, ok := srv.concurrentSems(call.method); ok {
+			// Take the call's place in line now, so that calls
+			// start in order, but wait for the slots in the
+			// call's own goroutine: a call held up by its
+			// method's limit mustn't hold up calls to other
+			// methods.
+			mslot := msem.wait()
+			var sslot <-chan struct{}
+			if msem == nil {
+				sslot = ssem.wait()
+			}
+			call.acked = true
+			call.release = func() {
+				ssem.release()
+				msem.release()
+			}
+			go func() {
+				defer cancelCall(nil)
+				<-mslot
+				if sslot == nil {
+					sslot = ssem.wait()
+				}
+				<-sslot
+				srv.handleCall(callCtx, call)
+				call.releaseSlots()
+			}()
+			continue
+		}
 		func() {
-			defer cancelCall()
+			defer cancelCall(nil)
 			srv.handleCall(callCtx, call)
 		}()
 
@@ -207,10 +510,41 @@ func (srv *Server) handleCalls(ctx context.Context) {
 	}
 }
 
+type methodID struct {
+	interfaceID uint64
+	methodID    uint16
+}
+
+// concurrentSems reports whether calls to m run concurrently and, if
+// so, returns the semaphores for the method's and the server's limits.
+// A nil semaphore means no limit.
+func (srv *Server) concurrentSems(m *Method) (msem, ssem *semaphore, ok bool) {
+	if m.Concurrency == Serial && srv.concurrency == Serial {
+		return nil, nil, false
+	}
+	if m.Concurrency > 0 {
+		srv.mu.Lock()
+		id := methodID{m.InterfaceID, m.MethodID}
+		msem = srv.methodSems[id]
+		if msem == nil {
+			if srv.methodSems == nil {
+				srv.methodSems = make(map[methodID]*semaphore)
+			}
+			msem = newSem(m.Concurrency)
+			srv.methodSems[id] = msem
+		}
+		srv.mu.Unlock()
+	}
+	return msem, srv.sem, true
+}
+
 func (srv *Server) handleCall(ctx context.Context, c *Call) {
 	defer srv.wg.Done()
 
-	err := c.method.Impl(ctx, c)
+	err := srv.stopped()
+	if err == nil {
+		err = srv.invoke(ctx, c)
+	}
 
 	c.recv.ReleaseArgs()
 	c.recv.Returner.PrepareReturn(err)
@@ -223,8 +557,129 @@ func (srv *Server) handleCall(ctx context.Context, c *Call) {
 	c.recv.Returner.ReleaseResults()
 }
 
+// invoke calls the method for c, handling a panic according to the
+// server's panic policy.
+func (srv *Server) invoke(ctx context.Context, c *Call) (err error) {
+	if srv.panicPolicy == RethrowPanics {
+		return srv.dispatch(ctx, c)
+	}
+	// recover returns nil after panic(nil), so check whether dispatch
+	// returned instead.
+	returned := false
+	defer func() {
+		if !returned {
+			err = srv.recovered(c, recover())
+		}
+	}()
+	err = srv.dispatch(ctx, c)
+	returned = true
+	return err
+}
+
+// recovered handles the panic value x from the method for c and returns
+// the error to fail the call with.
+func (srv *Server) recovered(c *Call, x any) error {
+	pe := &PanicError{
+		Method: c.Method(),
+		Value:  x,
+		Stack:  trimStack(debug.Stack()),
+	}
+	err := exc.Annotator("capnp server").Failed(fmt.Errorf("%w\n%s", pe, pe.Stack))
+	if srv.reporter != nil {
+		srv.reporter.ReportError(err)
+	}
+	if srv.panicPolicy == ShutdownOnPanic {
+		srv.stop(exc.Annotator("capnp server").WrapDisconnected("shut down", pe))
+	}
+	return err
+}
+
+// stop shuts the server down after a panic: it rejects new calls with
+// err, stops handling queued calls and cancels ongoing ones.  Once the
+// ongoing calls have returned, it calls the Shutdowner.
+func (srv *Server) stop(err error) {
+	first := false
+	syncutil.With(&srv.mu, func() {
+		if srv.stopErr == nil {
+			srv.stopErr = err
+			first = true
+		}
+	})
+	if !first {
+		return
+	}
+	srv.cancelHandleCalls()
+	go func() {
+		// No calls start once stopErr is set, so this can't race
+		// with wg.Add.
+		srv.wg.Wait()
+		srv.shutdownOnce.Do(srv.callShutdowner)
+	}()
+}
+
+// trimStack removes the frames for the recovery from a stack trace taken
+// in a deferred function, so that it starts at the function that
+// panicked.  It returns stack unchanged if it has no panic frame.
+func trimStack(stack []byte) []byte {
+	header := bytes.IndexByte(stack, '\n') + 1
+	i := bytes.LastIndex(stack, []byte("\npanic("))
+	if header == 0 || i < 0 {
+		return stack
+	}
+	// Skip the panic frame's function and file lines.
+	rest := stack[i+1:]
+	for n := 0; n < 2; n++ {
+		j := bytes.IndexByte(rest, '\n')
+		if j < 0 {
+			return stack
+		}
+		rest = rest[j+1:]
+	}
+	trimmed := make([]byte, 0, header+len(rest))
+	trimmed = append(trimmed, stack[:header]...)
+	return append(trimmed, rest...)
+}
+
+func (srv *Server) callShutdowner() {
+	if srv.shutdown != nil {
+		srv.shutdown.Shutdown()
+	}
+}
+
+// stopped returns the error to fail calls with if the server has shut
+// down after a panic, or nil.
+func (srv *Server) stopped() error {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.stopErr
+}
+
+// chainInterceptors returns a function that calls a method through
+// each of ins in turn.
+func chainInterceptors(ins []Interceptor) func(context.Context, *Call) error {
+	next := callImpl
+	for i := len(ins) - 1; i >= 0; i-- {
+		in, inner := ins[i], next
+		next = func(ctx context.Context, c *Call) error {
+			return in(ctx, c, inner)
+		}
+	}
+	return next
+}
+
+func callImpl(ctx context.Context, c *Call) error {
+	return c.method.Impl(ctx, c)
+}
+
 func (srv *Server) start(ctx context.Context, m *Method, r capnp.Recv) capnp.PipelineCaller {
+	srv.mu.Lock()
+	if err := srv.stopErr; err != nil {
+		srv.mu.Unlock()
+		r.Reject(err)
+		return nil
+	}
 	srv.wg.Add(1)
+	srv.mu.Unlock()
 
 	aq := newAnswerQueue(r.Method)
 	srv.callQueue.Send(&Call{
@@ -248,9 +703,7 @@ func (srv *Server) Brand() capnp.Brand {
 func (srv *Server) Shutdown() {
 	srv.cancelHandleCalls()
 	srv.wg.Wait()
-	if srv.shutdown != nil {
-		srv.shutdown.Shutdown()
-	}
+	srv.shutdownOnce.Do(srv.callShutdowner)
 }
 
 // IsServer reports whether a brand returned by capnp.Client.Brand
@@ -293,6 +746,17 @@ func newBlankStruct(sz capnp.ObjectSize) (capnp.Struct, error) {
 	return st, nil
 }
 
+// validate checks args with m.Validate, if set.
+func (m *Method) validate(args capnp.Struct) error {
+	if m.Validate == nil {
+		return nil
+	}
+	if err := m.Validate(args); err != nil {
+		return exc.Annotator("capnp server").WrapFailed("invalid arguments", err)
+	}
+	return nil
+}
+
 type sortedMethods []Method
 
 // find returns the method with the given ID or nil.
@@ -333,6 +797,10 @@ type resultsAllocer interface {
 	AllocResults(capnp.ObjectSize) (capnp.Struct, error)
 }
 
+// errShutdown is the cancellation cause of the Contexts of calls that
+// are running or queued when the Server shuts down.
+var errShutdown = exc.New(exc.Disconnected, "capnp server", "server shut down")
+
 func newError(msg string) error {
 	return exc.New(exc.Failed, "capnp server", msg)
 }
@@ -7,11 +7,14 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/exc"
 	air "capnproto.org/go/capnp/v3/internal/aircraftlib"
+	"capnproto.org/go/capnp/v3/internal/ctxutil"
 	"capnproto.org/go/capnp/v3/server"
 
 	"github.com/stretchr/testify/assert"
@@ -137,6 +140,472 @@ func TestServerCall(t *testing.T) {
 	})
 }
 
+func TestServerValidate(t *testing.T) {
+	t.Parallel()
+
+	var called int32
+	methods := air.Echo_Methods(nil, echoImpl{})
+	impl := methods[0].Impl
+	methods[0].Impl = func(ctx context.Context, call *server.Call) error {
+		atomic.StoreInt32(&called, 1)
+		return impl(ctx, call)
+	}
+	methods[0].Validate = func(p capnp.Struct) error {
+		if in, _ := air.Echo_echo_Params(p).In(); in == "" {
+			return &capnp.ValidationError{Field: "in", Msg: "required"}
+		}
+		return nil
+	}
+	echo := air.Echo(capnp.NewClient(server.New(methods, nil, nil)))
+	defer echo.Release()
+
+	t.Run("Valid", func(t *testing.T) {
+		ans, finish := echo.Echo(context.Background(), func(p air.Echo_echo_Params) error {
+			return p.SetIn("foo")
+		})
+		defer finish()
+		result, err := ans.Struct()
+		require.NoError(t, err)
+		out, _ := result.Out()
+		assert.Equal(t, "foofoo", out)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		atomic.StoreInt32(&called, 0)
+		ans, finish := echo.Echo(context.Background(), func(p air.Echo_echo_Params) error {
+			return nil
+		})
+		defer finish()
+		_, err := ans.Struct()
+		require.Error(t, err)
+		assert.Equal(t, exc.Failed, exc.TypeOf(err), "error type")
+		assert.True(t, capnp.IsValidation(err), "IsValidation(%v)", err)
+		assert.Contains(t, err.Error(), "validate in: required")
+		assert.Zero(t, atomic.LoadInt32(&called), "Impl called for invalid arguments")
+	})
+}
+
+func TestServerInterceptors(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu  sync.Mutex
+		log []string
+	)
+	record := func(name string) server.Interceptor {
+		return func(ctx context.Context, c *server.Call, next func(context.Context, *server.Call) error) error {
+			m := c.Method()
+			mu.Lock()
+			log = append(log, name+" "+m.InterfaceName+"."+m.MethodName)
+			mu.Unlock()
+			err := next(ctx, c)
+			mu.Lock()
+			log = append(log, name+" done")
+			mu.Unlock()
+			return err
+		}
+	}
+	reject := func(ctx context.Context, c *server.Call, next func(context.Context, *server.Call) error) error {
+		in, _ := air.Echo_echo_Params(c.Args()).In()
+		if in == "secret" {
+			return exc.New(exc.Unimplemented, "auth", "permission denied")
+		}
+		return next(ctx, c)
+	}
+	srv := air.Echo_NewServer(echoImpl{},
+		server.WithInterceptors(record("a"), record("b")),
+		server.WithInterceptors(reject))
+	echo := air.Echo(capnp.NewClient(srv))
+	defer echo.Release()
+
+	t.Run("Order", func(t *testing.T) {
+		ans, finish := echo.Echo(context.Background(), func(p air.Echo_echo_Params) error {
+			return p.SetIn("foo")
+		})
+		defer finish()
+		result, err := ans.Struct()
+		require.NoError(t, err)
+		out, _ := result.Out()
+		assert.Equal(t, "foofoo", out)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{
+			"a aircraft.capnp:Echo.echo",
+			"b aircraft.capnp:Echo.echo",
+			"b done",
+			"a done",
+		}, log)
+		log = nil
+	})
+	t.Run("Reject", func(t *testing.T) {
+		ans, finish := echo.Echo(context.Background(), func(p air.Echo_echo_Params) error {
+			return p.SetIn("secret")
+		})
+		defer finish()
+		_, err := ans.Struct()
+		require.Error(t, err)
+		assert.Equal(t, exc.Unimplemented, exc.TypeOf(err), "error type")
+		assert.Contains(t, err.Error(), "permission denied")
+	})
+}
+
+type panicEchoImpl struct{}
+
+func (panicEchoImpl) Echo(_ context.Context, call air.Echo_echo) error {
+	if in, _ := call.Args().In(); in == "panic" {
+		panic("echo broke")
+	}
+	return echoImpl{}.Echo(context.Background(), call)
+}
+
+type errorList struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (el *errorList) ReportError(err error) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+	el.errs = append(el.errs, err)
+}
+
+func callEcho(echo air.Echo, in string) (string, error) {
+	ans, finish := echo.Echo(context.Background(), func(p air.Echo_echo_Params) error {
+		return p.SetIn(in)
+	})
+	defer finish()
+	result, err := ans.Struct()
+	if err != nil {
+		return "", err
+	}
+	return result.Out()
+}
+
+func TestServerPanic(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Recover", func(t *testing.T) {
+		reporter := new(errorList)
+		echo := air.Echo(capnp.NewClient(air.Echo_NewServer(panicEchoImpl{},
+			server.WithErrorReporter(reporter))))
+		defer echo.Release()
+
+		_, err := callEcho(echo, "panic")
+		require.Error(t, err)
+		assert.Equal(t, exc.Failed, exc.TypeOf(err), "error type")
+		var pe *server.PanicError
+		require.True(t, errors.As(err, &pe), "error %v is not a *PanicError", err)
+		assert.Equal(t, "echo broke", pe.Value)
+		assert.Equal(t, "echo", pe.Method.MethodName)
+		assert.Contains(t, string(pe.Stack), "panicEchoImpl")
+
+		// The caller and the reporter see the stack, starting at the
+		// panic.
+		assert.Contains(t, err.Error(), "panicEchoImpl")
+		assert.NotContains(t, string(pe.Stack), "runtime/debug.Stack")
+		reporter.mu.Lock()
+		require.Len(t, reporter.errs, 1, "reported errors")
+		rerr := reporter.errs[0]
+		reporter.mu.Unlock()
+		assert.True(t, errors.As(rerr, &pe), "reported error %v is not a *PanicError", rerr)
+		assert.Contains(t, rerr.Error(), "echo broke")
+		assert.Contains(t, rerr.Error(), "panicEchoImpl")
+
+		// The server keeps serving.
+		out, err := callEcho(echo, "foo")
+		require.NoError(t, err)
+		assert.Equal(t, "foofoo", out)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		methods := air.Echo_Methods(nil, echoImpl{})
+		methods[0].Impl = func(context.Context, *server.Call) error {
+			panic(nil)
+		}
+		echo := air.Echo(capnp.NewClient(server.New(methods, nil, nil)))
+		defer echo.Release()
+
+		_, err := callEcho(echo, "foo")
+		require.Error(t, err)
+		assert.Equal(t, exc.Failed, exc.TypeOf(err), "error type")
+		var pe *server.PanicError
+		assert.True(t, errors.As(err, &pe), "error %v is not a *PanicError", err)
+	})
+	t.Run("Shutdown", func(t *testing.T) {
+		sd := &countShutdowns{done: make(chan struct{}, 2)}
+		methods := air.Echo_Methods(nil, panicEchoImpl{})
+		echo := air.Echo(capnp.NewClient(server.New(methods, nil, sd,
+			server.WithPanicPolicy(server.ShutdownOnPanic))))
+
+		_, err := callEcho(echo, "panic")
+		require.Error(t, err)
+		assert.Equal(t, exc.Failed, exc.TypeOf(err), "error type")
+
+		// The server shuts down without waiting to be released.
+		select {
+		case <-sd.done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Shutdowner not called after panic")
+		}
+
+		_, err = callEcho(echo, "foo")
+		require.Error(t, err)
+		assert.Equal(t, exc.Disconnected, exc.TypeOf(err), "error type after shutdown")
+		assert.Contains(t, err.Error(), "echo broke")
+
+		echo.Release()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&sd.n), "Shutdowner calls")
+	})
+	t.Run("ShutdownCancelsCalls", func(t *testing.T) {
+		started := make(chan struct{})
+		causes := make(chan error, 1)
+		methods := air.Echo_Methods(nil, panicEchoImpl{})
+		impl := methods[0].Impl
+		methods[0].Impl = func(ctx context.Context, call *server.Call) error {
+			if in, _ := air.Echo_echo_Params(call.Args()).In(); in == "wait" {
+				close(started)
+				<-ctx.Done()
+				causes <- ctxutil.Cause(ctx)
+				return ctx.Err()
+			}
+			return impl(ctx, call)
+		}
+		echo := air.Echo(capnp.NewClient(server.New(methods, nil, nil,
+			server.WithPanicPolicy(server.ShutdownOnPanic),
+			server.WithConcurrency(server.Unlimited))))
+		defer echo.Release()
+
+		waitAns, finish := echo.Echo(context.Background(), func(p air.Echo_echo_Params) error {
+			return p.SetIn("wait")
+		})
+		defer finish()
+		<-started
+		_, err := callEcho(echo, "panic")
+		require.Error(t, err)
+
+		select {
+		case cause := <-causes:
+			assert.Equal(t, exc.Disconnected, exc.TypeOf(cause), "cause %v", cause)
+		case <-time.After(5 * time.Second):
+			t.Fatal("ongoing call not canceled after panic")
+		}
+		_, err = waitAns.Struct()
+		assert.Error(t, err)
+	})
+}
+
+type countShutdowns struct {
+	n    int32
+	done chan struct{}
+}
+
+func (sd *countShutdowns) Shutdown() {
+	atomic.AddInt32(&sd.n, 1)
+	sd.done <- struct{}{}
+}
+
+type blockingEcho struct {
+	started chan struct{}
+	release chan struct{}
+	running int32
+	max     int32
+}
+
+func newBlockingEcho() *blockingEcho {
+	return &blockingEcho{
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+}
+
+func (be *blockingEcho) Echo(ctx context.Context, call air.Echo_echo) error {
+	n := atomic.AddInt32(&be.running, 1)
+	for {
+		max := atomic.LoadInt32(&be.max)
+		if n <= max || atomic.CompareAndSwapInt32(&be.max, max, n) {
+			break
+		}
+	}
+	be.started <- struct{}{}
+	<-be.release
+	atomic.AddInt32(&be.running, -1)
+	return echoImpl{}.Echo(ctx, call)
+}
+
+func TestServerConcurrency(t *testing.T) {
+	t.Parallel()
+
+	run := func(t *testing.T, c, mc server.Concurrency, calls, wantRunning int) {
+		be := newBlockingEcho()
+		methods := air.Echo_Methods(nil, be)
+		methods[0].Concurrency = mc
+		echo := air.Echo(capnp.NewClient(server.New(methods, be, nil, server.WithConcurrency(c))))
+		defer echo.Release()
+
+		var finishes []capnp.ReleaseFunc
+		var answers []air.Echo_echo_Results_Future
+		for i := 0; i < calls; i++ {
+			ans, finish := echo.Echo(context.Background(), func(p air.Echo_echo_Params) error {
+				return p.SetIn("x")
+			})
+			answers = append(answers, ans)
+			finishes = append(finishes, finish)
+		}
+		for i := 0; i < wantRunning; i++ {
+			select {
+			case <-be.started:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("only %d of %d calls started", i, wantRunning)
+			}
+		}
+		select {
+		case <-be.started:
+			t.Errorf("more than %d calls running at once", wantRunning)
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(be.release)
+		for i, ans := range answers {
+			_, err := ans.Struct()
+			assert.NoError(t, err, "call %d", i)
+			finishes[i]()
+		}
+		assert.Equal(t, int32(wantRunning), atomic.LoadInt32(&be.max), "most calls running at once")
+	}
+	t.Run("Bounded", func(t *testing.T) {
+		run(t, server.Bounded(2), server.Serial, 4, 2)
+	})
+	t.Run("Unlimited", func(t *testing.T) {
+		run(t, server.Unlimited, server.Serial, 4, 4)
+	})
+	t.Run("Serial", func(t *testing.T) {
+		run(t, server.Serial, server.Serial, 3, 1)
+	})
+	t.Run("SerialBoundedMethod", func(t *testing.T) {
+		// A Serial server leaves the limit to the method.
+		run(t, server.Serial, server.Bounded(2), 3, 2)
+	})
+	t.Run("BoundedBoundedMethod", func(t *testing.T) {
+		// Otherwise, both limits apply.
+		run(t, server.Bounded(1), server.Bounded(2), 3, 1)
+		run(t, server.Bounded(3), server.Bounded(2), 4, 2)
+	})
+}
+
+func TestServerMethodConcurrency(t *testing.T) {
+	t.Parallel()
+
+	// Calls to a method with Bounded(1) run in order, even on a server
+	// that otherwise runs calls concurrently.
+	seq := new(callSeq)
+	methods := air.CallSequence_Methods(nil, seq)
+	methods[0].Concurrency = server.Bounded(1)
+	client := air.CallSequence(capnp.NewClient(server.New(methods, seq, nil,
+		server.WithConcurrency(server.Unlimited))))
+	defer client.Release()
+
+	const n = 20
+	var futures []air.CallSequence_getNumber_Results_Future
+	for i := 0; i < n; i++ {
+		ans, finish := client.GetNumber(context.Background(), nil)
+		defer finish()
+		futures = append(futures, ans)
+	}
+	for i, ans := range futures {
+		r, err := ans.Struct()
+		require.NoError(t, err, "call %d", i)
+		assert.Equal(t, uint32(i), r.N(), "call %d", i)
+	}
+}
+
+func TestServerMethodConcurrencyReentrant(t *testing.T) {
+	t.Parallel()
+
+	run := func(t *testing.T, goFirst bool, opts ...server.Option) {
+		// newPipeliner calls back into its own server for a number.
+		var self air.Pipeliner
+		methods := air.Pipeliner_Methods(nil, &pipeliner{})
+		methods[0].Concurrency = server.Bounded(1)
+		methods[0].Impl = func(ctx context.Context, call *server.Call) error {
+			if goFirst {
+				call.Go()
+			}
+			ans, finish := self.GetNumber(ctx, nil)
+			defer finish()
+			_, err := ans.Struct()
+			return err
+		}
+		self = air.Pipeliner(capnp.NewClient(server.New(methods, nil, nil, opts...)))
+		defer self.Release()
+
+		// The second call waits for the first, which waits for its
+		// callback; the callback must not wait for the second call.
+		var futures []air.Pipeliner_newPipeliner_Results_Future
+		for i := 0; i < 2; i++ {
+			ans, finish := self.NewPipeliner(context.Background(), nil)
+			defer finish()
+			futures = append(futures, ans)
+		}
+		for i, ans := range futures {
+			select {
+			case <-ans.Done():
+			case <-time.After(5 * time.Second):
+				t.Fatalf("call %d deadlocked", i)
+			}
+			_, err := ans.Struct()
+			assert.NoError(t, err, "call %d", i)
+		}
+	}
+	t.Run("Serial", func(t *testing.T) {
+		run(t, false)
+	})
+	t.Run("Unlimited", func(t *testing.T) {
+		run(t, false, server.WithConcurrency(server.Unlimited))
+	})
+	t.Run("BoundedServer", func(t *testing.T) {
+		// The server's only slot is the method's; Go gives it back.
+		run(t, true, server.WithConcurrency(server.Bounded(1)))
+	})
+}
+
+func TestServerCancelCause(t *testing.T) {
+	t.Parallel()
+
+	newWaiter := func() (air.Echo, chan struct{}, chan error) {
+		started := make(chan struct{}, 1)
+		causes := make(chan error, 1)
+		methods := air.Echo_Methods(nil, echoImpl{})
+		methods[0].Impl = func(ctx context.Context, call *server.Call) error {
+			started <- struct{}{}
+			<-ctx.Done()
+			causes <- ctxutil.Cause(ctx)
+			return ctx.Err()
+		}
+		return air.Echo(capnp.NewClient(server.New(methods, nil, nil))), started, causes
+	}
+
+	t.Run("Caller", func(t *testing.T) {
+		echo, started, causes := newWaiter()
+		defer echo.Release()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		_, finish := echo.Echo(ctx, nil)
+		defer finish()
+		<-started
+		cancel()
+		cause := <-causes
+		assert.ErrorIs(t, cause, context.Canceled)
+	})
+	t.Run("Shutdown", func(t *testing.T) {
+		echo, started, causes := newWaiter()
+
+		_, finish := echo.Echo(context.Background(), nil)
+		defer finish()
+		<-started
+		echo.Release()
+		cause := <-causes
+		assert.Equal(t, exc.Disconnected, exc.TypeOf(cause), "cause %v", cause)
+	})
+}
+
 type callSeq uint32
 
 func (seq *callSeq) GetNumber(ctx context.Context, call air.CallSequence_getNumber) error {
@@ -0,0 +1,3 @@
+//go:generate capnp compile -I ../std -ogo signing.capnp
+
+package signing
@@ -0,0 +1,168 @@
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package signing
+
+import (
+	capnp "capnproto.org/go/capnp/v3"
+	text "capnproto.org/go/capnp/v3/encoding/text"
+	schemas "capnproto.org/go/capnp/v3/schemas"
+)
+
+type Envelope capnp.Struct
+
+// Envelope_TypeID is the unique identifier for the type Envelope.
+const Envelope_TypeID = 0xe580c3b98a3a61ab
+
+func NewEnvelope(s *capnp.Segment) (Envelope, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return Envelope(st), err
+}
+
+func NewRootEnvelope(s *capnp.Segment) (Envelope, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return Envelope(st), err
+}
+
+func ReadRootEnvelope(msg *capnp.Message) (Envelope, error) {
+	root, err := msg.Root()
+	return Envelope(root.Struct()), err
+}
+
+func (s Envelope) String() string {
+	str, _ := text.Marshal(0xe580c3b98a3a61ab, capnp.Struct(s))
+	return str
+}
+
+func (s Envelope) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Envelope) DecodeFromPtr(p capnp.Ptr) Envelope {
+	return Envelope(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Envelope) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Envelope) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Envelope) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Envelope) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Envelope) Content() (capnp.Struct, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Struct(), err
+}
+
+func (s Envelope) HasContent() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+// DisownContent detaches the content field from s without
+// copying it.  The field is left null.
+func (s Envelope) DisownContent() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptContent sets the content field to an orphan from
+// s's message without copying it.
+func (s Envelope) AdoptContent(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
+func (s Envelope) SetContent(v capnp.Struct) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+func (s Envelope) PublicKey() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s Envelope) HasPublicKey() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+// DisownPublicKey detaches the publicKey field from s without
+// copying it.  The field is left null.
+func (s Envelope) DisownPublicKey() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptPublicKey sets the publicKey field to an orphan from
+// s's message without copying it.
+func (s Envelope) AdoptPublicKey(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
+func (s Envelope) SetPublicKey(v []byte) error {
+	return capnp.Struct(s).SetData(1, v)
+}
+
+func (s Envelope) Signature() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s Envelope) HasSignature() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+// DisownSignature detaches the signature field from s without
+// copying it.  The field is left null.
+func (s Envelope) DisownSignature() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptSignature sets the signature field to an orphan from
+// s's message without copying it.
+func (s Envelope) AdoptSignature(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
+func (s Envelope) SetSignature(v []byte) error {
+	return capnp.Struct(s).SetData(2, v)
+}
+
+// Envelope_List is a list of Envelope.
+type Envelope_List = capnp.StructList[Envelope]
+
+// NewEnvelope creates a new list of Envelope.
+func NewEnvelope_List(s *capnp.Segment, sz int32) (Envelope_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return capnp.StructList[Envelope](l), err
+}
+
+// Envelope_Future is a wrapper for a Envelope promised by a client call.
+type Envelope_Future struct{ *capnp.Future }
+
+func (f Envelope_Future) Struct() (Envelope, error) {
+	p, err := f.Future.Ptr()
+	return Envelope(p.Struct()), err
+}
+func (p Envelope_Future) Content() *capnp.Future {
+	return p.Future.Field(0, nil)
+}
+
+const schema_e4c977815219f03d = "x\xda4\xcd1J\xc4@\x14\x06\xe0\xff\x7f3\xab\x8d" +
+	"\x11gI\xe3!\x14l\x17\xc4EXP\xb4\xf0\x05K" +
+	"\x9b\x18\x86\x10\x08\x93A\x13\xc5Jm\xbd\x8a\x85\xb56" +
+	"\x16\x92\xc2{h\xef\x0dF\"\xd8~\xcd\xb7\x15\x97v" +
+	"/{'D\xf3\xd9Zz.\x17O\xaf\x1f\x0f\xdfp" +
+	"\x9bL\xfb?\xdb\xc5\xe3\xed\xe7\x17ff\x1dp\xe3\x1b" +
+	"\xe8\xc6\x17\xec\xa4\xeb\xa6\x0eM\xa8wY\x951\xc4\xc5" +
+	"*\x1c\xdc\xf8\xb6\x8b^7\x8c\x05,\x01\xb7:\x04t" +
+	"i\xa8\xa7BG\xe6\x9c\xf0\xb8\x00\xf4\xc8P\xcf\x85N" +
+	"$\xa7\x00N'<3\xd4\x0b\xe1}\xd5\x85\xde\x87\x9e" +
+	"sK\x90s0\xc5\xe1\xb2m\xaa\x13\x0f\xde1\x830" +
+	"\x03\xff\xfe\xb2\x1f\xae@\xffo\xbf\x03\x00\x87c.]"
+
+func init() {
+	schemas.Register(schema_e4c977815219f03d,
+		0xe580c3b98a3a61ab)
+}
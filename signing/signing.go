@@ -0,0 +1,113 @@
+// Package signing computes stable digests of Cap'n Proto structs and
+// signs them with Ed25519.
+//
+// Digests and signatures are computed over the canonical encoding of a
+// struct (see capnp.CanonicalizePtr), so they do not depend on how the
+// struct happens to be laid out in its message.  Two equal structs
+// always have the same digest, which makes digests suitable as keys for
+// content-addressable storage.  Structs that contain capabilities have
+// no canonical encoding and are rejected.
+//
+// Signed structs are stored in an Envelope, defined in signing.capnp,
+// which carries the content, the signer's public key and the signature.
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"errors"
+	"hash"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/exc"
+)
+
+var signingerr = exc.Annotator("signing")
+
+var canonicalOptions = &capnp.CanonicalOptions{Caps: capnp.CanonicalCapError}
+
+// canonicalize returns the canonical encoding of s.
+func canonicalize(s capnp.Struct) ([]byte, error) {
+	return capnp.CanonicalizePtr(s.ToPtr(), canonicalOptions)
+}
+
+// Digest writes the canonical encoding of s to h and returns the
+// resulting hash.  h is reset before use.
+func Digest(h hash.Hash, s capnp.Struct) ([]byte, error) {
+	b, err := canonicalize(s)
+	if err != nil {
+		return nil, signingerr.Annotate(err, "digest")
+	}
+	h.Reset()
+	h.Write(b)
+	return h.Sum(nil), nil
+}
+
+// SHA256 returns the SHA-256 hash of the canonical encoding of s.
+func SHA256(s capnp.Struct) ([sha256.Size]byte, error) {
+	b, err := canonicalize(s)
+	if err != nil {
+		return [sha256.Size]byte{}, signingerr.Annotate(err, "digest")
+	}
+	return sha256.Sum256(b), nil
+}
+
+// Sign signs content with key and returns a new Envelope, allocated in
+// seg, that holds a copy of content along with the signature.
+func Sign(seg *capnp.Segment, content capnp.Struct, key ed25519.PrivateKey) (Envelope, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return Envelope{}, signingerr.Failed(errors.New("sign: invalid private key size"))
+	}
+	b, err := canonicalize(content)
+	if err != nil {
+		return Envelope{}, signingerr.Annotate(err, "sign")
+	}
+	e, err := NewEnvelope(seg)
+	if err != nil {
+		return Envelope{}, signingerr.Annotate(err, "sign")
+	}
+	if err := e.SetContent(content); err != nil {
+		return Envelope{}, signingerr.Annotate(err, "sign")
+	}
+	if err := e.SetPublicKey(key.Public().(ed25519.PublicKey)); err != nil {
+		return Envelope{}, signingerr.Annotate(err, "sign")
+	}
+	if err := e.SetSignature(ed25519.Sign(key, b)); err != nil {
+		return Envelope{}, signingerr.Annotate(err, "sign")
+	}
+	return e, nil
+}
+
+// Verify checks that e's content was signed by the holder of the
+// private key corresponding to key, and returns the content if so.
+// The public key stored in the envelope must match key; it is never
+// trusted on its own.
+func Verify(e Envelope, key ed25519.PublicKey) (capnp.Struct, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return capnp.Struct{}, signingerr.Failed(errors.New("verify: invalid public key size"))
+	}
+	pub, err := e.PublicKey()
+	if err != nil {
+		return capnp.Struct{}, signingerr.Annotate(err, "verify")
+	}
+	if !bytes.Equal(pub, key) {
+		return capnp.Struct{}, signingerr.Failed(errors.New("verify: envelope signed with a different key"))
+	}
+	sig, err := e.Signature()
+	if err != nil {
+		return capnp.Struct{}, signingerr.Annotate(err, "verify")
+	}
+	content, err := e.Content()
+	if err != nil {
+		return capnp.Struct{}, signingerr.Annotate(err, "verify")
+	}
+	b, err := canonicalize(content)
+	if err != nil {
+		return capnp.Struct{}, signingerr.Annotate(err, "verify")
+	}
+	if !ed25519.Verify(key, b, sig) {
+		return capnp.Struct{}, signingerr.Failed(errors.New("verify: invalid signature"))
+	}
+	return content, nil
+}
@@ -0,0 +1,144 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+	"capnproto.org/go/capnp/v3/exc"
+	air "capnproto.org/go/capnp/v3/internal/aircraftlib"
+)
+
+func newZ(t *testing.T, arena capnp.Arena) air.Z {
+	t.Helper()
+	_, seg, err := capnp.NewMessage(arena)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := air.NewRootZ(seg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := z.SetText("hello, world"); err != nil {
+		t.Fatal(err)
+	}
+	return z
+}
+
+func TestDigest(t *testing.T) {
+	t.Parallel()
+
+	single, err := SHA256(capnp.Struct(newZ(t, capnp.SingleSegment(nil))))
+	if err != nil {
+		t.Fatal("SHA256(single segment):", err)
+	}
+	multi, err := SHA256(capnp.Struct(newZ(t, capnp.MultiSegment(nil))))
+	if err != nil {
+		t.Fatal("SHA256(multi segment):", err)
+	}
+	if single != multi {
+		t.Errorf("SHA256 differs by layout: %x != %x", single, multi)
+	}
+
+	d, err := Digest(sha256.New(), capnp.Struct(newZ(t, capnp.SingleSegment(nil))))
+	if err != nil {
+		t.Fatal("Digest:", err)
+	}
+	if !bytes.Equal(d, single[:]) {
+		t.Errorf("Digest(sha256) = %x; want %x", d, single)
+	}
+
+	z := newZ(t, capnp.SingleSegment(nil))
+	if err := z.SetText("goodbye"); err != nil {
+		t.Fatal(err)
+	}
+	other, err := SHA256(capnp.Struct(z))
+	if err != nil {
+		t.Fatal("SHA256(changed):", err)
+	}
+	if other == single {
+		t.Error("SHA256 did not change after modifying content")
+	}
+}
+
+func TestDigestCapability(t *testing.T) {
+	t.Parallel()
+
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	s, _ := capnp.NewRootStruct(seg, capnp.ObjectSize{PointerCount: 1})
+	s.SetPtr(0, capnp.NewInterface(seg, 0).ToPtr())
+	if _, err := SHA256(s); err == nil {
+		t.Error("SHA256(struct with capability) did not return an error")
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(t *testing.T) Envelope {
+		t.Helper()
+		_, seg, _ := capnp.NewMessage(capnp.MultiSegment(nil))
+		e, err := Sign(seg, capnp.Struct(newZ(t, capnp.SingleSegment(nil))), priv)
+		if err != nil {
+			t.Fatal("Sign:", err)
+		}
+		return e
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		content, err := Verify(sign(t), pub)
+		if err != nil {
+			t.Fatal("Verify:", err)
+		}
+		z := air.Z(content)
+		if txt, _ := z.Text(); txt != "hello, world" {
+			t.Errorf("content text = %q; want \"hello, world\"", txt)
+		}
+	})
+	t.Run("WrongKey", func(t *testing.T) {
+		t.Parallel()
+		_, err := Verify(sign(t), otherPub)
+		if err == nil {
+			t.Fatal("Verify with wrong key did not return an error")
+		}
+		if !exc.IsType(err, exc.Failed) {
+			t.Errorf("Verify with wrong key returned %v; want a failed exception", err)
+		}
+	})
+	t.Run("Tampered", func(t *testing.T) {
+		t.Parallel()
+		e := sign(t)
+		content, _ := e.Content()
+		if err := air.Z(content).SetText("hello, mallory"); err != nil {
+			t.Fatal(err)
+		}
+		_, err := Verify(e, pub)
+		if err == nil {
+			t.Fatal("Verify of tampered content did not return an error")
+		}
+		if !exc.IsType(err, exc.Failed) {
+			t.Errorf("Verify of tampered content returned %v; want a failed exception", err)
+		}
+	})
+	t.Run("ReplacedKey", func(t *testing.T) {
+		t.Parallel()
+		e := sign(t)
+		if err := e.SetPublicKey(otherPub); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Verify(e, pub); err == nil {
+			t.Error("Verify with replaced envelope key did not return an error")
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	capnp "capnproto.org/go/capnp/v3"
 	text "capnproto.org/go/capnp/v3/encoding/text"
 	schemas "capnproto.org/go/capnp/v3/schemas"
+	errors "errors"
 	math "math"
 	strconv "strconv"
 )
@@ -145,6 +146,23 @@ func (s Value) HasString_() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownString_ detaches the string_ field from s without
+// copying it.  The field is left null.  It returns an error if
+// string_ is not the union member that is set.
+func (s Value) DisownString_() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 3 {
+		return capnp.Orphan{}, errors.New("Which() != string_")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptString_ sets the string_ field to an orphan from
+// s's message without copying it.
+func (s Value) AdoptString_(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 3)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value) String_Bytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -170,6 +188,23 @@ func (s Value) HasArray() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownArray detaches the array field from s without
+// copying it.  The field is left null.  It returns an error if
+// array is not the union member that is set.
+func (s Value) DisownArray() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 4 {
+		return capnp.Orphan{}, errors.New("Which() != array")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptArray sets the array field to an orphan from
+// s's message without copying it.
+func (s Value) AdoptArray(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 4)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value) SetArray(v Value_List) error {
 	capnp.Struct(s).SetUint16(0, 4)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -201,6 +236,23 @@ func (s Value) HasObject() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownObject detaches the object field from s without
+// copying it.  The field is left null.  It returns an error if
+// object is not the union member that is set.
+func (s Value) DisownObject() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 5 {
+		return capnp.Orphan{}, errors.New("Which() != object")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptObject sets the object field to an orphan from
+// s's message without copying it.
+func (s Value) AdoptObject(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 5)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value) SetObject(v Value_Field_List) error {
 	capnp.Struct(s).SetUint16(0, 5)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -232,6 +284,23 @@ func (s Value) HasCall() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownCall detaches the call field from s without
+// copying it.  The field is left null.  It returns an error if
+// call is not the union member that is set.
+func (s Value) DisownCall() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 6 {
+		return capnp.Orphan{}, errors.New("Which() != call")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptCall sets the call field to an orphan from
+// s's message without copying it.
+func (s Value) AdoptCall(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 6)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value) SetCall(v Value_Call) error {
 	capnp.Struct(s).SetUint16(0, 6)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -325,6 +394,18 @@ func (s Value_Field) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s Value_Field) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s Value_Field) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value_Field) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -343,6 +424,18 @@ func (s Value_Field) HasValue() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownValue detaches the value field from s without
+// copying it.  The field is left null.
+func (s Value_Field) DisownValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptValue sets the value field to an orphan from
+// s's message without copying it.
+func (s Value_Field) AdoptValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Value_Field) SetValue(v Value) error {
 	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
@@ -434,6 +527,18 @@ func (s Value_Call) HasFunction() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownFunction detaches the function field from s without
+// copying it.  The field is left null.
+func (s Value_Call) DisownFunction() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptFunction sets the function field to an orphan from
+// s's message without copying it.
+func (s Value_Call) AdoptFunction(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value_Call) FunctionBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -452,6 +557,18 @@ func (s Value_Call) HasParams() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownParams detaches the params field from s without
+// copying it.  The field is left null.
+func (s Value_Call) DisownParams() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptParams sets the params field to an orphan from
+// s's message without copying it.
+func (s Value_Call) AdoptParams(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Value_Call) SetParams(v Value_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -540,6 +657,18 @@ func (s FlattenOptions) HasPrefix() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownPrefix detaches the prefix field from s without
+// copying it.  The field is left null.
+func (s FlattenOptions) DisownPrefix() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptPrefix sets the prefix field to an orphan from
+// s's message without copying it.
+func (s FlattenOptions) AdoptPrefix(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s FlattenOptions) PrefixBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -622,6 +751,18 @@ func (s DiscriminatorOptions) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s DiscriminatorOptions) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s DiscriminatorOptions) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s DiscriminatorOptions) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -640,6 +781,18 @@ func (s DiscriminatorOptions) HasValueName() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownValueName detaches the valueName field from s without
+// copying it.  The field is left null.
+func (s DiscriminatorOptions) DisownValueName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptValueName sets the valueName field to an orphan from
+// s's message without copying it.
+func (s DiscriminatorOptions) AdoptValueName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s DiscriminatorOptions) ValueNameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(1)
 	return p.TextBytes(), err
@@ -107,9 +107,9 @@ type Persistent_Server interface {
 }
 
 // Persistent_NewServer creates a new Server from an implementation of Persistent_Server.
-func Persistent_NewServer(s Persistent_Server) *server.Server {
+func Persistent_NewServer(s Persistent_Server, opts ...server.Option) *server.Server {
 	c, _ := s.(server.Shutdowner)
-	return server.New(Persistent_Methods(nil, s), s, c)
+	return server.New(Persistent_Methods(nil, s), s, c, opts...)
 }
 
 // Persistent_ServerToClient creates a new Client from an implementation of Persistent_Server.
@@ -221,6 +221,18 @@ func (s Persistent_SaveParams) HasSealFor() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownSealFor detaches the sealFor field from s without
+// copying it.  The field is left null.
+func (s Persistent_SaveParams) DisownSealFor() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptSealFor sets the sealFor field to an orphan from
+// s's message without copying it.
+func (s Persistent_SaveParams) AdoptSealFor(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Persistent_SaveParams) SetSealFor(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(0, v)
 }
@@ -300,6 +312,18 @@ func (s Persistent_SaveResults) HasSturdyRef() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownSturdyRef detaches the sturdyRef field from s without
+// copying it.  The field is left null.
+func (s Persistent_SaveResults) DisownSturdyRef() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptSturdyRef sets the sturdyRef field to an orphan from
+// s's message without copying it.
+func (s Persistent_SaveResults) AdoptSturdyRef(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Persistent_SaveResults) SetSturdyRef(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(0, v)
 }
@@ -6,6 +6,7 @@ import (
 	capnp "capnproto.org/go/capnp/v3"
 	text "capnproto.org/go/capnp/v3/encoding/text"
 	schemas "capnproto.org/go/capnp/v3/schemas"
+	errors "errors"
 	strconv "strconv"
 )
 
@@ -129,6 +130,23 @@ func (s Message) HasUnimplemented() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownUnimplemented detaches the unimplemented field from s without
+// copying it.  The field is left null.  It returns an error if
+// unimplemented is not the union member that is set.
+func (s Message) DisownUnimplemented() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 0 {
+		return capnp.Orphan{}, errors.New("Which() != unimplemented")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptUnimplemented sets the unimplemented field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptUnimplemented(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 0)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetUnimplemented(v Message) error {
 	capnp.Struct(s).SetUint16(0, 0)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -161,6 +179,23 @@ func (s Message) HasAbort() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAbort detaches the abort field from s without
+// copying it.  The field is left null.  It returns an error if
+// abort is not the union member that is set.
+func (s Message) DisownAbort() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 1 {
+		return capnp.Orphan{}, errors.New("Which() != abort")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAbort sets the abort field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptAbort(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 1)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetAbort(v Exception) error {
 	capnp.Struct(s).SetUint16(0, 1)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -193,6 +228,23 @@ func (s Message) HasBootstrap() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBootstrap detaches the bootstrap field from s without
+// copying it.  The field is left null.  It returns an error if
+// bootstrap is not the union member that is set.
+func (s Message) DisownBootstrap() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 8 {
+		return capnp.Orphan{}, errors.New("Which() != bootstrap")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBootstrap sets the bootstrap field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptBootstrap(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 8)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetBootstrap(v Bootstrap) error {
 	capnp.Struct(s).SetUint16(0, 8)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -225,6 +277,23 @@ func (s Message) HasCall() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownCall detaches the call field from s without
+// copying it.  The field is left null.  It returns an error if
+// call is not the union member that is set.
+func (s Message) DisownCall() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 2 {
+		return capnp.Orphan{}, errors.New("Which() != call")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptCall sets the call field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptCall(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 2)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetCall(v Call) error {
 	capnp.Struct(s).SetUint16(0, 2)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -257,6 +326,23 @@ func (s Message) HasReturn() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownReturn detaches the return field from s without
+// copying it.  The field is left null.  It returns an error if
+// return is not the union member that is set.
+func (s Message) DisownReturn() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 3 {
+		return capnp.Orphan{}, errors.New("Which() != return")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptReturn sets the return field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptReturn(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 3)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetReturn(v Return) error {
 	capnp.Struct(s).SetUint16(0, 3)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -289,6 +375,23 @@ func (s Message) HasFinish() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownFinish detaches the finish field from s without
+// copying it.  The field is left null.  It returns an error if
+// finish is not the union member that is set.
+func (s Message) DisownFinish() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 4 {
+		return capnp.Orphan{}, errors.New("Which() != finish")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptFinish sets the finish field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptFinish(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 4)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetFinish(v Finish) error {
 	capnp.Struct(s).SetUint16(0, 4)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -321,6 +424,23 @@ func (s Message) HasResolve() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownResolve detaches the resolve field from s without
+// copying it.  The field is left null.  It returns an error if
+// resolve is not the union member that is set.
+func (s Message) DisownResolve() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 5 {
+		return capnp.Orphan{}, errors.New("Which() != resolve")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptResolve sets the resolve field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptResolve(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 5)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetResolve(v Resolve) error {
 	capnp.Struct(s).SetUint16(0, 5)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -353,6 +473,23 @@ func (s Message) HasRelease() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownRelease detaches the release field from s without
+// copying it.  The field is left null.  It returns an error if
+// release is not the union member that is set.
+func (s Message) DisownRelease() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 6 {
+		return capnp.Orphan{}, errors.New("Which() != release")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptRelease sets the release field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptRelease(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 6)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetRelease(v Release) error {
 	capnp.Struct(s).SetUint16(0, 6)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -385,6 +522,23 @@ func (s Message) HasDisembargo() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownDisembargo detaches the disembargo field from s without
+// copying it.  The field is left null.  It returns an error if
+// disembargo is not the union member that is set.
+func (s Message) DisownDisembargo() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 13 {
+		return capnp.Orphan{}, errors.New("Which() != disembargo")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptDisembargo sets the disembargo field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptDisembargo(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 13)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetDisembargo(v Disembargo) error {
 	capnp.Struct(s).SetUint16(0, 13)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -416,6 +570,23 @@ func (s Message) HasObsoleteSave() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownObsoleteSave detaches the obsoleteSave field from s without
+// copying it.  The field is left null.  It returns an error if
+// obsoleteSave is not the union member that is set.
+func (s Message) DisownObsoleteSave() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 7 {
+		return capnp.Orphan{}, errors.New("Which() != obsoleteSave")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptObsoleteSave sets the obsoleteSave field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptObsoleteSave(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 7)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetObsoleteSave(v capnp.Ptr) error {
 	capnp.Struct(s).SetUint16(0, 7)
 	return capnp.Struct(s).SetPtr(0, v)
@@ -434,6 +605,23 @@ func (s Message) HasObsoleteDelete() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownObsoleteDelete detaches the obsoleteDelete field from s without
+// copying it.  The field is left null.  It returns an error if
+// obsoleteDelete is not the union member that is set.
+func (s Message) DisownObsoleteDelete() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 9 {
+		return capnp.Orphan{}, errors.New("Which() != obsoleteDelete")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptObsoleteDelete sets the obsoleteDelete field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptObsoleteDelete(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 9)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetObsoleteDelete(v capnp.Ptr) error {
 	capnp.Struct(s).SetUint16(0, 9)
 	return capnp.Struct(s).SetPtr(0, v)
@@ -453,6 +641,23 @@ func (s Message) HasProvide() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownProvide detaches the provide field from s without
+// copying it.  The field is left null.  It returns an error if
+// provide is not the union member that is set.
+func (s Message) DisownProvide() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 10 {
+		return capnp.Orphan{}, errors.New("Which() != provide")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptProvide sets the provide field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptProvide(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 10)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetProvide(v Provide) error {
 	capnp.Struct(s).SetUint16(0, 10)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -485,6 +690,23 @@ func (s Message) HasAccept() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAccept detaches the accept field from s without
+// copying it.  The field is left null.  It returns an error if
+// accept is not the union member that is set.
+func (s Message) DisownAccept() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 11 {
+		return capnp.Orphan{}, errors.New("Which() != accept")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAccept sets the accept field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptAccept(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 11)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetAccept(v Accept) error {
 	capnp.Struct(s).SetUint16(0, 11)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -517,6 +739,23 @@ func (s Message) HasJoin() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownJoin detaches the join field from s without
+// copying it.  The field is left null.  It returns an error if
+// join is not the union member that is set.
+func (s Message) DisownJoin() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 12 {
+		return capnp.Orphan{}, errors.New("Which() != join")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptJoin sets the join field to an orphan from
+// s's message without copying it.
+func (s Message) AdoptJoin(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 12)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Message) SetJoin(v Join) error {
 	capnp.Struct(s).SetUint16(0, 12)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -656,6 +895,18 @@ func (s Bootstrap) HasDeprecatedObjectId() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownDeprecatedObjectId detaches the deprecatedObjectId field from s without
+// copying it.  The field is left null.
+func (s Bootstrap) DisownDeprecatedObjectId() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptDeprecatedObjectId sets the deprecatedObjectId field to an orphan from
+// s's message without copying it.
+func (s Bootstrap) AdoptDeprecatedObjectId(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Bootstrap) SetDeprecatedObjectId(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(0, v)
 }
@@ -766,6 +1017,18 @@ func (s Call) HasTarget() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownTarget detaches the target field from s without
+// copying it.  The field is left null.
+func (s Call) DisownTarget() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptTarget sets the target field to an orphan from
+// s's message without copying it.
+func (s Call) AdoptTarget(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Call) SetTarget(v MessageTarget) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -814,6 +1077,18 @@ func (s Call) HasParams() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownParams detaches the params field from s without
+// copying it.  The field is left null.
+func (s Call) DisownParams() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptParams sets the params field to an orphan from
+// s's message without copying it.
+func (s Call) AdoptParams(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Call) SetParams(v Payload) error {
 	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
@@ -869,6 +1144,23 @@ func (s Call_sendResultsTo) HasThirdParty() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
+// DisownThirdParty detaches the thirdParty field from s without
+// copying it.  The field is left null.  It returns an error if
+// thirdParty is not the union member that is set.
+func (s Call_sendResultsTo) DisownThirdParty() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(6) != 2 {
+		return capnp.Orphan{}, errors.New("Which() != thirdParty")
+	}
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptThirdParty sets the thirdParty field to an orphan from
+// s's message without copying it.
+func (s Call_sendResultsTo) AdoptThirdParty(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(6, 2)
+	return capnp.Struct(s).Adopt(2, o)
+}
+
 func (s Call_sendResultsTo) SetThirdParty(v capnp.Ptr) error {
 	capnp.Struct(s).SetUint16(6, 2)
 	return capnp.Struct(s).SetPtr(2, v)
@@ -1023,6 +1315,23 @@ func (s Return) HasResults() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownResults detaches the results field from s without
+// copying it.  The field is left null.  It returns an error if
+// results is not the union member that is set.
+func (s Return) DisownResults() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(6) != 0 {
+		return capnp.Orphan{}, errors.New("Which() != results")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptResults sets the results field to an orphan from
+// s's message without copying it.
+func (s Return) AdoptResults(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(6, 0)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Return) SetResults(v Payload) error {
 	capnp.Struct(s).SetUint16(6, 0)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -1055,6 +1364,23 @@ func (s Return) HasException() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownException detaches the exception field from s without
+// copying it.  The field is left null.  It returns an error if
+// exception is not the union member that is set.
+func (s Return) DisownException() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(6) != 1 {
+		return capnp.Orphan{}, errors.New("Which() != exception")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptException sets the exception field to an orphan from
+// s's message without copying it.
+func (s Return) AdoptException(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(6, 1)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Return) SetException(v Exception) error {
 	capnp.Struct(s).SetUint16(6, 1)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -1108,6 +1434,23 @@ func (s Return) HasAcceptFromThirdParty() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAcceptFromThirdParty detaches the acceptFromThirdParty field from s without
+// copying it.  The field is left null.  It returns an error if
+// acceptFromThirdParty is not the union member that is set.
+func (s Return) DisownAcceptFromThirdParty() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(6) != 5 {
+		return capnp.Orphan{}, errors.New("Which() != acceptFromThirdParty")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAcceptFromThirdParty sets the acceptFromThirdParty field to an orphan from
+// s's message without copying it.
+func (s Return) AdoptAcceptFromThirdParty(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(6, 5)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Return) SetAcceptFromThirdParty(v capnp.Ptr) error {
 	capnp.Struct(s).SetUint16(6, 5)
 	return capnp.Struct(s).SetPtr(0, v)
@@ -1311,6 +1654,23 @@ func (s Resolve) HasCap() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownCap detaches the cap field from s without
+// copying it.  The field is left null.  It returns an error if
+// cap is not the union member that is set.
+func (s Resolve) DisownCap() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(4) != 0 {
+		return capnp.Orphan{}, errors.New("Which() != cap")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptCap sets the cap field to an orphan from
+// s's message without copying it.
+func (s Resolve) AdoptCap(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(4, 0)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Resolve) SetCap(v CapDescriptor) error {
 	capnp.Struct(s).SetUint16(4, 0)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -1343,6 +1703,23 @@ func (s Resolve) HasException() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownException detaches the exception field from s without
+// copying it.  The field is left null.  It returns an error if
+// exception is not the union member that is set.
+func (s Resolve) DisownException() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(4) != 1 {
+		return capnp.Orphan{}, errors.New("Which() != exception")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptException sets the exception field to an orphan from
+// s's message without copying it.
+func (s Resolve) AdoptException(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(4, 1)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Resolve) SetException(v Exception) error {
 	capnp.Struct(s).SetUint16(4, 1)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -1544,6 +1921,18 @@ func (s Disembargo) HasTarget() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownTarget detaches the target field from s without
+// copying it.  The field is left null.
+func (s Disembargo) DisownTarget() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptTarget sets the target field to an orphan from
+// s's message without copying it.
+func (s Disembargo) AdoptTarget(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Disembargo) SetTarget(v MessageTarget) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -1711,6 +2100,18 @@ func (s Provide) HasTarget() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownTarget detaches the target field from s without
+// copying it.  The field is left null.
+func (s Provide) DisownTarget() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptTarget sets the target field to an orphan from
+// s's message without copying it.
+func (s Provide) AdoptTarget(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Provide) SetTarget(v MessageTarget) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -1734,6 +2135,18 @@ func (s Provide) HasRecipient() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownRecipient detaches the recipient field from s without
+// copying it.  The field is left null.
+func (s Provide) DisownRecipient() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptRecipient sets the recipient field to an orphan from
+// s's message without copying it.
+func (s Provide) AdoptRecipient(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Provide) SetRecipient(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(1, v)
 }
@@ -1824,6 +2237,18 @@ func (s Accept) HasProvision() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownProvision detaches the provision field from s without
+// copying it.  The field is left null.
+func (s Accept) DisownProvision() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptProvision sets the provision field to an orphan from
+// s's message without copying it.
+func (s Accept) AdoptProvision(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Accept) SetProvision(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(0, v)
 }
@@ -1919,6 +2344,18 @@ func (s Join) HasTarget() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownTarget detaches the target field from s without
+// copying it.  The field is left null.
+func (s Join) DisownTarget() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptTarget sets the target field to an orphan from
+// s's message without copying it.
+func (s Join) AdoptTarget(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Join) SetTarget(v MessageTarget) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -1942,6 +2379,18 @@ func (s Join) HasKeyPart() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownKeyPart detaches the keyPart field from s without
+// copying it.  The field is left null.
+func (s Join) DisownKeyPart() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptKeyPart sets the keyPart field to an orphan from
+// s's message without copying it.
+func (s Join) AdoptKeyPart(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Join) SetKeyPart(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(1, v)
 }
@@ -2065,6 +2514,23 @@ func (s MessageTarget) HasPromisedAnswer() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownPromisedAnswer detaches the promisedAnswer field from s without
+// copying it.  The field is left null.  It returns an error if
+// promisedAnswer is not the union member that is set.
+func (s MessageTarget) DisownPromisedAnswer() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(4) != 1 {
+		return capnp.Orphan{}, errors.New("Which() != promisedAnswer")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptPromisedAnswer sets the promisedAnswer field to an orphan from
+// s's message without copying it.
+func (s MessageTarget) AdoptPromisedAnswer(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(4, 1)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s MessageTarget) SetPromisedAnswer(v PromisedAnswer) error {
 	capnp.Struct(s).SetUint16(4, 1)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2157,6 +2623,18 @@ func (s Payload) HasContent() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownContent detaches the content field from s without
+// copying it.  The field is left null.
+func (s Payload) DisownContent() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptContent sets the content field to an orphan from
+// s's message without copying it.
+func (s Payload) AdoptContent(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Payload) SetContent(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(0, v)
 }
@@ -2169,6 +2647,18 @@ func (s Payload) HasCapTable() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownCapTable detaches the capTable field from s without
+// copying it.  The field is left null.
+func (s Payload) DisownCapTable() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptCapTable sets the capTable field to an orphan from
+// s's message without copying it.
+func (s Payload) AdoptCapTable(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Payload) SetCapTable(v CapDescriptor_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -2341,6 +2831,23 @@ func (s CapDescriptor) HasReceiverAnswer() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownReceiverAnswer detaches the receiverAnswer field from s without
+// copying it.  The field is left null.  It returns an error if
+// receiverAnswer is not the union member that is set.
+func (s CapDescriptor) DisownReceiverAnswer() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 4 {
+		return capnp.Orphan{}, errors.New("Which() != receiverAnswer")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptReceiverAnswer sets the receiverAnswer field to an orphan from
+// s's message without copying it.
+func (s CapDescriptor) AdoptReceiverAnswer(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 4)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s CapDescriptor) SetReceiverAnswer(v PromisedAnswer) error {
 	capnp.Struct(s).SetUint16(0, 4)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2373,6 +2880,23 @@ func (s CapDescriptor) HasThirdPartyHosted() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownThirdPartyHosted detaches the thirdPartyHosted field from s without
+// copying it.  The field is left null.  It returns an error if
+// thirdPartyHosted is not the union member that is set.
+func (s CapDescriptor) DisownThirdPartyHosted() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 5 {
+		return capnp.Orphan{}, errors.New("Which() != thirdPartyHosted")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptThirdPartyHosted sets the thirdPartyHosted field to an orphan from
+// s's message without copying it.
+func (s CapDescriptor) AdoptThirdPartyHosted(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 5)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s CapDescriptor) SetThirdPartyHosted(v ThirdPartyCapDescriptor) error {
 	capnp.Struct(s).SetUint16(0, 5)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -2485,6 +3009,18 @@ func (s PromisedAnswer) HasTransform() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownTransform detaches the transform field from s without
+// copying it.  The field is left null.
+func (s PromisedAnswer) DisownTransform() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptTransform sets the transform field to an orphan from
+// s's message without copying it.
+func (s PromisedAnswer) AdoptTransform(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s PromisedAnswer) SetTransform(v PromisedAnswer_Op_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -2675,6 +3211,18 @@ func (s ThirdPartyCapDescriptor) HasId() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownId detaches the id field from s without
+// copying it.  The field is left null.
+func (s ThirdPartyCapDescriptor) DisownId() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptId sets the id field to an orphan from
+// s's message without copying it.
+func (s ThirdPartyCapDescriptor) AdoptId(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s ThirdPartyCapDescriptor) SetId(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(0, v)
 }
@@ -2762,6 +3310,18 @@ func (s Exception) HasReason() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownReason detaches the reason field from s without
+// copying it.  The field is left null.
+func (s Exception) DisownReason() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptReason sets the reason field to an orphan from
+// s's message without copying it.
+func (s Exception) AdoptReason(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Exception) ReasonBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -2804,6 +3364,18 @@ func (s Exception) HasTrace() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownTrace detaches the trace field from s without
+// copying it.  The field is left null.
+func (s Exception) DisownTrace() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptTrace sets the trace field to an orphan from
+// s's message without copying it.
+func (s Exception) AdoptTrace(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Exception) TraceBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(1)
 	return p.TextBytes(), err
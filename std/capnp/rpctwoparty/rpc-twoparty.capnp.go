@@ -485,6 +485,18 @@ func (s JoinResult) HasCap() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownCap detaches the cap field from s without
+// copying it.  The field is left null.
+func (s JoinResult) DisownCap() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptCap sets the cap field to an orphan from
+// s's message without copying it.
+func (s JoinResult) AdoptCap(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s JoinResult) SetCap(v capnp.Ptr) error {
 	return capnp.Struct(s).SetPtr(0, v)
 }
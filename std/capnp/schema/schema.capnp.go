@@ -6,6 +6,7 @@ import (
 	capnp "capnproto.org/go/capnp/v3"
 	text "capnproto.org/go/capnp/v3/encoding/text"
 	schemas "capnproto.org/go/capnp/v3/schemas"
+	errors "errors"
 	math "math"
 	strconv "strconv"
 )
@@ -118,6 +119,18 @@ func (s Node) HasDisplayName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownDisplayName detaches the displayName field from s without
+// copying it.  The field is left null.
+func (s Node) DisownDisplayName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptDisplayName sets the displayName field to an orphan from
+// s's message without copying it.
+func (s Node) AdoptDisplayName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Node) DisplayNameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -152,6 +165,18 @@ func (s Node) HasParameters() bool {
 	return capnp.Struct(s).HasPtr(5)
 }
 
+// DisownParameters detaches the parameters field from s without
+// copying it.  The field is left null.
+func (s Node) DisownParameters() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(5)
+}
+
+// AdoptParameters sets the parameters field to an orphan from
+// s's message without copying it.
+func (s Node) AdoptParameters(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(5, o)
+}
+
 func (s Node) SetParameters(v Node_Parameter_List) error {
 	return capnp.Struct(s).SetPtr(5, v.ToPtr())
 }
@@ -183,6 +208,18 @@ func (s Node) HasNestedNodes() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownNestedNodes detaches the nestedNodes field from s without
+// copying it.  The field is left null.
+func (s Node) DisownNestedNodes() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptNestedNodes sets the nestedNodes field to an orphan from
+// s's message without copying it.
+func (s Node) AdoptNestedNodes(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Node) SetNestedNodes(v Node_NestedNode_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -206,6 +243,18 @@ func (s Node) HasAnnotations() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
+// DisownAnnotations detaches the annotations field from s without
+// copying it.  The field is left null.
+func (s Node) DisownAnnotations() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptAnnotations sets the annotations field to an orphan from
+// s's message without copying it.
+func (s Node) AdoptAnnotations(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
 func (s Node) SetAnnotations(v Annotation_List) error {
 	return capnp.Struct(s).SetPtr(2, v.ToPtr())
 }
@@ -299,6 +348,18 @@ func (s Node_structNode) HasFields() bool {
 	return capnp.Struct(s).HasPtr(3)
 }
 
+// DisownFields detaches the fields field from s without
+// copying it.  The field is left null.
+func (s Node_structNode) DisownFields() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptFields sets the fields field to an orphan from
+// s's message without copying it.
+func (s Node_structNode) AdoptFields(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
 func (s Node_structNode) SetFields(v Field_List) error {
 	return capnp.Struct(s).SetPtr(3, v.ToPtr())
 }
@@ -339,6 +400,18 @@ func (s Node_enum) HasEnumerants() bool {
 	return capnp.Struct(s).HasPtr(3)
 }
 
+// DisownEnumerants detaches the enumerants field from s without
+// copying it.  The field is left null.
+func (s Node_enum) DisownEnumerants() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptEnumerants sets the enumerants field to an orphan from
+// s's message without copying it.
+func (s Node_enum) AdoptEnumerants(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
 func (s Node_enum) SetEnumerants(v Enumerant_List) error {
 	return capnp.Struct(s).SetPtr(3, v.ToPtr())
 }
@@ -379,6 +452,18 @@ func (s Node_interface) HasMethods() bool {
 	return capnp.Struct(s).HasPtr(3)
 }
 
+// DisownMethods detaches the methods field from s without
+// copying it.  The field is left null.
+func (s Node_interface) DisownMethods() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptMethods sets the methods field to an orphan from
+// s's message without copying it.
+func (s Node_interface) AdoptMethods(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
 func (s Node_interface) SetMethods(v Method_List) error {
 	return capnp.Struct(s).SetPtr(3, v.ToPtr())
 }
@@ -402,6 +487,18 @@ func (s Node_interface) HasSuperclasses() bool {
 	return capnp.Struct(s).HasPtr(4)
 }
 
+// DisownSuperclasses detaches the superclasses field from s without
+// copying it.  The field is left null.
+func (s Node_interface) DisownSuperclasses() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(4)
+}
+
+// AdoptSuperclasses sets the superclasses field to an orphan from
+// s's message without copying it.
+func (s Node_interface) AdoptSuperclasses(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(4, o)
+}
+
 func (s Node_interface) SetSuperclasses(v Superclass_List) error {
 	return capnp.Struct(s).SetPtr(4, v.ToPtr())
 }
@@ -442,6 +539,18 @@ func (s Node_const) HasType() bool {
 	return capnp.Struct(s).HasPtr(3)
 }
 
+// DisownType detaches the type field from s without
+// copying it.  The field is left null.
+func (s Node_const) DisownType() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptType sets the type field to an orphan from
+// s's message without copying it.
+func (s Node_const) AdoptType(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
 func (s Node_const) SetType(v Type) error {
 	return capnp.Struct(s).SetPtr(3, capnp.Struct(v).ToPtr())
 }
@@ -466,6 +575,18 @@ func (s Node_const) HasValue() bool {
 	return capnp.Struct(s).HasPtr(4)
 }
 
+// DisownValue detaches the value field from s without
+// copying it.  The field is left null.
+func (s Node_const) DisownValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(4)
+}
+
+// AdoptValue sets the value field to an orphan from
+// s's message without copying it.
+func (s Node_const) AdoptValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(4, o)
+}
+
 func (s Node_const) SetValue(v Value) error {
 	return capnp.Struct(s).SetPtr(4, capnp.Struct(v).ToPtr())
 }
@@ -507,6 +628,18 @@ func (s Node_annotation) HasType() bool {
 	return capnp.Struct(s).HasPtr(3)
 }
 
+// DisownType detaches the type field from s without
+// copying it.  The field is left null.
+func (s Node_annotation) DisownType() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptType sets the type field to an orphan from
+// s's message without copying it.
+func (s Node_annotation) AdoptType(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
 func (s Node_annotation) SetType(v Type) error {
 	return capnp.Struct(s).SetPtr(3, capnp.Struct(v).ToPtr())
 }
@@ -745,6 +878,18 @@ func (s Node_Parameter) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s Node_Parameter) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s Node_Parameter) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Node_Parameter) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -827,6 +972,18 @@ func (s Node_NestedNode) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s Node_NestedNode) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s Node_NestedNode) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Node_NestedNode) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -925,6 +1082,18 @@ func (s Node_SourceInfo) HasDocComment() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownDocComment detaches the docComment field from s without
+// copying it.  The field is left null.
+func (s Node_SourceInfo) DisownDocComment() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptDocComment sets the docComment field to an orphan from
+// s's message without copying it.
+func (s Node_SourceInfo) AdoptDocComment(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Node_SourceInfo) DocCommentBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -943,6 +1112,18 @@ func (s Node_SourceInfo) HasMembers() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownMembers detaches the members field from s without
+// copying it.  The field is left null.
+func (s Node_SourceInfo) DisownMembers() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptMembers sets the members field to an orphan from
+// s's message without copying it.
+func (s Node_SourceInfo) AdoptMembers(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Node_SourceInfo) SetMembers(v Node_SourceInfo_Member_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -1031,6 +1212,18 @@ func (s Node_SourceInfo_Member) HasDocComment() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownDocComment detaches the docComment field from s without
+// copying it.  The field is left null.
+func (s Node_SourceInfo_Member) DisownDocComment() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptDocComment sets the docComment field to an orphan from
+// s's message without copying it.
+func (s Node_SourceInfo_Member) AdoptDocComment(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Node_SourceInfo_Member) DocCommentBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -1157,6 +1350,18 @@ func (s Field) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s Field) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s Field) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Field) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -1183,6 +1388,18 @@ func (s Field) HasAnnotations() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownAnnotations detaches the annotations field from s without
+// copying it.  The field is left null.
+func (s Field) DisownAnnotations() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptAnnotations sets the annotations field to an orphan from
+// s's message without copying it.
+func (s Field) AdoptAnnotations(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Field) SetAnnotations(v Annotation_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -1239,6 +1456,18 @@ func (s Field_slot) HasType() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
+// DisownType detaches the type field from s without
+// copying it.  The field is left null.
+func (s Field_slot) DisownType() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptType sets the type field to an orphan from
+// s's message without copying it.
+func (s Field_slot) AdoptType(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
 func (s Field_slot) SetType(v Type) error {
 	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
 }
@@ -1263,6 +1492,18 @@ func (s Field_slot) HasDefaultValue() bool {
 	return capnp.Struct(s).HasPtr(3)
 }
 
+// DisownDefaultValue detaches the defaultValue field from s without
+// copying it.  The field is left null.
+func (s Field_slot) DisownDefaultValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptDefaultValue sets the defaultValue field to an orphan from
+// s's message without copying it.
+func (s Field_slot) AdoptDefaultValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
 func (s Field_slot) SetDefaultValue(v Value) error {
 	return capnp.Struct(s).SetPtr(3, capnp.Struct(v).ToPtr())
 }
@@ -1450,6 +1691,18 @@ func (s Enumerant) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s Enumerant) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s Enumerant) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Enumerant) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -1476,6 +1729,18 @@ func (s Enumerant) HasAnnotations() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownAnnotations detaches the annotations field from s without
+// copying it.  The field is left null.
+func (s Enumerant) DisownAnnotations() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptAnnotations sets the annotations field to an orphan from
+// s's message without copying it.
+func (s Enumerant) AdoptAnnotations(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Enumerant) SetAnnotations(v Annotation_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -1572,6 +1837,18 @@ func (s Superclass) HasBrand() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBrand detaches the brand field from s without
+// copying it.  The field is left null.
+func (s Superclass) DisownBrand() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBrand sets the brand field to an orphan from
+// s's message without copying it.
+func (s Superclass) AdoptBrand(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Superclass) SetBrand(v Brand) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -1663,6 +1940,18 @@ func (s Method) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s Method) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s Method) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Method) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -1689,6 +1978,18 @@ func (s Method) HasImplicitParameters() bool {
 	return capnp.Struct(s).HasPtr(4)
 }
 
+// DisownImplicitParameters detaches the implicitParameters field from s without
+// copying it.  The field is left null.
+func (s Method) DisownImplicitParameters() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(4)
+}
+
+// AdoptImplicitParameters sets the implicitParameters field to an orphan from
+// s's message without copying it.
+func (s Method) AdoptImplicitParameters(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(4, o)
+}
+
 func (s Method) SetImplicitParameters(v Node_Parameter_List) error {
 	return capnp.Struct(s).SetPtr(4, v.ToPtr())
 }
@@ -1720,6 +2021,18 @@ func (s Method) HasParamBrand() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
+// DisownParamBrand detaches the paramBrand field from s without
+// copying it.  The field is left null.
+func (s Method) DisownParamBrand() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptParamBrand sets the paramBrand field to an orphan from
+// s's message without copying it.
+func (s Method) AdoptParamBrand(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
 func (s Method) SetParamBrand(v Brand) error {
 	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
 }
@@ -1752,6 +2065,18 @@ func (s Method) HasResultBrand() bool {
 	return capnp.Struct(s).HasPtr(3)
 }
 
+// DisownResultBrand detaches the resultBrand field from s without
+// copying it.  The field is left null.
+func (s Method) DisownResultBrand() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptResultBrand sets the resultBrand field to an orphan from
+// s's message without copying it.
+func (s Method) AdoptResultBrand(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
 func (s Method) SetResultBrand(v Brand) error {
 	return capnp.Struct(s).SetPtr(3, capnp.Struct(v).ToPtr())
 }
@@ -1776,6 +2101,18 @@ func (s Method) HasAnnotations() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownAnnotations detaches the annotations field from s without
+// copying it.  The field is left null.
+func (s Method) DisownAnnotations() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptAnnotations sets the annotations field to an orphan from
+// s's message without copying it.
+func (s Method) AdoptAnnotations(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Method) SetAnnotations(v Annotation_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -2085,6 +2422,18 @@ func (s Type_list) HasElementType() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownElementType detaches the elementType field from s without
+// copying it.  The field is left null.
+func (s Type_list) DisownElementType() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptElementType sets the elementType field to an orphan from
+// s's message without copying it.
+func (s Type_list) AdoptElementType(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Type_list) SetElementType(v Type) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -2134,6 +2483,18 @@ func (s Type_enum) HasBrand() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBrand detaches the brand field from s without
+// copying it.  The field is left null.
+func (s Type_enum) DisownBrand() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBrand sets the brand field to an orphan from
+// s's message without copying it.
+func (s Type_enum) AdoptBrand(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Type_enum) SetBrand(v Brand) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -2183,6 +2544,18 @@ func (s Type_structType) HasBrand() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBrand detaches the brand field from s without
+// copying it.  The field is left null.
+func (s Type_structType) DisownBrand() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBrand sets the brand field to an orphan from
+// s's message without copying it.
+func (s Type_structType) AdoptBrand(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Type_structType) SetBrand(v Brand) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -2232,6 +2605,18 @@ func (s Type_interface) HasBrand() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBrand detaches the brand field from s without
+// copying it.  The field is left null.
+func (s Type_interface) DisownBrand() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBrand sets the brand field to an orphan from
+// s's message without copying it.
+func (s Type_interface) AdoptBrand(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Type_interface) SetBrand(v Brand) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -2532,6 +2917,18 @@ func (s Brand) HasScopes() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownScopes detaches the scopes field from s without
+// copying it.  The field is left null.
+func (s Brand) DisownScopes() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptScopes sets the scopes field to an orphan from
+// s's message without copying it.
+func (s Brand) AdoptScopes(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Brand) SetScopes(v Brand_Scope_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -2656,6 +3053,23 @@ func (s Brand_Scope) HasBind() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownBind detaches the bind field from s without
+// copying it.  The field is left null.  It returns an error if
+// bind is not the union member that is set.
+func (s Brand_Scope) DisownBind() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(8) != 0 {
+		return capnp.Orphan{}, errors.New("Which() != bind")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptBind sets the bind field to an orphan from
+// s's message without copying it.
+func (s Brand_Scope) AdoptBind(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(8, 0)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Brand_Scope) SetBind(v Brand_Binding_List) error {
 	capnp.Struct(s).SetUint16(8, 0)
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
@@ -2783,6 +3197,23 @@ func (s Brand_Binding) HasType() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownType detaches the type field from s without
+// copying it.  The field is left null.  It returns an error if
+// type is not the union member that is set.
+func (s Brand_Binding) DisownType() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 1 {
+		return capnp.Orphan{}, errors.New("Which() != type")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptType sets the type field to an orphan from
+// s's message without copying it.
+func (s Brand_Binding) AdoptType(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 1)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Brand_Binding) SetType(v Type) error {
 	capnp.Struct(s).SetUint16(0, 1)
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
@@ -3092,6 +3523,23 @@ func (s Value) HasText() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownText detaches the text field from s without
+// copying it.  The field is left null.  It returns an error if
+// text is not the union member that is set.
+func (s Value) DisownText() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 12 {
+		return capnp.Orphan{}, errors.New("Which() != text")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptText sets the text field to an orphan from
+// s's message without copying it.
+func (s Value) AdoptText(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 12)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value) TextBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -3117,6 +3565,23 @@ func (s Value) HasData() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownData detaches the data field from s without
+// copying it.  The field is left null.  It returns an error if
+// data is not the union member that is set.
+func (s Value) DisownData() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 13 {
+		return capnp.Orphan{}, errors.New("Which() != data")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptData sets the data field to an orphan from
+// s's message without copying it.
+func (s Value) AdoptData(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 13)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value) SetData(v []byte) error {
 	capnp.Struct(s).SetUint16(0, 13)
 	return capnp.Struct(s).SetData(0, v)
@@ -3136,6 +3601,23 @@ func (s Value) HasList() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownList detaches the list field from s without
+// copying it.  The field is left null.  It returns an error if
+// list is not the union member that is set.
+func (s Value) DisownList() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 14 {
+		return capnp.Orphan{}, errors.New("Which() != list")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptList sets the list field to an orphan from
+// s's message without copying it.
+func (s Value) AdoptList(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 14)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value) SetList(v capnp.Ptr) error {
 	capnp.Struct(s).SetUint16(0, 14)
 	return capnp.Struct(s).SetPtr(0, v)
@@ -3166,6 +3648,23 @@ func (s Value) HasStructValue() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownStructValue detaches the structValue field from s without
+// copying it.  The field is left null.  It returns an error if
+// structValue is not the union member that is set.
+func (s Value) DisownStructValue() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 16 {
+		return capnp.Orphan{}, errors.New("Which() != structValue")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptStructValue sets the structValue field to an orphan from
+// s's message without copying it.
+func (s Value) AdoptStructValue(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 16)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value) SetStructValue(v capnp.Ptr) error {
 	capnp.Struct(s).SetUint16(0, 16)
 	return capnp.Struct(s).SetPtr(0, v)
@@ -3189,6 +3688,23 @@ func (s Value) HasAnyPointer() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownAnyPointer detaches the anyPointer field from s without
+// copying it.  The field is left null.  It returns an error if
+// anyPointer is not the union member that is set.
+func (s Value) DisownAnyPointer() (capnp.Orphan, error) {
+	if capnp.Struct(s).Uint16(0) != 18 {
+		return capnp.Orphan{}, errors.New("Which() != anyPointer")
+	}
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptAnyPointer sets the anyPointer field to an orphan from
+// s's message without copying it.
+func (s Value) AdoptAnyPointer(o capnp.Orphan) error {
+	capnp.Struct(s).SetUint16(0, 18)
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Value) SetAnyPointer(v capnp.Ptr) error {
 	capnp.Struct(s).SetUint16(0, 18)
 	return capnp.Struct(s).SetPtr(0, v)
@@ -3284,6 +3800,18 @@ func (s Annotation) HasBrand() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownBrand detaches the brand field from s without
+// copying it.  The field is left null.
+func (s Annotation) DisownBrand() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptBrand sets the brand field to an orphan from
+// s's message without copying it.
+func (s Annotation) AdoptBrand(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s Annotation) SetBrand(v Brand) error {
 	return capnp.Struct(s).SetPtr(1, capnp.Struct(v).ToPtr())
 }
@@ -3308,6 +3836,18 @@ func (s Annotation) HasValue() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownValue detaches the value field from s without
+// copying it.  The field is left null.
+func (s Annotation) DisownValue() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptValue sets the value field to an orphan from
+// s's message without copying it.
+func (s Annotation) AdoptValue(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s Annotation) SetValue(v Value) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
@@ -3564,6 +4104,18 @@ func (s CodeGeneratorRequest) HasCapnpVersion() bool {
 	return capnp.Struct(s).HasPtr(2)
 }
 
+// DisownCapnpVersion detaches the capnpVersion field from s without
+// copying it.  The field is left null.
+func (s CodeGeneratorRequest) DisownCapnpVersion() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(2)
+}
+
+// AdoptCapnpVersion sets the capnpVersion field to an orphan from
+// s's message without copying it.
+func (s CodeGeneratorRequest) AdoptCapnpVersion(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(2, o)
+}
+
 func (s CodeGeneratorRequest) SetCapnpVersion(v CapnpVersion) error {
 	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
 }
@@ -3588,6 +4140,18 @@ func (s CodeGeneratorRequest) HasNodes() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownNodes detaches the nodes field from s without
+// copying it.  The field is left null.
+func (s CodeGeneratorRequest) DisownNodes() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptNodes sets the nodes field to an orphan from
+// s's message without copying it.
+func (s CodeGeneratorRequest) AdoptNodes(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s CodeGeneratorRequest) SetNodes(v Node_List) error {
 	return capnp.Struct(s).SetPtr(0, v.ToPtr())
 }
@@ -3611,6 +4175,18 @@ func (s CodeGeneratorRequest) HasSourceInfo() bool {
 	return capnp.Struct(s).HasPtr(3)
 }
 
+// DisownSourceInfo detaches the sourceInfo field from s without
+// copying it.  The field is left null.
+func (s CodeGeneratorRequest) DisownSourceInfo() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(3)
+}
+
+// AdoptSourceInfo sets the sourceInfo field to an orphan from
+// s's message without copying it.
+func (s CodeGeneratorRequest) AdoptSourceInfo(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(3, o)
+}
+
 func (s CodeGeneratorRequest) SetSourceInfo(v Node_SourceInfo_List) error {
 	return capnp.Struct(s).SetPtr(3, v.ToPtr())
 }
@@ -3634,6 +4210,18 @@ func (s CodeGeneratorRequest) HasRequestedFiles() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownRequestedFiles detaches the requestedFiles field from s without
+// copying it.  The field is left null.
+func (s CodeGeneratorRequest) DisownRequestedFiles() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptRequestedFiles sets the requestedFiles field to an orphan from
+// s's message without copying it.
+func (s CodeGeneratorRequest) AdoptRequestedFiles(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s CodeGeneratorRequest) SetRequestedFiles(v CodeGeneratorRequest_RequestedFile_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -3733,6 +4321,18 @@ func (s CodeGeneratorRequest_RequestedFile) HasFilename() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownFilename detaches the filename field from s without
+// copying it.  The field is left null.
+func (s CodeGeneratorRequest_RequestedFile) DisownFilename() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptFilename sets the filename field to an orphan from
+// s's message without copying it.
+func (s CodeGeneratorRequest_RequestedFile) AdoptFilename(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s CodeGeneratorRequest_RequestedFile) FilenameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
@@ -3751,6 +4351,18 @@ func (s CodeGeneratorRequest_RequestedFile) HasImports() bool {
 	return capnp.Struct(s).HasPtr(1)
 }
 
+// DisownImports detaches the imports field from s without
+// copying it.  The field is left null.
+func (s CodeGeneratorRequest_RequestedFile) DisownImports() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(1)
+}
+
+// AdoptImports sets the imports field to an orphan from
+// s's message without copying it.
+func (s CodeGeneratorRequest_RequestedFile) AdoptImports(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(1, o)
+}
+
 func (s CodeGeneratorRequest_RequestedFile) SetImports(v CodeGeneratorRequest_RequestedFile_Import_List) error {
 	return capnp.Struct(s).SetPtr(1, v.ToPtr())
 }
@@ -3847,6 +4459,18 @@ func (s CodeGeneratorRequest_RequestedFile_Import) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
+// DisownName detaches the name field from s without
+// copying it.  The field is left null.
+func (s CodeGeneratorRequest_RequestedFile_Import) DisownName() (capnp.Orphan, error) {
+	return capnp.Struct(s).Disown(0)
+}
+
+// AdoptName sets the name field to an orphan from
+// s's message without copying it.
+func (s CodeGeneratorRequest_RequestedFile_Import) AdoptName(o capnp.Orphan) error {
+	return capnp.Struct(s).Adopt(0, o)
+}
+
 func (s CodeGeneratorRequest_RequestedFile_Import) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
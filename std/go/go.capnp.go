@@ -13,33 +13,64 @@ const Tag = uint64(0xa574b41924caefc7)
 const Notag = uint64(0xc8768679ec52e012)
 const Customtype = uint64(0xfa10659ae02f2093)
 const Name = uint64(0xc2b96012172f8df1)
-const schema_d12a1c51fedd6c88 = "x\xda\x12\x98\xe8\xc0b\xc8{\x9c\x89\x81)P\x81\x95" +
-	"\xed\xff\xf1\xf7\xa7T$\xb7\x94,e\x08\xe4d\xe5\xf8" +
-	"\xdf\x91s\xf7_\xa0\x8c\xd6E\x06\x06FaO\xc6," +
-	"a_Fv\x06\x86`\x0fFfF\x06\xc6\xff\x0f\xb4" +
-	"+\x95\x05\xeaW\xee\x03)eDQj\xc9\xd8%\xec" +
-	"\x08Vj\x03Q\xfa\xb1W_\\(a\xe7!\x86\x8b" +
-	"\x9c\xac\xff\xf8Q\xd4\xea2\x16\x09\x1b\x82\xd5\xea@\xd4" +
-	"\xc6M\x9e\x1f\xb8\xf7Z\xd7Q\x90\xb1&(Je\x19" +
-	"\xb3\x84\x15\xc1Je J\x85\x1e\x04\xbd\xa9l+;" +
-	"\x81\xe9X^\xc6*aA\xb0R\x1e\x88\xd2\xad.|" +
-	"j\x8c\xdb\x0c\x1eb8V\xf0o\x930#He\xe0" +
-	"\x1f\xb0\xc2\xc9\x0a\xfa\x0ff\xa5\x0a\xfc\x02)T@Q" +
-	"\xf8v\x91\xe0W\x90\xba\x0f\xcc\x8c\x0c<\xff\xd3\xf3\xf5" +
-	"\x92\x13\x0b\xf2\x0a\x18\xf8\xadJ\x12\xd3\x03\x18\x19\x19y" +
-	"\x18\x98\xe0\xa2\x8cV\x05\x89\xc9\xd9\x89\xe9\xa9\x0c\x0c\xe8" +
-	"R\x0c\xf2Vy\x89\xb9\xa9\x18\xc2\xfcV)\xf9\xc9\x18" +
-	"\xa2\xf6Vy\xf9\x10\xf3\x19\x98\x91L\xcf\xcc-\xc8/" +
-	"*a\xc0\xb46\xb9\xb4\xb8$?\xb7\x84\xbd\xb2\x00f" +
-	"\x03 \x00\x00\xff\xff\xb5C\x8e\x03"
+const Min = uint64(0xeef9cfe81ddeca5e)
+const Max = uint64(0xe1f93203db42ac8b)
+const MaxLen = uint64(0x8baa1a3595b98165)
+const Required = uint64(0x8b2455025d97a887)
+const Pattern = uint64(0xcffe29b68470b6e0)
+const Map = uint64(0xf33187c586e25254)
+const Generics = uint64(0xd0f242f6fc401f34)
+const schema_d12a1c51fedd6c88 = "x\xdat\xd0KHTQ\x1c\xc7\xf1s\xfe6\xfd\xb5" +
+	"\x97\xe2YDQ(dA\x0f_\xa1\x90\x97\xa2Q\x0a" +
+	"\x12\x0a\xbc\x1e\x07r1\xe1e\xbcLwj\xee\xdc\xb9" +
+	"\xde\x09'\x1a\"\xf1\x11#n\x94\x08\xda\x04AP\xd1" +
+	"\xb2\x06\\\xd4\"\x90\xc2\x8d\xb9i\x13\xc5\xd8\xaaE\x14" +
+	"\x15\x04F\xcc\x89\xc3\x91\xf0v\xef,\xbe\xab\x1f\x1f\xce" +
+	"\xa3\xa3\x8dF\xb7t\xeel\x8f\x10\xd0OF\xb6\x8a\x99" +
+	"Gw\xe3\x10k\x99%z]\xa4Y\xdc\xbe\xfa\xa1\xa2" +
+	"\xef;\xb2J\x08e\x05\x98gS\x802>\x095\x94" +
+	"\x106\x07(\xcc[\x8bw\xba\xf7>\x09\x01y\x98`" +
+	"\x05@\x19\xbf\xa1\xc0\x14\xa0x\xfdm\xb9e\xcf3\xef" +
+	"\xa1\x04\xb5>\x90\x85\x14\xcb\x01\xca\xb8\xa7@\x01P\x94" +
+	"\x8f\xe6\x0f4\xdc|\xfcR\x02\xea\x03\x16\x14Y\x16P" +
+	"\xc6\x1d\x05\xf2\x80\xe2\xfb\\\xfb\xee\xc6\x91\xc5Wd\xb5" +
+	".R\xa9\xf7\x09\x13\\f\x01\xca\xf8e%\xb2\x80\xe2" +
+	"\xd2\xc2}\xfd\xc5\xbb\xe2\x92<\xa2\xcb\x07\xe2\x90b\x06" +
+	"\xa0\x8c\x8f(`\x01\x8a\xc6\xf2\xe0\x97\xfc\xf4\xb57\xc1" +
+	"G\xc4\xe0:\x1b\x06\x94\xf1\x8b\x0a\x18\xf2\x11%g\xb2" +
+	"t\xb8\xb2\x12\xfc\xa6\x0bPd1@\x19\x1fR \x0e" +
+	"(\xba\x9a\xa2\x7f~\xf5\xfdx\x1b|u?\xcc3\x1d" +
+	"P\xc6\x07\x14\x18\x06\x14\xcf\xcf\xec:DK\x1dkA" +
+	"p\x16&X?\xa0\x8c\x9fS@\x07\x14\xb3O\xfb\xde" +
+	"\xd7\x1c__\x0b^\xe9\x14\xa4X/\xa0\x8cG\x15\xe8" +
+	"\x97\xbf\xb4\xfcq\xff\xe7\x95\xf5\xafA\xd0\x0d)\xd6\x03" +
+	"(\xe3'\x14\xe8\x05\x14C\x83\x9f\xa6\x97f:\x7f\x06" +
+	"A+\xa4X'\xa0\x8cw(\xd0\x03(\x16\x9a\xdb\xcb" +
+	"\xf7\xcc\x86\xdfAp\x10\x1e\xb0V@\x19?\xa6@7" +
+	" \xd9&\x92\x99\xb6\x84\xe1\xd8\x0e\xd5\\3\x9b\xb3\\" +
+	"\x93\x8e\x0eP:@\x81\xd4D\xe9\xa65m\x8c\x9f7" +
+	"m\xa26ZK`\xd3J\xea5\xcfHnL;|" +
+	"\x13\xd5\x1c#q\xc5H\x9a\x84\x84\xee\xa4I\xb3\x8d\xb4" +
+	"\x19\xbe\xd5k\xa3\x99D\xf8tZ\xb33\xff\xce\xfc\xef" +
+	"\xaa\x8e\xe1y\xa6kW9\x91jI\xd36]+A" +
+	"\xc7B\xb5\x95v2\xaeG\xaa\xdd(m\x8coL\xdb" +
+	"\x03\x93eW\x9d\x0c'\xf4\xb0Dn\xcc\xcb\xa4=\xcc" +
+	";\xbe/\xf8;\x00\x9a\xd6/\\"
 
 func init() {
 	schemas.Register(schema_d12a1c51fedd6c88,
+		0x8b2455025d97a887,
+		0x8baa1a3595b98165,
 		0xa574b41924caefc7,
 		0xbea97f1023792be0,
 		0xc2b96012172f8df1,
 		0xc58ad6bd519f935e,
 		0xc8768679ec52e012,
+		0xcffe29b68470b6e0,
+		0xd0f242f6fc401f34,
 		0xe130b601260e44b5,
+		0xe1f93203db42ac8b,
+		0xeef9cfe81ddeca5e,
+		0xf33187c586e25254,
 		0xfa10659ae02f2093)
 }
@@ -0,0 +1,46 @@
+package capnp
+
+import "errors"
+
+// A ValidationError is returned by the Validate methods that capnpc-go
+// generates for structs with constraint annotations (see the min, max,
+// maxLen, required and pattern annotations in std/go.capnp).
+type ValidationError struct {
+	// Field is the path from the validated struct to the field that
+	// failed validation, such as "name", "address.zip" or "items[2].id".
+	Field string
+
+	// Msg describes the constraint that was not satisfied.
+	Msg string
+}
+
+func (e *ValidationError) Error() string {
+	return "validate " + e.Field + ": " + e.Msg
+}
+
+// WrapValidationError prepends field to the path of err if err is a
+// *ValidationError, and returns any other error unchanged.  Generated
+// Validate methods use it to report errors from nested structs, groups
+// and list elements.
+func WrapValidationError(field string, err error) error {
+	var e *ValidationError
+	if !errors.As(err, &e) {
+		return err
+	}
+	path := field
+	switch {
+	case e.Field == "":
+	case e.Field[0] == '[':
+		path += e.Field
+	default:
+		path += "." + e.Field
+	}
+	return &ValidationError{Field: path, Msg: e.Msg}
+}
+
+// IsValidation reports whether err indicates that a struct failed
+// validation.
+func IsValidation(err error) bool {
+	var e *ValidationError
+	return errors.As(err, &e)
+}
@@ -0,0 +1,44 @@
+package capnp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWrapValidationError(t *testing.T) {
+	t.Parallel()
+
+	leaf := &ValidationError{Field: "zip", Msg: "required"}
+	tests := []struct {
+		field string
+		err   error
+		want  string
+	}{
+		{"address", leaf, "address.zip"},
+		{"items[2]", leaf, "items[2].zip"},
+		{"items", &ValidationError{Field: "[2]", Msg: "required"}, "items[2]"},
+		{"address", &ValidationError{Msg: "required"}, "address"},
+	}
+	for _, test := range tests {
+		err := WrapValidationError(test.field, test.err)
+		var ve *ValidationError
+		if !errors.As(err, &ve) {
+			t.Errorf("WrapValidationError(%q, %v) = %v; want *ValidationError", test.field, test.err, err)
+			continue
+		}
+		if ve.Field != test.want || ve.Msg != "required" {
+			t.Errorf("WrapValidationError(%q, %v) = %+v; want Field=%q Msg=\"required\"", test.field, test.err, ve, test.want)
+		}
+	}
+
+	other := errors.New("bad pointer")
+	if err := WrapValidationError("address", other); err != other {
+		t.Errorf("WrapValidationError(\"address\", %v) = %v; want error unchanged", other, err)
+	}
+	if IsValidation(other) {
+		t.Errorf("IsValidation(%v) = true; want false", other)
+	}
+	if !IsValidation(leaf) {
+		t.Errorf("IsValidation(%v) = false; want true", leaf)
+	}
+}